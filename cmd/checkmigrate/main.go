@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Command checkmigrate rewrites TestCheckResourceAttr() check functions in
+// Go acceptance test files into statecheck.ExpectKnownValue() state checks.
+//
+// Usage:
+//
+//	checkmigrate [-w] [path ...]
+//
+// Each path is a Go test file or a directory of Go test files. A directory
+// path ending in /... also includes subdirectories. The current directory is
+// used when no paths are given. Without -w, the changes are only reported.
+//
+// Check functions which need manual migration are reported in the
+// file:line:column: message format of Go tools. The command can be run with
+// go generate:
+//
+//	//go:generate go run github.com/hashicorp/terraform-plugin-testing/cmd/checkmigrate -w .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/checkmigrate"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("checkmigrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	write := flags.Bool("w", false, "write the rewritten source to the files")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	paths := flags.Args()
+
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	files, err := testFiles(paths)
+
+	if err != nil {
+		fmt.Fprintf(stderr, "checkmigrate: %s\n", err)
+
+		return 1
+	}
+
+	var converted, changedFiles, findings int
+
+	for _, file := range files {
+		result, err := migrateFile(file, *write)
+
+		if err != nil {
+			fmt.Fprintf(stderr, "checkmigrate: %s\n", err)
+
+			return 1
+		}
+
+		for _, finding := range result.Findings {
+			fmt.Fprintln(stdout, finding)
+		}
+
+		converted += result.Converted
+		findings += len(result.Findings)
+
+		if result.Changed() {
+			changedFiles++
+		}
+	}
+
+	action := "can convert"
+
+	if *write {
+		action = "converted"
+	}
+
+	fmt.Fprintf(stderr, "checkmigrate: %s %d checks in %d files, %d checks need manual migration\n", action, converted, changedFiles, findings)
+
+	return 0
+}
+
+// migrateFile rewrites the file, writing the result if enabled.
+func migrateFile(path string, write bool) (*checkmigrate.Result, error) {
+	src, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := checkmigrate.Rewrite(path, src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !write || !result.Changed() {
+		return result, nil
+	}
+
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, result.Source, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// testFiles returns the Go test files for the given paths.
+func testFiles(paths []string) ([]string, error) {
+	var files []string
+
+	for _, path := range paths {
+		recursive := false
+
+		if strings.HasSuffix(path, "/...") {
+			path = strings.TrimSuffix(path, "/...")
+			recursive = true
+		}
+
+		info, err := os.Stat(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if p != path && (!recursive || isIgnoredDir(d.Name())) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if strings.HasSuffix(d.Name(), "_test.go") {
+				files = append(files, p)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// isIgnoredDir returns true for directories ignored by the go command, such
+// as testdata and vendor.
+func isIgnoredDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSource = `package example
+
+import "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+var step = resource.TestStep{
+	Check: resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr("example_widget.test", "name", "example"),
+		resource.TestCheckResourceAttr("example_widget.test", "tags.%", "1"),
+	),
+}
+`
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args          func(dir string) []string
+		expectWritten bool
+	}{
+		"dry-run": {
+			args: func(dir string) []string {
+				return []string{dir}
+			},
+		},
+		"write": {
+			args: func(dir string) []string {
+				return []string{"-w", dir}
+			},
+			expectWritten: true,
+		},
+		"write-recursive": {
+			args: func(dir string) []string {
+				return []string{"-w", filepath.Dir(dir) + "/..."}
+			},
+			expectWritten: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := filepath.Join(t.TempDir(), "example")
+			path := filepath.Join(dir, "example_test.go")
+
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := os.WriteFile(path, []byte(testSource), 0644); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var stdout, stderr bytes.Buffer
+
+			if code := run(testCase.args(dir), &stdout, &stderr); code != 0 {
+				t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+			}
+
+			if !strings.Contains(stdout.String(), `example_test.go:8:3: attribute key "tags.%" is an element count`) {
+				t.Errorf("expected finding, got: %s", stdout.String())
+			}
+
+			got, err := os.ReadFile(path)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			written := strings.Contains(string(got), "statecheck.ExpectKnownValue")
+
+			if written != testCase.expectWritten {
+				t.Errorf("expected written %t, got: %s", testCase.expectWritten, got)
+			}
+
+			if !strings.Contains(stderr.String(), "1 checks in 1 files, 1 checks need manual migration") {
+				t.Errorf("unexpected summary: %s", stderr.String())
+			}
+		})
+	}
+}
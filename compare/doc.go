@@ -0,0 +1,7 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package compare contains the value comparer interface and common value
+// comparer implementations, which are used by checks comparing values, such
+// as statecheck.CompareValue.
+package compare
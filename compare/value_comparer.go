@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare
+
+// ValueComparer defines an interface for implementing test logic that
+// compares values and returns an error if the values do not compare as
+// expected. Values are in the order they were collected, such as the value
+// of an attribute after each TestStep, and have the types of values decoded
+// from Terraform JSON, such as string, json.Number, bool, []interface{}, or
+// map[string]interface{}.
+type ValueComparer interface {
+	// CompareValues should compare the given values.
+	CompareValues(values ...interface{}) error
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var _ ValueComparer = valuesDiffer{}
+
+type valuesDiffer struct{}
+
+// CompareValues determines whether each value differs from the preceding
+// value.
+func (v valuesDiffer) CompareValues(values ...interface{}) error {
+	for i := 1; i < len(values); i++ {
+		if reflect.DeepEqual(values[i-1], values[i]) {
+			return fmt.Errorf("expected values to differ, but value %d is the same as value %d: %v", i+1, i, values[i])
+		}
+	}
+
+	return nil
+}
+
+// ValuesDiffer returns a ValueComparer which asserts that each value differs
+// from the preceding value, such as an identifier which must change when a
+// resource is replaced.
+func ValuesDiffer() ValueComparer {
+	return valuesDiffer{}
+}
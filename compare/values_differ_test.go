@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+)
+
+func TestValuesDiffer(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		values        []interface{}
+		expectedError error
+	}{
+		"none": {},
+		"single": {
+			values: []interface{}{"str"},
+		},
+		"differ": {
+			values: []interface{}{"str", "other", "str"},
+		},
+		"same": {
+			values:        []interface{}{"str", "other", "other"},
+			expectedError: fmt.Errorf("expected values to differ, but value 3 is the same as value 2: other"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := compare.ValuesDiffer().CompareValues(testCase.values...)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
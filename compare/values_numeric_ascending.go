@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var _ ValueComparer = valuesNumericAscending{}
+
+type valuesNumericAscending struct{}
+
+// CompareValues determines whether each value is a number greater than the
+// preceding value.
+func (v valuesNumericAscending) CompareValues(values ...interface{}) error {
+	numbers := make([]*big.Float, len(values))
+
+	for i, value := range values {
+		number, ok := numericValue(value)
+
+		if !ok {
+			return fmt.Errorf("expected values to be numbers, but value %d is %T: %v", i+1, value, value)
+		}
+
+		numbers[i] = number
+	}
+
+	for i := 1; i < len(numbers); i++ {
+		if numbers[i].Cmp(numbers[i-1]) <= 0 {
+			return fmt.Errorf("expected values to be ascending, but value %d is not greater than value %d: %v <= %v", i+1, i, values[i], values[i-1])
+		}
+	}
+
+	return nil
+}
+
+// ValuesNumericAscending returns a ValueComparer which asserts that all
+// values are numbers and each value is greater than the preceding value,
+// such as a revision or generation attribute which must increase when a
+// resource is updated.
+func ValuesNumericAscending() ValueComparer {
+	return valuesNumericAscending{}
+}
+
+// numericValue returns the given number value, which is a json.Number when
+// decoded from Terraform JSON, with arbitrary precision.
+func numericValue(value interface{}) (*big.Float, bool) {
+	switch value := value.(type) {
+	case json.Number:
+		number, ok := new(big.Float).SetString(value.String())
+
+		return number, ok
+	case float64:
+		if math.IsNaN(value) {
+			return nil, false
+		}
+
+		return big.NewFloat(value), true
+	case int64:
+		return new(big.Float).SetInt64(value), true
+	case int:
+		return new(big.Float).SetInt64(int64(value)), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+)
+
+func TestValuesNumericAscending(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		values        []interface{}
+		expectedError error
+	}{
+		"none": {},
+		"single": {
+			values: []interface{}{json.Number("1")},
+		},
+		"ascending": {
+			values: []interface{}{json.Number("1"), json.Number("2.5"), json.Number("10")},
+		},
+		"ascending-large": {
+			values: []interface{}{json.Number("9007199254740993"), json.Number("9007199254740994")},
+		},
+		"ascending-mixed": {
+			values: []interface{}{json.Number("1"), float64(2), int64(3), 4},
+		},
+		"equal": {
+			values:        []interface{}{json.Number("1"), json.Number("2"), json.Number("2")},
+			expectedError: fmt.Errorf("expected values to be ascending, but value 3 is not greater than value 2: 2 <= 2"),
+		},
+		"descending": {
+			values:        []interface{}{json.Number("10"), json.Number("9")},
+			expectedError: fmt.Errorf("expected values to be ascending, but value 2 is not greater than value 1: 9 <= 10"),
+		},
+		"not-number": {
+			values:        []interface{}{json.Number("1"), "2"},
+			expectedError: fmt.Errorf("expected values to be numbers, but value 2 is string: 2"),
+		},
+		"invalid-number": {
+			values:        []interface{}{json.Number("one")},
+			expectedError: fmt.Errorf("expected values to be numbers, but value 1 is json.Number: one"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := compare.ValuesNumericAscending().CompareValues(testCase.values...)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
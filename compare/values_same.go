@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var _ ValueComparer = valuesSame{}
+
+type valuesSame struct{}
+
+// CompareValues determines whether each value is the same as the first value.
+func (v valuesSame) CompareValues(values ...interface{}) error {
+	for i := 1; i < len(values); i++ {
+		if !reflect.DeepEqual(values[0], values[i]) {
+			return fmt.Errorf("expected values to be the same, but value %d differs: %v != %v", i+1, values[0], values[i])
+		}
+	}
+
+	return nil
+}
+
+// ValuesSame returns a ValueComparer which asserts that all values are the
+// same, such as an identifier which must not change when a resource is
+// updated in-place.
+func ValuesSame() ValueComparer {
+	return valuesSame{}
+}
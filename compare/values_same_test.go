@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compare_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+)
+
+func TestValuesSame(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		values        []interface{}
+		expectedError error
+	}{
+		"none": {},
+		"single": {
+			values: []interface{}{"str"},
+		},
+		"same": {
+			values: []interface{}{"str", "str", "str"},
+		},
+		"same-nested": {
+			values: []interface{}{
+				map[string]interface{}{"ports": []interface{}{json.Number("80")}},
+				map[string]interface{}{"ports": []interface{}{json.Number("80")}},
+			},
+		},
+		"differ": {
+			values:        []interface{}{"str", "str", "other"},
+			expectedError: fmt.Errorf("expected values to be the same, but value 3 differs: str != other"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := compare.ValuesSame().CompareValues(testCase.values...)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+// StaticDirectory is a helper function that returns the supplied
+// directory when running a TestStep, regardless of the test name or
+// step number.
+func StaticDirectory(directory string) func(TestStepConfigRequest) string {
+	return func(_ TestStepConfigRequest) string {
+		return directory
+	}
+}
+
+// TestNameDirectory returns the name of the test prefixed with
+// "testdata", such as "testdata/TestExample". Subtests are nested
+// within the directory of their parent test, such as
+// "testdata/TestExample/subtest".
+func TestNameDirectory() func(TestStepConfigRequest) string {
+	return func(req TestStepConfigRequest) string {
+		return filepath.Join("testdata", req.TestName)
+	}
+}
+
+// TestStepDirectory returns the name of the test and the step number
+// prefixed with "testdata", such as "testdata/TestExample/1".
+func TestStepDirectory() func(TestStepConfigRequest) string {
+	return func(req TestStepConfigRequest) string {
+		return filepath.Join("testdata", req.TestName, strconv.Itoa(req.StepNumber))
+	}
+}
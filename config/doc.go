@@ -0,0 +1,6 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package config contains the types and functions for sourcing the
+// Terraform configuration of a TestStep from outside of the test code.
+package config
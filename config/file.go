@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+// StaticFile is a helper function that returns the supplied
+// file when running a TestStep, regardless of the test name or
+// step number.
+func StaticFile(file string) func(TestStepConfigRequest) string {
+	return func(_ TestStepConfigRequest) string {
+		return file
+	}
+}
+
+// TestNameFile returns the supplied file within the directory for the
+// name of the test prefixed with "testdata", such as
+// "testdata/TestExample/main.tf".
+func TestNameFile(file string) func(TestStepConfigRequest) string {
+	return func(req TestStepConfigRequest) string {
+		return filepath.Join("testdata", req.TestName, file)
+	}
+}
+
+// TestStepFile returns the supplied file within the directory for the
+// name of the test and the step number prefixed with "testdata", such
+// as "testdata/TestExample/1/main.tf".
+func TestStepFile(file string) func(TestStepConfigRequest) string {
+	return func(req TestStepConfigRequest) string {
+		return filepath.Join("testdata", req.TestName, strconv.Itoa(req.StepNumber), file)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+// TestStepConfigFunc is the callback type used with the TestStep
+// ConfigDirectory and ConfigFile fields. It returns the path to the
+// Terraform configuration for the TestStep.
+type TestStepConfigFunc func(TestStepConfigRequest) string
+
+// TestStepConfigRequest defines the request supplied to types
+// implementing TestStepConfigFunc.
+type TestStepConfigRequest struct {
+	// StepNumber is the 1-based index of the TestStep in the TestCase.
+	StepNumber int
+
+	// TestName is the name of the Go test running the TestCase, as
+	// returned by testing.T Name().
+	TestName string
+}
+
+// Exec executes TestStepConfigFunc if it is not nil, otherwise an
+// empty string is returned.
+func (f TestStepConfigFunc) Exec(req TestStepConfigRequest) string {
+	if f != nil {
+		return f(req)
+	}
+
+	return ""
+}
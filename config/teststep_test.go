@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+)
+
+func TestTestStepConfigFunc_Exec(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testStepConfigFunc config.TestStepConfigFunc
+		expected           string
+	}{
+		"nil": {
+			expected: "",
+		},
+		"static-directory": {
+			testStepConfigFunc: config.StaticDirectory("name_of_directory"),
+			expected:           "name_of_directory",
+		},
+		"static-file": {
+			testStepConfigFunc: config.StaticFile("name_of_directory/main.tf"),
+			expected:           "name_of_directory/main.tf",
+		},
+		"test-name-directory": {
+			testStepConfigFunc: config.TestNameDirectory(),
+			expected:           filepath.Join("testdata", "TestExample"),
+		},
+		"test-step-directory": {
+			testStepConfigFunc: config.TestStepDirectory(),
+			expected:           filepath.Join("testdata", "TestExample", "1"),
+		},
+		"test-name-file": {
+			testStepConfigFunc: config.TestNameFile("main.tf"),
+			expected:           filepath.Join("testdata", "TestExample", "main.tf"),
+		},
+		"test-step-file": {
+			testStepConfigFunc: config.TestStepFile("main.tf"),
+			expected:           filepath.Join("testdata", "TestExample", "1", "main.tf"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testStepConfigFunc.Exec(config.TestStepConfigRequest{
+				StepNumber: 1,
+				TestName:   "TestExample",
+			})
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Variable interface is an alias to json.Marshaler.
+type Variable interface {
+	json.Marshaler
+}
+
+// Variables is a type holding a key-value map of variable names
+// to types implementing Variable interface.
+type Variables map[string]Variable
+
+// MarshalJSON returns the JSON encoding of Variables, suitable for a
+// Terraform *.auto.tfvars.json file.
+func (v Variables) MarshalJSON() ([]byte, error) {
+	values := make(map[string]json.RawMessage, len(v))
+
+	for name, variable := range v {
+		if variable == nil {
+			return nil, fmt.Errorf("variable %q is nil", name)
+		}
+
+		b, err := variable.MarshalJSON()
+
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", name, err)
+		}
+
+		values[name] = b
+	}
+
+	return json.Marshal(values)
+}
+
+// typeOf returns a string representation of the Go type of the Variable,
+// used to verify that collection elements are of the same type. A
+// sensitiveStringVariable is of the same type as a stringVariable.
+func typeOf(variable Variable) string {
+	if _, ok := variable.(sensitiveStringVariable); ok {
+		return typeOf(stringVariable{})
+	}
+
+	return fmt.Sprintf("%T", variable)
+}
+
+// elementsSameType returns an error if the supplied variables are not all
+// of the same type.
+func elementsSameType(variables []Variable) error {
+	for i := 1; i < len(variables); i++ {
+		if typeOf(variables[i]) != typeOf(variables[0]) {
+			return fmt.Errorf("all elements must be of the same type, got %s and %s", typeOf(variables[0]), typeOf(variables[i]))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var _ Variable = listVariable{}
+
+// listVariable is a Variable for a Terraform list value.
+type listVariable struct {
+	value []Variable
+}
+
+// MarshalJSON returns the JSON encoding of listVariable. All elements
+// must be of the same type.
+func (v listVariable) MarshalJSON() ([]byte, error) {
+	if err := elementsSameType(v.value); err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return marshalSlice(v.value)
+}
+
+// ListVariable returns a Variable for a Terraform list value. All elements
+// must be of the same type.
+func ListVariable(value ...Variable) listVariable {
+	return listVariable{
+		value: value,
+	}
+}
+
+var _ Variable = setVariable{}
+
+// setVariable is a Variable for a Terraform set value.
+type setVariable struct {
+	value []Variable
+}
+
+// MarshalJSON returns the JSON encoding of setVariable. All elements
+// must be of the same type and unique.
+func (v setVariable) MarshalJSON() ([]byte, error) {
+	if err := elementsSameType(v.value); err != nil {
+		return nil, fmt.Errorf("set: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(v.value))
+
+	for _, element := range v.value {
+		b, err := element.MarshalJSON()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[string(b)]; ok {
+			return nil, fmt.Errorf("set: duplicate element %s", b)
+		}
+
+		seen[string(b)] = struct{}{}
+	}
+
+	return marshalSlice(v.value)
+}
+
+// SetVariable returns a Variable for a Terraform set value. All elements
+// must be of the same type and unique.
+func SetVariable(value ...Variable) setVariable {
+	return setVariable{
+		value: value,
+	}
+}
+
+var _ Variable = tupleVariable{}
+
+// tupleVariable is a Variable for a Terraform tuple value.
+type tupleVariable struct {
+	value []Variable
+}
+
+// MarshalJSON returns the JSON encoding of tupleVariable.
+func (v tupleVariable) MarshalJSON() ([]byte, error) {
+	return marshalSlice(v.value)
+}
+
+// TupleVariable returns a Variable for a Terraform tuple value. Elements
+// can be of differing types.
+func TupleVariable(value ...Variable) tupleVariable {
+	return tupleVariable{
+		value: value,
+	}
+}
+
+var _ Variable = mapVariable{}
+
+// mapVariable is a Variable for a Terraform map value.
+type mapVariable struct {
+	value map[string]Variable
+}
+
+// MarshalJSON returns the JSON encoding of mapVariable. All values
+// must be of the same type.
+func (v mapVariable) MarshalJSON() ([]byte, error) {
+	values := make([]Variable, 0, len(v.value))
+
+	for _, value := range v.value {
+		values = append(values, value)
+	}
+
+	if err := elementsSameType(values); err != nil {
+		return nil, fmt.Errorf("map: %w", err)
+	}
+
+	return Variables(v.value).MarshalJSON()
+}
+
+// MapVariable returns a Variable for a Terraform map value. All values
+// must be of the same type.
+func MapVariable(value map[string]Variable) mapVariable {
+	return mapVariable{
+		value: value,
+	}
+}
+
+var _ Variable = objectVariable{}
+
+// objectVariable is a Variable for a Terraform object value.
+type objectVariable struct {
+	value map[string]Variable
+}
+
+// MarshalJSON returns the JSON encoding of objectVariable.
+func (v objectVariable) MarshalJSON() ([]byte, error) {
+	return Variables(v.value).MarshalJSON()
+}
+
+// ObjectVariable returns a Variable for a Terraform object value, where
+// the map keys are the attribute names. Attributes can be of differing
+// types.
+func ObjectVariable(value map[string]Variable) objectVariable {
+	return objectVariable{
+		value: value,
+	}
+}
+
+// marshalSlice returns the JSON encoding of a slice of Variable as an array.
+func marshalSlice(variables []Variable) ([]byte, error) {
+	values := make([]json.RawMessage, 0, len(variables))
+
+	for _, variable := range variables {
+		if variable == nil {
+			return nil, fmt.Errorf("element is nil")
+		}
+
+		b, err := variable.MarshalJSON()
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, b)
+	}
+
+	return json.Marshal(values)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+var _ Variable = boolVariable{}
+
+// boolVariable is a Variable for a Terraform bool value.
+type boolVariable struct {
+	value bool
+}
+
+// MarshalJSON returns the JSON encoding of boolVariable.
+func (v boolVariable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// BoolVariable returns a Variable for a Terraform bool value.
+func BoolVariable(value bool) boolVariable {
+	return boolVariable{
+		value: value,
+	}
+}
+
+var _ Variable = floatVariable{}
+
+// floatVariable is a Variable for a Terraform number value with a
+// fractional component.
+type floatVariable struct {
+	value float64
+}
+
+// MarshalJSON returns the JSON encoding of floatVariable. Infinity and NaN
+// cannot be represented in Terraform and return an error.
+func (v floatVariable) MarshalJSON() ([]byte, error) {
+	if math.IsInf(v.value, 0) || math.IsNaN(v.value) {
+		return nil, fmt.Errorf("float value %v cannot be represented as a Terraform number", v.value)
+	}
+
+	return json.Marshal(v.value)
+}
+
+// FloatVariable returns a Variable for a Terraform number value from any
+// Go floating point type.
+func FloatVariable[T ~float32 | ~float64](value T) floatVariable {
+	return floatVariable{
+		value: float64(value),
+	}
+}
+
+var _ Variable = integerVariable{}
+
+// integerVariable is a Variable for a Terraform whole number value.
+type integerVariable struct {
+	value json.Number
+}
+
+// MarshalJSON returns the JSON encoding of integerVariable.
+func (v integerVariable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// IntegerVariable returns a Variable for a Terraform number value from any
+// Go integer type.
+func IntegerVariable[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](value T) integerVariable {
+	return integerVariable{
+		value: json.Number(fmt.Sprintf("%d", value)),
+	}
+}
+
+var _ Variable = stringVariable{}
+
+// stringVariable is a Variable for a Terraform string value.
+type stringVariable struct {
+	value string
+}
+
+// MarshalJSON returns the JSON encoding of stringVariable.
+func (v stringVariable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// StringVariable returns a Variable for a Terraform string value.
+func StringVariable(value string) stringVariable {
+	return stringVariable{
+		value: value,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+var _ Variable = sensitiveStringVariable{}
+
+// sensitiveStringVariable is a Variable for a Terraform string value which
+// must not appear unredacted in Terraform output.
+type sensitiveStringVariable struct {
+	value string
+}
+
+// MarshalJSON returns the JSON encoding of sensitiveStringVariable.
+func (v sensitiveStringVariable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// SensitiveStringVariable returns a Variable for a Terraform string value,
+// such as a password or token, which must not appear unredacted in Terraform
+// output. The variable should also be declared with sensitive = true in the
+// configuration.
+//
+// When used in the TestStep ConfigVariables field, the testing framework
+// verifies that the value is marked sensitive wherever it appears in the
+// resource and output changes of the plan, and that it does not appear in the
+// Terraform logs, if logging to a file is enabled.
+//
+// A SensitiveStringVariable is considered to be of the same type as a
+// StringVariable in list, set, and map variables.
+func SensitiveStringVariable(value string) sensitiveStringVariable {
+	return sensitiveStringVariable{
+		value: value,
+	}
+}
+
+// SensitiveValues returns the sorted, unique values of all
+// SensitiveStringVariable in the Variables, including elements of
+// collection and object variables. Empty values are not included.
+func (v Variables) SensitiveValues() []string {
+	seen := make(map[string]struct{})
+
+	for _, variable := range v {
+		addSensitiveValues(seen, variable)
+	}
+
+	values := make([]string, 0, len(seen))
+
+	for value := range seen {
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// addSensitiveValues adds the values of SensitiveStringVariable in the given
+// variable and its elements to seen.
+func addSensitiveValues(seen map[string]struct{}, variable Variable) {
+	var elements []Variable
+
+	switch v := variable.(type) {
+	case sensitiveStringVariable:
+		if v.value != "" {
+			seen[v.value] = struct{}{}
+		}
+
+		return
+	case listVariable:
+		elements = v.value
+	case setVariable:
+		elements = v.value
+	case tupleVariable:
+		elements = v.value
+	case mapVariable:
+		for _, element := range v.value {
+			elements = append(elements, element)
+		}
+	case objectVariable:
+		for _, element := range v.value {
+			elements = append(elements, element)
+		}
+	}
+
+	for _, element := range elements {
+		addSensitiveValues(seen, element)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package config_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+)
+
+func TestVariables_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		variables     config.Variables
+		expected      string
+		expectedError error
+	}{
+		"empty": {
+			variables: config.Variables{},
+			expected:  `{}`,
+		},
+		"primitives": {
+			variables: config.Variables{
+				"bool":    config.BoolVariable(true),
+				"float":   config.FloatVariable(1.5),
+				"integer": config.IntegerVariable(uint64(18446744073709551615)),
+				"string":  config.StringVariable("str"),
+			},
+			expected: `{"bool":true,"float":1.5,"integer":18446744073709551615,"string":"str"}`,
+		},
+		"collections": {
+			variables: config.Variables{
+				"list": config.ListVariable(config.StringVariable("a"), config.StringVariable("b")),
+				"map": config.MapVariable(map[string]config.Variable{
+					"key": config.IntegerVariable(1),
+				}),
+				"object": config.ObjectVariable(map[string]config.Variable{
+					"name":    config.StringVariable("str"),
+					"enabled": config.BoolVariable(false),
+				}),
+				"set":   config.SetVariable(config.IntegerVariable(1), config.IntegerVariable(2)),
+				"tuple": config.TupleVariable(config.StringVariable("a"), config.IntegerVariable(1)),
+			},
+			expected: `{"list":["a","b"],"map":{"key":1},"object":{"enabled":false,"name":"str"},"set":[1,2],"tuple":["a",1]}`,
+		},
+		"sensitive": {
+			variables: config.Variables{
+				"list":     config.ListVariable(config.StringVariable("a"), config.SensitiveStringVariable("b")),
+				"password": config.SensitiveStringVariable("secret"),
+			},
+			expected: `{"list":["a","b"],"password":"secret"}`,
+		},
+		"list-different-types": {
+			variables: config.Variables{
+				"list": config.ListVariable(config.StringVariable("a"), config.BoolVariable(true)),
+			},
+			expectedError: fmt.Errorf(`variable "list": list: all elements must be of the same type, got config.stringVariable and config.boolVariable`),
+		},
+		"set-duplicate": {
+			variables: config.Variables{
+				"set": config.SetVariable(config.StringVariable("a"), config.StringVariable("a")),
+			},
+			expectedError: fmt.Errorf(`variable "set": set: duplicate element "a"`),
+		},
+		"float-infinity": {
+			variables: config.Variables{
+				"float": config.FloatVariable(math.Inf(1)),
+			},
+			expectedError: fmt.Errorf(`variable "float": float value +Inf cannot be represented as a Terraform number`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.variables.MarshalJSON()
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if err.Error() != testCase.expectedError.Error() {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if string(got) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestVariables_SensitiveValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		variables config.Variables
+		expected  []string
+	}{
+		"nil": {
+			expected: []string{},
+		},
+		"none": {
+			variables: config.Variables{
+				"string": config.StringVariable("str"),
+			},
+			expected: []string{},
+		},
+		"primitive": {
+			variables: config.Variables{
+				"password": config.SensitiveStringVariable("secret"),
+				"string":   config.StringVariable("str"),
+			},
+			expected: []string{"secret"},
+		},
+		"nested": {
+			variables: config.Variables{
+				"list": config.ListVariable(config.SensitiveStringVariable("b"), config.SensitiveStringVariable("a")),
+				"object": config.ObjectVariable(map[string]config.Variable{
+					"credentials": config.MapVariable(map[string]config.Variable{
+						"token": config.SensitiveStringVariable("c"),
+					}),
+				}),
+				"tuple": config.TupleVariable(config.SensitiveStringVariable("a"), config.SensitiveStringVariable("")),
+			},
+			expected: []string{"a", "b", "c"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.variables.SensitiveValues()
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
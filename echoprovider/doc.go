@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package echoprovider contains a protocol version 6 provider for use in
+// acceptance tests, which echoes the value of its provider configuration data
+// attribute into the state of its echo data source. Values which are otherwise
+// not observable by state checks, such as values from ephemeral resources or
+// sensitive variables which can be referenced in provider configuration, can
+// then be verified with the echo data source state.
+//
+// The provider is not intended for use outside of tests.
+package echoprovider
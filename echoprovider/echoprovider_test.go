@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package echoprovider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTest_EchoProvider_SensitiveVariable(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_0_0), // dynamic provider configuration attributes
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"echo": echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+variable "secret" {
+  type      = string
+  sensitive = true
+}
+
+provider "echo" {
+  data = var.secret
+}
+
+data "echo" "test" {}
+`,
+				ConfigVariables: config.Variables{
+					"secret": config.StringVariable("hunter2"),
+				},
+				Check: resource.TestCheckResourceAttr("data.echo.test", "data", "hunter2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectMark("data.echo.test", tfjsonpath.New("data"), statecheck.MarkSensitive),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,314 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package echoprovider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// DataSourceTypeName is the type name of the echo data source.
+const DataSourceTypeName = "echo"
+
+// dataAttributeName is the name of the provider configuration attribute and
+// the echo data source attribute containing the echoed value.
+const dataAttributeName = "data"
+
+// NewProviderServer returns a provider server factory for the echo provider,
+// for use with the TestCase or TestStep ProtoV6ProviderFactories field:
+//
+//	ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+//		"echo": echoprovider.NewProviderServer(),
+//	},
+//
+// The provider data attribute accepts a value of any type, which is returned
+// as the data attribute of the echo data source:
+//
+//	provider "echo" {
+//		data = var.example
+//	}
+//
+//	data "echo" "test" {}
+//
+// The data source data attribute is marked as sensitive, so echoed values are
+// not shown in Terraform CLI output, however they are available to state
+// checks. The provider data attribute must be known when the data source is
+// read. For values which are not known until apply, such as the attributes of
+// a resource created in the same configuration, set the data source
+// depends_on argument to that resource so the read is deferred until apply.
+func NewProviderServer() func() (tfprotov6.ProviderServer, error) {
+	return func() (tfprotov6.ProviderServer, error) {
+		return &echoProviderServer{}, nil
+	}
+}
+
+var _ tfprotov6.ProviderServer = &echoProviderServer{}
+
+// echoProviderServer is the echo provider server.
+type echoProviderServer struct {
+	// mu protects data.
+	mu sync.Mutex
+
+	// data is the value of the provider configuration data attribute,
+	// saved during ConfigureProvider.
+	data tftypes.Value
+
+	// configured is true after ConfigureProvider.
+	configured bool
+}
+
+// providerSchema returns the schema of the provider configuration.
+func providerSchema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        dataAttributeName,
+					Type:        tftypes.DynamicPseudoType,
+					Description: "Value of any type to echo into the echo data source data attribute.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// dataSourceSchema returns the schema of the echo data source.
+func dataSourceSchema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        dataAttributeName,
+					Type:        tftypes.DynamicPseudoType,
+					Description: "Value of the provider configuration data attribute.",
+					Computed:    true,
+					Sensitive:   true,
+				},
+			},
+		},
+	}
+}
+
+// GetProviderSchema implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{
+		Provider: providerSchema(),
+		DataSourceSchemas: map[string]*tfprotov6.Schema{
+			DataSourceTypeName: dataSourceSchema(),
+		},
+		ResourceSchemas: map[string]*tfprotov6.Schema{},
+	}, nil
+}
+
+// ValidateProviderConfig implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ValidateProviderConfig(_ context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	return &tfprotov6.ValidateProviderConfigResponse{
+		PreparedConfig: req.Config,
+	}, nil
+}
+
+// ConfigureProvider implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ConfigureProvider(_ context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	data, err := configData(req.Config)
+
+	if err != nil {
+		return &tfprotov6.ConfigureProviderResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				errorDiagnostic("Error Reading Provider Configuration", err),
+			},
+		}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = data
+	s.configured = true
+
+	return &tfprotov6.ConfigureProviderResponse{}, nil
+}
+
+// StopProvider implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) StopProvider(_ context.Context, _ *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	return &tfprotov6.StopProviderResponse{}, nil
+}
+
+// ValidateDataResourceConfig implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ValidateDataResourceConfig(_ context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	if req.TypeName != DataSourceTypeName {
+		return &tfprotov6.ValidateDataResourceConfigResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				unsupportedTypeDiagnostic("data source", req.TypeName),
+			},
+		}, nil
+	}
+
+	return &tfprotov6.ValidateDataResourceConfigResponse{}, nil
+}
+
+// ReadDataSource implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ReadDataSource(_ context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	if req.TypeName != DataSourceTypeName {
+		return &tfprotov6.ReadDataSourceResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				unsupportedTypeDiagnostic("data source", req.TypeName),
+			},
+		}, nil
+	}
+
+	s.mu.Lock()
+	data, configured := s.data, s.configured
+	s.mu.Unlock()
+
+	if !configured {
+		return &tfprotov6.ReadDataSourceResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				errorDiagnostic("Error Reading Echo Data Source", fmt.Errorf("provider has not been configured")),
+			},
+		}, nil
+	}
+
+	if !data.IsFullyKnown() {
+		return &tfprotov6.ReadDataSourceResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				errorDiagnostic(
+					"Error Reading Echo Data Source",
+					fmt.Errorf("provider data attribute is not known, set the data source depends_on argument to the resources referenced by the provider data attribute"),
+				),
+			},
+		}, nil
+	}
+
+	stateType := dataSourceSchema().ValueType()
+
+	state, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, map[string]tftypes.Value{
+		dataAttributeName: data,
+	}))
+
+	if err != nil {
+		return &tfprotov6.ReadDataSourceResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				errorDiagnostic("Error Encoding Echo Data Source State", err),
+			},
+		}, nil
+	}
+
+	return &tfprotov6.ReadDataSourceResponse{
+		State: &state,
+	}, nil
+}
+
+// ValidateResourceConfig implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ValidateResourceConfig(_ context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	return &tfprotov6.ValidateResourceConfigResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			unsupportedTypeDiagnostic("resource", req.TypeName),
+		},
+	}, nil
+}
+
+// UpgradeResourceState implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) UpgradeResourceState(_ context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	return &tfprotov6.UpgradeResourceStateResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			unsupportedTypeDiagnostic("resource", req.TypeName),
+		},
+	}, nil
+}
+
+// ReadResource implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ReadResource(_ context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	return &tfprotov6.ReadResourceResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			unsupportedTypeDiagnostic("resource", req.TypeName),
+		},
+	}, nil
+}
+
+// PlanResourceChange implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) PlanResourceChange(_ context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return &tfprotov6.PlanResourceChangeResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			unsupportedTypeDiagnostic("resource", req.TypeName),
+		},
+	}, nil
+}
+
+// ApplyResourceChange implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ApplyResourceChange(_ context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	return &tfprotov6.ApplyResourceChangeResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			unsupportedTypeDiagnostic("resource", req.TypeName),
+		},
+	}, nil
+}
+
+// ImportResourceState implements tfprotov6.ProviderServer.
+func (s *echoProviderServer) ImportResourceState(_ context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	return &tfprotov6.ImportResourceStateResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			unsupportedTypeDiagnostic("resource", req.TypeName),
+		},
+	}, nil
+}
+
+// configData returns the value of the provider configuration data attribute.
+func configData(config *tfprotov6.DynamicValue) (tftypes.Value, error) {
+	if config == nil {
+		return tftypes.NewValue(tftypes.DynamicPseudoType, nil), nil
+	}
+
+	configValue, err := config.Unmarshal(providerSchema().ValueType())
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	if !configValue.IsKnown() {
+		return tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue), nil
+	}
+
+	if configValue.IsNull() {
+		return tftypes.NewValue(tftypes.DynamicPseudoType, nil), nil
+	}
+
+	var attributes map[string]tftypes.Value
+
+	if err := configValue.As(&attributes); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	data, ok := attributes[dataAttributeName]
+
+	if !ok {
+		return tftypes.NewValue(tftypes.DynamicPseudoType, nil), nil
+	}
+
+	return data, nil
+}
+
+// errorDiagnostic returns an error diagnostic with the given summary and the
+// error as the detail.
+func errorDiagnostic(summary string, err error) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   err.Error(),
+	}
+}
+
+// unsupportedTypeDiagnostic returns an error diagnostic for a resource or data
+// source type which is not implemented by the echo provider.
+func unsupportedTypeDiagnostic(kind string, typeName string) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityError,
+		Summary:  "Unsupported Type",
+		Detail:   fmt.Sprintf("The echo provider does not support the %s type %q.", kind, typeName),
+	}
+}
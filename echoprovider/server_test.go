@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package echoprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEchoProviderServerReadDataSource(t *testing.T) {
+	t.Parallel()
+
+	configType := providerSchema().ValueType()
+	stateType := dataSourceSchema().ValueType()
+
+	testCases := map[string]struct {
+		config              *tftypes.Value
+		typeName            string
+		expectedData        tftypes.Value
+		expectedDiagnostics []*tfprotov6.Diagnostic
+	}{
+		"string": {
+			config: valuePointer(tftypes.NewValue(configType, map[string]tftypes.Value{
+				"data": tftypes.NewValue(tftypes.String, "hello"),
+			})),
+			typeName:     DataSourceTypeName,
+			expectedData: tftypes.NewValue(tftypes.String, "hello"),
+		},
+		"object": {
+			config: valuePointer(tftypes.NewValue(configType, map[string]tftypes.Value{
+				"data": tftypes.NewValue(
+					tftypes.Object{AttributeTypes: map[string]tftypes.Type{"number": tftypes.Number}},
+					map[string]tftypes.Value{"number": tftypes.NewValue(tftypes.Number, 1)},
+				),
+			})),
+			typeName: DataSourceTypeName,
+			expectedData: tftypes.NewValue(
+				tftypes.Object{AttributeTypes: map[string]tftypes.Type{"number": tftypes.Number}},
+				map[string]tftypes.Value{"number": tftypes.NewValue(tftypes.Number, 1)},
+			),
+		},
+		"null": {
+			config: valuePointer(tftypes.NewValue(configType, map[string]tftypes.Value{
+				"data": tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+			})),
+			typeName:     DataSourceTypeName,
+			expectedData: tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+		},
+		"unknown": {
+			config: valuePointer(tftypes.NewValue(configType, map[string]tftypes.Value{
+				"data": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			})),
+			typeName: DataSourceTypeName,
+			expectedDiagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Error Reading Echo Data Source",
+					Detail:   "provider data attribute is not known, set the data source depends_on argument to the resources referenced by the provider data attribute",
+				},
+			},
+		},
+		"not-configured": {
+			typeName: DataSourceTypeName,
+			expectedDiagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Error Reading Echo Data Source",
+					Detail:   "provider has not been configured",
+				},
+			},
+		},
+		"unsupported-type": {
+			typeName: "echo_other",
+			expectedDiagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Unsupported Type",
+					Detail:   `The echo provider does not support the data source type "echo_other".`,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			server, err := NewProviderServer()()
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.config != nil {
+				config, err := tfprotov6.NewDynamicValue(configType, *testCase.config)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if len(resp.Diagnostics) > 0 {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+			}
+
+			resp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{TypeName: testCase.typeName})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(resp.Diagnostics, testCase.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+
+			if testCase.expectedDiagnostics != nil {
+				return
+			}
+
+			state, err := resp.State.Unmarshal(stateType)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			expectedState := tftypes.NewValue(stateType, map[string]tftypes.Value{
+				"data": testCase.expectedData,
+			})
+
+			if !state.Equal(expectedState) {
+				t.Errorf("expected state %s, got: %s", expectedState, state)
+			}
+		})
+	}
+}
+
+func valuePointer(value tftypes.Value) *tftypes.Value {
+	return &value
+}
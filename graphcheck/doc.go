@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package graphcheck contains the graph check interface, request/response
+// structs, and common graph check implementations, which assert the
+// dependency graph of a configuration as output by the
+// `terraform graph -type=plan` command.
+package graphcheck
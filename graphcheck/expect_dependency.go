@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package graphcheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ GraphCheck = expectDependency{}
+
+type expectDependency struct {
+	from string
+	to   string
+}
+
+// CheckGraph implements the graph check logic.
+func (e expectDependency) CheckGraph(ctx context.Context, req CheckGraphRequest, resp *CheckGraphResponse) {
+	if err := checkNodes(req.Graph, e.from, e.to); err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if !req.Graph.DependsOn(e.from, e.to) {
+		resp.Error = fmt.Errorf("%s - expected dependency on %s, but none found", e.from, e.to)
+	}
+}
+
+// ExpectDependency returns a graph check that asserts that the from resource
+// depends on the to resource, either directly or through other nodes, such as
+// an implicit dependency created by referencing an attribute of the to
+// resource in the configuration of the from resource.
+//
+// Resources are addressed without instance keys, such as
+// "example_widget.test" or "module.child.example_widget.test".
+func ExpectDependency(from, to string) GraphCheck {
+	return expectDependency{
+		from: from,
+		to:   to,
+	}
+}
+
+// checkNodes returns an error if the graph is nil or does not contain any of
+// the given nodes.
+func checkNodes(graph *Graph, nodes ...string) error {
+	if graph == nil {
+		return fmt.Errorf("graph is nil")
+	}
+
+	for _, node := range nodes {
+		if !graph.HasNode(node) {
+			return fmt.Errorf("%s - Node not found in graph", node)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package graphcheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/graphcheck"
+)
+
+func TestExpectDependency(t *testing.T) {
+	t.Parallel()
+
+	graph, err := graphcheck.ParseGraph(testPlanGraph)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		graph         *graphcheck.Graph
+		graphCheck    graphcheck.GraphCheck
+		expectedError error
+	}{
+		"direct": {
+			graph:      graph,
+			graphCheck: graphcheck.ExpectDependency("example_widget.b", "example_widget.a"),
+		},
+		"transitive": {
+			graph:      graph,
+			graphCheck: graphcheck.ExpectDependency("example_widget.c", "example_widget.a"),
+		},
+		"reverse": {
+			graph:         graph,
+			graphCheck:    graphcheck.ExpectDependency("example_widget.a", "example_widget.b"),
+			expectedError: fmt.Errorf("example_widget.a - expected dependency on example_widget.b, but none found"),
+		},
+		"through-provider-close": {
+			graph:         graph,
+			graphCheck:    graphcheck.ExpectDependency("module.child.example_widget.d", "example_widget.c"),
+			expectedError: fmt.Errorf("module.child.example_widget.d - expected dependency on example_widget.c, but none found"),
+		},
+		"node-not-found": {
+			graph:         graph,
+			graphCheck:    graphcheck.ExpectDependency("example_widget.b", "example_widget.e"),
+			expectedError: fmt.Errorf("example_widget.e - Node not found in graph"),
+		},
+		"graph-nil": {
+			graphCheck:    graphcheck.ExpectDependency("example_widget.b", "example_widget.a"),
+			expectedError: fmt.Errorf("graph is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := graphcheck.CheckGraphResponse{}
+
+			testCase.graphCheck.CheckGraph(context.Background(), graphcheck.CheckGraphRequest{Graph: testCase.graph}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package graphcheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ GraphCheck = expectNoDependency{}
+
+type expectNoDependency struct {
+	from string
+	to   string
+}
+
+// CheckGraph implements the graph check logic.
+func (e expectNoDependency) CheckGraph(ctx context.Context, req CheckGraphRequest, resp *CheckGraphResponse) {
+	if err := checkNodes(req.Graph, e.from, e.to); err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if req.Graph.DependsOn(e.from, e.to) {
+		resp.Error = fmt.Errorf("%s - expected no dependency on %s, but found one", e.from, e.to)
+	}
+}
+
+// ExpectNoDependency returns a graph check that asserts that the from
+// resource does not depend on the to resource, either directly or through
+// other nodes, such as to verify that resources can be created in parallel.
+//
+// Resources are addressed without instance keys, such as
+// "example_widget.test" or "module.child.example_widget.test".
+func ExpectNoDependency(from, to string) GraphCheck {
+	return expectNoDependency{
+		from: from,
+		to:   to,
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package graphcheck
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// graphQuotedIDPattern matches a double-quoted DOT identifier, which may
+	// contain escaped quotes.
+	graphQuotedIDPattern = `"((?:[^"\\]|\\.)*)"`
+
+	// graphEdgeRegex matches a DOT edge statement between quoted nodes.
+	graphEdgeRegex = regexp.MustCompile(`^\s*` + graphQuotedIDPattern + `\s*->\s*` + graphQuotedIDPattern)
+
+	// graphNodeRegex matches a DOT node statement of a quoted node.
+	graphNodeRegex = regexp.MustCompile(`^\s*` + graphQuotedIDPattern + `\s*(?:\[|;|$)`)
+
+	// graphNodeSuffixRegex matches the suffix of the graph nodes which
+	// Terraform uses for resources and modules. Other suffixes, such as
+	// " (close)", are kept, as those nodes depend on the nodes they close.
+	graphNodeSuffixRegex = regexp.MustCompile(` \(expand\)$`)
+)
+
+// Graph is a dependency graph parsed from the DOT output of the
+// `terraform graph` command. Node names are normalized by removing the
+// "[root] " module prefix and the " (expand)" suffix of Terraform, so
+// resources are named by their address, such as "example_widget.test" or
+// "module.child.example_widget.test".
+type Graph struct {
+	// Nodes are the names of all nodes in the graph, sorted.
+	Nodes []string
+
+	// Edges are the dependencies between nodes, where the From node depends
+	// on the To node.
+	Edges []Edge
+}
+
+// Edge is a dependency between two nodes of a Graph.
+type Edge struct {
+	// From is the name of the node with the dependency.
+	From string
+
+	// To is the name of the node which From depends on.
+	To string
+}
+
+// ParseGraph returns the Graph of the given DOT output of the
+// `terraform graph` command.
+func ParseGraph(dot string) (*Graph, error) {
+	if !strings.Contains(dot, "digraph") {
+		return nil, fmt.Errorf("graph output is not a DOT digraph")
+	}
+
+	nodes := make(map[string]bool)
+	edges := make(map[Edge]bool)
+	graph := &Graph{}
+
+	for _, line := range strings.Split(dot, "\n") {
+		if match := graphEdgeRegex.FindStringSubmatch(line); match != nil {
+			edge := Edge{
+				From: graphNodeName(match[1]),
+				To:   graphNodeName(match[2]),
+			}
+
+			nodes[edge.From] = true
+			nodes[edge.To] = true
+
+			if edge.From == edge.To || edges[edge] {
+				continue
+			}
+
+			edges[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+
+			continue
+		}
+
+		if match := graphNodeRegex.FindStringSubmatch(line); match != nil {
+			nodes[graphNodeName(match[1])] = true
+		}
+	}
+
+	for node := range nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	sort.Strings(graph.Nodes)
+
+	return graph, nil
+}
+
+// graphNodeName returns the normalized name of a quoted DOT node identifier.
+func graphNodeName(id string) string {
+	name := strings.ReplaceAll(id, `\"`, `"`)
+	name = strings.TrimPrefix(name, "[root] ")
+
+	return graphNodeSuffixRegex.ReplaceAllString(name, "")
+}
+
+// HasNode returns true if the graph contains the given node.
+func (g *Graph) HasNode(name string) bool {
+	i := sort.SearchStrings(g.Nodes, name)
+
+	return i < len(g.Nodes) && g.Nodes[i] == name
+}
+
+// DependsOn returns true if the from node depends on the to node, either
+// directly or through other nodes. Terraform removes edges which are implied
+// by other paths from the graph, so a direct edge may not exist for every
+// dependency.
+func (g *Graph) DependsOn(from, to string) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range g.Edges {
+			if edge.From != node || visited[edge.To] {
+				continue
+			}
+
+			if edge.To == to {
+				return true
+			}
+
+			visited[edge.To] = true
+			queue = append(queue, edge.To)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package graphcheck_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/graphcheck"
+)
+
+// testPlanGraph is the output of terraform graph -type=plan, which includes
+// provider and closing nodes.
+const testPlanGraph = `digraph {
+	compound = "true"
+	newrank = "true"
+	subgraph "root" {
+		"[root] example_widget.a (expand)" [label = "example_widget.a", shape = "box"]
+		"[root] example_widget.b (expand)" [label = "example_widget.b", shape = "box"]
+		"[root] example_widget.c (expand)" [label = "example_widget.c", shape = "box"]
+		"[root] module.child.example_widget.d (expand)" [label = "module.child.example_widget.d", shape = "box"]
+		"[root] provider[\"registry.terraform.io/hashicorp/example\"]" [label = "provider[\"registry.terraform.io/hashicorp/example\"]", shape = "diamond"]
+		"[root] example_widget.a (expand)" -> "[root] provider[\"registry.terraform.io/hashicorp/example\"]"
+		"[root] example_widget.b (expand)" -> "[root] example_widget.a (expand)"
+		"[root] example_widget.c (expand)" -> "[root] example_widget.b (expand)"
+		"[root] module.child.example_widget.d (expand)" -> "[root] provider[\"registry.terraform.io/hashicorp/example\"]"
+		"[root] provider[\"registry.terraform.io/hashicorp/example\"] (close)" -> "[root] example_widget.c (expand)"
+		"[root] provider[\"registry.terraform.io/hashicorp/example\"] (close)" -> "[root] module.child.example_widget.d (expand)"
+		"[root] root" -> "[root] provider[\"registry.terraform.io/hashicorp/example\"] (close)"
+	}
+}
+`
+
+// testResourceGraph is the simplified resource-only output of terraform
+// graph in Terraform 1.7 and later.
+const testResourceGraph = `digraph G {
+  rankdir = "RL";
+  node [shape = rect, fontname = "sans-serif"];
+  "example_widget.a" [label="example_widget.a"];
+  "example_widget.b" [label="example_widget.b"];
+  "example_widget.b" -> "example_widget.a";
+}
+`
+
+func TestParseGraph(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		dot           string
+		expected      *graphcheck.Graph
+		expectedError error
+	}{
+		"plan": {
+			dot: testPlanGraph,
+			expected: &graphcheck.Graph{
+				Nodes: []string{
+					"example_widget.a",
+					"example_widget.b",
+					"example_widget.c",
+					"module.child.example_widget.d",
+					`provider["registry.terraform.io/hashicorp/example"]`,
+					`provider["registry.terraform.io/hashicorp/example"] (close)`,
+					"root",
+				},
+				Edges: []graphcheck.Edge{
+					{From: "example_widget.a", To: `provider["registry.terraform.io/hashicorp/example"]`},
+					{From: "example_widget.b", To: "example_widget.a"},
+					{From: "example_widget.c", To: "example_widget.b"},
+					{From: "module.child.example_widget.d", To: `provider["registry.terraform.io/hashicorp/example"]`},
+					{From: `provider["registry.terraform.io/hashicorp/example"] (close)`, To: "example_widget.c"},
+					{From: `provider["registry.terraform.io/hashicorp/example"] (close)`, To: "module.child.example_widget.d"},
+					{From: "root", To: `provider["registry.terraform.io/hashicorp/example"] (close)`},
+				},
+			},
+		},
+		"resources": {
+			dot: testResourceGraph,
+			expected: &graphcheck.Graph{
+				Nodes: []string{
+					"example_widget.a",
+					"example_widget.b",
+				},
+				Edges: []graphcheck.Edge{
+					{From: "example_widget.b", To: "example_widget.a"},
+				},
+			},
+		},
+		"not-dot": {
+			dot:           "Error: No configuration files",
+			expectedError: fmt.Errorf("graph output is not a DOT digraph"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := graphcheck.ParseGraph(testCase.dot)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
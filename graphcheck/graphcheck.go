@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package graphcheck
+
+import (
+	"context"
+)
+
+// GraphCheck defines an interface for implementing test logic that checks a
+// dependency graph and then returns an error if the graph does not match
+// what is expected.
+type GraphCheck interface {
+	// CheckGraph should perform the graph check.
+	CheckGraph(context.Context, CheckGraphRequest, *CheckGraphResponse)
+}
+
+// CheckGraphRequest is a request for an invoke of the CheckGraph function.
+type CheckGraphRequest struct {
+	// Graph represents the parsed DOT output of the
+	// `terraform graph -type=plan` command.
+	Graph *Graph
+
+	// StepNumber is the 1-based index of the TestStep in the TestCase.
+	StepNumber int
+
+	// StepName is the Name of the TestStep, if set.
+	StepName string
+
+	// TestName is the name of the Go test running the TestCase, as
+	// returned by testing.T Name().
+	TestName string
+}
+
+// CheckGraphResponse is a response to an invoke of the CheckGraph function.
+type CheckGraphResponse struct {
+	// Error is used to report the failure of a graph check assertion and is
+	// combined with other GraphCheck errors to be reported as a test failure.
+	Error error
+}
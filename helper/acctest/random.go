@@ -14,14 +14,52 @@ import (
 	"math/big"
 	"math/rand"
 	"net/netip"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/crypto/ssh"
 )
 
+// envRandomSeed is the environment variable with the initial seed of the
+// random source, which matches the TF_ACC_RANDOM_SEED environment variable of
+// the helper/resource package.
+const envRandomSeed = "TF_ACC_RANDOM_SEED"
+
+var (
+	// random is the source of the random values generated by this package.
+	// It is not safe for concurrent use, so access is guarded by randomMu.
+	random   *rand.Rand
+	randomMu sync.Mutex
+)
+
 func init() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := time.Now().UTC().UnixNano()
+
+	if v, err := strconv.ParseInt(os.Getenv(envRandomSeed), 10, 64); err == nil {
+		seed = v
+	}
+
+	random = rand.New(rand.NewSource(seed))
+}
+
+// SeedRandom seeds the source of the random values generated by this
+// package, such as by RandomWithPrefix, so that the same values are
+// generated after each call with the same seed. The source is initially
+// seeded with the TF_ACC_RANDOM_SEED environment variable, if set, otherwise
+// the current time.
+//
+// The source is shared by all tests, so values are only reproducible if no
+// other test generates values concurrently, such as tests calling t.Parallel.
+// The TestCase RandomSeed field of the helper/resource package calls
+// SeedRandom when the TestCase starts.
+func SeedRandom(seed int64) {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	random.Seed(seed)
 }
 
 // Helpers for generating random tidbits for use in identifiers to prevent
@@ -29,7 +67,10 @@ func init() {
 
 // RandInt generates a random integer
 func RandInt() int {
-	return rand.Int()
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	return random.Int()
 }
 
 // RandomWithPrefix is used to generate a unique name with a prefix, for
@@ -40,7 +81,10 @@ func RandomWithPrefix(name string) string {
 
 // RandIntRange returns a random integer between min (inclusive) and max (exclusive)
 func RandIntRange(min int, max int) int {
-	return rand.Intn(max-min) + min
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	return random.Intn(max-min) + min
 }
 
 // RandString generates a random alphanumeric string of the length specified
@@ -151,7 +195,9 @@ func RandIpAddress(s string) (string, error) {
 		return prefix.Addr().String(), nil
 	}
 
-	randInt := rand.Int63n(randIntMax.Int64())
+	randomMu.Lock()
+	randInt := random.Int63n(randIntMax.Int64())
+	randomMu.Unlock()
 
 	if randInt == 0 {
 		return prefix.Addr().String(), nil
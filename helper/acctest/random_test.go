@@ -138,3 +138,20 @@ func TestRandSSHKeyPair(t *testing.T) {
 		})
 	}
 }
+
+//nolint:paralleltest // Can't use t.Parallel with the shared random source
+func TestSeedRandom(t *testing.T) {
+	SeedRandom(1234)
+
+	first := []interface{}{RandInt(), RandString(10), RandomWithPrefix("tf-acc")}
+
+	SeedRandom(1234)
+
+	second := []interface{}{RandInt(), RandString(10), RandomWithPrefix("tf-acc")}
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("expected value %d to be reproduced, got %v and %v", i, first[i], second[i])
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package checkmigrate rewrites Go acceptance test source code to migrate
+// TestStep check functions to state checks.
+//
+// TestCheckResourceAttr() check functions in the Check field of a TestStep
+// are rewritten into statecheck.ExpectKnownValue() state checks in the
+// ConfigStateChecks field, where the check can be converted without knowing
+// the provider schema. Checks which cannot be converted are left unchanged
+// and reported as findings for manual migration.
+//
+// The cmd/checkmigrate command runs this package against Go test files,
+// which can be invoked with go generate:
+//
+//	//go:generate go run github.com/hashicorp/terraform-plugin-testing/cmd/checkmigrate -w .
+package checkmigrate
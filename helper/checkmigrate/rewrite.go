@@ -0,0 +1,674 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checkmigrate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const (
+	knownvaluePackagePath = "github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	resourcePackagePath   = "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	statecheckPackagePath = "github.com/hashicorp/terraform-plugin-testing/statecheck"
+	tfjsonpathPackagePath = "github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// Result is the outcome of rewriting a Go source file.
+type Result struct {
+	// Source is the rewritten and formatted Go source file. It is the
+	// original source if no checks were converted.
+	Source []byte
+
+	// Converted is the number of TestCheckResourceAttr() check functions
+	// which were rewritten into state checks.
+	Converted int
+
+	// Findings are the TestCheckResourceAttr() check functions which were
+	// not rewritten and need manual migration.
+	Findings []Finding
+}
+
+// Changed returns true if any checks were converted.
+func (r Result) Changed() bool {
+	return r.Converted > 0
+}
+
+// Finding is a TestCheckResourceAttr() check function which could not be
+// rewritten.
+type Finding struct {
+	// Position is the location of the check function in the source file.
+	Position token.Position
+
+	// Message describes why the check function was not rewritten.
+	Message string
+}
+
+// String returns the finding in the file:line:column: message format of Go
+// tools.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Position, f.Message)
+}
+
+// Rewrite rewrites the TestCheckResourceAttr() check functions in the Check
+// field of TestStep in the given Go source file into
+// statecheck.ExpectKnownValue() state checks in the ConfigStateChecks field.
+// The filename is only used for positions in findings.
+//
+// A check function is rewritten when it is the Check field value or an
+// argument of a ComposeTestCheckFunc() or ComposeAggregateTestCheckFunc()
+// call in the Check field, and the attribute key is a string literal which
+// can be converted with tfjsonpath.FromFlatmap(). As TestCheckResourceAttr()
+// compares flatmap string values, the value is checked with
+// knownvalue.StringExact(). String literal values which look like a bool or
+// number are not rewritten, as the attribute type cannot be determined
+// without the provider schema. Neither are element count keys ending in "#"
+// or "%".
+//
+// An error is returned if the source cannot be parsed or the rewritten
+// source cannot be formatted.
+func Rewrite(filename string, src []byte) (*Result, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filename, err)
+	}
+
+	r := &rewriter{
+		fset:    fset,
+		file:    file,
+		handled: make(map[*ast.CallExpr]bool),
+		src:     src,
+	}
+
+	r.resourceName = importName(file, resourcePackagePath, "resource")
+
+	result := &Result{
+		Source: src,
+	}
+
+	if r.resourceName == "" {
+		return result, nil
+	}
+
+	r.statecheckName = r.packageName(statecheckPackagePath, "statecheck")
+	r.knownvalueName = r.packageName(knownvaluePackagePath, "knownvalue")
+	r.tfjsonpathName = r.packageName(tfjsonpathPackagePath, "tfjsonpath")
+
+	for _, step := range r.testSteps() {
+		r.rewriteStep(step)
+	}
+
+	r.reportUnhandled()
+
+	result.Converted = r.converted
+	result.Findings = r.findings
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		return result.Findings[i].Position.Offset < result.Findings[j].Position.Offset
+	})
+
+	if r.converted == 0 {
+		return result, nil
+	}
+
+	r.addImports()
+
+	rewritten, err := format.Source(r.apply())
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to format rewritten %s: %w", filename, err)
+	}
+
+	result.Source = rewritten
+
+	return result, nil
+}
+
+// edit replaces the source between the start and end offsets with text.
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// rewriter holds the state of rewriting a single Go source file.
+type rewriter struct {
+	fset *token.FileSet
+	file *ast.File
+	src  []byte
+
+	resourceName   string
+	statecheckName string
+	knownvalueName string
+	tfjsonpathName string
+
+	// missingImports are the package paths to import for converted checks.
+	missingImports []string
+
+	// handled are the TestCheckResourceAttr() calls already converted or
+	// reported.
+	handled map[*ast.CallExpr]bool
+
+	converted int
+	edits     []edit
+	findings  []Finding
+}
+
+// importName returns the name the package with the given path is imported
+// as in the file, or an empty string if it is not imported.
+func importName(file *ast.File, path string, defaultName string) string {
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+
+		if err != nil || importPath != path {
+			continue
+		}
+
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+
+		return defaultName
+	}
+
+	return ""
+}
+
+// packageName returns the name the package with the given path is imported
+// as, or the default name after marking the package as a missing import.
+func (r *rewriter) packageName(path string, defaultName string) string {
+	if name := importName(r.file, path, defaultName); name != "" {
+		return name
+	}
+
+	r.missingImports = append(r.missingImports, path)
+
+	return defaultName
+}
+
+// testSteps returns the TestStep composite literals in the file, including
+// those with an elided type within a []TestStep composite literal.
+func (r *rewriter) testSteps() []*ast.CompositeLit {
+	var steps []*ast.CompositeLit
+
+	ast.Inspect(r.file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+
+		if !ok {
+			return true
+		}
+
+		if r.isResourceSelector(lit.Type, "TestStep") {
+			steps = append(steps, lit)
+
+			return true
+		}
+
+		arrayType, ok := lit.Type.(*ast.ArrayType)
+
+		if !ok || !r.isResourceSelector(arrayType.Elt, "TestStep") {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			if step, ok := elt.(*ast.CompositeLit); ok && step.Type == nil {
+				steps = append(steps, step)
+			}
+		}
+
+		return true
+	})
+
+	return steps
+}
+
+// isResourceSelector returns true if the expression refers to the given name
+// in the helper/resource package.
+func (r *rewriter) isResourceSelector(expr ast.Expr, name string) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+
+	if !ok || selector.Sel.Name != name {
+		return false
+	}
+
+	ident, ok := selector.X.(*ast.Ident)
+
+	return ok && ident.Name == r.resourceName
+}
+
+// isResourceCall returns the call expression if the expression is a call of
+// one of the given functions in the helper/resource package.
+func (r *rewriter) isResourceCall(expr ast.Expr, names ...string) (*ast.CallExpr, bool) {
+	call, ok := expr.(*ast.CallExpr)
+
+	if !ok {
+		return nil, false
+	}
+
+	for _, name := range names {
+		if r.isResourceSelector(call.Fun, name) {
+			return call, true
+		}
+	}
+
+	return nil, false
+}
+
+// rewriteStep converts the check functions of the TestStep and records the
+// edits to move them into the ConfigStateChecks field.
+func (r *rewriter) rewriteStep(step *ast.CompositeLit) {
+	checkField := keyValue(step, "Check")
+
+	if checkField == nil {
+		return
+	}
+
+	var checkFuncs []ast.Expr
+	var compose *ast.CallExpr
+
+	if call, ok := r.isResourceCall(checkField.Value, "ComposeTestCheckFunc", "ComposeAggregateTestCheckFunc"); ok {
+		if call.Ellipsis.IsValid() {
+			return
+		}
+
+		compose = call
+		checkFuncs = call.Args
+	} else {
+		checkFuncs = []ast.Expr{checkField.Value}
+	}
+
+	// State checks can only be added to a ConfigStateChecks composite
+	// literal, not a variable or function call.
+	var stateChecksLit *ast.CompositeLit
+
+	if stateChecksField := keyValue(step, "ConfigStateChecks"); stateChecksField != nil {
+		lit, ok := stateChecksField.Value.(*ast.CompositeLit)
+
+		if !ok {
+			for _, checkFunc := range checkFuncs {
+				if call, ok := r.isResourceCall(checkFunc, "TestCheckResourceAttr"); ok {
+					r.handled[call] = true
+					r.addFinding(call, "TestStep ConfigStateChecks is not a composite literal")
+				}
+			}
+
+			return
+		}
+
+		stateChecksLit = lit
+	}
+
+	var stateChecks []string
+	var kept []int
+
+	for i, checkFunc := range checkFuncs {
+		call, ok := r.isResourceCall(checkFunc, "TestCheckResourceAttr")
+
+		if !ok {
+			kept = append(kept, i)
+
+			continue
+		}
+
+		r.handled[call] = true
+
+		stateCheck, err := r.convert(call)
+
+		if err != nil {
+			r.addFinding(call, err.Error())
+
+			kept = append(kept, i)
+
+			continue
+		}
+
+		stateChecks = append(stateChecks, stateCheck)
+	}
+
+	if len(stateChecks) == 0 {
+		return
+	}
+
+	r.converted += len(stateChecks)
+
+	checkFieldEnd, checkFieldComma := r.commaAfter(checkField.End())
+
+	// Remove or update the Check field.
+	switch {
+	case len(kept) == 0 && stateChecksLit == nil:
+		r.edits = append(r.edits, edit{
+			start: r.offset(checkField.Pos()),
+			end:   checkFieldEnd,
+			text:  r.newStateChecksField(stateChecks, checkFieldComma),
+		})
+	case len(kept) == 0:
+		r.edits = append(r.edits, edit{
+			start: r.offset(checkField.Pos()),
+			end:   checkFieldEnd,
+		})
+	default:
+		r.edits = append(r.edits, r.keepArgs(compose, kept))
+	}
+
+	// Add the state checks to the ConfigStateChecks field.
+	switch {
+	case stateChecksLit != nil:
+		r.edits = append(r.edits, r.appendElts(stateChecksLit, stateChecks))
+	case len(kept) > 0 && checkFieldComma:
+		r.edits = append(r.edits, edit{
+			start: checkFieldEnd,
+			end:   checkFieldEnd,
+			text:  "\n" + r.newStateChecksField(stateChecks, true),
+		})
+	case len(kept) > 0:
+		r.edits = append(r.edits, edit{
+			start: checkFieldEnd,
+			end:   checkFieldEnd,
+			text:  ", " + r.newStateChecksField(stateChecks, false),
+		})
+	}
+}
+
+// convert returns the statecheck.ExpectKnownValue() source code equivalent
+// to the TestCheckResourceAttr() call, or an error describing why it cannot
+// be converted.
+func (r *rewriter) convert(call *ast.CallExpr) (string, error) {
+	if len(call.Args) != 3 {
+		return "", fmt.Errorf("TestCheckResourceAttr has %d arguments, expected 3", len(call.Args))
+	}
+
+	keyLit, ok := call.Args[1].(*ast.BasicLit)
+
+	if !ok || keyLit.Kind != token.STRING {
+		return "", fmt.Errorf("attribute key is not a string literal")
+	}
+
+	key, err := strconv.Unquote(keyLit.Value)
+
+	if err != nil {
+		return "", fmt.Errorf("attribute key is not a valid string literal: %w", err)
+	}
+
+	if strings.HasSuffix(key, ".#") || strings.HasSuffix(key, ".%") {
+		return "", fmt.Errorf("attribute key %q is an element count, which has no equivalent known value check", key)
+	}
+
+	if _, err := tfjsonpath.FromFlatmap(key); err != nil {
+		return "", err
+	}
+
+	value := r.source(call.Args[2])
+
+	if valueLit, ok := call.Args[2].(*ast.BasicLit); ok && valueLit.Kind == token.STRING {
+		v, err := strconv.Unquote(valueLit.Value)
+
+		if err != nil {
+			return "", fmt.Errorf("value is not a valid string literal: %w", err)
+		}
+
+		if v == "true" || v == "false" {
+			return "", fmt.Errorf("value %q may be a bool, which requires a knownvalue.Bool() check", v)
+		}
+
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return "", fmt.Errorf("value %q may be a number, which requires a knownvalue.Int64Exact() or knownvalue.Float64Exact() check", v)
+		}
+	}
+
+	return fmt.Sprintf("%s.ExpectKnownValue(%s, %s, %s.StringExact(%s))",
+		r.statecheckName,
+		r.source(call.Args[0]),
+		r.pathSource(key),
+		r.knownvalueName,
+		value,
+	), nil
+}
+
+// pathSource returns the tfjsonpath.Path source code for the flatmap key,
+// which has been validated by tfjsonpath.FromFlatmap().
+func (r *rewriter) pathSource(key string) string {
+	parts := strings.Split(key, ".")
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s.New(%s)", r.tfjsonpathName, strconv.Quote(parts[0]))
+
+	for _, part := range parts[1:] {
+		if index, err := strconv.Atoi(part); err == nil && part[0] >= '0' && part[0] <= '9' {
+			fmt.Fprintf(&b, ".AtSliceIndex(%d)", index)
+
+			continue
+		}
+
+		fmt.Fprintf(&b, ".AtMapKey(%s)", strconv.Quote(part))
+	}
+
+	return b.String()
+}
+
+// newStateChecksField returns the source code of a ConfigStateChecks field
+// with the given state checks.
+func (r *rewriter) newStateChecksField(stateChecks []string, trailingComma bool) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "ConfigStateChecks: []%s.StateCheck{\n", r.statecheckName)
+
+	for _, stateCheck := range stateChecks {
+		b.WriteString(stateCheck + ",\n")
+	}
+
+	b.WriteString("}")
+
+	if trailingComma {
+		b.WriteString(",")
+	}
+
+	return b.String()
+}
+
+// keepArgs returns the edit which removes all but the kept arguments from
+// the call. Comments before each kept argument are preserved.
+func (r *rewriter) keepArgs(call *ast.CallExpr, kept []int) edit {
+	var segments []string
+
+	for _, i := range kept {
+		start := r.offset(call.Lparen) + 1
+
+		if i > 0 {
+			start, _ = r.commaAfter(call.Args[i-1].End())
+		}
+
+		segments = append(segments, string(r.src[start:r.offset(call.Args[i].End())]))
+	}
+
+	lastArgEnd := r.offset(call.Args[len(call.Args)-1].End())
+	rparen := r.offset(call.Rparen)
+
+	return edit{
+		start: r.offset(call.Lparen) + 1,
+		end:   rparen,
+		text:  strings.Join(segments, ",") + string(r.src[lastArgEnd:rparen]),
+	}
+}
+
+// appendElts returns the edit which appends the elements to the composite
+// literal.
+func (r *rewriter) appendElts(lit *ast.CompositeLit, elts []string) edit {
+	// Insert after the last element, so whitespace before the closing brace
+	// is preserved.
+	offset := len(bytes.TrimRight(r.src[:r.offset(lit.Rbrace)], " \t\r\n"))
+
+	var b strings.Builder
+
+	if len(lit.Elts) > 0 && r.src[offset-1] != ',' {
+		b.WriteString(",")
+	}
+
+	for _, elt := range elts {
+		b.WriteString("\n" + elt + ",")
+	}
+
+	if len(lit.Elts) == 0 {
+		b.WriteString("\n")
+	}
+
+	return edit{
+		start: offset,
+		end:   offset,
+		text:  b.String(),
+	}
+}
+
+// addImports records the edit which imports the missing packages.
+func (r *rewriter) addImports() {
+	if len(r.missingImports) == 0 {
+		return
+	}
+
+	var b strings.Builder
+
+	for _, decl := range r.file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+
+		if genDecl.Lparen.IsValid() {
+			for _, path := range r.missingImports {
+				b.WriteString("\t" + strconv.Quote(path) + "\n")
+			}
+
+			rparen := r.offset(genDecl.Rparen)
+
+			r.edits = append(r.edits, edit{
+				start: rparen,
+				end:   rparen,
+				text:  b.String(),
+			})
+
+			return
+		}
+
+		// Replace a single import declaration with an import block.
+		b.WriteString("import (\n\t" + r.source(genDecl.Specs[0]) + "\n")
+
+		for _, path := range r.missingImports {
+			b.WriteString("\t" + strconv.Quote(path) + "\n")
+		}
+
+		b.WriteString(")")
+
+		r.edits = append(r.edits, edit{
+			start: r.offset(genDecl.Pos()),
+			end:   r.offset(genDecl.End()),
+			text:  b.String(),
+		})
+
+		return
+	}
+}
+
+// reportUnhandled adds findings for TestCheckResourceAttr() calls which are
+// not within a TestStep Check field.
+func (r *rewriter) reportUnhandled() {
+	ast.Inspect(r.file, func(n ast.Node) bool {
+		call, ok := r.isResourceCall(asExpr(n), "TestCheckResourceAttr")
+
+		if !ok || r.handled[call] {
+			return true
+		}
+
+		r.addFinding(call, "TestCheckResourceAttr is not the Check field or a ComposeTestCheckFunc argument of a TestStep")
+
+		return true
+	})
+}
+
+// apply returns the source with all edits applied.
+func (r *rewriter) apply() []byte {
+	sort.SliceStable(r.edits, func(i, j int) bool {
+		return r.edits[i].start > r.edits[j].start
+	})
+
+	src := append([]byte(nil), r.src...)
+
+	for _, e := range r.edits {
+		src = append(src[:e.start], append([]byte(e.text), src[e.end:]...)...)
+	}
+
+	return src
+}
+
+func (r *rewriter) addFinding(call *ast.CallExpr, message string) {
+	r.findings = append(r.findings, Finding{
+		Position: r.fset.Position(call.Pos()),
+		Message:  message,
+	})
+}
+
+// commaAfter returns the offset after the comma following the position,
+// ignoring whitespace, and true, or the offset of the position and false if
+// there is no comma.
+func (r *rewriter) commaAfter(pos token.Pos) (int, bool) {
+	offset := r.offset(pos)
+
+	for i := offset; i < len(r.src); i++ {
+		switch r.src[i] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case ',':
+			return i + 1, true
+		}
+
+		break
+	}
+
+	return offset, false
+}
+
+func (r *rewriter) offset(pos token.Pos) int {
+	return r.fset.Position(pos).Offset
+}
+
+func (r *rewriter) source(node ast.Node) string {
+	return string(r.src[r.offset(node.Pos()):r.offset(node.End())])
+}
+
+// keyValue returns the field with the given name in the composite literal,
+// or nil if it is not set.
+func keyValue(lit *ast.CompositeLit, name string) *ast.KeyValueExpr {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+
+		if !ok {
+			continue
+		}
+
+		if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == name {
+			return kv
+		}
+	}
+
+	return nil
+}
+
+// asExpr returns the node as an expression, or nil if it is not one.
+func asExpr(n ast.Node) ast.Expr {
+	expr, _ := n.(ast.Expr)
+
+	return expr
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package checkmigrate_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/checkmigrate"
+)
+
+func TestRewrite(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		src               string
+		expectedSrc       string
+		expectedConverted int
+		expectedFindings  []string
+	}{
+		"compose-all-converted": {
+			src: `package example
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExample(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					// checks the name
+					resource.TestCheckResourceAttr("example_widget.test", "name", rName),
+					resource.TestCheckResourceAttr("example_widget.test", "rule.0.tags.env", "prod"),
+				),
+			},
+		},
+	})
+}
+`,
+			expectedSrc: `package example
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccExample(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(rName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("example_widget.test", tfjsonpath.New("name"), knownvalue.StringExact(rName)),
+					statecheck.ExpectKnownValue("example_widget.test", tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("tags").AtMapKey("env"), knownvalue.StringExact("prod")),
+				},
+			},
+		},
+	})
+}
+`,
+			expectedConverted: 2,
+		},
+		"compose-partially-converted": {
+			src: `package example
+
+import (
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestAccExample(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Steps: []r.TestStep{
+			{
+				Config: testAccConfig(),
+				Check: r.ComposeAggregateTestCheckFunc(
+					r.TestCheckResourceAttr("example_widget.test", "name", "example"),
+					// checks the rule count
+					r.TestCheckResourceAttr("example_widget.test", "rule.#", "1"),
+					r.TestCheckResourceAttr("example_widget.test", "enabled", "true"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectMark("example_widget.test", tfjsonpath.New("password"), statecheck.MarkSensitive),
+				},
+			},
+		},
+	})
+}
+`,
+			expectedSrc: `package example
+
+import (
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccExample(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Steps: []r.TestStep{
+			{
+				Config: testAccConfig(),
+				Check: r.ComposeAggregateTestCheckFunc(
+					// checks the rule count
+					r.TestCheckResourceAttr("example_widget.test", "rule.#", "1"),
+					r.TestCheckResourceAttr("example_widget.test", "enabled", "true"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectMark("example_widget.test", tfjsonpath.New("password"), statecheck.MarkSensitive),
+					statecheck.ExpectKnownValue("example_widget.test", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+				},
+			},
+		},
+	})
+}
+`,
+			expectedConverted: 1,
+			expectedFindings: []string{
+				`example_test.go:18:6: attribute key "rule.#" is an element count, which has no equivalent known value check`,
+				`example_test.go:19:6: value "true" may be a bool, which requires a knownvalue.Bool() check`,
+			},
+		},
+		"single-check": {
+			src: `package example
+
+import "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+var step = resource.TestStep{Config: config, Check: resource.TestCheckResourceAttr("example_widget.test", "name", "example")}
+`,
+			expectedSrc: `package example
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var step = resource.TestStep{Config: config, ConfigStateChecks: []statecheck.StateCheck{
+	statecheck.ExpectKnownValue("example_widget.test", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+}}
+`,
+			expectedConverted: 1,
+		},
+		"single-check-not-converted": {
+			src: `package example
+
+import "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+var step = resource.TestStep{Config: config, Check: resource.TestCheckResourceAttr("example_widget.test", "port", "80")}
+`,
+			expectedFindings: []string{
+				`example_test.go:5:53: value "80" may be a number, which requires a knownvalue.Int64Exact() or knownvalue.Float64Exact() check`,
+			},
+		},
+		"config-state-checks-variable": {
+			src: `package example
+
+import "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+var step = resource.TestStep{
+	Check:             resource.TestCheckResourceAttr("example_widget.test", "name", "example"),
+	ConfigStateChecks: stateChecks,
+}
+`,
+			expectedFindings: []string{
+				`example_test.go:6:21: TestStep ConfigStateChecks is not a composite literal`,
+			},
+		},
+		"outside-step": {
+			src: `package example
+
+import "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+func testAccCheck() resource.TestCheckFunc {
+	return resource.TestCheckResourceAttr("example_widget.test", "name", "example")
+}
+`,
+			expectedFindings: []string{
+				`example_test.go:6:9: TestCheckResourceAttr is not the Check field or a ComposeTestCheckFunc argument of a TestStep`,
+			},
+		},
+		"key-not-literal": {
+			src: `package example
+
+import "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+var step = resource.TestStep{
+	Check: resource.TestCheckResourceAttr("example_widget.test", key, "example"),
+}
+`,
+			expectedFindings: []string{
+				`example_test.go:6:9: attribute key is not a string literal`,
+			},
+		},
+		"no-resource-import": {
+			src: `package example
+
+var step = TestStep{
+	Check: TestCheckResourceAttr("example_widget.test", "name", "example"),
+}
+`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := checkmigrate.Rewrite("example_test.go", []byte(testCase.src))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			expectedSrc := testCase.expectedSrc
+
+			if expectedSrc == "" {
+				expectedSrc = testCase.src
+			}
+
+			if diff := cmp.Diff(string(got.Source), expectedSrc); diff != "" {
+				t.Errorf("unexpected source difference: %s", diff)
+			}
+
+			if got.Converted != testCase.expectedConverted {
+				t.Errorf("expected %d converted, got %d", testCase.expectedConverted, got.Converted)
+			}
+
+			if got.Changed() != (testCase.expectedConverted > 0) {
+				t.Errorf("unexpected changed: %t", got.Changed())
+			}
+
+			var findings []string
+
+			for _, finding := range got.Findings {
+				findings = append(findings, finding.String())
+			}
+
+			if diff := cmp.Diff(findings, testCase.expectedFindings); diff != "" {
+				t.Errorf("unexpected findings difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRewrite_ParseError(t *testing.T) {
+	t.Parallel()
+
+	_, err := checkmigrate.Rewrite("example_test.go", []byte("package"))
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -16,7 +16,8 @@ const (
 	// Environment variable with hostname for the provider under acceptance
 	// test. The hostname is the first portion of the full provider source
 	// address, such as "example.com" in example.com/myorg/myprovider. Defaults
-	// to "registry.terraform.io".
+	// to "registry.terraform.io", or "registry.opentofu.org" when running
+	// against OpenTofu.
 	//
 	// Only required if any Terraform configuration set via the TestStep
 	// type Config field includes a provider source, such as the terraform
@@ -32,4 +33,64 @@ const (
 	// type Config field includes a provider source, such as the terraform
 	// configuration block required_providers attribute.
 	EnvTfAccProviderNamespace = "TF_ACC_PROVIDER_NAMESPACE"
+
+	// Environment variable to enable interrupt signal handling during
+	// acceptance testing. When enabled and the test binary receives an
+	// interrupt (SIGINT) or termination (SIGTERM) signal, any running
+	// Terraform CLI command is stopped, no further TestStep are run, and a
+	// best effort destroy of the resources created by each in-flight TestCase
+	// is attempted before the test fails. TestCase which start after the
+	// signal are not run, and a second signal exits the test binary
+	// immediately. Defaults to disabled, in which the test binary exits
+	// immediately on those signals. Can be set to any value to enable signal
+	// handling, however "1" is conventional.
+	EnvTfAccDestroyOnInterrupt = "TF_ACC_DESTROY_ON_INTERRUPT"
+
+	// Environment variable with path to a journal file, which has an entry
+	// appended before each TestStep apply, including convergence applies.
+	// Each entry is a single line JSON object containing the test name, step
+	// number, SHA-256 hash of the configuration contents, working directory,
+	// and timestamp, so external cleanup tooling can identify which test
+	// created resources that remain after a crashed or interrupted run.
+	// Defaults to disabled.
+	EnvTfAccJournalPath = "TF_ACC_JOURNAL_PATH"
+
+	// Environment variable with path to a report file, which has an event
+	// appended after each TestStep and TestCase. Each event is a single line
+	// JSON object containing the test name, step number, phase, duration,
+	// result, and any failure messages, so CI tooling can report on
+	// individual TestStep. Defaults to disabled.
+	EnvTfAccReportPath = "TF_ACC_REPORT_PATH"
+
+	// Environment variable with path to a JUnit XML file, which is written
+	// from the TF_ACC_REPORT_PATH report file after all tests have run when
+	// using the TestMain() function of this package. Requires
+	// TF_ACC_REPORT_PATH to be set. Defaults to disabled.
+	EnvTfAccReportJUnitPath = "TF_ACC_REPORT_JUNIT_PATH"
+
+	// Environment variable with the base seed of the random values generated
+	// by the helper/acctest package, such as the value written to the test
+	// output of a failed TestCase. TestCase which do not set the RandomSeed
+	// field are seeded with a seed derived from the base seed and the test
+	// name, so tests of the same run do not generate the same values. Also
+	// seeds the helper/acctest package when the test binary starts. Defaults
+	// to a new base seed for each TestCase.
+	EnvTfAccRandomSeed = "TF_ACC_RANDOM_SEED"
+
+	// Environment variable to rerun a failed TestCase once from the start in
+	// a new working directory. The TestCase passes if the rerun passes, in
+	// which case the TestCase event in the TF_ACC_REPORT_PATH report has the
+	// flaky-pass result. Set to "1" to rerun any failure, or to a comma
+	// separated list of report failure categories, such as
+	// "timeout,init_network", to only rerun failures in those categories.
+	// TestCase with TestStep subtests are not rerun. Defaults to disabled.
+	EnvTfAccRerunFailed = "TF_ACC_RERUN_FAILED"
+
+	// Environment variable to run each TestStep as a Go subtest of the
+	// TestCase test, as if the TestCase StepSubtests field was enabled, so
+	// go test output, -run filtering, and CI reporting show individual
+	// TestStep. TestCase run with a testing.T other than *testing.T are run
+	// without subtests. Defaults to disabled. Can be set to any value to
+	// enable subtests, however "1" is conventional.
+	EnvTfAccStepSubtests = "TF_ACC_STEP_SUBTESTS"
 )
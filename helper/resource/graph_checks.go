@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/graphcheck"
+)
+
+// runGraphChecks calls each of the given graph checks in order, returning an
+// aggregate error of all failed graph checks.
+func runGraphChecks(ctx context.Context, t testing.T, graph *graphcheck.Graph, stepNumber int, stepName string, graphChecks []graphcheck.GraphCheck) error {
+	t.Helper()
+
+	req := graphcheck.CheckGraphRequest{
+		Graph:      graph,
+		StepNumber: stepNumber,
+		StepName:   stepName,
+		TestName:   t.Name(),
+	}
+
+	var result *multierror.Error
+
+	for i, graphCheck := range graphChecks {
+		resp := graphcheck.CheckGraphResponse{}
+		graphCheck.CheckGraph(ctx, req, &resp)
+
+		if resp.Error != nil {
+			result = multierror.Append(result, fmt.Errorf("graph check %d/%d error: %w", i+1, len(graphChecks), resp.Error))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// runPlanChecks calls each of the given plan checks in order, returning an
+// aggregate error of all failed plan checks.
+func runPlanChecks(ctx context.Context, t testing.T, plan *tfjson.Plan, renderedPlan string, stepNumber int, stepName string, planChecks []plancheck.PlanCheck) error {
+	t.Helper()
+
+	req := plancheck.CheckPlanRequest{
+		Plan:         plan,
+		RenderedPlan: renderedPlan,
+		StepNumber:   stepNumber,
+		StepName:     stepName,
+		TestName:     t.Name(),
+	}
+
+	var result *multierror.Error
+
+	for i, planCheck := range planChecks {
+		resp := plancheck.CheckPlanResponse{}
+		planCheck.CheckPlan(ctx, req, &resp)
+
+		if resp.Error != nil {
+			result = multierror.Append(result, fmt.Errorf("plan check %d/%d error: %w", i+1, len(planChecks), resp.Error))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// savedRenderedPlan returns the human-readable rendering of the saved plan if
+// any of the given plan checks requires it, otherwise an empty string.
+func savedRenderedPlan(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, providers *providerFactories, planChecks ...[]plancheck.PlanCheck) (string, error) {
+	t.Helper()
+
+	required := false
+
+	for _, phase := range planChecks {
+		for _, planCheck := range phase {
+			if p, ok := planCheck.(plancheck.PlanCheckWithRenderedPlan); ok && p.RequiresRenderedPlan() {
+				required = true
+			}
+		}
+	}
+
+	if !required {
+		return "", nil
+	}
+
+	var renderedPlan string
+
+	err := runProviderCommand(ctx, t, func() error {
+		var err error
+		renderedPlan, err = wd.SavedPlanRawStdout(ctx)
+		return err
+	}, wd, providers)
+
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving rendered plan: %w", err)
+	}
+
+	return renderedPlan, nil
+}
+
+// postApplyPreRefresh returns the plan checks of the PostApplyPreRefresh
+// phase, followed by those of the deprecated PostApply field.
+func (c ConfigPlanChecks) postApplyPreRefresh() []plancheck.PlanCheck {
+	if len(c.PostApply) == 0 { //nolint:staticcheck // deprecated field is still supported
+		return c.PostApplyPreRefresh
+	}
+
+	planChecks := make([]plancheck.PlanCheck, 0, len(c.PostApplyPreRefresh)+len(c.PostApply)) //nolint:staticcheck // deprecated field is still supported
+	planChecks = append(planChecks, c.PostApplyPreRefresh...)
+
+	return append(planChecks, c.PostApply...) //nolint:staticcheck // deprecated field is still supported
+}
+
+// expectsNonEmptyPlan returns true if the given plan checks contain
+// plancheck.ExpectNonEmptyPlan(), which replaces the implicit empty plan
+// check of a ConfigPlanChecks phase.
+func expectsNonEmptyPlan(planChecks []plancheck.PlanCheck) bool {
+	for _, planCheck := range planChecks {
+		if planCheck == plancheck.ExpectNonEmptyPlan() {
+			return true
+		}
+	}
+
+	return false
+}
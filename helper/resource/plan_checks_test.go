@@ -0,0 +1,431 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestRunPlanChecks(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{FormatVersion: "1.1"}
+	errCheck := errors.New("check failed")
+
+	first := &planCheckSpy{}
+	second := &planCheckSpy{err: errCheck}
+	third := &planCheckSpy{}
+
+	err := runPlanChecks(context.Background(), t, plan, "", 2, "update", []plancheck.PlanCheck{first, second, third})
+
+	if !errors.Is(err, errCheck) {
+		t.Errorf("expected error %q, got: %s", errCheck, err)
+	}
+
+	if first.plan != plan {
+		t.Errorf("expected first plan check to receive plan")
+	}
+
+	if first.stepNumber != 2 || first.stepName != "update" || first.testName != t.Name() {
+		t.Errorf("expected first plan check to receive step details, got %q step %d %q", first.testName, first.stepNumber, first.stepName)
+	}
+
+	if !second.called {
+		t.Errorf("expected second plan check to be called")
+	}
+
+	if !third.called {
+		t.Errorf("expected third plan check to be called")
+	}
+}
+
+func TestRunPlanChecks_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{FormatVersion: "1.1"}
+
+	err := runPlanChecks(context.Background(), t, plan, "", 1, "", []plancheck.PlanCheck{
+		&planCheckSpy{err: errors.New("first failed")},
+		&planCheckSpy{},
+		&planCheckSpy{err: errors.New("third failed")},
+	})
+
+	expected := "2 errors occurred:\n\t* plan check 1/3 error: first failed\n\t* plan check 3/3 error: third failed\n\n"
+
+	if err == nil {
+		t.Fatalf("expected error: %s", expected)
+	}
+
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got: %q", expected, err)
+	}
+}
+
+func TestTest_TestStep_ConfigPlanChecks_PreApply(t *testing.T) {
+	t.Parallel()
+
+	spy := &planCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{spy},
+				},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected PreApply plan check to be called")
+	}
+}
+
+func TestTest_TestStep_ConfigPlanChecks_PreApply_Error(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						&planCheckSpy{err: errors.New("spy error")},
+					},
+				},
+				ExpectError: regexp.MustCompile(`Pre-apply plan check\(s\) failed:\nspy error`),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConfigPlanChecks_PostApply(t *testing.T) {
+	t.Parallel()
+
+	spy := &planCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PostApply: []plancheck.PlanCheck{spy}, //nolint:staticcheck // testing deprecated field
+				},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected PostApply plan check to be called")
+	}
+}
+
+func TestTest_TestStep_ConfigPlanChecks_PostApplyPreRefresh(t *testing.T) {
+	t.Parallel()
+
+	spy := &planCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+						spy,
+					},
+				},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected PostApplyPreRefresh plan check to be called")
+	}
+}
+
+func TestConfigPlanChecksPostApplyPreRefresh(t *testing.T) {
+	t.Parallel()
+
+	preRefresh := &planCheckSpy{}
+	postApply := &planCheckSpy{}
+
+	testCases := map[string]struct {
+		configPlanChecks ConfigPlanChecks
+		expected         []plancheck.PlanCheck
+	}{
+		"none": {},
+		"post-apply-pre-refresh": {
+			configPlanChecks: ConfigPlanChecks{
+				PostApplyPreRefresh: []plancheck.PlanCheck{preRefresh},
+			},
+			expected: []plancheck.PlanCheck{preRefresh},
+		},
+		"post-apply": {
+			configPlanChecks: ConfigPlanChecks{
+				PostApply: []plancheck.PlanCheck{postApply}, //nolint:staticcheck // testing deprecated field
+			},
+			expected: []plancheck.PlanCheck{postApply},
+		},
+		"both": {
+			configPlanChecks: ConfigPlanChecks{
+				PostApplyPreRefresh: []plancheck.PlanCheck{preRefresh},
+				PostApply:           []plancheck.PlanCheck{postApply}, //nolint:staticcheck // testing deprecated field
+			},
+			expected: []plancheck.PlanCheck{preRefresh, postApply},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.configPlanChecks.postApplyPreRefresh()
+
+			if len(got) != len(testCase.expected) {
+				t.Fatalf("expected %d plan checks, got %d", len(testCase.expected), len(got))
+			}
+
+			for i := range got {
+				if got[i] != testCase.expected[i] {
+					t.Errorf("expected plan check %d to be %p, got %p", i, testCase.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTest_TestStep_ConfigPlanChecks_PostApplyPostRefresh(t *testing.T) {
+	t.Parallel()
+
+	spy := &planCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+						spy,
+					},
+				},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected PostApplyPostRefresh plan check to be called")
+	}
+}
+
+func TestTest_TestStep_ConfigPlanChecks_ExpectNonEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length  = 16
+					keepers = {
+						time = timestamp()
+					}
+				}`,
+				ConfigPlanChecks: ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectNonEmptyPlan(),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectNonEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_RefreshPlanChecks_PostRefresh(t *testing.T) {
+	t.Parallel()
+
+	spy := &planCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+			},
+			{
+				RefreshState: true,
+				RefreshPlanChecks: RefreshPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+						spy,
+					},
+				},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected PostRefresh plan check to be called")
+	}
+}
+
+func TestTest_TestStep_DestroyPlanChecks_PreDestroy(t *testing.T) {
+	t.Parallel()
+
+	spy := &planCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+			},
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				Destroy: true,
+				DestroyPlanChecks: DestroyPlanChecks{
+					PreDestroy: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("random_string.one", plancheck.ResourceActionDestroy),
+						plancheck.ExpectResourceActionCount(plancheck.ResourceActionDestroy, 1),
+						spy,
+					},
+				},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected PreDestroy plan check to be called")
+	}
+}
+
+func TestExpectsNonEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		planChecks []plancheck.PlanCheck
+		expected   bool
+	}{
+		"nil": {},
+		"expect-empty-plan": {
+			planChecks: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+		},
+		"expect-non-empty-plan": {
+			planChecks: []plancheck.PlanCheck{
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 1),
+				plancheck.ExpectNonEmptyPlan(),
+			},
+			expected: true,
+		},
+		"not-expect-non-empty-plan": {
+			planChecks: []plancheck.PlanCheck{plancheck.Not(plancheck.ExpectNonEmptyPlan())},
+		},
+		"spy": {
+			planChecks: []plancheck.PlanCheck{&planCheckSpy{}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := expectsNonEmptyPlan(testCase.planChecks)
+
+			if got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+var _ plancheck.PlanCheck = &planCheckSpy{}
+
+// planCheckSpy is a plan check which records whether it was called and the
+// plan it received, and responds with the given error.
+type planCheckSpy struct {
+	err        error
+	called     bool
+	plan       *tfjson.Plan
+	stepNumber int
+	stepName   string
+	testName   string
+}
+
+func (s *planCheckSpy) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	s.called = true
+	s.plan = req.Plan
+	s.stepNumber = req.StepNumber
+	s.stepName = req.StepName
+	s.testName = req.TestName
+	resp.Error = s.err
+}
@@ -5,9 +5,12 @@ package resource
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -111,6 +114,48 @@ type providerFactories struct {
 	legacy  sdkProviderFactories
 	protov5 protov5ProviderFactories
 	protov6 protov6ProviderFactories
+
+	// diagnostics, if set, records the attribute paths of all diagnostics
+	// returned by the providers.
+	diagnostics *diagnosticRecorder
+
+	// crashes, if set, recovers and records panics in the providers, which
+	// then stop as if the provider process exited.
+	crashes *providerCrashRecorder
+
+	// consistency, if set, checks the resource change responses of the
+	// providers with the data consistency rules of Terraform core.
+	consistency *providerConsistencyRecorder
+}
+
+// initKey returns a hash of the provider configuration and the names of the
+// provider factories, which together determine the result of running init.
+// The reattach addresses of the provider servers are not included, as they
+// change with every command and do not affect init.
+func (f *providerFactories) initKey(providerCfg string) string {
+	var names []string
+
+	for name := range f.legacy {
+		names = append(names, "legacy:"+name)
+	}
+
+	for name := range f.protov5 {
+		names = append(names, "protov5:"+name)
+	}
+
+	for name := range f.protov6 {
+		names = append(names, "protov6:"+name)
+	}
+
+	sort.Strings(names)
+
+	h := sha256.New()
+
+	h.Write([]byte(providerCfg))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(names, "\n")))
+
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *plugintest.WorkingDir, factories *providerFactories) error {
@@ -151,7 +196,8 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 	// Terraform we're talking to. We're also going to allow overriding
 	// the host or namespace using environment variables.
 	var namespaces []string
-	host := "registry.terraform.io"
+	defaultHost := defaultProviderHost(wd)
+	host := defaultHost
 	if v := os.Getenv(EnvTfAccProviderNamespace); v != "" {
 		namespaces = append(namespaces, v)
 	} else {
@@ -176,7 +222,7 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 		// providerName may be returned as terraform-provider-foo, and
 		// we need just foo. So let's fix that.
 		providerName = strings.TrimPrefix(providerName, "terraform-provider-")
-		providerAddress := getProviderAddr(providerName)
+		providerAddress := getProviderAddr(providerName, defaultHost)
 
 		logging.HelperResourceDebug(ctx, "Creating sdkv2 provider instance", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
 
@@ -201,9 +247,34 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 		// the GRPCProviderFunc wraps a non-gRPC provider server
 		// into a gRPC interface, and the logger just discards logs
 		// from go-plugin.
+		var providerServer tfprotov5.ProviderServer = grpcProviderServer
+
+		if factories.consistency != nil {
+			providerServer = consistencyProtoV5ProviderServer{
+				ProviderServer: providerServer,
+				recorder:       factories.consistency,
+			}
+		}
+
+		if factories.diagnostics != nil {
+			providerServer = diagnosticsProtoV5ProviderServer{
+				ProviderServer: providerServer,
+				recorder:       factories.diagnostics,
+			}
+		}
+
+		if factories.crashes != nil {
+			providerServer = crashProtoV5ProviderServer{
+				ProviderServer:  providerServer,
+				providerAddress: providerAddress,
+				recorder:        factories.crashes,
+				stop:            cancel,
+			}
+		}
+
 		opts := &plugin.ServeOpts{
 			GRPCProviderFunc: func() tfprotov5.ProviderServer {
-				return grpcProviderServer
+				return providerServer
 			},
 			Logger: hclog.New(&hclog.LoggerOptions{
 				Name:   "plugintest",
@@ -222,7 +293,10 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 			return fmt.Errorf("unable to serve provider %q: %w", providerName, err)
 		}
 
-		logging.HelperResourceDebug(ctx, "Started sdkv2 provider instance server", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
+		logging.HelperResourceDebug(ctx, "Started sdkv2 provider instance server", map[string]interface{}{
+			logging.KeyProviderAddress:         providerAddress,
+			logging.KeyProviderProtocolVersion: config.ProtocolVersion,
+		})
 
 		tfexecConfig := tfexec.ReattachConfig{
 			Protocol:        config.Protocol,
@@ -258,7 +332,7 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 		// providerName may be returned as terraform-provider-foo, and
 		// we need just foo. So let's fix that.
 		providerName = strings.TrimPrefix(providerName, "terraform-provider-")
-		providerAddress := getProviderAddr(providerName)
+		providerAddress := getProviderAddr(providerName, defaultHost)
 
 		// If the user has supplied the same provider in both
 		// ProviderFactories and ProtoV5ProviderFactories, they made a
@@ -281,6 +355,29 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 
 		logging.HelperResourceDebug(ctx, "Created tfprotov5 provider instance", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
 
+		if factories.consistency != nil {
+			provider = consistencyProtoV5ProviderServer{
+				ProviderServer: provider,
+				recorder:       factories.consistency,
+			}
+		}
+
+		if factories.diagnostics != nil {
+			provider = diagnosticsProtoV5ProviderServer{
+				ProviderServer: provider,
+				recorder:       factories.diagnostics,
+			}
+		}
+
+		if factories.crashes != nil {
+			provider = crashProtoV5ProviderServer{
+				ProviderServer:  provider,
+				providerAddress: providerAddress,
+				recorder:        factories.crashes,
+				stop:            cancel,
+			}
+		}
+
 		// keep track of the running factory, so we can make sure it's
 		// shut down.
 		wg.Add(1)
@@ -310,7 +407,10 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 			return fmt.Errorf("unable to serve provider %q: %w", providerName, err)
 		}
 
-		logging.HelperResourceDebug(ctx, "Started tfprotov5 provider instance server", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
+		logging.HelperResourceDebug(ctx, "Started tfprotov5 provider instance server", map[string]interface{}{
+			logging.KeyProviderAddress:         providerAddress,
+			logging.KeyProviderProtocolVersion: config.ProtocolVersion,
+		})
 
 		tfexecConfig := tfexec.ReattachConfig{
 			Protocol:        config.Protocol,
@@ -347,7 +447,7 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 		// providerName may be returned as terraform-provider-foo, and
 		// we need just foo. So let's fix that.
 		providerName = strings.TrimPrefix(providerName, "terraform-provider-")
-		providerAddress := getProviderAddr(providerName)
+		providerAddress := getProviderAddr(providerName, defaultHost)
 
 		// If the user has already registered this provider in
 		// ProviderFactories or ProtoV5ProviderFactories, they made a
@@ -370,6 +470,29 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 
 		logging.HelperResourceDebug(ctx, "Created tfprotov6 provider instance", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
 
+		if factories.consistency != nil {
+			provider = consistencyProtoV6ProviderServer{
+				ProviderServer: provider,
+				recorder:       factories.consistency,
+			}
+		}
+
+		if factories.diagnostics != nil {
+			provider = diagnosticsProtoV6ProviderServer{
+				ProviderServer: provider,
+				recorder:       factories.diagnostics,
+			}
+		}
+
+		if factories.crashes != nil {
+			provider = crashProtoV6ProviderServer{
+				ProviderServer:  provider,
+				providerAddress: providerAddress,
+				recorder:        factories.crashes,
+				stop:            cancel,
+			}
+		}
+
 		// keep track of the running factory, so we can make sure it's
 		// shut down.
 		wg.Add(1)
@@ -395,7 +518,10 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 			return fmt.Errorf("unable to serve provider %q: %w", providerName, err)
 		}
 
-		logging.HelperResourceDebug(ctx, "Started tfprotov6 provider instance server", map[string]interface{}{logging.KeyProviderAddress: providerAddress})
+		logging.HelperResourceDebug(ctx, "Started tfprotov6 provider instance server", map[string]interface{}{
+			logging.KeyProviderAddress:         providerAddress,
+			logging.KeyProviderProtocolVersion: config.ProtocolVersion,
+		})
 
 		tfexecConfig := tfexec.ReattachConfig{
 			Protocol:        config.Protocol,
@@ -477,8 +603,19 @@ func runProviderCommand(ctx context.Context, t testing.T, f func() error, wd *pl
 	return err
 }
 
-func getProviderAddr(name string) string {
-	host := "registry.terraform.io"
+// defaultProviderHost returns the registry hostname of the provider under
+// test when TF_ACC_PROVIDER_HOST is not set, which depends on whether the
+// Terraform CLI executable is OpenTofu.
+func defaultProviderHost(wd *plugintest.WorkingDir) string {
+	if h := wd.GetHelper(); h != nil && h.IsOpenTofu() {
+		return "registry.opentofu.org"
+	}
+
+	return "registry.terraform.io"
+}
+
+func getProviderAddr(name string, defaultHost string) string {
+	host := defaultHost
 	namespace := "hashicorp"
 	if v := os.Getenv(EnvTfAccProviderNamespace); v != "" {
 		namespace = v
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -243,6 +244,75 @@ func TestSdkProviderFactoriesMerge(t *testing.T) {
 	}
 }
 
+func TestProviderFactoriesInitKey(t *testing.T) {
+	t.Parallel()
+
+	providerCfg := `terraform {
+  required_providers {
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+      version = "3.4.3"
+    }
+  }
+}`
+
+	base := &providerFactories{
+		protov5: map[string]func() (tfprotov5.ProviderServer, error){
+			"example": nil, // does not need to be real
+		},
+	}
+
+	testCases := map[string]struct {
+		providers   *providerFactories
+		providerCfg string
+		expectEqual bool
+	}{
+		"same": {
+			providers: &providerFactories{
+				protov5: map[string]func() (tfprotov5.ProviderServer, error){
+					"example": nil, // does not need to be real
+				},
+			},
+			providerCfg: providerCfg,
+			expectEqual: true,
+		},
+		"different-config": {
+			providers:   base,
+			providerCfg: strings.Replace(providerCfg, "3.4.3", "3.5.1", 1),
+		},
+		"different-factory-name": {
+			providers: &providerFactories{
+				protov5: map[string]func() (tfprotov5.ProviderServer, error){
+					"other": nil, // does not need to be real
+				},
+			},
+			providerCfg: providerCfg,
+		},
+		"different-factory-protocol": {
+			providers: &providerFactories{
+				protov6: map[string]func() (tfprotov6.ProviderServer, error){
+					"example": nil, // does not need to be real
+				},
+			},
+			providerCfg: providerCfg,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.providers.initKey(testCase.providerCfg) == base.initKey(providerCfg)
+
+			if got != testCase.expectEqual {
+				t.Errorf("expected equal init keys: %t, got: %t", testCase.expectEqual, got)
+			}
+		})
+	}
+}
+
 func TestRunProviderCommand(t *testing.T) {
 	t.Parallel()
 
@@ -303,3 +373,45 @@ func TestRunProviderCommand(t *testing.T) {
 		t.Error("expected func to be called")
 	}
 }
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestGetProviderAddr(t *testing.T) {
+	testCases := map[string]struct {
+		defaultHost string
+		host        string
+		namespace   string
+		expected    string
+	}{
+		"terraform": {
+			defaultHost: "registry.terraform.io",
+			expected:    "registry.terraform.io/hashicorp/example",
+		},
+		"opentofu": {
+			defaultHost: "registry.opentofu.org",
+			expected:    "registry.opentofu.org/hashicorp/example",
+		},
+		"host-override": {
+			defaultHost: "registry.opentofu.org",
+			host:        "example.com",
+			expected:    "example.com/hashicorp/example",
+		},
+		"namespace-override": {
+			defaultHost: "registry.terraform.io",
+			namespace:   "myorg",
+			expected:    "registry.terraform.io/myorg/example",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvTfAccProviderHost, testCase.host)
+			t.Setenv(EnvTfAccProviderNamespace, testCase.namespace)
+
+			got := getProviderAddr("example", testCase.defaultHost)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
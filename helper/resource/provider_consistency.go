@@ -0,0 +1,696 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerConsistencyViolation is a response of a provider under test which
+// violates the data consistency rules of Terraform core.
+type providerConsistencyViolation struct {
+	// rpc is the name of the RPC, such as ApplyResourceChange.
+	rpc string
+
+	// typeName is the resource type name of the RPC.
+	typeName string
+
+	// attributePath is the attribute path of the violation, as shown by
+	// Terraform, such as .name or .rule[0].tags["env"]. It is empty if the
+	// violation is for the whole resource.
+	attributePath string
+
+	// detail describes the violation.
+	detail string
+}
+
+func (v providerConsistencyViolation) String() string {
+	if v.attributePath == "" {
+		return fmt.Sprintf("%s during %s: %s", v.typeName, v.rpc, v.detail)
+	}
+
+	return fmt.Sprintf("%s %s during %s: %s", v.typeName, v.attributePath, v.rpc, v.detail)
+}
+
+// providerConsistencyResource is the schema of a resource type, as returned
+// by GetProviderSchema, which is needed to decode and check its values.
+type providerConsistencyResource struct {
+	valueType tftypes.Type
+	block     *tfjson.SchemaBlock
+}
+
+// providerConsistencyRecorder checks the PlanResourceChange and
+// ApplyResourceChange responses of the providers under test with the same
+// rules Terraform core uses, and collects any violations. Terraform tolerates
+// some violations, such as for providers using the terraform-plugin-sdk
+// legacy type system, only logging warnings, so they are otherwise not
+// reported by tests.
+type providerConsistencyRecorder struct {
+	mu         sync.Mutex
+	resources  map[string]providerConsistencyResource
+	violations []providerConsistencyViolation
+}
+
+// recordSchema saves the schema of a resource type. Resource types with
+// schemas which cannot be converted are not checked.
+func (r *providerConsistencyRecorder) recordSchema(typeName string, valueType tftypes.Type, schema *tfjson.Schema, err error) {
+	if err != nil || schema == nil || schema.Block == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.resources == nil {
+		r.resources = make(map[string]providerConsistencyResource)
+	}
+
+	r.resources[typeName] = providerConsistencyResource{
+		valueType: valueType,
+		block:     schema.Block,
+	}
+}
+
+// resource returns the saved schema of a resource type, if any.
+func (r *providerConsistencyRecorder) resource(typeName string) (providerConsistencyResource, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resource, ok := r.resources[typeName]
+
+	return resource, ok
+}
+
+// recordViolations saves the given violations.
+func (r *providerConsistencyRecorder) recordViolations(violations []providerConsistencyViolation) {
+	if len(violations) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.violations = append(r.violations, violations...)
+}
+
+// checkPlan checks the planned state of a PlanResourceChange response
+// against the configuration. Values of attributes which are not computed,
+// and of optional and computed attributes which are set in the
+// configuration, must be planned exactly as configured, including unknown
+// values.
+func (r *providerConsistencyRecorder) checkPlan(typeName string, config func(tftypes.Type) (tftypes.Value, error), plannedState func(tftypes.Type) (tftypes.Value, error)) {
+	resource, ok := r.resource(typeName)
+
+	if !ok {
+		return
+	}
+
+	configValue, err := config(resource.valueType)
+
+	if err != nil {
+		return
+	}
+
+	plannedValue, err := plannedState(resource.valueType)
+
+	if err != nil {
+		return
+	}
+
+	// Destroy plans are not checked.
+	if configValue.IsNull() || plannedValue.IsNull() {
+		return
+	}
+
+	var violations []providerConsistencyViolation
+
+	for _, v := range checkPlannedBlock(tftypes.NewAttributePath(), resource.block, configValue, plannedValue) {
+		violations = append(violations, providerConsistencyViolation{
+			rpc:           "PlanResourceChange",
+			typeName:      typeName,
+			attributePath: v.attributePath,
+			detail:        v.detail,
+		})
+	}
+
+	r.recordViolations(violations)
+}
+
+// checkApply checks the new state of an ApplyResourceChange response
+// against the planned state. Known planned values must be unchanged, and
+// the new state must not contain unknown values.
+func (r *providerConsistencyRecorder) checkApply(typeName string, plannedState func(tftypes.Type) (tftypes.Value, error), newState func(tftypes.Type) (tftypes.Value, error)) {
+	resource, ok := r.resource(typeName)
+
+	if !ok {
+		return
+	}
+
+	plannedValue, err := plannedState(resource.valueType)
+
+	if err != nil {
+		return
+	}
+
+	newValue, err := newState(resource.valueType)
+
+	if err != nil {
+		return
+	}
+
+	var violations []providerConsistencyViolation
+
+	if plannedValue.IsNull() != newValue.IsNull() {
+		detail := "Root resource was present, but now absent"
+
+		if plannedValue.IsNull() {
+			detail = "Root resource was absent, but now present"
+		}
+
+		violations = append(violations, providerConsistencyViolation{
+			rpc:      "ApplyResourceChange",
+			typeName: typeName,
+			detail:   detail,
+		})
+	} else {
+		for _, v := range checkNewValue(tftypes.NewAttributePath(), plannedValue, newValue) {
+			violations = append(violations, providerConsistencyViolation{
+				rpc:           "ApplyResourceChange",
+				typeName:      typeName,
+				attributePath: v.attributePath,
+				detail:        v.detail,
+			})
+		}
+	}
+
+	r.recordViolations(violations)
+}
+
+// check returns an error with all recorded violations, if any, sorted for
+// stable output.
+func (r *providerConsistencyRecorder) check() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(r.violations))
+
+	for _, violation := range r.violations {
+		messages = append(messages, violation.String())
+	}
+
+	sort.Strings(messages)
+
+	return fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n%s", strings.Join(messages, "\n"))
+}
+
+// consistencyViolation is a violation found while comparing values, before
+// the RPC and resource type are known.
+type consistencyViolation struct {
+	attributePath string
+	detail        string
+}
+
+// checkPlannedBlock checks the planned attributes and nested blocks of a
+// block against the configuration.
+func checkPlannedBlock(path *tftypes.AttributePath, block *tfjson.SchemaBlock, config tftypes.Value, planned tftypes.Value) []consistencyViolation {
+	configValues, plannedValues, ok := consistencyObjectValues(config, planned)
+
+	if !ok {
+		return nil
+	}
+
+	var violations []consistencyViolation
+
+	for name := range block.Attributes {
+		violations = append(violations, checkPlannedAttribute(path.WithAttributeName(name), block.Attributes[name], configValues[name], plannedValues[name])...)
+	}
+
+	for name := range block.NestedBlocks {
+		blockType := block.NestedBlocks[name]
+
+		if blockType == nil || blockType.Block == nil {
+			continue
+		}
+
+		violations = append(violations, checkPlannedNestedBlock(path.WithAttributeName(name), blockType, configValues[name], plannedValues[name])...)
+	}
+
+	return violations
+}
+
+// checkPlannedAttributes checks planned nested attributes against the
+// configuration.
+func checkPlannedAttributes(path *tftypes.AttributePath, attributes map[string]*tfjson.SchemaAttribute, config tftypes.Value, planned tftypes.Value) []consistencyViolation {
+	configValues, plannedValues, ok := consistencyObjectValues(config, planned)
+
+	if !ok {
+		return nil
+	}
+
+	var violations []consistencyViolation
+
+	for name := range attributes {
+		violations = append(violations, checkPlannedAttribute(path.WithAttributeName(name), attributes[name], configValues[name], plannedValues[name])...)
+	}
+
+	return violations
+}
+
+// checkPlannedAttribute checks a planned attribute value against the
+// configuration.
+func checkPlannedAttribute(path *tftypes.AttributePath, attribute *tfjson.SchemaAttribute, config tftypes.Value, planned tftypes.Value) []consistencyViolation {
+	if attribute == nil || config.Type() == nil || planned.Type() == nil {
+		return nil
+	}
+
+	// Computed attributes may be planned with any value when not configured.
+	if attribute.Computed && config.IsNull() {
+		return nil
+	}
+
+	nested := attribute.AttributeNestedType
+
+	if nested != nil && config.IsKnown() && !config.IsNull() && planned.IsKnown() && !planned.IsNull() {
+		switch nested.NestingMode {
+		case tfjson.SchemaNestingModeSingle:
+			return checkPlannedAttributes(path, nested.Attributes, config, planned)
+		case tfjson.SchemaNestingModeList, tfjson.SchemaNestingModeMap:
+			return checkPlannedElements(path, config, planned, func(elementPath *tftypes.AttributePath, config tftypes.Value, planned tftypes.Value) []consistencyViolation {
+				return checkPlannedAttributes(elementPath, nested.Attributes, config, planned)
+			})
+		}
+	}
+
+	if config.Equal(planned) {
+		return nil
+	}
+
+	if attribute.Computed {
+		return []consistencyViolation{{
+			attributePath: formatConsistencyPath(path),
+			detail:        fmt.Sprintf("planned value %s does not match config value %s", planned, config),
+		}}
+	}
+
+	return []consistencyViolation{{
+		attributePath: formatConsistencyPath(path),
+		detail:        fmt.Sprintf("planned value %s for a non-computed attribute", planned),
+	}}
+}
+
+// checkPlannedNestedBlock checks a planned nested block against the
+// configuration. Set nested blocks are not checked, as their elements cannot
+// be correlated with the configuration.
+func checkPlannedNestedBlock(path *tftypes.AttributePath, blockType *tfjson.SchemaBlockType, config tftypes.Value, planned tftypes.Value) []consistencyViolation {
+	if config.Type() == nil || planned.Type() == nil || !config.IsKnown() || !planned.IsKnown() {
+		return nil
+	}
+
+	switch blockType.NestingMode {
+	case tfjson.SchemaNestingModeSingle, tfjson.SchemaNestingModeGroup:
+		if config.IsNull() != planned.IsNull() {
+			return []consistencyViolation{{
+				attributePath: formatConsistencyPath(path),
+				detail:        fmt.Sprintf("planned block %s does not match config block %s", planned, config),
+			}}
+		}
+
+		return checkPlannedBlock(path, blockType.Block, config, planned)
+	case tfjson.SchemaNestingModeList, tfjson.SchemaNestingModeMap:
+		return checkPlannedElements(path, config, planned, func(elementPath *tftypes.AttributePath, config tftypes.Value, planned tftypes.Value) []consistencyViolation {
+			return checkPlannedBlock(elementPath, blockType.Block, config, planned)
+		})
+	}
+
+	return nil
+}
+
+// checkPlannedElements checks each planned list or map element against the
+// configuration element with the same index or key.
+func checkPlannedElements(path *tftypes.AttributePath, config tftypes.Value, planned tftypes.Value, checkElement func(*tftypes.AttributePath, tftypes.Value, tftypes.Value) []consistencyViolation) []consistencyViolation {
+	if config.IsNull() || planned.IsNull() {
+		return nil
+	}
+
+	var violations []consistencyViolation
+
+	if config.Type().Is(tftypes.List{}) {
+		var configElements, plannedElements []tftypes.Value
+
+		if err := config.As(&configElements); err != nil {
+			return nil
+		}
+
+		if err := planned.As(&plannedElements); err != nil {
+			return nil
+		}
+
+		if len(configElements) != len(plannedElements) {
+			return []consistencyViolation{{
+				attributePath: formatConsistencyPath(path),
+				detail:        fmt.Sprintf("count in plan (%d) disagrees with count in config (%d)", len(plannedElements), len(configElements)),
+			}}
+		}
+
+		for i := range configElements {
+			violations = append(violations, checkElement(path.WithElementKeyInt(i), configElements[i], plannedElements[i])...)
+		}
+
+		return violations
+	}
+
+	var configElements, plannedElements map[string]tftypes.Value
+
+	if err := config.As(&configElements); err != nil {
+		return nil
+	}
+
+	if err := planned.As(&plannedElements); err != nil {
+		return nil
+	}
+
+	for key := range configElements {
+		plannedElement, ok := plannedElements[key]
+
+		if !ok {
+			violations = append(violations, consistencyViolation{
+				attributePath: formatConsistencyPath(path.WithElementKeyString(key)),
+				detail:        "planned for absence but config wants existence",
+			})
+
+			continue
+		}
+
+		violations = append(violations, checkElement(path.WithElementKeyString(key), configElements[key], plannedElement)...)
+	}
+
+	for key := range plannedElements {
+		if _, ok := configElements[key]; !ok {
+			violations = append(violations, consistencyViolation{
+				attributePath: formatConsistencyPath(path.WithElementKeyString(key)),
+				detail:        "planned for existence but config wants absence",
+			})
+		}
+	}
+
+	return violations
+}
+
+// checkNewValue checks a value of the new state after apply against the
+// planned value. Unknown planned values may be replaced with any known
+// value. Set elements are only compared if the planned set is fully known,
+// as their elements cannot otherwise be correlated.
+func checkNewValue(path *tftypes.AttributePath, planned tftypes.Value, actual tftypes.Value) []consistencyViolation {
+	if planned.Type() == nil || actual.Type() == nil {
+		return nil
+	}
+
+	if !actual.IsFullyKnown() && !planned.IsKnown() {
+		return []consistencyViolation{{
+			attributePath: formatConsistencyPath(path),
+			detail:        "was unknown, but now still unknown after apply",
+		}}
+	}
+
+	if !planned.IsKnown() {
+		return nil
+	}
+
+	if !actual.IsKnown() || planned.IsNull() || actual.IsNull() {
+		if planned.Equal(actual) {
+			return nil
+		}
+
+		return []consistencyViolation{{
+			attributePath: formatConsistencyPath(path),
+			detail:        fmt.Sprintf("was %s, but now %s", planned, actual),
+		}}
+	}
+
+	switch {
+	case planned.Type().Is(tftypes.List{}) || planned.Type().Is(tftypes.Tuple{}):
+		var plannedElements, actualElements []tftypes.Value
+
+		if err := planned.As(&plannedElements); err != nil {
+			return nil
+		}
+
+		if err := actual.As(&actualElements); err != nil {
+			return nil
+		}
+
+		if len(plannedElements) != len(actualElements) {
+			return []consistencyViolation{{
+				attributePath: formatConsistencyPath(path),
+				detail:        fmt.Sprintf("length changed from %d to %d", len(plannedElements), len(actualElements)),
+			}}
+		}
+
+		var violations []consistencyViolation
+
+		for i := range plannedElements {
+			violations = append(violations, checkNewValue(path.WithElementKeyInt(i), plannedElements[i], actualElements[i])...)
+		}
+
+		return violations
+	case planned.Type().Is(tftypes.Object{}) || planned.Type().Is(tftypes.Map{}):
+		var plannedElements, actualElements map[string]tftypes.Value
+
+		if err := planned.As(&plannedElements); err != nil {
+			return nil
+		}
+
+		if err := actual.As(&actualElements); err != nil {
+			return nil
+		}
+
+		isObject := planned.Type().Is(tftypes.Object{})
+
+		var violations []consistencyViolation
+
+		for key := range plannedElements {
+			elementPath := path.WithElementKeyString(key)
+
+			if isObject {
+				elementPath = path.WithAttributeName(key)
+			}
+
+			actualElement, ok := actualElements[key]
+
+			if !ok {
+				violations = append(violations, consistencyViolation{
+					attributePath: formatConsistencyPath(elementPath),
+					detail:        "element has vanished",
+				})
+
+				continue
+			}
+
+			violations = append(violations, checkNewValue(elementPath, plannedElements[key], actualElement)...)
+		}
+
+		for key := range actualElements {
+			if _, ok := plannedElements[key]; !ok {
+				violations = append(violations, consistencyViolation{
+					attributePath: formatConsistencyPath(path.WithElementKeyString(key)),
+					detail:        "new element has appeared",
+				})
+			}
+		}
+
+		return violations
+	case planned.Type().Is(tftypes.Set{}):
+		if !planned.IsFullyKnown() || planned.Equal(actual) {
+			return nil
+		}
+	default:
+		if planned.Equal(actual) {
+			return nil
+		}
+	}
+
+	return []consistencyViolation{{
+		attributePath: formatConsistencyPath(path),
+		detail:        fmt.Sprintf("was %s, but now %s", planned, actual),
+	}}
+}
+
+// consistencyObjectValues returns the attribute values of the configuration
+// and planned objects, or false if either is null, unknown, or not an
+// object.
+func consistencyObjectValues(config tftypes.Value, planned tftypes.Value) (map[string]tftypes.Value, map[string]tftypes.Value, bool) {
+	if config.Type() == nil || planned.Type() == nil {
+		return nil, nil, false
+	}
+
+	if !config.IsKnown() || !planned.IsKnown() || config.IsNull() || planned.IsNull() {
+		return nil, nil, false
+	}
+
+	var configValues, plannedValues map[string]tftypes.Value
+
+	if err := config.As(&configValues); err != nil {
+		return nil, nil, false
+	}
+
+	if err := planned.As(&plannedValues); err != nil {
+		return nil, nil, false
+	}
+
+	return configValues, plannedValues, true
+}
+
+// formatConsistencyPath returns the attribute path as shown by Terraform,
+// such as .rule[0].tags["env"].
+func formatConsistencyPath(path *tftypes.AttributePath) string {
+	var b strings.Builder
+
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			fmt.Fprintf(&b, ".%s", string(step))
+		case tftypes.ElementKeyInt:
+			fmt.Fprintf(&b, "[%d]", int64(step))
+		case tftypes.ElementKeyString:
+			fmt.Fprintf(&b, "[%q]", string(step))
+		case tftypes.ElementKeyValue:
+			fmt.Fprintf(&b, "[%s]", tftypes.Value(step))
+		}
+	}
+
+	return b.String()
+}
+
+var _ tfprotov5.ProviderServer = consistencyProtoV5ProviderServer{}
+
+// consistencyProtoV5ProviderServer checks the resource change responses of
+// the wrapped tfprotov5.ProviderServer for data consistency violations.
+type consistencyProtoV5ProviderServer struct {
+	tfprotov5.ProviderServer
+
+	recorder *providerConsistencyRecorder
+}
+
+func (s consistencyProtoV5ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+
+	if resp != nil {
+		for typeName, schema := range resp.ResourceSchemas {
+			if schema == nil {
+				continue
+			}
+
+			jsonSchema, err := protoV5Schema(schema)
+
+			s.recorder.recordSchema(typeName, schema.ValueType(), jsonSchema, err)
+		}
+	}
+
+	return resp, err
+}
+
+func (s consistencyProtoV5ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+
+	if resp != nil && req.Config != nil && resp.PlannedState != nil && !protoV5DiagnosticsHaveError(resp.Diagnostics) {
+		s.recorder.checkPlan(req.TypeName, req.Config.Unmarshal, resp.PlannedState.Unmarshal)
+	}
+
+	return resp, err
+}
+
+func (s consistencyProtoV5ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+
+	if resp != nil && req.PlannedState != nil && resp.NewState != nil && !protoV5DiagnosticsHaveError(resp.Diagnostics) {
+		s.recorder.checkApply(req.TypeName, req.PlannedState.Unmarshal, resp.NewState.Unmarshal)
+	}
+
+	return resp, err
+}
+
+// protoV5DiagnosticsHaveError returns true if any diagnostic is an error.
+// Terraform does not check the values of responses with errors.
+func protoV5DiagnosticsHaveError(diags []*tfprotov5.Diagnostic) bool {
+	for _, diag := range diags {
+		if diag != nil && diag.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+var _ tfprotov6.ProviderServer = consistencyProtoV6ProviderServer{}
+
+// consistencyProtoV6ProviderServer checks the resource change responses of
+// the wrapped tfprotov6.ProviderServer for data consistency violations.
+type consistencyProtoV6ProviderServer struct {
+	tfprotov6.ProviderServer
+
+	recorder *providerConsistencyRecorder
+}
+
+func (s consistencyProtoV6ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+
+	if resp != nil {
+		for typeName, schema := range resp.ResourceSchemas {
+			if schema == nil {
+				continue
+			}
+
+			jsonSchema, err := protoV6Schema(schema)
+
+			s.recorder.recordSchema(typeName, schema.ValueType(), jsonSchema, err)
+		}
+	}
+
+	return resp, err
+}
+
+func (s consistencyProtoV6ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+
+	if resp != nil && req.Config != nil && resp.PlannedState != nil && !protoV6DiagnosticsHaveError(resp.Diagnostics) {
+		s.recorder.checkPlan(req.TypeName, req.Config.Unmarshal, resp.PlannedState.Unmarshal)
+	}
+
+	return resp, err
+}
+
+func (s consistencyProtoV6ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+
+	if resp != nil && req.PlannedState != nil && resp.NewState != nil && !protoV6DiagnosticsHaveError(resp.Diagnostics) {
+		s.recorder.checkApply(req.TypeName, req.PlannedState.Unmarshal, resp.NewState.Unmarshal)
+	}
+
+	return resp, err
+}
+
+// protoV6DiagnosticsHaveError returns true if any diagnostic is an error.
+// Terraform does not check the values of responses with errors.
+func protoV6DiagnosticsHaveError(diags []*tfprotov6.Diagnostic) bool {
+	for _, diag := range diags {
+		if diag != nil && diag.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testConsistencySchema is a resource schema with computed, optional, and
+// nested attributes and a list nested block.
+var testConsistencySchema = &tfprotov6.Schema{
+	Block: &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+			{
+				Name:     "name",
+				Type:     tftypes.String,
+				Required: true,
+			},
+			{
+				Name:     "region",
+				Type:     tftypes.String,
+				Optional: true,
+				Computed: true,
+			},
+			{
+				Name: "settings",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "enabled",
+							Type:     tftypes.Bool,
+							Optional: true,
+						},
+					},
+					Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+				},
+				Optional: true,
+			},
+		},
+		BlockTypes: []*tfprotov6.SchemaNestedBlock{
+			{
+				TypeName: "rule",
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "port",
+							Type:     tftypes.Number,
+							Required: true,
+						},
+					},
+				},
+				Nesting: tfprotov6.SchemaNestedBlockNestingModeList,
+			},
+		},
+	},
+}
+
+// testConsistencyValue returns a value of testConsistencySchema, where nil
+// arguments are null values.
+func testConsistencyValue(id interface{}, name interface{}, region interface{}, enabled interface{}, ports ...interface{}) tftypes.Value {
+	objectType := testConsistencySchema.ValueType().(tftypes.Object)
+	settingsType := objectType.AttributeTypes["settings"].(tftypes.Object)
+	ruleType := objectType.AttributeTypes["rule"].(tftypes.List).ElementType
+
+	rules := make([]tftypes.Value, 0, len(ports))
+
+	for _, port := range ports {
+		rules = append(rules, tftypes.NewValue(ruleType, map[string]tftypes.Value{
+			"port": tftypes.NewValue(tftypes.Number, port),
+		}))
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, id),
+		"name":   tftypes.NewValue(tftypes.String, name),
+		"region": tftypes.NewValue(tftypes.String, region),
+		"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+			"enabled": tftypes.NewValue(tftypes.Bool, enabled),
+		}),
+		"rule": tftypes.NewValue(objectType.AttributeTypes["rule"], rules),
+	})
+}
+
+// testConsistencyRecorder returns a providerConsistencyRecorder with the
+// testConsistencySchema recorded for the test_thing resource type.
+func testConsistencyRecorder(t *testing.T) *providerConsistencyRecorder {
+	t.Helper()
+
+	jsonSchema, err := protoV6Schema(testConsistencySchema)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	recorder := &providerConsistencyRecorder{}
+	recorder.recordSchema("test_thing", testConsistencySchema.ValueType(), jsonSchema, nil)
+
+	return recorder
+}
+
+// testConsistencyUnmarshal returns a function which returns the value, as
+// the Unmarshal method of a DynamicValue would.
+func testConsistencyUnmarshal(value tftypes.Value) func(tftypes.Type) (tftypes.Value, error) {
+	return func(tftypes.Type) (tftypes.Value, error) {
+		return value, nil
+	}
+}
+
+func TestProviderConsistencyRecorderCheckPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config        tftypes.Value
+		planned       tftypes.Value
+		expectedError error
+	}{
+		"consistent": {
+			config:  testConsistencyValue(nil, "one", nil, true, 80),
+			planned: testConsistencyValue(tftypes.UnknownValue, "one", "us-east-1", true, 80),
+		},
+		"consistent-unknown-config": {
+			config:  testConsistencyValue(nil, tftypes.UnknownValue, "us-west-2", nil),
+			planned: testConsistencyValue(tftypes.UnknownValue, tftypes.UnknownValue, "us-west-2", nil),
+		},
+		"non-computed": {
+			config:  testConsistencyValue(nil, "one", nil, nil),
+			planned: testConsistencyValue(tftypes.UnknownValue, "ONE", nil, nil),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .name during PlanResourceChange: planned value tftypes.String<"ONE"> for a non-computed attribute`),
+		},
+		"optional-computed-configured": {
+			config:  testConsistencyValue(nil, "one", "us-west-2", nil),
+			planned: testConsistencyValue(tftypes.UnknownValue, "one", tftypes.UnknownValue, nil),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .region during PlanResourceChange: planned value tftypes.String<unknown> does not match config value tftypes.String<"us-west-2">`),
+		},
+		"nested-attribute": {
+			config:  testConsistencyValue(nil, "one", nil, nil),
+			planned: testConsistencyValue(tftypes.UnknownValue, "one", nil, false),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .settings.enabled during PlanResourceChange: planned value tftypes.Bool<"false"> for a non-computed attribute`),
+		},
+		"block-count": {
+			config:  testConsistencyValue(nil, "one", nil, nil, 80, 443),
+			planned: testConsistencyValue(tftypes.UnknownValue, "one", nil, nil, 80),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .rule during PlanResourceChange: count in plan (1) disagrees with count in config (2)`),
+		},
+		"block-element": {
+			config:  testConsistencyValue(nil, "one", nil, nil, 80, 443),
+			planned: testConsistencyValue(tftypes.UnknownValue, "one", nil, nil, 80, 8443),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .rule[1].port during PlanResourceChange: planned value tftypes.Number<"8443"> for a non-computed attribute`),
+		},
+		"destroy": {
+			config:  testConsistencyValue(nil, "one", nil, nil),
+			planned: tftypes.NewValue(testConsistencySchema.ValueType(), nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := testConsistencyRecorder(t)
+
+			recorder.checkPlan("test_thing", testConsistencyUnmarshal(testCase.config), testConsistencyUnmarshal(testCase.planned))
+
+			err := recorder.check()
+
+			if err == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if err != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err != nil {
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected error difference: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderConsistencyRecorderCheckApply(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		planned       tftypes.Value
+		newState      tftypes.Value
+		expectedError error
+	}{
+		"consistent": {
+			planned:  testConsistencyValue(tftypes.UnknownValue, "one", tftypes.UnknownValue, true, 80),
+			newState: testConsistencyValue("abc123", "one", "us-east-1", true, 80),
+		},
+		"changed": {
+			planned:  testConsistencyValue(tftypes.UnknownValue, "one", "us-west-2", nil, 80),
+			newState: testConsistencyValue("abc123", "one", "us-east-1", nil, 80),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .region during ApplyResourceChange: was tftypes.String<"us-west-2">, but now tftypes.String<"us-east-1">`),
+		},
+		"still-unknown": {
+			planned:  testConsistencyValue(tftypes.UnknownValue, "one", nil, nil),
+			newState: testConsistencyValue(tftypes.UnknownValue, "one", nil, nil),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .id during ApplyResourceChange: was unknown, but now still unknown after apply`),
+		},
+		"list-length": {
+			planned:  testConsistencyValue(tftypes.UnknownValue, "one", nil, nil, 80, 443),
+			newState: testConsistencyValue("abc123", "one", nil, nil, 80),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing .rule during ApplyResourceChange: length changed from 2 to 1`),
+		},
+		"absent": {
+			planned:  testConsistencyValue(tftypes.UnknownValue, "one", nil, nil),
+			newState: tftypes.NewValue(testConsistencySchema.ValueType(), nil),
+			expectedError: fmt.Errorf("provider produced data inconsistent with the rules of Terraform core:\n\n" +
+				`test_thing during ApplyResourceChange: Root resource was present, but now absent`),
+		},
+		"destroy": {
+			planned:  tftypes.NewValue(testConsistencySchema.ValueType(), nil),
+			newState: tftypes.NewValue(testConsistencySchema.ValueType(), nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := testConsistencyRecorder(t)
+
+			recorder.checkApply("test_thing", testConsistencyUnmarshal(testCase.planned), testConsistencyUnmarshal(testCase.newState))
+
+			err := recorder.check()
+
+			if err == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if err != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err != nil {
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected error difference: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderConsistencyRecorderUnknownResourceType(t *testing.T) {
+	t.Parallel()
+
+	recorder := testConsistencyRecorder(t)
+
+	recorder.checkApply("test_other", testConsistencyUnmarshal(testConsistencyValue(nil, "one", nil, nil)), testConsistencyUnmarshal(testConsistencyValue(nil, "two", nil, nil)))
+
+	if err := recorder.check(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// providerCrash is a panic recovered from a provider RPC.
+type providerCrash struct {
+	// providerAddress is the address of the provider which panicked.
+	providerAddress string
+
+	// rpc is the name of the RPC which panicked.
+	rpc string
+
+	// value is the value passed to panic().
+	value interface{}
+
+	// stack is the goroutine stack trace at the time of the panic.
+	stack []byte
+}
+
+// providerCrashRecorder collects panics in the providers under test, which
+// are recovered when a TestStep sets ExpectProviderCrash. Providers run in
+// the same process as the test, so an unrecovered panic would end the test
+// binary, rather than only the provider process as with Terraform launching
+// a provider.
+type providerCrashRecorder struct {
+	mu      sync.Mutex
+	crashes []providerCrash
+}
+
+// recover saves the panic, if any, stops the provider servers with the given
+// function, as if the provider process exited, and sets the RPC error so
+// Terraform reports that the provider did not respond. It must be deferred
+// directly by the RPC method.
+func (r *providerCrashRecorder) recover(providerAddress string, rpc string, stop func(), err *error) {
+	value := recover()
+
+	if value == nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.crashes = append(r.crashes, providerCrash{
+		providerAddress: providerAddress,
+		rpc:             rpc,
+		value:           value,
+		stack:           debug.Stack(),
+	})
+	r.mu.Unlock()
+
+	stop()
+
+	*err = status.Errorf(codes.Unavailable, "provider %s crashed during %s: %v", providerAddress, rpc, value)
+}
+
+// wrap returns an error which includes the recorded crashes and the given
+// error of the Terraform command, or the given error if it is nil or there
+// are no recorded crashes.
+func (r *providerCrashRecorder) wrap(err error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err == nil || len(r.crashes) == 0 {
+		return err
+	}
+
+	return &providerCrashError{
+		crashes: append([]providerCrash(nil), r.crashes...),
+		err:     err,
+	}
+}
+
+// check returns an error if the given error of the TestStep was not caused
+// by a recorded crash.
+func (r *providerCrashRecorder) check(err error) error {
+	var crashErr *providerCrashError
+
+	if errors.As(err, &crashErr) {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.crashes) > 0 {
+		return fmt.Errorf("expected an error from Terraform after the provider crash, got none")
+	}
+
+	if err != nil {
+		return fmt.Errorf("expected a provider crash, got error: %w", err)
+	}
+
+	return fmt.Errorf("expected a provider crash, got none")
+}
+
+var _ error = &providerCrashError{}
+
+// providerCrashError is the error of a Terraform command during which a
+// provider crashed.
+type providerCrashError struct {
+	crashes []providerCrash
+	err     error
+}
+
+func (e *providerCrashError) Error() string {
+	var b strings.Builder
+
+	for _, crash := range e.crashes {
+		fmt.Fprintf(&b, "provider %s crashed during %s: %v\n\n%s\n", crash.providerAddress, crash.rpc, crash.value, crash.stack)
+	}
+
+	b.WriteString(e.err.Error())
+
+	return b.String()
+}
+
+func (e *providerCrashError) Unwrap() error {
+	return e.err
+}
+
+var _ tfprotov5.ProviderServer = crashProtoV5ProviderServer{}
+
+// crashProtoV5ProviderServer recovers panics in the wrapped
+// tfprotov5.ProviderServer RPCs and saves them to the recorder.
+type crashProtoV5ProviderServer struct {
+	tfprotov5.ProviderServer
+
+	providerAddress string
+	recorder        *providerCrashRecorder
+	stop            func()
+}
+
+func (s crashProtoV5ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (resp *tfprotov5.GetProviderSchemaResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "GetProviderSchema", s.stop, &err)
+
+	return s.ProviderServer.GetProviderSchema(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (resp *tfprotov5.PrepareProviderConfigResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "PrepareProviderConfig", s.stop, &err)
+
+	return s.ProviderServer.PrepareProviderConfig(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (resp *tfprotov5.ConfigureProviderResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ConfigureProvider", s.stop, &err)
+
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (resp *tfprotov5.ValidateResourceTypeConfigResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ValidateResourceTypeConfig", s.stop, &err)
+
+	return s.ProviderServer.ValidateResourceTypeConfig(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (resp *tfprotov5.UpgradeResourceStateResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "UpgradeResourceState", s.stop, &err)
+
+	return s.ProviderServer.UpgradeResourceState(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (resp *tfprotov5.ReadResourceResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ReadResource", s.stop, &err)
+
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (resp *tfprotov5.PlanResourceChangeResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "PlanResourceChange", s.stop, &err)
+
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (resp *tfprotov5.ApplyResourceChangeResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ApplyResourceChange", s.stop, &err)
+
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (resp *tfprotov5.ImportResourceStateResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ImportResourceState", s.stop, &err)
+
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (resp *tfprotov5.ValidateDataSourceConfigResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ValidateDataSourceConfig", s.stop, &err)
+
+	return s.ProviderServer.ValidateDataSourceConfig(ctx, req)
+}
+
+func (s crashProtoV5ProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (resp *tfprotov5.ReadDataSourceResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ReadDataSource", s.stop, &err)
+
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
+
+var _ tfprotov6.ProviderServer = crashProtoV6ProviderServer{}
+
+// crashProtoV6ProviderServer recovers panics in the wrapped
+// tfprotov6.ProviderServer RPCs and saves them to the recorder.
+type crashProtoV6ProviderServer struct {
+	tfprotov6.ProviderServer
+
+	providerAddress string
+	recorder        *providerCrashRecorder
+	stop            func()
+}
+
+func (s crashProtoV6ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (resp *tfprotov6.GetProviderSchemaResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "GetProviderSchema", s.stop, &err)
+
+	return s.ProviderServer.GetProviderSchema(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ValidateProviderConfig(ctx context.Context, req *tfprotov6.ValidateProviderConfigRequest) (resp *tfprotov6.ValidateProviderConfigResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ValidateProviderConfig", s.stop, &err)
+
+	return s.ProviderServer.ValidateProviderConfig(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (resp *tfprotov6.ConfigureProviderResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ConfigureProvider", s.stop, &err)
+
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ValidateResourceConfig(ctx context.Context, req *tfprotov6.ValidateResourceConfigRequest) (resp *tfprotov6.ValidateResourceConfigResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ValidateResourceConfig", s.stop, &err)
+
+	return s.ProviderServer.ValidateResourceConfig(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov6.UpgradeResourceStateRequest) (resp *tfprotov6.UpgradeResourceStateResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "UpgradeResourceState", s.stop, &err)
+
+	return s.ProviderServer.UpgradeResourceState(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (resp *tfprotov6.ReadResourceResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ReadResource", s.stop, &err)
+
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (resp *tfprotov6.PlanResourceChangeResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "PlanResourceChange", s.stop, &err)
+
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (resp *tfprotov6.ApplyResourceChangeResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ApplyResourceChange", s.stop, &err)
+
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (resp *tfprotov6.ImportResourceStateResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ImportResourceState", s.stop, &err)
+
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ValidateDataResourceConfig(ctx context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (resp *tfprotov6.ValidateDataResourceConfigResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ValidateDataResourceConfig", s.stop, &err)
+
+	return s.ProviderServer.ValidateDataResourceConfig(ctx, req)
+}
+
+func (s crashProtoV6ProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (resp *tfprotov6.ReadDataSourceResponse, err error) {
+	defer s.recorder.recover(s.providerAddress, "ReadDataSource", s.stop, &err)
+
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProviderCrashRecorder_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		crashes       []providerCrash
+		err           error
+		expectedError error
+	}{
+		"crash-and-error": {
+			crashes: []providerCrash{
+				{providerAddress: "registry.terraform.io/hashicorp/test", rpc: "ApplyResourceChange", value: "oops"},
+			},
+			err: fmt.Errorf("Plugin did not respond"),
+		},
+		"crash-without-error": {
+			crashes: []providerCrash{
+				{providerAddress: "registry.terraform.io/hashicorp/test", rpc: "ApplyResourceChange", value: "oops"},
+			},
+			expectedError: fmt.Errorf("expected an error from Terraform after the provider crash, got none"),
+		},
+		"error-without-crash": {
+			err:           fmt.Errorf("Invalid resource type"),
+			expectedError: fmt.Errorf("expected a provider crash, got error: Invalid resource type"),
+		},
+		"no-crash-or-error": {
+			expectedError: fmt.Errorf("expected a provider crash, got none"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &providerCrashRecorder{
+				crashes: testCase.crashes,
+			}
+
+			err := recorder.check(recorder.wrap(testCase.err))
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestProviderCrashRecorder_Wrap(t *testing.T) {
+	t.Parallel()
+
+	terraformErr := fmt.Errorf("Plugin did not respond")
+	recorder := &providerCrashRecorder{
+		crashes: []providerCrash{
+			{
+				providerAddress: "registry.terraform.io/hashicorp/test",
+				rpc:             "ApplyResourceChange",
+				value:           "oops",
+				stack:           []byte("goroutine 1 [running]:"),
+			},
+		},
+	}
+
+	err := recorder.wrap(terraformErr)
+
+	if !errors.Is(err, terraformErr) {
+		t.Errorf("expected wrapped Terraform error, got: %s", err)
+	}
+
+	expected := "provider registry.terraform.io/hashicorp/test crashed during ApplyResourceChange: oops\n\ngoroutine 1 [running]:\nPlugin did not respond"
+
+	if diff := cmp.Diff(err.Error(), expected); diff != "" {
+		t.Errorf("unexpected error difference: %s", diff)
+	}
+}
+
+func TestCrashProtoV5ProviderServer(t *testing.T) {
+	t.Parallel()
+
+	var stopped bool
+
+	recorder := &providerCrashRecorder{}
+	server := crashProtoV5ProviderServer{
+		ProviderServer:  testCrashProtoV5ProviderServer{},
+		providerAddress: "registry.terraform.io/hashicorp/test",
+		recorder:        recorder,
+		stop:            func() { stopped = true },
+	}
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{})
+
+	if resp != nil {
+		t.Errorf("unexpected response: %v", resp)
+	}
+
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable error, got: %s", err)
+	}
+
+	if !stopped {
+		t.Error("expected provider servers to be stopped")
+	}
+
+	if err := recorder.check(recorder.wrap(err)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCrashProtoV6ProviderServer(t *testing.T) {
+	t.Parallel()
+
+	var stopped bool
+
+	recorder := &providerCrashRecorder{}
+	server := crashProtoV6ProviderServer{
+		ProviderServer:  testCrashProtoV6ProviderServer{},
+		providerAddress: "registry.terraform.io/hashicorp/test",
+		recorder:        recorder,
+		stop:            func() { stopped = true },
+	}
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{})
+
+	if resp != nil {
+		t.Errorf("unexpected response: %v", resp)
+	}
+
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable error, got: %s", err)
+	}
+
+	if !stopped {
+		t.Error("expected provider servers to be stopped")
+	}
+
+	if err := recorder.check(recorder.wrap(err)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCrashProtoV5ProviderServer_NoPanic(t *testing.T) {
+	t.Parallel()
+
+	recorder := &providerCrashRecorder{}
+	server := crashProtoV5ProviderServer{
+		ProviderServer:  testCrashProtoV5ProviderServer{},
+		providerAddress: "registry.terraform.io/hashicorp/test",
+		recorder:        recorder,
+		stop:            func() { t.Error("unexpected stop") },
+	}
+
+	_, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(recorder.crashes) != 0 {
+		t.Errorf("unexpected crashes: %v", recorder.crashes)
+	}
+}
+
+// testCrashProtoV5ProviderServer panics in ApplyResourceChange and returns
+// an empty schema from GetProviderSchema. Other methods are not implemented.
+type testCrashProtoV5ProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (testCrashProtoV5ProviderServer) ApplyResourceChange(_ context.Context, _ *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	panic("unrecoverable condition")
+}
+
+func (testCrashProtoV5ProviderServer) GetProviderSchema(_ context.Context, _ *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return &tfprotov5.GetProviderSchemaResponse{}, nil
+}
+
+// testCrashProtoV6ProviderServer panics in ApplyResourceChange. Other methods
+// are not implemented.
+type testCrashProtoV6ProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (testCrashProtoV6ProviderServer) ApplyResourceChange(_ context.Context, _ *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	panic("unrecoverable condition")
+}
+
+func TestTest_TestStep_ExpectProviderCrash(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"test_resource": {
+							CreateContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								panic("unrecoverable condition")
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Optional: true,
+									Type:     schema.TypeString,
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config:              `resource "test_resource" "test" {}`,
+				ExpectProviderCrash: true,
+				ExpectError:         regexp.MustCompile(`crashed during ApplyResourceChange: unrecoverable condition`),
+			},
+		},
+	})
+}
@@ -0,0 +1,457 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// ProviderDiagnosticSeverity is the severity of a ProviderDiagnostic.
+type ProviderDiagnosticSeverity string
+
+const (
+	// ProviderDiagnosticSeverityError is the severity of error diagnostics.
+	ProviderDiagnosticSeverityError ProviderDiagnosticSeverity = "error"
+
+	// ProviderDiagnosticSeverityWarning is the severity of warning
+	// diagnostics.
+	ProviderDiagnosticSeverityWarning ProviderDiagnosticSeverity = "warning"
+)
+
+// ProviderDiagnostic is a diagnostic returned by a provider under test, such
+// as a warning returned while destroying a resource.
+type ProviderDiagnostic struct {
+	// Severity is the severity of the diagnostic.
+	Severity ProviderDiagnosticSeverity
+
+	// Summary is the short description of the diagnostic.
+	Summary string
+
+	// Detail is the longer description of the diagnostic, if any.
+	Detail string
+
+	// AttributePath is the attribute path of the diagnostic. It is empty if
+	// the diagnostic has no attribute path or the path cannot be
+	// represented, such as paths into set elements.
+	AttributePath tfjsonpath.Path
+}
+
+// diagnosticRecorder collects the diagnostics returned by providers under
+// test. Attribute paths are recorded from the protocol responses, where both
+// terraform-plugin-sdk cty.Path and terraform-plugin-framework path.Path
+// values have already been converted to tftypes.AttributePath, so the
+// recorded paths are the same regardless of which SDK the provider uses.
+type diagnosticRecorder struct {
+	mu          sync.Mutex
+	diagnostics []ProviderDiagnostic
+	paths       []tfjsonpath.Path
+}
+
+// record saves the attribute path, if it can be represented as a
+// tfjsonpath.Path.
+func (r *diagnosticRecorder) record(attributePath *tftypes.AttributePath) {
+	path, ok := attributePathToTFJSONPath(attributePath)
+
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.paths = append(r.paths, path)
+}
+
+// recordDiagnostic saves the diagnostic and its attribute path.
+func (r *diagnosticRecorder) recordDiagnostic(severity ProviderDiagnosticSeverity, summary string, detail string, attributePath *tftypes.AttributePath) {
+	path, _ := attributePathToTFJSONPath(attributePath)
+
+	r.mu.Lock()
+	r.diagnostics = append(r.diagnostics, ProviderDiagnostic{
+		Severity:      severity,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: path,
+	})
+	r.mu.Unlock()
+
+	r.record(attributePath)
+}
+
+// recordProtoV5 saves the given diagnostics.
+func (r *diagnosticRecorder) recordProtoV5(diags []*tfprotov5.Diagnostic) {
+	for _, diag := range diags {
+		if diag == nil {
+			continue
+		}
+
+		severity := ProviderDiagnosticSeverityError
+
+		if diag.Severity == tfprotov5.DiagnosticSeverityWarning {
+			severity = ProviderDiagnosticSeverityWarning
+		}
+
+		r.recordDiagnostic(severity, diag.Summary, diag.Detail, diag.Attribute)
+	}
+}
+
+// recordProtoV6 saves the given diagnostics.
+func (r *diagnosticRecorder) recordProtoV6(diags []*tfprotov6.Diagnostic) {
+	for _, diag := range diags {
+		if diag == nil {
+			continue
+		}
+
+		severity := ProviderDiagnosticSeverityError
+
+		if diag.Severity == tfprotov6.DiagnosticSeverityWarning {
+			severity = ProviderDiagnosticSeverityWarning
+		}
+
+		r.recordDiagnostic(severity, diag.Summary, diag.Detail, diag.Attribute)
+	}
+}
+
+// recorded returns a copy of the recorded diagnostics.
+func (r *diagnosticRecorder) recorded() []ProviderDiagnostic {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]ProviderDiagnostic(nil), r.diagnostics...)
+}
+
+// check returns an error if any of the expected attribute paths were not
+// recorded.
+func (r *diagnosticRecorder) check(expected []tfjsonpath.Path) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var missing []string
+
+	for _, expectedPath := range expected {
+		found := false
+
+		for _, path := range r.paths {
+			if path.Equal(expectedPath) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, expectedPath.String())
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if len(r.paths) == 0 {
+		return fmt.Errorf("expected diagnostics for attribute paths: %s, got no diagnostics with attribute paths", strings.Join(missing, ", "))
+	}
+
+	got := make([]string, 0, len(r.paths))
+
+	for _, path := range r.paths {
+		got = append(got, path.String())
+	}
+
+	return fmt.Errorf("expected diagnostics for attribute paths: %s, got diagnostics for attribute paths: %s", strings.Join(missing, ", "), strings.Join(got, ", "))
+}
+
+// attributePathToTFJSONPath converts a protocol attribute path into a
+// tfjsonpath.Path. Attribute names and map keys become map steps and list
+// indexes become slice steps. Paths into set elements cannot be represented,
+// in which case false is returned.
+func attributePathToTFJSONPath(attributePath *tftypes.AttributePath) (tfjsonpath.Path, bool) {
+	if attributePath == nil {
+		return tfjsonpath.Path{}, false
+	}
+
+	steps := attributePath.Steps()
+
+	if len(steps) == 0 {
+		return tfjsonpath.Path{}, false
+	}
+
+	var result tfjsonpath.Path
+
+	for i, step := range steps {
+		switch s := step.(type) {
+		case tftypes.AttributeName:
+			if i == 0 {
+				result = tfjsonpath.New(string(s))
+			} else {
+				result = result.AtMapKey(string(s))
+			}
+		case tftypes.ElementKeyString:
+			if i == 0 {
+				result = tfjsonpath.New(string(s))
+			} else {
+				result = result.AtMapKey(string(s))
+			}
+		case tftypes.ElementKeyInt:
+			if i == 0 {
+				result = tfjsonpath.New(int(s))
+			} else {
+				result = result.AtSliceIndex(int(s))
+			}
+		default:
+			return tfjsonpath.Path{}, false
+		}
+	}
+
+	return result, true
+}
+
+var _ tfprotov5.ProviderServer = diagnosticsProtoV5ProviderServer{}
+
+// diagnosticsProtoV5ProviderServer records the attribute paths of all
+// diagnostics returned by the wrapped tfprotov5.ProviderServer.
+type diagnosticsProtoV5ProviderServer struct {
+	tfprotov5.ProviderServer
+
+	recorder *diagnosticRecorder
+}
+
+func (s diagnosticsProtoV5ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	resp, err := s.ProviderServer.PrepareProviderConfig(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp, err := s.ProviderServer.ValidateResourceTypeConfig(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	resp, err := s.ProviderServer.UpgradeResourceState(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	resp, err := s.ProviderServer.ValidateDataSourceConfig(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV5ProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV5(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+var _ tfprotov6.ProviderServer = diagnosticsProtoV6ProviderServer{}
+
+// diagnosticsProtoV6ProviderServer records the attribute paths of all
+// diagnostics returned by the wrapped tfprotov6.ProviderServer.
+type diagnosticsProtoV6ProviderServer struct {
+	tfprotov6.ProviderServer
+
+	recorder *diagnosticRecorder
+}
+
+func (s diagnosticsProtoV6ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ValidateProviderConfig(ctx context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	resp, err := s.ProviderServer.ValidateProviderConfig(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ValidateResourceConfig(ctx context.Context, req *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	resp, err := s.ProviderServer.ValidateResourceConfig(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error) {
+	resp, err := s.ProviderServer.UpgradeResourceState(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ValidateDataResourceConfig(ctx context.Context, req *tfprotov6.ValidateDataResourceConfigRequest) (*tfprotov6.ValidateDataResourceConfigResponse, error) {
+	resp, err := s.ProviderServer.ValidateDataResourceConfig(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
+
+func (s diagnosticsProtoV6ProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+
+	if resp != nil {
+		s.recorder.recordProtoV6(resp.Diagnostics)
+	}
+
+	return resp, err
+}
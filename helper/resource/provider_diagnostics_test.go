@@ -0,0 +1,371 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAttributePathToTFJSONPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		attributePath *tftypes.AttributePath
+		expected      string
+		expectedOk    bool
+	}{
+		"nil": {
+			attributePath: nil,
+		},
+		"empty": {
+			attributePath: tftypes.NewAttributePath(),
+		},
+		"attribute-name": {
+			attributePath: tftypes.NewAttributePath().WithAttributeName("name"),
+			expected:      "name",
+			expectedOk:    true,
+		},
+		"list-block": {
+			attributePath: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(0).WithAttributeName("name"),
+			expected:      "nested.0.name",
+			expectedOk:    true,
+		},
+		"map-key": {
+			attributePath: tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("key"),
+			expected:      "tags.key",
+			expectedOk:    true,
+		},
+		"set-element": {
+			attributePath: tftypes.NewAttributePath().WithAttributeName("set").WithElementKeyValue(tftypes.NewValue(tftypes.String, "value")),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := attributePathToTFJSONPath(testCase.attributePath)
+
+			if ok != testCase.expectedOk {
+				t.Fatalf("expected ok %t, got %t", testCase.expectedOk, ok)
+			}
+
+			if diff := cmp.Diff(got.String(), testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticRecorder_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		recorded      []*tftypes.AttributePath
+		expected      []tfjsonpath.Path
+		expectedError error
+	}{
+		"found": {
+			recorded: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+				tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(0).WithAttributeName("name"),
+			},
+			expected: []tfjsonpath.Path{
+				tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("name"),
+			},
+		},
+		"missing": {
+			recorded: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("name"),
+			},
+			expected: []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+				tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("name"),
+			},
+			expectedError: fmt.Errorf("expected diagnostics for attribute paths: nested.0.name, got diagnostics for attribute paths: name"),
+		},
+		"none-recorded": {
+			expected: []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+			},
+			expectedError: fmt.Errorf("expected diagnostics for attribute paths: name, got no diagnostics with attribute paths"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &diagnosticRecorder{}
+
+			for _, attributePath := range testCase.recorded {
+				recorder.record(attributePath)
+			}
+
+			err := recorder.check(testCase.expected)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestDiagnosticRecorder_Recorded(t *testing.T) {
+	t.Parallel()
+
+	recorder := &diagnosticRecorder{}
+
+	recorder.recordProtoV5([]*tfprotov5.Diagnostic{
+		{
+			Severity:  tfprotov5.DiagnosticSeverityWarning,
+			Summary:   "Resource not found",
+			Detail:    "The resource was already deleted.",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+		},
+		nil,
+	})
+	recorder.recordProtoV6([]*tfprotov6.Diagnostic{
+		{
+			Severity:  tfprotov6.DiagnosticSeverityError,
+			Summary:   "Invalid value",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("set").WithElementKeyValue(tftypes.NewValue(tftypes.String, "value")),
+		},
+	})
+
+	got := recorder.recorded()
+	expected := []ProviderDiagnostic{
+		{
+			Severity:      ProviderDiagnosticSeverityWarning,
+			Summary:       "Resource not found",
+			Detail:        "The resource was already deleted.",
+			AttributePath: tfjsonpath.New("name"),
+		},
+		{
+			Severity: ProviderDiagnosticSeverityError,
+			Summary:  "Invalid value",
+		},
+	}
+
+	if diff := cmp.Diff(got, expected, cmp.Comparer(func(x, y tfjsonpath.Path) bool { return x.Equal(y) })); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestDiagnosticsProtoV5ProviderServer(t *testing.T) {
+	t.Parallel()
+
+	recorder := &diagnosticRecorder{}
+	server := diagnosticsProtoV5ProviderServer{
+		ProviderServer: testDiagnosticsProtoV5ProviderServer{},
+		recorder:       recorder,
+	}
+
+	_, err := server.ValidateResourceTypeConfig(context.Background(), &tfprotov5.ValidateResourceTypeConfigRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := recorder.check([]tfjsonpath.Path{tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("name")}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestDiagnosticsProtoV6ProviderServer(t *testing.T) {
+	t.Parallel()
+
+	recorder := &diagnosticRecorder{}
+	server := diagnosticsProtoV6ProviderServer{
+		ProviderServer: testDiagnosticsProtoV6ProviderServer{},
+		recorder:       recorder,
+	}
+
+	_, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := recorder.check([]tfjsonpath.Path{tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("name")}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+// testDiagnosticsProtoV5ProviderServer returns an attribute diagnostic from
+// ValidateResourceTypeConfig. Other methods are not implemented.
+type testDiagnosticsProtoV5ProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (testDiagnosticsProtoV5ProviderServer) ValidateResourceTypeConfig(_ context.Context, _ *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	return &tfprotov5.ValidateResourceTypeConfigResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid value",
+				Attribute: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(0).WithAttributeName("name"),
+			},
+		},
+	}, nil
+}
+
+// testDiagnosticsProtoV6ProviderServer returns an attribute diagnostic from
+// ValidateResourceConfig. Other methods are not implemented.
+type testDiagnosticsProtoV6ProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (testDiagnosticsProtoV6ProviderServer) ValidateResourceConfig(_ context.Context, _ *tfprotov6.ValidateResourceConfigRequest) (*tfprotov6.ValidateResourceConfigResponse, error) {
+	return &tfprotov6.ValidateResourceConfigResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity:  tfprotov6.DiagnosticSeverityError,
+				Summary:   "Invalid value",
+				Attribute: tftypes.NewAttributePath().WithAttributeName("nested").WithElementKeyInt(0).WithAttributeName("name"),
+			},
+		},
+	}, nil
+}
+
+func TestTest_TestStep_ExpectDiagnosticAttributePaths(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"test_resource": {
+							CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								d.SetId("test")
+								return nil
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							Schema: map[string]*schema.Schema{
+								"nested": {
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"name": {
+												ForceNew: true,
+												Required: true,
+												Type:     schema.TypeString,
+												ValidateDiagFunc: func(_ interface{}, _ cty.Path) diag.Diagnostics {
+													return diag.Errorf("invalid name")
+												},
+											},
+										},
+									},
+									ForceNew: true,
+									Optional: true,
+									Type:     schema.TypeList,
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					nested {
+						name = "invalid"
+					}
+				}`,
+				ExpectDiagnosticAttributePaths: []tfjsonpath.Path{
+					tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("name"),
+				},
+				ExpectError: regexp.MustCompile(`invalid name`),
+			},
+		},
+	})
+}
+
+func TestTest_TestCase_PostDestroyCheck(t *testing.T) {
+	t.Parallel()
+
+	var got []ProviderDiagnostic
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"test_resource": {
+							CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								d.SetId("test")
+								return nil
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return diag.Diagnostics{
+									{
+										Severity: diag.Warning,
+										Summary:  "Resource already deleted",
+									},
+								}
+							},
+							ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							Schema: map[string]*schema.Schema{},
+						},
+					},
+				}, nil
+			},
+		},
+		PostDestroyCheck: func(diags []ProviderDiagnostic) error {
+			got = diags
+
+			for _, diag := range diags {
+				if diag.Severity == ProviderDiagnosticSeverityWarning && diag.Summary == "Resource already deleted" {
+					return nil
+				}
+			}
+
+			return fmt.Errorf("expected destroy warning, got: %v", diags)
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {}`,
+			},
+		},
+	})
+
+	if len(got) == 0 {
+		t.Error("expected PostDestroyCheck to be called with diagnostics")
+	}
+}
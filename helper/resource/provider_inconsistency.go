@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ProviderInconsistencyKind is the kind of provider inconsistency error
+// returned by Terraform.
+type ProviderInconsistencyKind string
+
+const (
+	// ProviderInconsistentResultAfterApply is the error returned by Terraform
+	// when the new state of a resource returned by a provider after apply
+	// does not match the planned values.
+	ProviderInconsistentResultAfterApply ProviderInconsistencyKind = "inconsistent result after apply"
+
+	// ProviderInconsistentFinalPlan is the error returned by Terraform when
+	// the plan of a resource returned by a provider during apply does not
+	// match the earlier plan, such as a known value changing.
+	ProviderInconsistentFinalPlan ProviderInconsistencyKind = "inconsistent final plan"
+)
+
+// ProviderInconsistency is an expected provider inconsistency error for the
+// TestStep ExpectProviderInconsistency field. Empty fields match any provider
+// inconsistency error.
+type ProviderInconsistency struct {
+	// Kind is the kind of provider inconsistency error.
+	Kind ProviderInconsistencyKind
+
+	// ResourceAddress is the address of the resource in the error, such as
+	// example_widget.test.
+	ResourceAddress string
+
+	// AttributePaths are the attribute paths which must all be reported in
+	// the errors, as they are shown by Terraform, such as .name or
+	// .rule[0].tags["env"].
+	AttributePaths []string
+}
+
+var (
+	// providerInconsistentResultAfterApplyRegexp matches the detail of the
+	// Terraform error after whitespace is collapsed, capturing the resource
+	// address and the inconsistency, such as `.name: was cty.StringVal("a"),
+	// but now cty.StringVal("b")`.
+	providerInconsistentResultAfterApplyRegexp = regexp.MustCompile(`When applying changes to (\S+), provider "(?:[^"\\]|\\.)*" produced an unexpected new value: (.*?)\. This is a bug in the provider`)
+
+	// providerInconsistentFinalPlanRegexp matches the detail of the Terraform
+	// error after whitespace is collapsed, capturing the resource address,
+	// the attribute path, and the inconsistency.
+	providerInconsistentFinalPlanRegexp = regexp.MustCompile(`When expanding the plan for (\S+) to include new values learned so far during apply, provider "(?:[^"\\]|\\.)*" produced an invalid new value for (\S+?): (.*?)\. This is a bug in the provider`)
+)
+
+// providerInconsistency is a provider inconsistency error parsed from the
+// Terraform output.
+type providerInconsistency struct {
+	kind            ProviderInconsistencyKind
+	resourceAddress string
+
+	// attributePath is empty if the inconsistency is for the whole resource,
+	// such as it being absent after apply.
+	attributePath string
+	detail        string
+}
+
+func (i providerInconsistency) String() string {
+	if i.attributePath == "" {
+		return fmt.Sprintf("%s: %s (%s)", i.resourceAddress, i.detail, i.kind)
+	}
+
+	return fmt.Sprintf("%s %s: %s (%s)", i.resourceAddress, i.attributePath, i.detail, i.kind)
+}
+
+// parseProviderInconsistencies returns the provider inconsistency errors in
+// the Terraform output. Whitespace is collapsed first, as Terraform wraps
+// long error details across lines.
+func parseProviderInconsistencies(output string) []providerInconsistency {
+	output = strings.Join(strings.Fields(output), " ")
+
+	var inconsistencies []providerInconsistency
+
+	for _, match := range providerInconsistentResultAfterApplyRegexp.FindAllStringSubmatch(output, -1) {
+		inconsistency := providerInconsistency{
+			kind:            ProviderInconsistentResultAfterApply,
+			resourceAddress: match[1],
+			detail:          match[2],
+		}
+
+		// Attribute inconsistencies are reported as .path: detail, while
+		// resource inconsistencies are a sentence, such as Root resource was
+		// present, but now absent.
+		if strings.HasPrefix(match[2], ".") || strings.HasPrefix(match[2], "[") {
+			if path, detail, ok := strings.Cut(match[2], ": "); ok {
+				inconsistency.attributePath = path
+				inconsistency.detail = detail
+			}
+		}
+
+		inconsistencies = append(inconsistencies, inconsistency)
+	}
+
+	for _, match := range providerInconsistentFinalPlanRegexp.FindAllStringSubmatch(output, -1) {
+		inconsistencies = append(inconsistencies, providerInconsistency{
+			kind:            ProviderInconsistentFinalPlan,
+			resourceAddress: match[1],
+			attributePath:   match[2],
+			detail:          match[3],
+		})
+	}
+
+	return inconsistencies
+}
+
+// check returns an error if the given error of the TestStep does not contain
+// the expected provider inconsistency.
+func (p ProviderInconsistency) check(err error) error {
+	if err == nil {
+		return fmt.Errorf("expected a provider inconsistency, got no error")
+	}
+
+	var matching []providerInconsistency
+
+	for _, inconsistency := range parseProviderInconsistencies(err.Error()) {
+		if p.Kind != "" && inconsistency.kind != p.Kind {
+			continue
+		}
+
+		if p.ResourceAddress != "" && inconsistency.resourceAddress != p.ResourceAddress {
+			continue
+		}
+
+		matching = append(matching, inconsistency)
+	}
+
+	if len(matching) == 0 {
+		return fmt.Errorf("expected a provider inconsistency%s, got error: %w", p.description(), err)
+	}
+
+	var missing []string
+
+	for _, attributePath := range p.AttributePaths {
+		found := false
+
+		for _, inconsistency := range matching {
+			if inconsistency.attributePath == attributePath {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, attributePath)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("expected a provider inconsistency%s for attribute path(s) %s, got:\n\n%s", p.description(), strings.Join(missing, ", "), formatProviderInconsistencies(matching))
+	}
+
+	return nil
+}
+
+// description returns the non-empty Kind and ResourceAddress for error
+// messages.
+func (p ProviderInconsistency) description() string {
+	var b strings.Builder
+
+	if p.Kind != "" {
+		fmt.Fprintf(&b, " (%s)", p.Kind)
+	}
+
+	if p.ResourceAddress != "" {
+		fmt.Fprintf(&b, " for %s", p.ResourceAddress)
+	}
+
+	return b.String()
+}
+
+func formatProviderInconsistencies(inconsistencies []providerInconsistency) string {
+	var b strings.Builder
+
+	for _, inconsistency := range inconsistencies {
+		fmt.Fprintf(&b, "  - %s\n", inconsistency)
+	}
+
+	return b.String()
+}
+
+var _ error = &providerInconsistencyError{}
+
+// providerInconsistencyError is an unexpected error of a TestStep which
+// contains provider inconsistency errors. The offending resources and
+// attribute paths are summarized after the Terraform error, as the wrapped
+// cty values of the error detail are difficult to read.
+type providerInconsistencyError struct {
+	inconsistencies []providerInconsistency
+	err             error
+}
+
+// withProviderInconsistencies returns the error wrapped with a summary of the
+// provider inconsistencies it contains, or the error itself if there are
+// none.
+func withProviderInconsistencies(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	inconsistencies := parseProviderInconsistencies(err.Error())
+
+	if len(inconsistencies) == 0 {
+		return err
+	}
+
+	return &providerInconsistencyError{
+		inconsistencies: inconsistencies,
+		err:             err,
+	}
+}
+
+func (e *providerInconsistencyError) Error() string {
+	return fmt.Sprintf("%s\n\nProvider inconsistencies:\n%s", e.err, formatProviderInconsistencies(e.inconsistencies))
+}
+
+func (e *providerInconsistencyError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testInconsistentResultAfterApplyOutput = `
+Error: Provider produced inconsistent result after apply
+
+When applying changes to example_widget.test, provider
+"provider[\"registry.terraform.io/hashicorp/example\"]" produced an unexpected
+new value: .name: was cty.StringVal("before"), but now
+cty.StringVal("after").
+
+This is a bug in the provider, which should be reported in the provider's own
+issue tracker.
+
+Error: Provider produced inconsistent result after apply
+
+When applying changes to example_widget.test, provider
+"provider[\"registry.terraform.io/hashicorp/example\"]" produced an unexpected
+new value: .tags["env"]: was cty.StringVal("prod"), but now null.
+
+This is a bug in the provider, which should be reported in the provider's own
+issue tracker.
+`
+
+const testInconsistentFinalPlanOutput = `
+Error: Provider produced inconsistent final plan
+
+When expanding the plan for example_widget.other to include new values learned
+so far during apply, provider
+"provider[\"registry.terraform.io/hashicorp/example\"]" produced an invalid new
+value for .size: was cty.NumberIntVal(1), but now cty.NumberIntVal(2).
+
+This is a bug in the provider, which should be reported in the provider's own
+issue tracker.
+`
+
+const testRootResourceAbsentOutput = `
+Error: Provider produced inconsistent result after apply
+
+When applying changes to module.child.example_widget.test[0], provider
+"provider[\"registry.terraform.io/hashicorp/example\"]" produced an unexpected
+new value: Root resource was present, but now absent.
+
+This is a bug in the provider, which should be reported in the provider's own
+issue tracker.
+`
+
+func TestParseProviderInconsistencies(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		output   string
+		expected []providerInconsistency
+	}{
+		"none": {
+			output: "Error: Missing required argument",
+		},
+		"result-after-apply": {
+			output: testInconsistentResultAfterApplyOutput,
+			expected: []providerInconsistency{
+				{
+					kind:            ProviderInconsistentResultAfterApply,
+					resourceAddress: "example_widget.test",
+					attributePath:   ".name",
+					detail:          `was cty.StringVal("before"), but now cty.StringVal("after")`,
+				},
+				{
+					kind:            ProviderInconsistentResultAfterApply,
+					resourceAddress: "example_widget.test",
+					attributePath:   `.tags["env"]`,
+					detail:          `was cty.StringVal("prod"), but now null`,
+				},
+			},
+		},
+		"final-plan": {
+			output: testInconsistentFinalPlanOutput,
+			expected: []providerInconsistency{
+				{
+					kind:            ProviderInconsistentFinalPlan,
+					resourceAddress: "example_widget.other",
+					attributePath:   ".size",
+					detail:          "was cty.NumberIntVal(1), but now cty.NumberIntVal(2)",
+				},
+			},
+		},
+		"root-resource-absent": {
+			output: testRootResourceAbsentOutput,
+			expected: []providerInconsistency{
+				{
+					kind:            ProviderInconsistentResultAfterApply,
+					resourceAddress: "module.child.example_widget.test[0]",
+					detail:          "Root resource was present, but now absent",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := parseProviderInconsistencies(testCase.output)
+
+			if diff := cmp.Diff(got, testCase.expected, cmp.AllowUnexported(providerInconsistency{})); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderInconsistency_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		inconsistency ProviderInconsistency
+		err           error
+		expectedError error
+	}{
+		"no-error": {
+			expectedError: fmt.Errorf("expected a provider inconsistency, got no error"),
+		},
+		"other-error": {
+			err:           errors.New("Error: Missing required argument"),
+			expectedError: fmt.Errorf("expected a provider inconsistency, got error: Error: Missing required argument"),
+		},
+		"any": {
+			err: errors.New(testInconsistentResultAfterApplyOutput),
+		},
+		"kind": {
+			inconsistency: ProviderInconsistency{
+				Kind: ProviderInconsistentFinalPlan,
+			},
+			err: errors.New(testInconsistentFinalPlanOutput),
+		},
+		"kind-mismatch": {
+			inconsistency: ProviderInconsistency{
+				Kind: ProviderInconsistentFinalPlan,
+			},
+			err:           errors.New("Error: Provider produced inconsistent result after apply"),
+			expectedError: fmt.Errorf("expected a provider inconsistency (inconsistent final plan), got error: Error: Provider produced inconsistent result after apply"),
+		},
+		"resource-address": {
+			inconsistency: ProviderInconsistency{
+				ResourceAddress: "module.child.example_widget.test[0]",
+			},
+			err: errors.New(testRootResourceAbsentOutput),
+		},
+		"attribute-paths": {
+			inconsistency: ProviderInconsistency{
+				Kind:            ProviderInconsistentResultAfterApply,
+				ResourceAddress: "example_widget.test",
+				AttributePaths:  []string{".name", `.tags["env"]`},
+			},
+			err: errors.New(testInconsistentResultAfterApplyOutput),
+		},
+		"attribute-paths-missing": {
+			inconsistency: ProviderInconsistency{
+				ResourceAddress: "example_widget.other",
+				AttributePaths:  []string{".size", ".name"},
+			},
+			err: errors.New(testInconsistentFinalPlanOutput),
+			expectedError: fmt.Errorf("expected a provider inconsistency for example_widget.other for attribute path(s) .name, got:\n\n" +
+				"  - example_widget.other .size: was cty.NumberIntVal(1), but now cty.NumberIntVal(2) (inconsistent final plan)\n"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.inconsistency.check(testCase.err)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestWithProviderInconsistencies(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("Error: Missing required argument")
+
+	if got := withProviderInconsistencies(err); got != err {
+		t.Errorf("expected error without provider inconsistencies to be unchanged, got: %s", got)
+	}
+
+	err = errors.New(testInconsistentFinalPlanOutput)
+	got := withProviderInconsistencies(err)
+
+	if !errors.Is(got, err) {
+		t.Errorf("expected wrapped error, got: %s", got)
+	}
+
+	expected := testInconsistentFinalPlanOutput + "\n\nProvider inconsistencies:\n" +
+		"  - example_widget.other .size: was cty.NumberIntVal(1), but now cty.NumberIntVal(2) (inconsistent final plan)\n"
+
+	if diff := cmp.Diff(got.Error(), expected); diff != "" {
+		t.Errorf("unexpected error difference: %s", diff)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+// runStateChecks calls each of the given state checks in order, returning an
+// aggregate error of all failed state checks. The prior state is the state
+// before the TestStep was applied, if any.
+func runStateChecks(ctx context.Context, t testing.T, state *tfjson.State, priorState *tfjson.State, stepNumber int, stepName string, stateChecks []statecheck.StateCheck) error {
+	t.Helper()
+
+	req := statecheck.CheckStateRequest{
+		State:      state,
+		PriorState: priorState,
+		StepNumber: stepNumber,
+		StepName:   stepName,
+		TestName:   t.Name(),
+	}
+
+	var result *multierror.Error
+
+	for i, stateCheck := range stateChecks {
+		resp := statecheck.CheckStateResponse{}
+		stateCheck.CheckState(ctx, req, &resp)
+
+		if resp.Error != nil {
+			result = multierror.Append(result, fmt.Errorf("state check %d/%d error: %w", i+1, len(stateChecks), resp.Error))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
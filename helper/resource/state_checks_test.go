@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestRunStateChecks(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{FormatVersion: "1.0"}
+	errCheck := errors.New("check failed")
+
+	first := &stateCheckSpy{}
+	second := &stateCheckSpy{err: errCheck}
+	third := &stateCheckSpy{}
+
+	err := runStateChecks(context.Background(), t, state, nil, 2, "update", []statecheck.StateCheck{first, second, third})
+
+	if !errors.Is(err, errCheck) {
+		t.Errorf("expected error %q, got: %s", errCheck, err)
+	}
+
+	if first.state != state {
+		t.Errorf("expected first state check to receive state")
+	}
+
+	if first.stepNumber != 2 || first.stepName != "update" || first.testName != t.Name() {
+		t.Errorf("expected first state check to receive step details, got %q step %d %q", first.testName, first.stepNumber, first.stepName)
+	}
+
+	if !second.called {
+		t.Errorf("expected second state check to be called")
+	}
+
+	if !third.called {
+		t.Errorf("expected third state check to be called")
+	}
+}
+
+func TestRunStateChecks_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{FormatVersion: "1.0"}
+
+	err := runStateChecks(context.Background(), t, state, nil, 1, "", []statecheck.StateCheck{
+		&stateCheckSpy{err: errors.New("first failed")},
+		&stateCheckSpy{},
+		&stateCheckSpy{err: errors.New("third failed")},
+	})
+
+	expected := "2 errors occurred:\n\t* state check 1/3 error: first failed\n\t* state check 3/3 error: third failed\n\n"
+
+	if err == nil {
+		t.Fatalf("expected error: %s", expected)
+	}
+
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got: %q", expected, err)
+	}
+}
+
+func TestTest_TestStep_ConfigStateChecks(t *testing.T) {
+	t.Parallel()
+
+	spy := &stateCheckSpy{}
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{spy},
+			},
+		},
+	})
+
+	if !spy.called {
+		t.Error("expected ConfigStateChecks to be called")
+	}
+
+	if spy.state == nil || spy.state.Values == nil {
+		t.Error("expected ConfigStateChecks to receive state values")
+	}
+}
+
+func TestTest_TestStep_ConfigStateChecks_Error(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					&stateCheckSpy{err: errors.New("spy error")},
+				},
+				ExpectError: regexp.MustCompile(`Post-apply state check\(s\) failed:\nspy error`),
+			},
+		},
+	})
+}
+
+var _ statecheck.StateCheck = &stateCheckSpy{}
+
+// stateCheckSpy is a state check which records whether it was called and the
+// state it received, and responds with the given error.
+type stateCheckSpy struct {
+	err        error
+	called     bool
+	state      *tfjson.State
+	stepNumber int
+	stepName   string
+	testName   string
+}
+
+func (s *stateCheckSpy) CheckState(ctx context.Context, req statecheck.CheckStateRequest, resp *statecheck.CheckStateResponse) {
+	s.called = true
+	s.state = req.State
+	s.stepNumber = req.StepNumber
+	s.stepName = req.StepName
+	s.testName = req.TestName
+	resp.Error = s.err
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"os"
+	"strings"
+	gotesting "testing"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// subtestRunner is implemented by *testing.T from the standard library, which
+// is required to run each Terraform CLI version as a subtest.
+type subtestRunner interface {
+	Run(name string, f func(t *gotesting.T)) bool
+}
+
+// terraformVersions returns the Terraform CLI versions to run the TestCase
+// against, from either the TerraformVersions field or the
+// TF_ACC_TERRAFORM_VERSIONS environment variable.
+func (c TestCase) terraformVersions() []string {
+	if len(c.TerraformVersions) > 0 {
+		return c.TerraformVersions
+	}
+
+	return parseTerraformVersions(os.Getenv(plugintest.EnvTfAccTerraformVersions))
+}
+
+// parseTerraformVersions returns the versions of a comma-separated list,
+// ignoring surrounding whitespace and empty entries.
+func parseTerraformVersions(value string) []string {
+	var versions []string
+
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+
+		if v == "" {
+			continue
+		}
+
+		versions = append(versions, v)
+	}
+
+	return versions
+}
+
+// runTerraformVersions runs the TestCase once per Terraform CLI version as a
+// subtest, each with its own helper and working directory.
+func runTerraformVersions(ctx context.Context, t testing.T, c TestCase, sourceDir string, versions []string) {
+	t.Helper()
+
+	runner, ok := t.(subtestRunner)
+
+	if !ok {
+		t.Fatalf("TestCase TerraformVersions requires a *testing.T, got %T", t)
+
+		return
+	}
+
+	for _, tfVersion := range versions {
+		tfVersion := tfVersion
+
+		runner.Run(terraformVersionSubtestName(tfVersion), func(t *gotesting.T) {
+			t.Helper()
+
+			ctx := logging.InitTestContext(ctx, t)
+
+			logging.HelperResourceDebug(ctx, "Starting TestCase for Terraform CLI version", map[string]interface{}{logging.KeyTestTerraformVersion: tfVersion})
+
+			helper, err := plugintest.AutoInitVersionHelper(ctx, sourceDir, tfVersion)
+
+			if err != nil {
+				t.Fatalf("Error preparing Terraform CLI %s: %s", tfVersion, err)
+			}
+
+			defer func(helper *plugintest.Helper) {
+				err := helper.Close()
+				if err != nil {
+					logging.HelperResourceError(ctx, "Unable to clean up temporary test files", map[string]interface{}{logging.KeyError: err})
+				}
+			}(helper)
+
+			if runTFVersionChecks(ctx, t, helper.TerraformVersion(), c.TerraformVersionChecks) {
+				return
+			}
+
+			runNewTest(ctx, t, c, helper)
+		})
+	}
+}
+
+// terraformVersionSubtestName returns the subtest name for a Terraform CLI
+// version, such as terraform_1.5.7.
+func terraformVersionSubtestName(tfVersion string) string {
+	return "terraform_" + strings.TrimPrefix(tfVersion, "v")
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+func TestParseTerraformVersions(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value    string
+		expected []string
+	}{
+		"empty": {
+			value:    "",
+			expected: nil,
+		},
+		"single": {
+			value:    "1.5.7",
+			expected: []string{"1.5.7"},
+		},
+		"multiple": {
+			value:    "1.0.11,1.5.7",
+			expected: []string{"1.0.11", "1.5.7"},
+		},
+		"whitespace-and-empty-entries": {
+			value:    " 1.0.11 , ,v1.5.7, ",
+			expected: []string{"1.0.11", "v1.5.7"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := parseTerraformVersions(testCase.value)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestCaseTerraformVersions(t *testing.T) {
+	testCases := map[string]struct {
+		testCase TestCase
+		env      string
+		expected []string
+	}{
+		"none": {
+			testCase: TestCase{},
+			expected: nil,
+		},
+		"env": {
+			testCase: TestCase{},
+			env:      "1.0.11,1.5.7",
+			expected: []string{"1.0.11", "1.5.7"},
+		},
+		"field": {
+			testCase: TestCase{
+				TerraformVersions: []string{"1.3.0"},
+			},
+			expected: []string{"1.3.0"},
+		},
+		"field-overrides-env": {
+			testCase: TestCase{
+				TerraformVersions: []string{"1.3.0"},
+			},
+			env:      "1.0.11,1.5.7",
+			expected: []string{"1.3.0"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(plugintest.EnvTfAccTerraformVersions, testCase.env)
+
+			got := testCase.testCase.terraformVersions()
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTerraformVersionSubtestName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		version  string
+		expected string
+	}{
+		"version": {
+			version:  "1.5.7",
+			expected: "terraform_1.5.7",
+		},
+		"version-prefix": {
+			version:  "v1.5.7",
+			expected: "terraform_1.5.7",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := terraformVersionSubtestName(testCase.version)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
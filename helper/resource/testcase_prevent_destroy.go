@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// overridePreventDestroy writes a Terraform override file which disables
+// the lifecycle prevent_destroy meta-argument of all managed resources in
+// the root module of the state, so the final destroy can succeed.
+func overridePreventDestroy(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, providers *providerFactories) error {
+	var state *tfjson.State
+
+	err := runProviderCommand(ctx, t, func() error {
+		var err error
+
+		state, err = wd.State(ctx)
+
+		return err
+	}, wd, providers)
+
+	if err != nil {
+		return fmt.Errorf("error retrieving state: %w", err)
+	}
+
+	return wd.SetOverrideConfig(ctx, preventDestroyOverrideConfig(state))
+}
+
+// preventDestroyOverrideConfig returns override configuration which sets
+// the lifecycle prevent_destroy meta-argument to false for all managed
+// resources in the root module of the given state, or an empty string if
+// there are none.
+func preventDestroyOverrideConfig(state *tfjson.State) string {
+	if state == nil || state.Values == nil || state.Values.RootModule == nil {
+		return ""
+	}
+
+	var resources []string
+
+	seen := make(map[string]struct{})
+
+	for _, resource := range state.Values.RootModule.Resources {
+		if resource.Mode != tfjson.ManagedResourceMode {
+			continue
+		}
+
+		key := resource.Type + "." + resource.Name
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		resources = append(resources, fmt.Sprintf("resource %q %q {\n  lifecycle {\n    prevent_destroy = false\n  }\n}\n", resource.Type, resource.Name))
+	}
+
+	sort.Strings(resources)
+
+	return strings.Join(resources, "\n")
+}
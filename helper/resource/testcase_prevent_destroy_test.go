@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPreventDestroyOverrideConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		state    *tfjson.State
+		expected string
+	}{
+		"nil": {},
+		"empty": {
+			state: &tfjson.State{
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{},
+				},
+			},
+		},
+		"resources": {
+			state: &tfjson.State{
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						Resources: []*tfjson.StateResource{
+							{
+								Address: "test_resource.two[0]",
+								Mode:    tfjson.ManagedResourceMode,
+								Type:    "test_resource",
+								Name:    "two",
+							},
+							{
+								Address: "test_resource.two[1]",
+								Mode:    tfjson.ManagedResourceMode,
+								Type:    "test_resource",
+								Name:    "two",
+							},
+							{
+								Address: "data.test_data_source.test",
+								Mode:    tfjson.DataResourceMode,
+								Type:    "test_data_source",
+								Name:    "test",
+							},
+							{
+								Address: "test_resource.one",
+								Mode:    tfjson.ManagedResourceMode,
+								Type:    "test_resource",
+								Name:    "one",
+							},
+						},
+						ChildModules: []*tfjson.StateModule{
+							{
+								Address: "module.child",
+								Resources: []*tfjson.StateResource{
+									{
+										Address: "module.child.test_resource.child",
+										Mode:    tfjson.ManagedResourceMode,
+										Type:    "test_resource",
+										Name:    "child",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: `resource "test_resource" "one" {
+  lifecycle {
+    prevent_destroy = false
+  }
+}
+
+resource "test_resource" "two" {
+  lifecycle {
+    prevent_destroy = false
+  }
+}
+`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := preventDestroyOverrideConfig(testCase.state)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTest_TestCase_OverridePreventDestroy(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		OverridePreventDestroy: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"test_resource": {
+							CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								d.SetId("test")
+								return nil
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							Schema: map[string]*schema.Schema{},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" {
+					lifecycle {
+						prevent_destroy = true
+					}
+				}`,
+			},
+		},
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/go-version"
+
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
 )
 
@@ -41,6 +43,13 @@ func (c TestCase) hasProviders(_ context.Context) bool {
 //
 //   - No overlapping ExternalProviders and Providers entries
 //   - No overlapping ExternalProviders and ProviderFactories entries
+//   - RefreshVerify, if set, has a ResourceAddress
+//   - DestroyConfig and OverridePreventDestroy are not both set
+//   - SkipFinalDestroy, if set, has a SkipFinalDestroyReason and is not set
+//     with CheckDestroy, DestroyConfig, OverridePreventDestroy, or
+//     PostDestroyCheck
+//   - TerraformVersions, if set, are valid versions
+//   - TestStep Name, if set, are unique
 //   - TestStep validations performed by the (TestStep).validate() method.
 func (c TestCase) validate(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Validating TestCase")
@@ -65,13 +74,62 @@ func (c TestCase) validate(ctx context.Context) error {
 		}
 	}
 
+	if c.RefreshVerify != nil && c.RefreshVerify.ResourceAddress == "" {
+		err := fmt.Errorf("TestCase RefreshVerify must have ResourceAddress")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if c.DestroyConfig != "" && c.OverridePreventDestroy {
+		err := fmt.Errorf("TestCase cannot have DestroyConfig and OverridePreventDestroy")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if c.SkipFinalDestroy && c.SkipFinalDestroyReason == "" {
+		err := fmt.Errorf("TestCase SkipFinalDestroy requires SkipFinalDestroyReason")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if c.SkipFinalDestroy && (c.CheckDestroy != nil || c.DestroyConfig != "" || c.OverridePreventDestroy || c.PostDestroyCheck != nil) {
+		err := fmt.Errorf("TestCase SkipFinalDestroy cannot be used with CheckDestroy, DestroyConfig, OverridePreventDestroy, or PostDestroyCheck")
+		logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	for _, tfVersion := range c.TerraformVersions {
+		if _, err := version.NewVersion(tfVersion); err != nil {
+			err := fmt.Errorf("TestCase TerraformVersions entry %q is invalid: %w", tfVersion, err)
+			logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	stepNames := make(map[string]int, len(c.Steps))
+
+	for stepIndex, step := range c.Steps {
+		if step.Name == "" {
+			continue
+		}
+
+		if previous, ok := stepNames[step.Name]; ok {
+			err := fmt.Errorf("TestStep %d/%d Name %q is also used by TestStep %d", stepIndex+1, len(c.Steps), step.Name, previous)
+			logging.HelperResourceError(ctx, "TestCase validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+
+		stepNames[step.Name] = stepIndex + 1
+	}
+
 	testCaseHasProviders := c.hasProviders(ctx)
 
 	for stepIndex, step := range c.Steps {
 		stepNumber := stepIndex + 1 // Use 1-based index for humans
 		stepValidateReq := testStepValidateRequest{
-			StepNumber:           stepNumber,
-			TestCaseHasProviders: testCaseHasProviders,
+			StepNumber:                   stepNumber,
+			TestCaseHasProviders:         testCaseHasProviders,
+			TestCaseHasExternalProviders: len(c.ExternalProviders) > 0,
 		}
 
 		err := step.validate(ctx, stepValidateReq)
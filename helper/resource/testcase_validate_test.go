@@ -12,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestTestCaseHasProviders(t *testing.T) {
@@ -133,6 +135,91 @@ func TestTestCaseValidate(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("TestCase provider \"test\" set in both ExternalProviders and ProviderFactories"),
 		},
+		"refreshverify-missing-resourceaddress": {
+			testCase: TestCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"test": nil, // does not need to be real
+				},
+				RefreshVerify: &RefreshVerify{},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase RefreshVerify must have ResourceAddress"),
+		},
+		"destroyconfig-and-overridepreventdestroy": {
+			testCase: TestCase{
+				DestroyConfig:          "# not empty",
+				OverridePreventDestroy: true,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase cannot have DestroyConfig and OverridePreventDestroy"),
+		},
+		"skipfinaldestroy-missing-reason": {
+			testCase: TestCase{
+				SkipFinalDestroy: true,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase SkipFinalDestroy requires SkipFinalDestroyReason"),
+		},
+		"skipfinaldestroy-checkdestroy": {
+			testCase: TestCase{
+				CheckDestroy:           func(_ *terraform.State) error { return nil },
+				SkipFinalDestroy:       true,
+				SkipFinalDestroyReason: "shared infrastructure",
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestCase SkipFinalDestroy cannot be used with CheckDestroy, DestroyConfig, OverridePreventDestroy, or PostDestroyCheck"),
+		},
+		"terraformversions-invalid": {
+			testCase: TestCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"test": nil, // does not need to be real
+				},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+				TerraformVersions: []string{"1.5.7", "invalid"},
+			},
+			expectedError: fmt.Errorf("TestCase TerraformVersions entry \"invalid\" is invalid"),
+		},
+		"steps-name-duplicate": {
+			testCase: TestCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"test": nil, // does not need to be real
+				},
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+						Name:   "create",
+					},
+					{
+						Config: "# not empty",
+					},
+					{
+						Config: "# not empty",
+						Name:   "create",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("TestStep 3/3 Name \"create\" is also used by TestStep 1"),
+		},
 		"steps-missing": {
 			testCase:      TestCase{},
 			expectedError: fmt.Errorf("TestCase missing Steps"),
@@ -0,0 +1,11 @@
+terraform {
+  required_providers {
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+    }
+  }
+}
+
+resource "random_string" "test" {
+  length = 8
+}
@@ -0,0 +1,3 @@
+module "random" {
+  source = "./modules/random"
+}
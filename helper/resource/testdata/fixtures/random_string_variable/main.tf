@@ -0,0 +1,15 @@
+terraform {
+  required_providers {
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+    }
+  }
+}
+
+variable "length" {
+  type = number
+}
+
+resource "random_string" "test" {
+  length = var.length
+}
@@ -23,7 +23,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/graphcheck"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"github.com/hashicorp/terraform-plugin-testing/internal/addrs"
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
@@ -110,6 +116,9 @@ func AddTestSweepers(name string, s *Sweeper) {
 //	-sweep-run: Comma-separated list of resource type sweepers to run. Defaults
 //	        to all sweepers.
 //
+// If the TF_ACC_REPORT_PATH and TF_ACC_REPORT_JUNIT_PATH environment variables
+// are set, a JUnit XML report is written after the tests are run.
+//
 // Refer to the Env prefixed constants for environment variables that further
 // control testing functionality.
 func TestMain(m interface {
@@ -128,6 +137,20 @@ func TestMain(m interface {
 		}
 	} else {
 		exitCode := m.Run()
+
+		reportPath := os.Getenv(EnvTfAccReportPath)
+		junitPath := os.Getenv(EnvTfAccReportJUnitPath)
+
+		if reportPath != "" && junitPath != "" {
+			if err := writeJUnitReportFile(reportPath, junitPath); err != nil {
+				log.Printf("[ERROR] Error writing JUnit report: %s", err)
+			}
+		}
+
+		if err := plugintest.ClosePluginCache(); err != nil {
+			log.Printf("[ERROR] Error removing provider plugin cache: %s", err)
+		}
+
 		os.Exit(exitCode)
 	}
 }
@@ -322,6 +345,25 @@ type TestCase struct {
 	// acceptance tests, such as verifying that keys are setup.
 	PreCheck func()
 
+	// TerraformVersionChecks is a list of checks which are run against the
+	// version of the Terraform CLI running the test, before any TestStep.
+	// Checks can either skip the test, such as tfversion.SkipBelow, or fail
+	// the test, such as tfversion.RequireAbove. The checks run in order
+	// and stop at the first skip or failure.
+	TerraformVersionChecks []tfversion.TerraformVersionCheck
+
+	// TerraformVersions is a list of Terraform CLI versions, such as 1.0.11
+	// and 1.5.7, to run the TestCase against. If set, the TestCase is run once
+	// per version as a subtest named after the version, each with its own
+	// Terraform CLI installation and working directory. Defaults to the
+	// comma-separated versions of the TF_ACC_TERRAFORM_VERSIONS environment
+	// variable, if set, otherwise the TestCase is run once with the Terraform
+	// CLI found by the default discovery behavior.
+	//
+	// Running versions as subtests requires the test to be given a
+	// *testing.T.
+	TerraformVersions []string
+
 	// ProviderFactories can be specified for the providers that are valid.
 	//
 	// This can also be specified at the TestStep level to enable per-step
@@ -393,10 +435,93 @@ type TestCase struct {
 	// are tested alongside real resources
 	PreventPostDestroyRefresh bool
 
+	// SkipFinalDestroy, if true, skips the destroy at the end of the
+	// TestCase, leaving all resources in the state. This is only intended
+	// for tests against pre-existing or shared infrastructure which must
+	// never be destroyed, such as resources imported with
+	// ImportStatePersist. SkipFinalDestroyReason must explain why.
+	//
+	// A warning with the reason is logged and, if enabled, added to the
+	// report of the TestCase. SkipFinalDestroy cannot be used with
+	// CheckDestroy, DestroyConfig, OverridePreventDestroy, or
+	// PostDestroyCheck.
+	SkipFinalDestroy bool
+
+	// SkipFinalDestroyReason is the required justification for
+	// SkipFinalDestroy.
+	SkipFinalDestroyReason string
+
+	// DestroyConfig, if set, is the configuration for the destroy at the
+	// end of the TestCase, instead of the configuration of the last
+	// TestStep. This is useful when the last configuration cannot be
+	// destroyed as-is, such as when it contains data sources which require
+	// resources that no longer exist.
+	//
+	// Any providers of the TestCase are added to the configuration, as with
+	// TestStep Config. Providers only set in TestStep must be configured by
+	// the DestroyConfig itself. Resources in the state which are not in the
+	// DestroyConfig are still destroyed.
+	DestroyConfig string
+
+	// OverridePreventDestroy, if true, disables the lifecycle prevent_destroy
+	// meta-argument of resources for the destroy at the end of the TestCase,
+	// so configurations using prevent_destroy can be tested without leaving
+	// dangling resources. TestStep using Destroy or the destroy of
+	// resources removed from the configuration are not affected.
+	//
+	// A Terraform override file setting prevent_destroy to false is written
+	// for each managed resource in the root module of the state. The
+	// lifecycle of resources in child modules cannot be overridden.
+	OverridePreventDestroy bool
+
 	// CheckDestroy is called after the resource is finally destroyed
 	// to allow the tester to test that the resource is truly gone.
 	CheckDestroy TestCheckFunc
 
+	// PostDestroyCheck, if set, is called after the destroy at the end of
+	// the TestCase with all diagnostics returned by the providers under test
+	// during the destroy, such as to verify that the destroy returned a
+	// specific warning. Returning an error fails the test.
+	//
+	// Only providers configured in ProviderFactories,
+	// ProtoV5ProviderFactories, or ProtoV6ProviderFactories are recorded.
+	PostDestroyCheck func([]ProviderDiagnostic) error
+
+	// OnFailure is called after a TestStep fails and before the final
+	// destroy, with the state and last saved plan of the failed TestStep, so
+	// provider-specific debugging information can be collected while the
+	// resources still exist. It is not called if the TestCase fails outside
+	// a TestStep, such as during validation or the final destroy.
+	OnFailure func(context.Context, TestFailure)
+
+	// PersistWorkingDirOnFailure copies the Terraform working directory,
+	// including the configuration, state, and saved plan, to a directory
+	// which remains after the test when a TestStep fails. The copy is made
+	// before the final destroy and its path is written to the test output.
+	PersistWorkingDirOnFailure bool
+
+	// RandomSeed seeds the random values generated by the helper/acctest
+	// package, such as unique names, when the TestCase starts, so failures
+	// which depend on generated values can be reproduced exactly. If zero,
+	// the seed is derived from the test name and the TF_ACC_RANDOM_SEED
+	// environment variable, if set, otherwise a new seed, so tests of the
+	// same run do not generate the same values.
+	//
+	// The seed is written to the test output when the TestCase fails, and
+	// is recorded in the TestCase event of the TF_ACC_REPORT_PATH report and
+	// the TestFailure given to the OnFailure function.
+	//
+	// Only values generated after the TestCase starts, such as within
+	// PreCheck, PreConfig, ConfigDirectory, and ConfigFile functions, are
+	// reproduced. Values generated before calling Test(), such as names used
+	// in Config, are only reproduced by setting the TF_ACC_RANDOM_SEED
+	// environment variable to the value written to the test output, which
+	// also seeds the helper/acctest package when the test binary starts, and
+	// running the test on its own, such as with the go test -run flag. The random source is shared by all tests, so
+	// values are not reproducible for tests which call t.Parallel, or use
+	// ParallelTest().
+	RandomSeed int64
+
 	// ErrorCheck allows providers the option to handle errors such as skipping
 	// tests based on certain errors.
 	ErrorCheck ErrorCheckFunc
@@ -405,6 +530,17 @@ type TestCase struct {
 	// same state. Each step can have its own check to verify correctness.
 	Steps []TestStep
 
+	// StepSubtests runs each TestStep as a Go subtest of the TestCase test,
+	// named by the TestStep Name or step_N for unnamed TestStep, where N is
+	// the 1-based index of the TestStep. This is enabled automatically when
+	// any TestStep has a Name, or for every TestCase by the
+	// TF_ACC_STEP_SUBTESTS environment variable. Refer to the TestStep Name
+	// field for details on running TestStep as subtests.
+	//
+	// Running TestStep as subtests requires the test to be given a
+	// *testing.T.
+	StepSubtests bool
+
 	// IDRefreshName is the name of the resource to check during ID-only
 	// refresh testing, which ensures that a resource can be refreshed solely
 	// by its identifier. This will default to the first non-nil primary
@@ -419,6 +555,57 @@ type TestCase struct {
 	// during ID-only refresh testing.
 	IDRefreshIgnore []string
 
+	// RefreshVerify, if set, performs an isolated refresh of a single
+	// resource after every Lifecycle (config) mode TestStep and verifies
+	// that the typed attribute values in state are unchanged by the refresh.
+	//
+	// Unlike IDRefreshName, which compares flatmap attributes of a resource
+	// rebuilt from only its identifier, this compares the full attribute
+	// values from the Terraform JSON state, so it works with any resource
+	// implementation.
+	RefreshVerify *RefreshVerify
+
+	// CredentialsProvider, if set, is called at the start of the TestCase to
+	// get credentials, such as short-lived credentials from an OIDC token
+	// exchange or assumed role, which are set as environment variables for
+	// only the Terraform commands of this TestCase. If the credentials have
+	// an Expiration, they are requested again before any TestStep or the
+	// final destroy which starts within five minutes of the expiration.
+	//
+	// The environment variables are only available to Terraform and the
+	// providers it starts, such as ExternalProviders. Providers under test
+	// configured in ProviderFactories, ProtoV5ProviderFactories, or
+	// ProtoV6ProviderFactories run in the test process and do not receive
+	// them. Once set, TF_VAR_ and TF_CLI_ARGS environment variables of the
+	// test process are no longer passed to Terraform.
+	CredentialsProvider CredentialsProviderFunc
+
+	// CheckProviderConsistency, if true, checks the PlanResourceChange and
+	// ApplyResourceChange responses of providers under test during each
+	// Config mode TestStep with the same data consistency rules Terraform
+	// core uses, failing the TestStep with the attribute paths of any
+	// violations. Planned values must match the configuration for attributes
+	// which are not computed, and applied values must match known planned
+	// values without remaining unknown.
+	//
+	// Terraform tolerates some violations, such as for providers using the
+	// terraform-plugin-sdk legacy type system, only logging warnings, so this
+	// can catch latent provider bugs before they become errors. Only
+	// providers configured in ProviderFactories, ProtoV5ProviderFactories, or
+	// ProtoV6ProviderFactories are checked. TestStep with
+	// ExpectProviderInconsistency are not checked.
+	CheckProviderConsistency bool
+
+	// SeparateConfigPreamble, if true, writes the generated terraform and
+	// provider configuration blocks of TestStep with Config into a separate
+	// providers_gen.tf file in the working directory, instead of prepending
+	// them to the Config. Line numbers in Terraform diagnostics then match
+	// the lines of the TestStep Config exactly.
+	//
+	// TestStep with ConfigDirectory, ConfigFile, or ConfigAliasModule are
+	// not affected.
+	SeparateConfigPreamble bool
+
 	// WorkingDir sets the base directory where testing files used by the testing
 	// module are generated. If WorkingDir is unset, a randomized, temporary
 	// directory is used.
@@ -429,6 +616,18 @@ type TestCase struct {
 	WorkingDir string
 }
 
+// RefreshVerify configures the TestCase RefreshVerify behavior.
+type RefreshVerify struct {
+	// ResourceAddress is the address of the resource to refresh, such as
+	// "examplecloud_thing.test" or "module.example.examplecloud_thing.test".
+	ResourceAddress string
+
+	// IgnorePaths are attribute paths, relative to the resource, which are
+	// excluded from the comparison of values before and after the refresh.
+	// Ignoring a path also ignores all values nested beneath it.
+	IgnorePaths []tfjsonpath.Path
+}
+
 // ExternalProvider holds information about third-party providers that should
 // be downloaded by Terraform as part of running the test step.
 type ExternalProvider struct {
@@ -446,6 +645,21 @@ type ExternalProvider struct {
 // Refer to the Env prefixed constants for environment variables that further
 // control testing functionality.
 type TestStep struct {
+	// Name is an optional name for the TestStep. When any TestStep in the
+	// TestCase has a Name, each TestStep is run as a Go subtest of the
+	// TestCase test, named by its Name or step_N for unnamed TestStep, where
+	// N is the 1-based index of the TestStep. Failures and timing are then
+	// reported per TestStep and the -run flag of go test can select
+	// individual TestStep, such as -run 'TestAccExample/update'.
+	//
+	// TestStep always run sequentially against the same working directory,
+	// so a TestStep which is not selected by the -run flag is not run and
+	// any later TestStep runs without its changes. Subsequent TestStep are
+	// not run after a TestStep fails.
+	//
+	// Names must be unique within the TestCase and cannot contain a slash.
+	Name string
+
 	// ResourceName should be set to the name of the resource
 	// that is being tested. Example: "aws_instance.foo". Various test
 	// modes use this to auto-detect state information.
@@ -488,6 +702,81 @@ type TestStep struct {
 	// contains valid JSON.
 	Config string
 
+	// ConfigDirectory is a function which returns the path to a directory
+	// of Terraform configuration files to give to Terraform, such as
+	// config.StaticDirectory("testdata/example"). The files and any
+	// subdirectories, such as local modules, are copied into the working
+	// directory and Terraform init is run before each apply.
+	//
+	// The configuration files must declare any providers that are not
+	// reattached via the ProtoV5ProviderFactories, ProtoV6ProviderFactories,
+	// or ProviderFactories fields, as ExternalProviders are not supported.
+	//
+	// ConfigDirectory is mutually exclusive with Config and ConfigFile.
+	ConfigDirectory config.TestStepConfigFunc
+
+	// ConfigFile is a function which returns the path to a single Terraform
+	// configuration file to give to Terraform, such as
+	// config.StaticFile("testdata/example.tf"). The file is copied into the
+	// working directory and Terraform init is run before each apply.
+	//
+	// The configuration file must declare any providers that are not
+	// reattached via the ProtoV5ProviderFactories, ProtoV6ProviderFactories,
+	// or ProviderFactories fields, as ExternalProviders are not supported.
+	//
+	// ConfigFile is mutually exclusive with Config and ConfigDirectory.
+	ConfigFile config.TestStepConfigFunc
+
+	// ConfigVariables is a map defining variables for use with the
+	// configuration, such as:
+	//
+	//	ConfigVariables: config.Variables{
+	//		"name": config.StringVariable("example"),
+	//	},
+	//
+	// The variables are written to an automatically loaded variable
+	// definitions file in the working directory, so the configuration must
+	// declare a variable block for each of them. ImportState mode steps
+	// without configuration use these variables with the configuration of
+	// the prior step.
+	//
+	// Values of config.SensitiveStringVariable must be marked sensitive
+	// wherever they appear in the plans of the TestStep, and must not appear
+	// in the Terraform logs, otherwise the TestStep fails.
+	ConfigVariables config.Variables
+
+	// ConfigAliasModule, if set, places the Config in a generated child
+	// module, which is called by a generated root module that passes
+	// provider configurations to the child module, such as an aliased
+	// provider configuration declared in the configuration_aliases of the
+	// child module. Resource addresses in checks must include the module
+	// path, such as module.test.examplecloud_thing.test. Terraform init is
+	// run before each apply.
+	//
+	// ConfigAliasModule requires Config and cannot be used with ImportState.
+	ConfigAliasModule *AliasModule
+
+	// ProviderConfig, if set, generates a provider configuration block for
+	// each provider configuration address, such as "examplecloud" or
+	// "examplecloud.alternate", with the given attribute values, such as:
+	//
+	//	ProviderConfig: map[string]map[string]interface{}{
+	//		"examplecloud": {
+	//			"endpoint":    server.URL,
+	//			"max_retries": 1,
+	//		},
+	//	},
+	//
+	// Values are rendered as Terraform attribute values after encoding them
+	// as JSON, so nested blocks are not supported. The generated blocks
+	// replace the empty provider blocks otherwise generated for
+	// ExternalProviders, so the Config must not declare a provider block for
+	// the same provider configuration address.
+	//
+	// ProviderConfig requires Config and cannot be used with
+	// ConfigAliasModule.
+	ProviderConfig map[string]map[string]interface{}
+
 	// Check is called after the Config is applied. Use this step to
 	// make your own API calls to check the status of things, and to
 	// inspect the format of the ResourceState itself.
@@ -498,18 +787,161 @@ type TestStep struct {
 	// If this is nil, no check is done on this step.
 	Check TestCheckFunc
 
+	// ConfigStateChecks allow assertions to be made against the state file after the Config is applied, using
+	// a state check. Unlike Check, the state checks receive the state as a typed [tfjson.State] rather than the
+	// legacy flatmap state representation. Custom state checks can be created by implementing the [StateCheck]
+	// interface, or by using a StateCheck implementation from the provided [statecheck] package.
+	//
+	// If a state check fails, the test will fail. In this case, a destroy
+	// plan will still be attempted.
+	//
+	// [tfjson.State]: https://pkg.go.dev/github.com/hashicorp/terraform-json#State
+	// [StateCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/statecheck#StateCheck
+	// [statecheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/statecheck
+	ConfigStateChecks []statecheck.StateCheck
+
+	// ConfigGraphChecks allow assertions to be made against the dependency
+	// graph of the Config before it is planned, using a graph check. The
+	// graph is retrieved via the `terraform graph -type=plan` command. Custom
+	// graph checks can be created by implementing the [GraphCheck]
+	// interface, or by using a GraphCheck implementation from the provided
+	// [graphcheck] package.
+	//
+	// [GraphCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/graphcheck#GraphCheck
+	// [graphcheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/graphcheck
+	ConfigGraphChecks []graphcheck.GraphCheck
+
 	// Destroy will create a destroy plan if set to true.
 	Destroy bool
 
+	// ConfigPlanChecks allow assertions to be made against the plan file at different points of a Config (apply) test using a plan check.
+	// Custom plan checks can be created by implementing the [PlanCheck] interface, or by using a PlanCheck implementation from the provided [plancheck] package
+	//
+	// [PlanCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck#PlanCheck
+	// [plancheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck
+	ConfigPlanChecks ConfigPlanChecks
+
+	// RefreshPlanChecks allow assertions to be made against the plan file at different points of a
+	// RefreshState test using a plan check, such as to verify which resources drifted and how.
+	// Custom plan checks can be created by implementing the [PlanCheck] interface, or by using a
+	// PlanCheck implementation from the provided [plancheck] package
+	//
+	// [PlanCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck#PlanCheck
+	// [plancheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck
+	RefreshPlanChecks RefreshPlanChecks
+
+	// DestroyPlanChecks allow assertions to be made against the plan file at different points of a
+	// Destroy test using a plan check, such as to verify that only the expected resources are planned
+	// for destruction. Custom plan checks can be created by implementing the [PlanCheck] interface, or
+	// by using a PlanCheck implementation from the provided [plancheck] package
+	//
+	// [PlanCheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck#PlanCheck
+	// [plancheck]: https://pkg.go.dev/github.com/hashicorp/terraform-plugin-testing/plancheck
+	DestroyPlanChecks DestroyPlanChecks
+
 	// ExpectNonEmptyPlan can be set to true for specific types of tests that are
 	// looking to verify that a diff occurs
+	//
+	// The plancheck.ExpectNonEmptyPlan() plan check in the ConfigPlanChecks
+	// PostApplyPreRefresh or PostApplyPostRefresh phase, or the RefreshPlanChecks
+	// PostRefresh phase, can instead be used to verify that a diff occurs in
+	// a specific phase.
 	ExpectNonEmptyPlan bool
 
+	// ConvergenceApplies is the maximum number of times the Config is
+	// applied before the post-apply plan is expected to be empty. This is
+	// useful for resources that only converge after a subsequent apply, such
+	// as those with attributes computed from remote asynchronous processes.
+	//
+	// After the first apply, a plan is created and, if it is not empty,
+	// applied again until either the plan is empty or this limit is reached.
+	// The usual post-apply plan checks are then performed, so the test fails
+	// if the resources have not converged within the given number of applies.
+	//
+	// If unset or set to 1, the Config is applied once.
+	ConvergenceApplies int
+
 	// ExpectError allows the construction of test cases that we expect to fail
 	// with an error. The specified regexp must match against the error for the
 	// test to pass.
 	ExpectError *regexp.Regexp
 
+	// ExpectedFailure marks this TestStep as known to be broken, such as
+	// by an upstream bug, with a reference to the issue tracking it, such
+	// as "https://github.com/example/terraform-provider-example/issues/123".
+	// It allows keeping the TestStep rather than deleting its coverage
+	// until the issue is fixed.
+	//
+	// If the TestStep fails, the failure is logged and reported with the
+	// expected-failure result instead of failing the test, and the test is
+	// skipped, as the remaining TestStep may depend on this TestStep. The
+	// final destroy still runs.
+	//
+	// If the TestStep passes, the test fails as unexpectedly fixed and the
+	// TestStep is reported with the unexpectedly-fixed result, so the
+	// ExpectedFailure can be removed.
+	ExpectedFailure string
+
+	// ExpectDeprecatedAttributes is a list of resource or data source
+	// attributes which the configuration is expected to use and which the
+	// provider reports as deprecated, such as
+	// "example_widget.test.old_name" or "data.example_widget.test.old_name".
+	//
+	// The configuration is validated with terraform validate before it is
+	// applied, and the test fails if any of the attributes did not produce a
+	// deprecation warning diagnostic. Attributes are identified from the
+	// diagnostic source location, so only top-level attributes of resource
+	// and data source blocks are supported.
+	ExpectDeprecatedAttributes []string
+
+	// ExpectDiagnosticAttributePaths is a list of attribute paths, such as
+	// tfjsonpath.New("nested_block").AtSliceIndex(0).AtMapKey("name"), which
+	// are expected to be the attribute path of at least one diagnostic
+	// returned by a provider under test during this TestStep. This is
+	// typically combined with ExpectError or ExpectNonEmptyPlan.
+	//
+	// Attribute paths are recorded from the provider protocol, so the same
+	// expectations apply to providers built with terraform-plugin-sdk, which
+	// use cty.Path, and terraform-plugin-framework, which use path.Path.
+	// Attribute names and map keys are map steps and list indexes are slice
+	// steps. Paths into set elements cannot be represented and are ignored.
+	//
+	// Only providers configured in ProviderFactories,
+	// ProtoV5ProviderFactories, or ProtoV6ProviderFactories are recorded.
+	ExpectDiagnosticAttributePaths []tfjsonpath.Path
+
+	// ExpectProviderCrash, if true, expects a provider under test to crash
+	// while Terraform runs this TestStep, such as to test the handling of an
+	// unrecoverable condition, and Terraform to return an error. The test
+	// fails if no provider crashed. ExpectError can be used to additionally
+	// match the error, which includes the provider panic and stack trace.
+	//
+	// Providers configured in ProviderFactories, ProtoV5ProviderFactories,
+	// or ProtoV6ProviderFactories run in the test process, so a panic in a
+	// provider RPC is recovered and the providers are stopped, as if the
+	// provider process exited. Panics in goroutines started by the provider
+	// and calls to os.Exit() cannot be recovered and will end the test
+	// binary.
+	ExpectProviderCrash bool
+
+	// ExpectProviderInconsistency, if set, expects Terraform to return a
+	// "Provider produced inconsistent result after apply" or "Provider
+	// produced inconsistent final plan" error matching the given
+	// ProviderInconsistency while running this TestStep, such as to
+	// reproduce a provider bug before fixing it. The test fails if no
+	// matching error is returned. ExpectError can be used to additionally
+	// match the error.
+	//
+	// Unexpected provider inconsistency errors always fail the TestStep,
+	// with the offending resource addresses and attribute paths summarized
+	// after the Terraform error.
+	ExpectProviderInconsistency *ProviderInconsistency
+
+	// AdditionalCLIOptions allows an intentionally limited set of options to
+	// be passed to the Terraform plan and apply commands run by the TestStep,
+	// such as allowing providers to defer resource changes.
+	AdditionalCLIOptions *AdditionalCLIOptions
+
 	// PlanOnly can be set to only run `plan` with this configuration, and not
 	// actually apply it. This is useful for ensuring config changes result in
 	// no-op plans
@@ -535,6 +967,15 @@ type TestStep struct {
 	// SkipFunc is called after PreConfig but before applying the Config.
 	SkipFunc func() (bool, error)
 
+	// OperationsCheck is called at the end of the TestStep with the number of
+	// each Terraform CLI command the testing framework invoked while running
+	// the TestStep, such as 1 init, 2 plan, and 1 apply. This can be used to
+	// detect changes in the testing framework or provider which silently add
+	// Terraform CLI invocations.
+	//
+	// If an error is returned, the test will fail.
+	OperationsCheck OperationsCheckFunc
+
 	//---------------------------------------------------------------
 	// ImportState testing
 	//---------------------------------------------------------------
@@ -594,6 +1035,19 @@ type TestStep struct {
 	// at the end of the test step that is verifying import behavior.
 	ImportStatePersist bool
 
+	// DestroyPersistedImport controls whether the resource imported by a
+	// TestStep with ImportStatePersist enabled is destroyed at the end of
+	// the TestCase. It is only valid with ImportStatePersist.
+	//
+	// When unset or true, the imported resource remains in the persisted
+	// state and is destroyed with all other resources at the end of the
+	// TestCase, which is the default behavior. When false, the imported
+	// resource is removed from the persisted state before the final
+	// destroy, so the remote object is left in place. CheckDestroy does
+	// not receive removed resources, and the test is responsible for
+	// cleaning up the remote object.
+	DestroyPersistedImport *bool
+
 	//---------------------------------------------------------------
 	// RefreshState testing
 	//---------------------------------------------------------------
@@ -679,6 +1133,54 @@ type TestStep struct {
 	ExternalProviders map[string]ExternalProvider
 }
 
+// ConfigPlanChecks defines the different points in a Config TestStep when plan checks can be run.
+type ConfigPlanChecks struct {
+	// PreApply runs all plan checks in the slice. This occurs before any apply commands are executed for
+	// a Config TestStep. These checks cannot be used with PlanOnly, as there is no plan created before the
+	// apply.
+	PreApply []plancheck.PlanCheck
+
+	// PostApplyPreRefresh runs all plan checks in the slice. This occurs against the plan created after
+	// the apply of a Config TestStep, before the refresh which checks for perpetual differences. A
+	// non-empty plan in this phase is a difference produced by the provider during the apply, rather
+	// than drift found by the refresh.
+	//
+	// If the slice contains plancheck.ExpectNonEmptyPlan(), the TestStep does not fail due to a
+	// non-empty plan in this phase.
+	PostApplyPreRefresh []plancheck.PlanCheck
+
+	// PostApply runs all plan checks in the slice after the PostApplyPreRefresh plan checks, against
+	// the same plan.
+	//
+	// Deprecated: Use PostApplyPreRefresh instead.
+	PostApply []plancheck.PlanCheck
+
+	// PostApplyPostRefresh runs all plan checks in the slice. This occurs against the plan created
+	// after the apply and refresh of a Config TestStep, which checks for perpetual differences.
+	//
+	// If the slice contains plancheck.ExpectNonEmptyPlan(), the TestStep does not fail due to a
+	// non-empty plan in this phase.
+	PostApplyPostRefresh []plancheck.PlanCheck
+}
+
+// DestroyPlanChecks defines the different points in a Destroy TestStep when plan checks can be run.
+type DestroyPlanChecks struct {
+	// PreDestroy runs all plan checks in the slice. This occurs against the destroy plan created
+	// before the destroy of a Destroy TestStep, after any ConfigPlanChecks PreApply plan checks.
+	// These checks cannot be used with PlanOnly, as there is no plan created before the destroy.
+	PreDestroy []plancheck.PlanCheck
+}
+
+// RefreshPlanChecks defines the different points in a RefreshState TestStep when plan checks can be run.
+type RefreshPlanChecks struct {
+	// PostRefresh runs all plan checks in the slice. This occurs against the plan created after the
+	// refresh of a RefreshState TestStep.
+	//
+	// If the slice contains plancheck.ExpectNonEmptyPlan(), the TestStep does not fail due to a
+	// non-empty plan.
+	PostRefresh []plancheck.PlanCheck
+}
+
 // ParallelTest performs an acceptance test on a resource, allowing concurrency
 // with other ParallelTest. The number of concurrent tests is controlled by the
 // "go test" command -parallel flag.
@@ -693,6 +1195,15 @@ func ParallelTest(t testing.T, c TestCase) {
 	Test(t, c)
 }
 
+// ParallelTestWithContext is a variant of ParallelTest which accepts a
+// context.Context. Refer to the TestWithContext() function documentation for
+// details about context cancellation.
+func ParallelTestWithContext(ctx context.Context, t testing.T, c TestCase) {
+	t.Helper()
+	t.Parallel()
+	TestWithContext(ctx, t, c)
+}
+
 // Test performs an acceptance test on a resource.
 //
 // Tests are not run unless an environmental variable "TF_ACC" is
@@ -717,18 +1228,49 @@ func ParallelTest(t testing.T, c TestCase) {
 //     TF_ACC_TERRAFORM_VERSION environment variable is also set.
 //   - If the TF_ACC_TERRAFORM_VERSION environment variable is set, install
 //     and use that Terraform CLI version.
-//   - If both the TF_ACC_TERRAFORM_PATH and TF_ACC_TERRAFORM_VERSION
-//     environment variables are unset, perform a lookup for the Terraform
-//     CLI binary based on the operating system PATH. If not found, the
-//     latest available Terraform CLI binary is installed.
+//   - If the TF_ACC_TERRAFORM_VERSION_CONSTRAINT environment variable is
+//     set, use a Terraform CLI binary from the operating system PATH which
+//     satisfies the constraint. If not found, the latest Terraform CLI
+//     version satisfying the constraint is installed.
+//   - If the TF_ACC_TERRAFORM_PATH, TF_ACC_TERRAFORM_VERSION, and
+//     TF_ACC_TERRAFORM_VERSION_CONSTRAINT environment variables are unset,
+//     perform a lookup for the Terraform CLI binary based on the operating
+//     system PATH. If not found, the latest available Terraform CLI binary
+//     is installed.
+//
+// Set the TF_ACC_TERRAFORM_CACHE_DIR environment variable to keep installed
+// Terraform CLI binaries for later test runs, and the TF_ACC_TERRAFORM_OFFLINE
+// environment variable to disable installation entirely. Set the
+// TF_ACC_TERRAFORM_VERSIONS environment variable, or the TestCase type
+// TerraformVersions field, to run the TestCase against multiple Terraform CLI
+// versions as subtests.
 //
 // Refer to the Env prefixed constants for additional details about these
 // environment variables, and others, that control testing functionality.
 func Test(t testing.T, c TestCase) {
 	t.Helper()
 
-	ctx := context.Background()
+	TestWithContext(context.Background(), t, c)
+}
+
+// TestWithContext is a variant of Test which accepts a context.Context. All
+// Terraform CLI commands are run with the given context, so they are
+// interrupted when the context is cancelled, such as when a test deadline is
+// reached or the test binary receives a signal. Once the context is
+// cancelled, no further TestStep or TestStep hooks (e.g. PreConfig) are
+// called and the test fails.
+//
+// The final destroy of any remaining resources is still attempted after the
+// context is cancelled, as a best effort to prevent dangling resources. Set
+// the TF_ACC_DESTROY_ON_INTERRUPT environment variable to also cancel the
+// context when the test binary receives an interrupt signal.
+//
+// Test() function requirements and documentation also apply to this function.
+func TestWithContext(ctx context.Context, t testing.T, c TestCase) {
+	t.Helper()
+
 	ctx = logging.InitTestContext(ctx, t)
+	ctx = testSourceContext(ctx)
 
 	err := c.validate(ctx)
 
@@ -762,8 +1304,21 @@ func Test(t testing.T, c TestCase) {
 		}
 	}
 
+	ctx, stop := interruptContext(ctx)
+	defer stop()
+
+	if err := ctx.Err(); err != nil {
+		logging.HelperResourceError(ctx,
+			"Test context done before TestCase",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		t.Fatalf("TestCase not run, test context done: %s", err)
+	}
+
 	logging.HelperResourceDebug(ctx, "Starting TestCase")
 
+	c.RandomSeed = seedRandom(ctx, t, c.RandomSeed)
+
 	// Run the PreCheck if we have it.
 	// This is done after the auto-configure to allow providers
 	// to override the default auto-configure parameters.
@@ -779,6 +1334,15 @@ func Test(t testing.T, c TestCase) {
 	if err != nil {
 		t.Fatalf("Error getting working dir: %s", err)
 	}
+
+	if versions := c.terraformVersions(); len(versions) > 0 {
+		runTerraformVersions(ctx, t, c, sourceDir, versions)
+
+		logging.HelperResourceDebug(ctx, "Finished TestCase")
+
+		return
+	}
+
 	helper := plugintest.AutoInitProviderHelper(ctx, sourceDir)
 	defer func(helper *plugintest.Helper) {
 		err := helper.Close()
@@ -787,6 +1351,10 @@ func Test(t testing.T, c TestCase) {
 		}
 	}(helper)
 
+	if runTFVersionChecks(ctx, t, helper.TerraformVersion(), c.TerraformVersionChecks) {
+		return
+	}
+
 	runNewTest(ctx, t, c, helper)
 
 	logging.HelperResourceDebug(ctx, "Finished TestCase")
@@ -804,6 +1372,16 @@ func UnitTest(t testing.T, c TestCase) {
 	Test(t, c)
 }
 
+// UnitTestWithContext is a variant of UnitTest which accepts a
+// context.Context. Refer to the TestWithContext() function documentation for
+// details about context cancellation.
+func UnitTestWithContext(ctx context.Context, t testing.T, c TestCase) {
+	t.Helper()
+
+	c.IsUnitTest = true
+	TestWithContext(ctx, t, c)
+}
+
 func testResource(c TestStep, state *terraform.State) (*terraform.ResourceState, error) {
 	for _, m := range state.Modules {
 		if len(m.Resources) > 0 {
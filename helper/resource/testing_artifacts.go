@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+const (
+	// artifactsConfigDir is the name of the failure artifacts directory
+	// containing the Terraform configuration files.
+	artifactsConfigDir = "config"
+
+	// artifactsPlanFile is the name of the failure artifacts file containing
+	// the saved plan JSON.
+	artifactsPlanFile = "plan.json"
+
+	// artifactsStateFile is the name of the failure artifacts file containing
+	// the state JSON.
+	artifactsStateFile = "state.json"
+)
+
+// collectFailureArtifacts writes the configuration, saved plan JSON, state
+// JSON, and Terraform logs of the working directory into a directory for the
+// test and TestStep under the TF_ACC_ARTIFACTS_DIR directory, if set, and
+// returns the directory. An empty string is returned if the environment
+// variable is not set or the directory cannot be created.
+//
+// Collection is best effort, as it runs after the test has already failed.
+// Errors are logged rather than failing the test again.
+func collectFailureArtifacts(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, providers *providerFactories, stepNumber int) string {
+	t.Helper()
+
+	root := os.Getenv(plugintest.EnvTfAccArtifactsDir)
+
+	if root == "" {
+		return ""
+	}
+
+	dir := failureArtifactsDir(root, t.Name(), stepNumber)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logging.HelperResourceWarn(ctx,
+			"Unable to create failure artifacts directory",
+			map[string]interface{}{logging.KeyError: err},
+		)
+
+		return ""
+	}
+
+	var errs []string
+
+	if err := copyConfigArtifacts(wd.BaseDir(), filepath.Join(dir, artifactsConfigDir)); err != nil {
+		errs = append(errs, fmt.Sprintf("configuration: %s", err))
+	}
+
+	if wd.HasSavedPlan() {
+		var plan *tfjson.Plan
+
+		err := runProviderCommand(ctx, t, func() error {
+			var err error
+			plan, err = wd.SavedPlan(ctx)
+			return err
+		}, wd, providers)
+
+		if err == nil {
+			err = writeJSONArtifact(filepath.Join(dir, artifactsPlanFile), plan)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("plan: %s", err))
+		}
+	}
+
+	var state *tfjson.State
+
+	err := runProviderCommand(ctx, t, func() error {
+		var err error
+		state, err = wd.State(ctx)
+		return err
+	}, wd, providers)
+
+	if err == nil {
+		err = writeJSONArtifact(filepath.Join(dir, artifactsStateFile), state)
+	}
+
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("state: %s", err))
+	}
+
+	if logPath := wd.LogPath(); logPath != "" {
+		if err := plugintest.CopyFile(logPath, filepath.Join(dir, plugintest.LogFileName)); err != nil {
+			errs = append(errs, fmt.Sprintf("Terraform logs: %s", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		logging.HelperResourceWarn(ctx,
+			"Unable to collect some failure artifacts",
+			map[string]interface{}{logging.KeyError: strings.Join(errs, "; ")},
+		)
+	}
+
+	t.Logf("Failure artifacts have been written to: %s", dir)
+
+	return dir
+}
+
+// failureArtifactsDir returns the failure artifacts directory for the test
+// and TestStep. Subtest separators in the test name are escaped, similar to
+// TF_LOG_PATH_MASK handling.
+func failureArtifactsDir(root string, testName string, stepNumber int) string {
+	return filepath.Join(root, strings.ReplaceAll(testName, "/", "__"), fmt.Sprintf("step_%d", stepNumber))
+}
+
+// copyConfigArtifacts copies the Terraform configuration and variable files,
+// and configuration subdirectories, of the working directory into the
+// destination directory.
+func copyConfigArtifacts(workingDir string, dest string) error {
+	entries, err := os.ReadDir(workingDir)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		// Directories copied from ConfigDirectory, such as local modules,
+		// are included. Symbolic links to the provider source directories
+		// and the hidden .terraform directory are not.
+		if entry.IsDir() {
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
+
+			if err := plugintest.CopyDir(filepath.Join(workingDir, name), filepath.Join(dest, name)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !isConfigArtifact(name) {
+			continue
+		}
+
+		if err := plugintest.CopyFile(filepath.Join(workingDir, name), filepath.Join(dest, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isConfigArtifact returns true if the file name is a Terraform configuration
+// or variable file.
+func isConfigArtifact(name string) bool {
+	for _, suffix := range []string{".tf", ".tf.json", ".tfvars", ".tfvars.json"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeJSONArtifact writes the indented JSON encoding of the value to path.
+func writeJSONArtifact(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
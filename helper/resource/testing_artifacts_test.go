@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFailureArtifactsDir(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testName   string
+		stepNumber int
+		expected   string
+	}{
+		"test": {
+			testName:   "TestAccExample",
+			stepNumber: 1,
+			expected:   filepath.Join("artifacts", "TestAccExample", "step_1"),
+		},
+		"subtest": {
+			testName:   "TestAccExample/terraform_1.5.7",
+			stepNumber: 2,
+			expected:   filepath.Join("artifacts", "TestAccExample__terraform_1.5.7", "step_2"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := failureArtifactsDir("artifacts", testCase.testName, testCase.stepNumber)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCopyConfigArtifacts(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	dest := filepath.Join(t.TempDir(), "config")
+
+	files := map[string]string{
+		"terraform_plugin_test.tf":                  `resource "test_resource" "test" {}`,
+		"terraform_plugin_test.tf.json":             `{}`,
+		"terraform_plugin_test.auto.tfvars.json":    `{"example": "value"}`,
+		"tfplan":                                    "binary plan",
+		"terraform.log":                             "log output",
+		filepath.Join("modules", "example", "a.tf"): `# module`,
+		filepath.Join(".terraform", "plugin"):       "binary plugin",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(workingDir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("unable to create directory: %s", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write file: %s", err)
+		}
+	}
+
+	if err := copyConfigArtifacts(workingDir, dest); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := os.ReadDir(dest)
+
+	if err != nil {
+		t.Fatalf("unable to read destination: %s", err)
+	}
+
+	var got []string
+
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+
+	sort.Strings(got)
+
+	expected := []string{
+		"modules",
+		"terraform_plugin_test.auto.tfvars.json",
+		"terraform_plugin_test.tf",
+		"terraform_plugin_test.tf.json",
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dest, "terraform_plugin_test.tf"))
+
+	if err != nil {
+		t.Fatalf("unable to read copied file: %s", err)
+	}
+
+	if diff := cmp.Diff(string(content), files["terraform_plugin_test.tf"]); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// BudgetFunc is a function which decides whether a TestStep may apply its
+// planned resource changes, such as to limit the cost of expensive resources
+// across a large test run. It is registered with SetBudgetFunc and called
+// for every TestCase after the pre-apply plan of each Config TestStep, except
+// Destroy TestStep.
+type BudgetFunc func(context.Context, BudgetRequest) BudgetResponse
+
+// BudgetRequest is the request sent to the BudgetFunc.
+type BudgetRequest struct {
+	// TestName is the name of the Go test running the TestCase.
+	TestName string
+
+	// StepNumber is the 1-based index of the TestStep in the TestCase.
+	StepNumber int
+
+	// StepName is the Name of the TestStep, if set.
+	StepName string
+
+	// PlannedCreates is the number of resource instances planned to be
+	// created by resource type, such as "example_widget", including
+	// replacements.
+	PlannedCreates map[string]int
+
+	// Plan is the pre-apply plan of the TestStep.
+	Plan *tfjson.Plan
+}
+
+// BudgetResponse is the response returned by the BudgetFunc.
+type BudgetResponse struct {
+	// Veto, if true, skips the TestCase before the TestStep apply. Resources
+	// created by earlier TestStep are still destroyed.
+	Veto bool
+
+	// Defer, if greater than zero and Veto is not set, waits the duration
+	// before calling the BudgetFunc again with the same request, such as
+	// while other TestCase are using the budget. The TestCase fails if the
+	// test context is done while waiting.
+	Defer time.Duration
+
+	// Reason describes the decision, which is included in the skip message
+	// and logs.
+	Reason string
+}
+
+var (
+	// budgetFunc is the registered BudgetFunc, if any.
+	budgetFunc BudgetFunc
+
+	// budgetFuncMutex prevents concurrent access to budgetFunc.
+	budgetFuncMutex sync.RWMutex
+)
+
+// SetBudgetFunc registers a BudgetFunc which is called for every TestCase
+// in the test binary, such as in a TestMain function. A nil BudgetFunc
+// removes the registered function.
+func SetBudgetFunc(f BudgetFunc) {
+	budgetFuncMutex.Lock()
+	defer budgetFuncMutex.Unlock()
+
+	budgetFunc = f
+}
+
+// registeredBudgetFunc returns the registered BudgetFunc, if any.
+func registeredBudgetFunc() BudgetFunc {
+	budgetFuncMutex.RLock()
+	defer budgetFuncMutex.RUnlock()
+
+	return budgetFunc
+}
+
+var _ error = &budgetVetoError{}
+
+// budgetVetoError is returned for a TestStep vetoed by the BudgetFunc.
+type budgetVetoError struct {
+	reason string
+}
+
+func (e *budgetVetoError) Error() string {
+	if e.reason == "" {
+		return "TestCase vetoed by test budget"
+	}
+
+	return fmt.Sprintf("TestCase vetoed by test budget: %s", e.reason)
+}
+
+// checkBudget calls the BudgetFunc with the pre-apply plan of the TestStep
+// until it does not defer, returning a *budgetVetoError if vetoed.
+func checkBudget(ctx context.Context, f BudgetFunc, req BudgetRequest) error {
+	for {
+		logging.HelperResourceDebug(ctx, "Calling BudgetFunc")
+
+		resp := f(ctx, req)
+
+		logging.HelperResourceDebug(ctx, "Called BudgetFunc")
+
+		if resp.Veto {
+			logging.HelperResourceWarn(ctx, fmt.Sprintf("BudgetFunc vetoed TestCase: %s", resp.Reason))
+
+			return &budgetVetoError{reason: resp.Reason}
+		}
+
+		if resp.Defer <= 0 {
+			return nil
+		}
+
+		logging.HelperResourceDebug(ctx, fmt.Sprintf("BudgetFunc deferred TestStep for %s: %s", resp.Defer, resp.Reason))
+
+		timer := time.NewTimer(resp.Defer)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("test context done while deferred by test budget: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// plannedCreates returns the number of resource instances planned to be
+// created by resource type, including replacements. Data sources are not
+// included.
+func plannedCreates(plan *tfjson.Plan) map[string]int {
+	creates := make(map[string]int)
+
+	if plan == nil {
+		return creates
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode == tfjson.DataResourceMode || rc.Change == nil {
+			continue
+		}
+
+		if rc.Change.Actions.Create() || rc.Change.Actions.Replace() {
+			creates[rc.Type]++
+		}
+	}
+
+	return creates
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestCheckBudget(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		responses     []BudgetResponse
+		expectedCalls int
+		expectedError error
+	}{
+		"allow": {
+			responses:     []BudgetResponse{{}},
+			expectedCalls: 1,
+		},
+		"veto": {
+			responses:     []BudgetResponse{{Veto: true, Reason: "too expensive"}},
+			expectedCalls: 1,
+			expectedError: errors.New("TestCase vetoed by test budget: too expensive"),
+		},
+		"veto-without-reason": {
+			responses:     []BudgetResponse{{Veto: true}},
+			expectedCalls: 1,
+			expectedError: errors.New("TestCase vetoed by test budget"),
+		},
+		"defer-then-allow": {
+			responses: []BudgetResponse{
+				{Defer: time.Millisecond, Reason: "waiting"},
+				{Defer: time.Millisecond, Reason: "waiting"},
+				{},
+			},
+			expectedCalls: 3,
+		},
+		"defer-then-veto": {
+			responses: []BudgetResponse{
+				{Defer: time.Millisecond, Reason: "waiting"},
+				{Veto: true, Reason: "budget exhausted"},
+			},
+			expectedCalls: 2,
+			expectedError: errors.New("TestCase vetoed by test budget: budget exhausted"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := BudgetRequest{
+				TestName:       "TestAccExample",
+				StepNumber:     1,
+				PlannedCreates: map[string]int{"example_widget": 2},
+			}
+
+			var calls int
+
+			err := checkBudget(context.Background(), func(_ context.Context, got BudgetRequest) BudgetResponse {
+				calls++
+
+				if diff := cmp.Diff(got, req); diff != "" {
+					t.Errorf("unexpected request difference: %s", diff)
+				}
+
+				return testCase.responses[calls-1]
+			}, req)
+
+			if calls != testCase.expectedCalls {
+				t.Errorf("expected %d calls, got %d", testCase.expectedCalls, calls)
+			}
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				var veto *budgetVetoError
+
+				if !errors.As(err, &veto) {
+					t.Errorf("expected *budgetVetoError, got %T", err)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestCheckBudget_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := checkBudget(ctx, func(context.Context, BudgetRequest) BudgetResponse {
+		cancel()
+
+		return BudgetResponse{Defer: time.Hour}
+	}, BudgetRequest{})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error, got: %v", err)
+	}
+
+	var veto *budgetVetoError
+
+	if errors.As(err, &veto) {
+		t.Error("expected error other than *budgetVetoError")
+	}
+}
+
+func TestPlannedCreates(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Mode:   tfjson.ManagedResourceMode,
+				Type:   "example_widget",
+				Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Mode:   tfjson.ManagedResourceMode,
+				Type:   "example_widget",
+				Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+			},
+			{
+				Mode:   tfjson.ManagedResourceMode,
+				Type:   "example_gadget",
+				Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Mode:   tfjson.ManagedResourceMode,
+				Type:   "example_gadget",
+				Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+			{
+				Mode:   tfjson.DataResourceMode,
+				Type:   "example_widget",
+				Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionRead}},
+			},
+			{
+				Mode: tfjson.ManagedResourceMode,
+				Type: "example_thing",
+			},
+		},
+	}
+
+	expected := map[string]int{
+		"example_gadget": 1,
+		"example_widget": 2,
+	}
+
+	if diff := cmp.Diff(plannedCreates(plan), expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	if diff := cmp.Diff(plannedCreates(nil), map[string]int{}); diff != "" {
+		t.Errorf("unexpected nil plan difference: %s", diff)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// credentialsRefreshWindow is how long before the expiration of credentials
+// they are requested again, so they do not expire during Terraform commands.
+const credentialsRefreshWindow = 5 * time.Minute
+
+// CredentialsProviderFunc is a function which returns the credentials for
+// the Terraform commands of a TestCase. Refer to the TestCase
+// CredentialsProvider field for details.
+type CredentialsProviderFunc func(context.Context) (Credentials, error)
+
+// Credentials are the credentials returned by a CredentialsProviderFunc.
+type Credentials struct {
+	// EnvironmentVariables are set for the Terraform commands of the
+	// TestCase, such as AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and
+	// AWS_SESSION_TOKEN. Environment variables managed by the testing
+	// framework, such as TF_LOG or TF_VAR_ variables, cannot be set.
+	EnvironmentVariables map[string]string
+
+	// Expiration, if set, is when the credentials expire.
+	Expiration time.Time
+}
+
+// credentialsEnvSetter sets environment variables for Terraform commands,
+// such as the *plugintest.WorkingDir of a TestCase.
+type credentialsEnvSetter interface {
+	SetEnv(context.Context, map[string]string) error
+}
+
+// testCaseCredentials tracks the credentials of a TestCase.
+type testCaseCredentials struct {
+	provider CredentialsProviderFunc
+
+	// now returns the current time, which can be replaced in testing.
+	now func() time.Time
+
+	requested  bool
+	expiration time.Time
+}
+
+// newTestCaseCredentials returns the credentials tracker of a TestCase, or
+// nil if the TestCase has no CredentialsProvider.
+func newTestCaseCredentials(provider CredentialsProviderFunc) *testCaseCredentials {
+	if provider == nil {
+		return nil
+	}
+
+	return &testCaseCredentials{
+		provider: provider,
+		now:      time.Now,
+	}
+}
+
+// refresh calls the CredentialsProvider and sets the environment variables
+// of the returned credentials, if they have not been requested yet or expire
+// soon.
+func (c *testCaseCredentials) refresh(ctx context.Context, setter credentialsEnvSetter) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.requested && (c.expiration.IsZero() || c.expiration.Sub(c.now()) > credentialsRefreshWindow) {
+		return nil
+	}
+
+	logging.HelperResourceDebug(ctx, "Calling TestCase CredentialsProvider")
+
+	creds, err := c.provider(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	logging.HelperResourceDebug(ctx, "Called TestCase CredentialsProvider")
+
+	if err := setter.SetEnv(ctx, creds.EnvironmentVariables); err != nil {
+		return err
+	}
+
+	c.requested = true
+	c.expiration = creds.Expiration
+
+	return nil
+}
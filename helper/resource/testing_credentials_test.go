@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testCredentialsEnvSetter struct {
+	env []map[string]string
+	err error
+}
+
+func (s *testCredentialsEnvSetter) SetEnv(_ context.Context, env map[string]string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.env = append(s.env, env)
+
+	return nil
+}
+
+func TestTestCaseCredentials_Refresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		expirations   []time.Time
+		refreshes     int
+		setterErr     error
+		expectedEnv   []map[string]string
+		expectedError error
+	}{
+		"no-expiration": {
+			expirations: []time.Time{{}},
+			refreshes:   3,
+			expectedEnv: []map[string]string{
+				{"EXAMPLE_TOKEN": "token-1"},
+			},
+		},
+		"not-expiring": {
+			expirations: []time.Time{now.Add(time.Hour)},
+			refreshes:   3,
+			expectedEnv: []map[string]string{
+				{"EXAMPLE_TOKEN": "token-1"},
+			},
+		},
+		"expiring": {
+			expirations: []time.Time{now.Add(time.Minute), now.Add(time.Hour)},
+			refreshes:   3,
+			expectedEnv: []map[string]string{
+				{"EXAMPLE_TOKEN": "token-1"},
+				{"EXAMPLE_TOKEN": "token-2"},
+			},
+		},
+		"setter-error": {
+			expirations:   []time.Time{{}},
+			refreshes:     1,
+			setterErr:     errors.New("unable to set environment variable TF_LOG, which is managed by the testing framework"),
+			expectedError: errors.New("unable to set environment variable TF_LOG, which is managed by the testing framework"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+
+			credentials := newTestCaseCredentials(func(context.Context) (Credentials, error) {
+				calls++
+
+				return Credentials{
+					EnvironmentVariables: map[string]string{
+						"EXAMPLE_TOKEN": "token-" + string(rune('0'+calls)),
+					},
+					Expiration: testCase.expirations[calls-1],
+				}, nil
+			})
+			credentials.now = func() time.Time { return now }
+
+			setter := &testCredentialsEnvSetter{err: testCase.setterErr}
+
+			var err error
+
+			for i := 0; i < testCase.refreshes && err == nil; i++ {
+				err = credentials.refresh(context.Background(), setter)
+			}
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(setter.env, testCase.expectedEnv); diff != "" {
+				t.Errorf("unexpected environment difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTestCaseCredentials_Refresh_ProviderError(t *testing.T) {
+	t.Parallel()
+
+	credentials := newTestCaseCredentials(func(context.Context) (Credentials, error) {
+		return Credentials{}, errors.New("unable to exchange OIDC token")
+	})
+
+	err := credentials.refresh(context.Background(), &testCredentialsEnvSetter{})
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if diff := cmp.Diff(err.Error(), "unable to exchange OIDC token"); diff != "" {
+		t.Errorf("unexpected error difference: %s", diff)
+	}
+}
+
+func TestTestCaseCredentials_Refresh_Nil(t *testing.T) {
+	t.Parallel()
+
+	var credentials *testCaseCredentials
+
+	if err := credentials.refresh(context.Background(), nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// TestFailure contains information about a failed TestCase, which is given
+// to the TestCase OnFailure function before the final destroy.
+type TestFailure struct {
+	// StepNumber is the 1-based index of the failed TestStep in the
+	// TestCase.
+	StepNumber int
+
+	// StepName is the Name of the failed TestStep, if set.
+	StepName string
+
+	// Operation is the mode of the failed TestStep, which is config for
+	// Config TestStep, import for ImportState TestStep, or refresh for
+	// RefreshState TestStep.
+	Operation string
+
+	// Errors are the failure messages reported by the failed TestStep.
+	Errors []string
+
+	// ArtifactsDir is the path of the failure artifacts directory of the
+	// failed TestStep, if enabled by the TF_ACC_ARTIFACTS_DIR environment
+	// variable.
+	ArtifactsDir string
+
+	// LogPath is the path of the Terraform log file, if Terraform logs are
+	// written, such as when the TF_ACC_LOG_PATH environment variable is set.
+	// The file may contain logs of other TestCase.
+	LogPath string
+
+	// WorkingDir is the path of the Terraform working directory of the
+	// TestCase. If the TestCase PersistWorkingDirOnFailure field is enabled,
+	// this is the path of the persisted copy, which remains after the test.
+	// Otherwise, the working directory is removed after the final destroy.
+	WorkingDir string
+
+	// State is the state after the failure, retrieved via the
+	// `terraform show -json` command, or nil if it could not be retrieved.
+	State *tfjson.State
+
+	// Plan is the last saved plan of the failed TestStep, retrieved via the
+	// `terraform show -json` command, or nil if there is no saved plan.
+	Plan *tfjson.Plan
+
+	// RandomSeed is the seed of the random values generated by the
+	// helper/acctest package during the TestCase. Refer to the TestCase
+	// RandomSeed field for details.
+	RandomSeed int64
+}
+
+// handleFailure persists the working directory and calls the OnFailure
+// function of the TestCase, if enabled, after a TestStep has failed and
+// before the final destroy. The failure messages of the TestStep and failure
+// artifacts directory, if any, are given to the OnFailure function.
+//
+// Handling is best effort, as it runs after the test has already failed.
+// Errors are logged rather than failing the test again.
+func handleFailure(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories, stepNumber int, errs []string, artifactsDir string) {
+	t.Helper()
+
+	if !c.PersistWorkingDirOnFailure && c.OnFailure == nil {
+		return
+	}
+
+	step := c.Steps[stepNumber-1]
+
+	failure := TestFailure{
+		StepNumber:   stepNumber,
+		StepName:     step.Name,
+		Operation:    step.reportPhase(),
+		Errors:       errs,
+		ArtifactsDir: artifactsDir,
+		LogPath:      wd.LogPath(),
+		WorkingDir:   wd.BaseDir(),
+		RandomSeed:   c.RandomSeed,
+	}
+
+	if c.PersistWorkingDirOnFailure {
+		dir, err := persistWorkingDir(wd)
+
+		if err != nil {
+			logging.HelperResourceWarn(ctx,
+				"Unable to persist working directory after failure",
+				map[string]interface{}{logging.KeyError: err},
+			)
+		} else {
+			t.Logf("Working directory of the failed TestCase has been copied to: %s", dir)
+
+			failure.WorkingDir = dir
+		}
+	}
+
+	if c.OnFailure == nil {
+		return
+	}
+
+	err := runProviderCommand(ctx, t, func() error {
+		var err error
+		failure.State, err = wd.State(ctx)
+		return err
+	}, wd, providers)
+
+	if err != nil {
+		logging.HelperResourceWarn(ctx,
+			"Unable to retrieve state for TestCase OnFailure",
+			map[string]interface{}{logging.KeyError: err},
+		)
+	}
+
+	if wd.HasSavedPlan() {
+		err := runProviderCommand(ctx, t, func() error {
+			var err error
+			failure.Plan, err = wd.SavedPlan(ctx)
+			return err
+		}, wd, providers)
+
+		if err != nil {
+			logging.HelperResourceWarn(ctx,
+				"Unable to retrieve saved plan for TestCase OnFailure",
+				map[string]interface{}{logging.KeyError: err},
+			)
+		}
+	}
+
+	logging.HelperResourceDebug(ctx, "Calling TestCase OnFailure")
+
+	c.OnFailure(ctx, failure)
+
+	logging.HelperResourceDebug(ctx, "Called TestCase OnFailure")
+}
+
+// persistWorkingDir copies the working directory into a new directory next
+// to the temporary directory of the Terraform CLI helper, which is not
+// removed after the test, and returns its path.
+func persistWorkingDir(wd *plugintest.WorkingDir) (string, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(wd.GetHelper().WorkingDirectory()), "plugintest-failed")
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := plugintest.CopyDir(wd.BaseDir(), dir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
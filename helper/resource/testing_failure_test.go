@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestTest_TestCase_OnFailure(t *testing.T) {
+	t.Parallel()
+
+	var failure *TestFailure
+
+	testExpectTFatal(t, func() {
+		Test(&mockT{}, TestCase{
+			ProviderFactories: map[string]func() (*schema.Provider, error){
+				"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+					return migrationTestProvider(), nil
+				},
+			},
+			OnFailure: func(_ context.Context, f TestFailure) {
+				failure = &f
+			},
+			PersistWorkingDirOnFailure: true,
+			Steps: []TestStep{
+				{
+					Config: `resource "test_resource" "test" { name = "create" }`,
+				},
+				{
+					Config: `resource "test_resource" "test" { name = "update" }`,
+					Check: func(*terraform.State) error {
+						return errors.New("check failed")
+					},
+				},
+			},
+		})
+	})
+
+	if failure == nil {
+		t.Fatal("expected OnFailure to be called")
+	}
+
+	defer os.RemoveAll(failure.WorkingDir)
+
+	if failure.StepNumber != 2 {
+		t.Errorf("expected failed step 2, got step %d", failure.StepNumber)
+	}
+
+	if failure.Operation != "config" {
+		t.Errorf("expected config operation, got %q", failure.Operation)
+	}
+
+	if len(failure.Errors) != 1 || !strings.Contains(failure.Errors[0], "check failed") {
+		t.Errorf("expected OnFailure to receive check error, got %q", failure.Errors)
+	}
+
+	if failure.State == nil || failure.State.Values == nil {
+		t.Error("expected OnFailure to receive state")
+	}
+
+	if _, err := os.Stat(filepath.Join(failure.WorkingDir, "terraform_plugin_test.tf")); err != nil {
+		t.Errorf("expected persisted working directory configuration: %s", err)
+	}
+}
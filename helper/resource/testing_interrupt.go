@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// interruptSignals are the signals which cancel the test context when the
+// TF_ACC_DESTROY_ON_INTERRUPT environment variable is set.
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// interrupted is set to 1 once the test binary receives an interrupt signal,
+// so TestCase which start afterwards are not run.
+var interrupted int32
+
+// interruptContext returns a context which is cancelled when the test binary
+// receives an interrupt signal, if enabled by the TF_ACC_DESTROY_ON_INTERRUPT
+// environment variable. The returned function must be called to stop
+// handling signals once the TestCase, including its final destroy, is
+// complete.
+//
+// Only the first signal is handled, after which the default signal handling
+// is restored once all running TestCase have received the signal, so a
+// second signal exits the test binary immediately, such as when the destroy
+// is stuck. The context is already cancelled for TestCase which start after
+// a signal was received.
+func interruptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if os.Getenv(EnvTfAccDestroyOnInterrupt) == "" {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	if atomic.LoadInt32(&interrupted) == 1 {
+		cancel()
+
+		return ctx, cancel
+	}
+
+	logging.HelperResourceTrace(ctx, "Handling interrupt signals to destroy resources")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, interruptSignals...)
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+
+			// Only report the signal once for all running TestCase.
+			if atomic.CompareAndSwapInt32(&interrupted, 0, 1) {
+				log.Printf("[WARN] Received %s, attempting to destroy any remaining resources. Send %s again to exit immediately.", sig, sig)
+			}
+
+			logging.HelperResourceWarn(ctx, "Received interrupt signal, attempting to destroy any remaining resources")
+
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
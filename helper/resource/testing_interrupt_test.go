@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInterruptContext(t *testing.T) {
+	t.Setenv(EnvTfAccDestroyOnInterrupt, "1")
+	t.Cleanup(func() { atomic.StoreInt32(&interrupted, 0) })
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+
+	if err != nil {
+		t.Fatalf("unable to find test process: %s", err)
+	}
+
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skipf("unable to send interrupt signal: %s", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected context to be cancelled by interrupt signal")
+	}
+
+	if atomic.LoadInt32(&interrupted) != 1 {
+		t.Error("expected interrupted to be set")
+	}
+}
+
+func TestInterruptContext_AfterInterrupt(t *testing.T) {
+	t.Setenv(EnvTfAccDestroyOnInterrupt, "1")
+
+	atomic.StoreInt32(&interrupted, 1)
+	t.Cleanup(func() { atomic.StoreInt32(&interrupted, 0) })
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	if ctx.Err() == nil {
+		t.Fatal("expected cancelled context for TestCase starting after interrupt")
+	}
+}
+
+func TestInterruptContext_Stop(t *testing.T) {
+	t.Setenv(EnvTfAccDestroyOnInterrupt, "1")
+
+	ctx, stop := interruptContext(context.Background())
+
+	if ctx.Err() != nil {
+		t.Fatalf("unexpected cancelled context: %s", ctx.Err())
+	}
+
+	stop()
+
+	if ctx.Err() == nil {
+		t.Fatal("expected cancelled context after stop")
+	}
+
+	if atomic.LoadInt32(&interrupted) != 0 {
+		t.Error("expected interrupted to be unset")
+	}
+}
+
+func TestInterruptContext_Disabled(t *testing.T) {
+	t.Setenv(EnvTfAccDestroyOnInterrupt, "")
+
+	ctx, stop := interruptContext(context.Background())
+	defer stop()
+
+	if ctx.Done() != nil {
+		t.Fatal("expected context without signal handling")
+	}
+}
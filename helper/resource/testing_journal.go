@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// journalMutex prevents concurrent TestCase from interleaving journal
+// entries when writing to the same journal file.
+var journalMutex sync.Mutex
+
+// journalEntry is a record of an apply, written to the journal file set by
+// the TF_ACC_JOURNAL_PATH environment variable.
+type journalEntry struct {
+	// TestName is the name of the Go test running the TestCase.
+	TestName string `json:"test_name"`
+
+	// StepNumber is the 1-based index of the TestStep in the TestCase.
+	StepNumber int `json:"step_number"`
+
+	// ConfigHash is the hex encoded SHA-256 hash of the configuration
+	// contents.
+	ConfigHash string `json:"config_hash"`
+
+	// WorkingDir is the directory where Terraform CLI is run.
+	WorkingDir string `json:"working_dir"`
+
+	// Timestamp is when the entry was written, in UTC.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// writeJournalEntry appends an entry to the journal file, if enabled by the
+// TF_ACC_JOURNAL_PATH environment variable.
+func writeJournalEntry(ctx context.Context, testName string, stepNumber int, cfg testStepConfig, workingDir string) error {
+	journalPath := os.Getenv(EnvTfAccJournalPath)
+
+	if journalPath == "" {
+		return nil
+	}
+
+	configHash, err := cfg.hash()
+
+	if err != nil {
+		return fmt.Errorf("unable to hash configuration: %w", err)
+	}
+
+	entry := journalEntry{
+		TestName:   testName,
+		StepNumber: stepNumber,
+		ConfigHash: configHash,
+		WorkingDir: workingDir,
+		Timestamp:  time.Now().UTC(),
+	}
+
+	line, err := json.Marshal(entry)
+
+	if err != nil {
+		return fmt.Errorf("unable to encode journal entry: %w", err)
+	}
+
+	logging.HelperResourceTrace(ctx, fmt.Sprintf("Writing journal entry to %s", journalPath))
+
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return fmt.Errorf("unable to open journal file: %w", err)
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write journal entry: %w", err)
+	}
+
+	return nil
+}
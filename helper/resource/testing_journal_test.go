@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteJournalEntry(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	t.Setenv(EnvTfAccJournalPath, journalPath)
+
+	ctx := context.Background()
+
+	if err := writeJournalEntry(ctx, "TestExample", 1, testStepConfig{raw: `resource "test" "test" {}`}, "/tmp/work1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := writeJournalEntry(ctx, "TestExample", 2, testStepConfig{raw: `resource "test" "test" {}`}, "/tmp/work1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	f, err := os.Open(journalPath)
+
+	if err != nil {
+		t.Fatalf("unable to open journal: %s", err)
+	}
+
+	defer f.Close()
+
+	var entries []journalEntry
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var entry journalEntry
+
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("unable to decode journal entry: %s", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 journal entries, got %d", len(entries))
+	}
+
+	for i, entry := range entries {
+		if entry.TestName != "TestExample" {
+			t.Errorf("entry %d: expected test name TestExample, got %q", i, entry.TestName)
+		}
+
+		if entry.StepNumber != i+1 {
+			t.Errorf("entry %d: expected step number %d, got %d", i, i+1, entry.StepNumber)
+		}
+
+		if entry.WorkingDir != "/tmp/work1" {
+			t.Errorf("entry %d: expected working dir /tmp/work1, got %q", i, entry.WorkingDir)
+		}
+
+		if entry.Timestamp.IsZero() {
+			t.Errorf("entry %d: expected timestamp", i)
+		}
+	}
+
+	if entries[0].ConfigHash == "" || entries[0].ConfigHash != entries[1].ConfigHash {
+		t.Errorf("expected matching config hashes, got %q and %q", entries[0].ConfigHash, entries[1].ConfigHash)
+	}
+}
+
+func TestWriteJournalEntry_Disabled(t *testing.T) {
+	t.Setenv(EnvTfAccJournalPath, "")
+
+	if err := writeJournalEntry(context.Background(), "TestExample", 1, testStepConfig{}, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,483 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// MigrationCase is a single set of tests to verify that resources managed by
+// a provider implemented with terraform-plugin-sdk are unchanged when the
+// same provider is reimplemented with terraform-plugin-framework, such as
+// when migrating resources with terraform-plugin-mux.
+//
+// Each TestStep is first applied with SDKProvider. The same configuration is
+// then planned and applied with FrameworkProvider, which must produce an empty
+// plan and identical resource state values.
+type MigrationCase struct {
+	// IsUnitTest, PreCheck, and TerraformVersionChecks have the same meaning
+	// as the TestCase fields of the same name.
+	IsUnitTest             bool
+	PreCheck               func()
+	TerraformVersionChecks []tfversion.TerraformVersionCheck
+
+	// SDKProvider is the provider implementation before the migration,
+	// typically ExternalProviders with the last released provider version
+	// or ProviderFactories with a terraform-plugin-sdk provider.
+	SDKProvider MigrationProvider
+
+	// FrameworkProvider is the provider implementation after the migration,
+	// typically ProtoV5ProviderFactories or ProtoV6ProviderFactories with a
+	// terraform-plugin-framework or terraform-plugin-mux provider server.
+	FrameworkProvider MigrationProvider
+
+	// CheckDestroy and ErrorCheck have the same meaning as the TestCase
+	// fields of the same name.
+	CheckDestroy TestCheckFunc
+	ErrorCheck   ErrorCheckFunc
+
+	// ExactStateEquivalence, if true, compares the normalized JSON encoding
+	// of each resource in the state after applying with FrameworkProvider
+	// byte-for-byte against the state after applying with SDKProvider. This
+	// includes both the attribute values and which attribute values are
+	// marked as sensitive. By default, only the attribute values are compared.
+	ExactStateEquivalence bool
+
+	// IgnoreStatePaths are attribute paths which are not compared between the
+	// resource states of SDKProvider and FrameworkProvider, such as attributes
+	// which are expected to change during the migration. The paths apply to
+	// every resource in the state.
+	IgnoreStatePaths []tfjsonpath.Path
+
+	// Steps are the configuration TestStep to migrate. Each TestStep must
+	// set Config, ConfigDirectory, or ConfigFile, and must not set
+	// ImportState, RefreshState, or any provider fields. Check and
+	// ConfigStateChecks are only run after applying with SDKProvider.
+	Steps []TestStep
+}
+
+// MigrationProvider is the provider configuration for one side of a
+// MigrationCase or TypeRenameCase. The fields have the same meaning as the
+// TestStep fields of the same name.
+type MigrationProvider struct {
+	// ExternalProviders are providers downloaded by Terraform CLI, such
+	// as the last released version of the provider under test.
+	ExternalProviders map[string]ExternalProvider
+
+	// ProviderFactories are terraform-plugin-sdk providers served in the
+	// test process.
+	ProviderFactories map[string]func() (*schema.Provider, error)
+
+	// ProtoV5ProviderFactories are protocol version 5 provider servers
+	// served in the test process.
+	ProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
+	// ProtoV6ProviderFactories are protocol version 6 provider servers
+	// served in the test process.
+	ProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+}
+
+// isEmpty returns true if no providers are configured.
+func (p MigrationProvider) isEmpty() bool {
+	return len(p.ExternalProviders) == 0 &&
+		len(p.ProviderFactories) == 0 &&
+		len(p.ProtoV5ProviderFactories) == 0 &&
+		len(p.ProtoV6ProviderFactories) == 0
+}
+
+// TestMigration performs an acceptance test verifying that resources created
+// with MigrationCase SDKProvider are unchanged after switching to
+// FrameworkProvider. Each TestStep is run as two TestStep in a single
+// TestCase: the first applies the configuration with SDKProvider, and the
+// second applies the same configuration with FrameworkProvider, failing if
+// the plan is not empty or any resource state values differ from the first.
+//
+// Test() function requirements and documentation also apply to this function.
+func TestMigration(t testing.T, c MigrationCase) {
+	t.Helper()
+
+	if err := c.validate(); err != nil {
+		t.Fatalf("Test validation error: %s", err)
+	}
+
+	Test(t, c.testCase())
+}
+
+// validate ensures the MigrationCase is valid based on the following criteria:
+//
+//   - SDKProvider and FrameworkProvider are set.
+//   - Steps are set.
+//   - Each TestStep sets Config, ConfigDirectory, or ConfigFile.
+//   - No TestStep sets ImportState, RefreshState, or provider fields.
+func (c MigrationCase) validate() error {
+	if c.SDKProvider.isEmpty() {
+		return fmt.Errorf("MigrationCase missing SDKProvider")
+	}
+
+	if c.FrameworkProvider.isEmpty() {
+		return fmt.Errorf("MigrationCase missing FrameworkProvider")
+	}
+
+	if len(c.Steps) == 0 {
+		return fmt.Errorf("MigrationCase missing Steps")
+	}
+
+	for stepIndex, step := range c.Steps {
+		stepNumber := stepIndex + 1 // Use 1-based index for humans
+
+		if !step.hasConfig() {
+			return fmt.Errorf("MigrationCase TestStep %d/%d missing Config or ConfigDirectory or ConfigFile", stepNumber, len(c.Steps))
+		}
+
+		if step.ImportState || step.RefreshState {
+			return fmt.Errorf("MigrationCase TestStep %d/%d cannot have ImportState or RefreshState", stepNumber, len(c.Steps))
+		}
+
+		if step.hasProviders(context.Background()) {
+			return fmt.Errorf("MigrationCase TestStep %d/%d cannot have providers, use SDKProvider and FrameworkProvider", stepNumber, len(c.Steps))
+		}
+	}
+
+	return nil
+}
+
+// testCase returns the TestCase which applies each TestStep with
+// SDKProvider, followed by a TestStep which verifies the same configuration
+// with FrameworkProvider.
+func (c MigrationCase) testCase() TestCase {
+	steps := make([]TestStep, 0, len(c.Steps)*2)
+
+	for _, step := range c.Steps {
+		values := &migrationStateValues{}
+
+		sdkStep := step
+		sdkStep.ExternalProviders = c.SDKProvider.ExternalProviders
+		sdkStep.ProviderFactories = c.SDKProvider.ProviderFactories
+		sdkStep.ProtoV5ProviderFactories = c.SDKProvider.ProtoV5ProviderFactories
+		sdkStep.ProtoV6ProviderFactories = c.SDKProvider.ProtoV6ProviderFactories
+		sdkStep.ConfigStateChecks = append(
+			append([]statecheck.StateCheck{}, step.ConfigStateChecks...),
+			migrationSaveState{values: values},
+		)
+
+		frameworkStep := TestStep{
+			Config:                   step.Config,
+			ConfigDirectory:          step.ConfigDirectory,
+			ConfigFile:               step.ConfigFile,
+			ConfigVariables:          step.ConfigVariables,
+			ExternalProviders:        c.FrameworkProvider.ExternalProviders,
+			ProviderFactories:        c.FrameworkProvider.ProviderFactories,
+			ProtoV5ProviderFactories: c.FrameworkProvider.ProtoV5ProviderFactories,
+			ProtoV6ProviderFactories: c.FrameworkProvider.ProtoV6ProviderFactories,
+			ConfigPlanChecks: ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					migrationExpectEmptyPlan{},
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				migrationCompareState{values: values},
+			},
+		}
+
+		steps = append(steps, sdkStep, frameworkStep)
+	}
+
+	return TestCase{
+		IsUnitTest:             c.IsUnitTest,
+		PreCheck:               c.PreCheck,
+		TerraformVersionChecks: c.TerraformVersionChecks,
+		CheckDestroy:           c.CheckDestroy,
+		ErrorCheck:             c.ErrorCheck,
+		Steps:                  steps,
+	}
+}
+
+// migrationStateValues are the managed resources, by resource address, saved
+// after applying a TestStep with the SDKProvider.
+type migrationStateValues struct {
+	resources map[string]*tfjson.StateResource
+}
+
+var _ statecheck.StateCheck = migrationSaveState{}
+
+// migrationSaveState saves the managed resources of the state.
+type migrationSaveState struct {
+	values *migrationStateValues
+}
+
+// CheckState implements the state check logic.
+func (s migrationSaveState) CheckState(_ context.Context, req statecheck.CheckStateRequest, _ *statecheck.CheckStateResponse) {
+	s.values.resources = migrationResources(req.State)
+}
+
+var _ statecheck.StateCheck = migrationCompareState{}
+
+// migrationCompareState compares the managed resources of the state with the
+// resources saved after applying with the SDKProvider.
+type migrationCompareState struct {
+	// exact enables comparing the normalized JSON encoding of the attribute
+	// and sensitive values, rather than only the attribute values.
+	exact bool
+
+	// ignorePaths are attribute paths removed from every resource before
+	// comparison.
+	ignorePaths []tfjsonpath.Path
+
+	values *migrationStateValues
+}
+
+// CheckState implements the state check logic.
+func (s migrationCompareState) CheckState(_ context.Context, req statecheck.CheckStateRequest, resp *statecheck.CheckStateResponse) {
+	got := migrationResources(req.State)
+
+	var diffs []string
+
+	for _, address := range migrationResourceAddresses(s.values.resources) {
+		gotResource, ok := got[address]
+
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: resource not found in state", address))
+
+			continue
+		}
+
+		diff, err := s.diff(s.values.resources[address], gotResource)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s: %w", address, err)
+
+			return
+		}
+
+		if diff != "" {
+			diffs = append(diffs, fmt.Sprintf("%s: attribute values differ (-sdk +framework):\n%s", address, diff))
+		}
+	}
+
+	if len(diffs) > 0 {
+		resp.Error = fmt.Errorf("expected identical resource state after migrating to the framework provider:\n\n%s", strings.Join(diffs, "\n"))
+	}
+}
+
+// diff returns the differences between the resources, or an empty string if
+// they are equivalent.
+func (s migrationCompareState) diff(sdk, framework *tfjson.StateResource) (string, error) {
+	if !s.exact {
+		return cmp.Diff(
+			migrationRemovePaths(sdk.AttributeValues, s.ignorePaths),
+			migrationRemovePaths(framework.AttributeValues, s.ignorePaths),
+		), nil
+	}
+
+	sdkJSON, err := migrationNormalizedJSON(sdk, s.ignorePaths)
+
+	if err != nil {
+		return "", err
+	}
+
+	frameworkJSON, err := migrationNormalizedJSON(framework, s.ignorePaths)
+
+	if err != nil {
+		return "", err
+	}
+
+	if sdkJSON == frameworkJSON {
+		return "", nil
+	}
+
+	return cmp.Diff(sdkJSON, frameworkJSON), nil
+}
+
+var _ plancheck.PlanCheck = migrationExpectEmptyPlan{}
+
+// migrationExpectEmptyPlan verifies that no resources are planned to change
+// after migrating to the FrameworkProvider.
+type migrationExpectEmptyPlan struct{}
+
+// CheckPlan implements the plan check logic.
+func (migrationExpectEmptyPlan) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	var changes []string
+
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Change == nil || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+			continue
+		}
+
+		changes = append(changes, fmt.Sprintf("%s (%s)", rc.Address, rc.Change.Actions))
+	}
+
+	if len(changes) > 0 {
+		resp.Error = fmt.Errorf("expected empty plan after migrating to the framework provider, got changes for: %s", strings.Join(changes, ", "))
+	}
+}
+
+// migrationResources returns all managed resources in all modules of the
+// state, by resource address.
+func migrationResources(state *tfjson.State) map[string]*tfjson.StateResource {
+	result := make(map[string]*tfjson.StateResource)
+
+	if state == nil || state.Values == nil {
+		return result
+	}
+
+	var walk func(module *tfjson.StateModule)
+
+	walk = func(module *tfjson.StateModule) {
+		if module == nil {
+			return
+		}
+
+		for _, resource := range module.Resources {
+			if resource.Mode != tfjson.ManagedResourceMode {
+				continue
+			}
+
+			result[resource.Address] = resource
+		}
+
+		for _, child := range module.ChildModules {
+			walk(child)
+		}
+	}
+
+	walk(state.Values.RootModule)
+
+	return result
+}
+
+// migrationResourceAddresses returns the resource addresses in sorted order.
+func migrationResourceAddresses(resources map[string]*tfjson.StateResource) []string {
+	addresses := make([]string, 0, len(resources))
+
+	for address := range resources {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// migrationNormalizedJSON returns the indented JSON encoding of the attribute
+// and sensitive values of the resource, without the ignored paths. Object keys
+// are sorted by the encoding, so identical values always produce identical
+// output.
+func migrationNormalizedJSON(resource *tfjson.StateResource, ignorePaths []tfjsonpath.Path) (string, error) {
+	var sensitiveValues map[string]interface{}
+
+	if len(resource.SensitiveValues) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(resource.SensitiveValues))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&sensitiveValues); err != nil {
+			return "", fmt.Errorf("unable to decode sensitive values: %w", err)
+		}
+	}
+
+	normalized := map[string]interface{}{
+		"attribute_values": migrationRemovePaths(resource.AttributeValues, ignorePaths),
+		"sensitive_values": migrationRemovePaths(sensitiveValues, ignorePaths),
+	}
+
+	result, err := json.MarshalIndent(normalized, "", "  ")
+
+	if err != nil {
+		return "", fmt.Errorf("unable to encode resource values: %w", err)
+	}
+
+	return string(result), nil
+}
+
+// migrationRemovePaths returns a copy of the attribute values without the
+// given paths.
+func migrationRemovePaths(values map[string]interface{}, paths []tfjsonpath.Path) map[string]interface{} {
+	if len(paths) == 0 || values == nil {
+		return values
+	}
+
+	result := make(map[string]interface{}, len(values))
+
+	for key, value := range values {
+		path := tfjsonpath.New(key)
+
+		if migrationPathIgnored(path, paths) {
+			continue
+		}
+
+		result[key] = migrationRemoveNestedPaths(value, path, paths)
+	}
+
+	return result
+}
+
+// migrationRemoveNestedPaths returns a copy of the value at the given path
+// without any nested ignored paths.
+func migrationRemoveNestedPaths(value interface{}, path tfjsonpath.Path, paths []tfjsonpath.Path) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+
+		for key, elem := range value {
+			elemPath := path.AtMapKey(key)
+
+			if migrationPathIgnored(elemPath, paths) {
+				continue
+			}
+
+			result[key] = migrationRemoveNestedPaths(elem, elemPath, paths)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+
+		for index, elem := range value {
+			elemPath := path.AtSliceIndex(index)
+
+			if migrationPathIgnored(elemPath, paths) {
+				continue
+			}
+
+			result = append(result, migrationRemoveNestedPaths(elem, elemPath, paths))
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// migrationPathIgnored returns true if the path is one of the ignored paths.
+func migrationPathIgnored(path tfjsonpath.Path, paths []tfjsonpath.Path) bool {
+	for _, p := range paths {
+		if p.Equal(path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,460 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestMigrationCaseValidate(t *testing.T) {
+	t.Parallel()
+
+	sdkProvider := MigrationProvider{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": nil, // does not need to be real
+		},
+	}
+	frameworkProvider := MigrationProvider{
+		ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+			"test": nil, // does not need to be real
+		},
+	}
+
+	testCases := map[string]struct {
+		migrationCase MigrationCase
+		expectedError error
+	}{
+		"valid": {
+			migrationCase: MigrationCase{
+				SDKProvider:       sdkProvider,
+				FrameworkProvider: frameworkProvider,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+		},
+		"sdkprovider-missing": {
+			migrationCase: MigrationCase{
+				FrameworkProvider: frameworkProvider,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("MigrationCase missing SDKProvider"),
+		},
+		"frameworkprovider-missing": {
+			migrationCase: MigrationCase{
+				SDKProvider: sdkProvider,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+					},
+				},
+			},
+			expectedError: fmt.Errorf("MigrationCase missing FrameworkProvider"),
+		},
+		"steps-missing": {
+			migrationCase: MigrationCase{
+				SDKProvider:       sdkProvider,
+				FrameworkProvider: frameworkProvider,
+			},
+			expectedError: fmt.Errorf("MigrationCase missing Steps"),
+		},
+		"step-config-missing": {
+			migrationCase: MigrationCase{
+				SDKProvider:       sdkProvider,
+				FrameworkProvider: frameworkProvider,
+				Steps: []TestStep{
+					{},
+				},
+			},
+			expectedError: fmt.Errorf("MigrationCase TestStep 1/1 missing Config or ConfigDirectory or ConfigFile"),
+		},
+		"step-importstate": {
+			migrationCase: MigrationCase{
+				SDKProvider:       sdkProvider,
+				FrameworkProvider: frameworkProvider,
+				Steps: []TestStep{
+					{
+						Config:      "# not empty",
+						ImportState: true,
+					},
+				},
+			},
+			expectedError: fmt.Errorf("MigrationCase TestStep 1/1 cannot have ImportState or RefreshState"),
+		},
+		"step-providers": {
+			migrationCase: MigrationCase{
+				SDKProvider:       sdkProvider,
+				FrameworkProvider: frameworkProvider,
+				Steps: []TestStep{
+					{
+						Config: "# not empty",
+						ExternalProviders: map[string]ExternalProvider{
+							"test": {}, // does not need to be real
+						},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("MigrationCase TestStep 1/1 cannot have providers, use SDKProvider and FrameworkProvider"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.migrationCase.validate()
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestMigrationCaseTestCase(t *testing.T) {
+	t.Parallel()
+
+	userStateCheck := statecheck.Raw(func(_ context.Context, _ statecheck.CheckStateRequest, _ *statecheck.CheckStateResponse) {})
+
+	migrationCase := MigrationCase{
+		SDKProvider: MigrationProvider{
+			ExternalProviders: map[string]ExternalProvider{
+				"test": {Source: "registry.terraform.io/hashicorp/test", VersionConstraint: "1.0.0"},
+			},
+		},
+		FrameworkProvider: MigrationProvider{
+			ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+				"test": nil, // does not need to be real
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config:            `resource "test_resource" "one" {}`,
+				ConfigStateChecks: []statecheck.StateCheck{userStateCheck},
+			},
+			{
+				Config: `resource "test_resource" "two" {}`,
+			},
+		},
+	}
+
+	got := migrationCase.testCase()
+
+	if len(got.Steps) != 4 {
+		t.Fatalf("expected 4 steps, got %d", len(got.Steps))
+	}
+
+	for i, step := range got.Steps {
+		expectedConfig := migrationCase.Steps[i/2].Config
+
+		if step.Config != expectedConfig {
+			t.Errorf("step %d: expected config %q, got %q", i+1, expectedConfig, step.Config)
+		}
+
+		if i%2 == 0 {
+			if len(step.ExternalProviders) != 1 || len(step.ProtoV5ProviderFactories) != 0 {
+				t.Errorf("step %d: expected SDKProvider", i+1)
+			}
+
+			continue
+		}
+
+		if len(step.ExternalProviders) != 0 || len(step.ProtoV5ProviderFactories) != 1 {
+			t.Errorf("step %d: expected FrameworkProvider", i+1)
+		}
+
+		if len(step.ConfigPlanChecks.PreApply) != 1 {
+			t.Errorf("step %d: expected empty plan check", i+1)
+		}
+	}
+
+	// The user state check is kept and the migration state check appended,
+	// without modifying the original TestStep.
+	if len(got.Steps[0].ConfigStateChecks) != 2 {
+		t.Errorf("expected 2 state checks, got %d", len(got.Steps[0].ConfigStateChecks))
+	}
+
+	if len(migrationCase.Steps[0].ConfigStateChecks) != 1 {
+		t.Errorf("expected original TestStep to be unmodified")
+	}
+}
+
+func TestMigrationExpectEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"empty": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_resource.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+					},
+					{
+						Address: "data.test_data_source.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionRead}},
+					},
+				},
+			},
+		},
+		"changes": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_resource.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+					},
+					{
+						Address: "test_resource.two",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("expected empty plan after migrating to the framework provider, got changes for: test_resource.one ([update]), test_resource.two ([delete create])"),
+		},
+		"nil": {
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			migrationExpectEmptyPlan{}.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", resp.Error)
+				}
+
+				if diff := cmp.Diff(resp.Error.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestMigrationCompareState(t *testing.T) {
+	t.Parallel()
+
+	sdkState := testMigrationState(t, `{"id": "one", "name": "example", "port": 80, "tags": {"a": "b"}}`, `{"name": true}`)
+
+	testCases := map[string]struct {
+		state         *tfjson.State
+		exact         bool
+		ignorePaths   []tfjsonpath.Path
+		expectedError string
+	}{
+		"identical": {
+			state: testMigrationState(t, `{"id": "one", "name": "example", "port": 80, "tags": {"a": "b"}}`, `{"name": true}`),
+		},
+		"different": {
+			state:         testMigrationState(t, `{"id": "one", "name": "changed", "port": 80, "tags": {"a": "b"}}`, `{"name": true}`),
+			expectedError: "test_resource.one: attribute values differ (-sdk +framework):",
+		},
+		"different-type": {
+			state:         testMigrationState(t, `{"id": "one", "name": "example", "port": "80", "tags": {"a": "b"}}`, `{"name": true}`),
+			expectedError: "test_resource.one: attribute values differ (-sdk +framework):",
+		},
+		"different-ignored": {
+			state:       testMigrationState(t, `{"id": "two", "name": "example", "port": 80, "tags": {"a": "c"}}`, `{"name": true}`),
+			ignorePaths: []tfjsonpath.Path{tfjsonpath.New("id"), tfjsonpath.New("tags").AtMapKey("a")},
+		},
+		"missing": {
+			state:         &tfjson.State{},
+			expectedError: "test_resource.one: resource not found in state",
+		},
+		"exact-identical": {
+			state: testMigrationState(t, `{"tags": {"a": "b"}, "port": 80, "name": "example", "id": "one"}`, `{"name": true}`),
+			exact: true,
+		},
+		"exact-different-sensitive": {
+			state:         testMigrationState(t, `{"id": "one", "name": "example", "port": 80, "tags": {"a": "b"}}`, `{}`),
+			exact:         true,
+			expectedError: `"sensitive_values": {}`,
+		},
+		"exact-different-type": {
+			state:         testMigrationState(t, `{"id": "one", "name": "example", "port": "80", "tags": {"a": "b"}}`, `{"name": true}`),
+			exact:         true,
+			expectedError: `"port": "80",`,
+		},
+		"exact-different-ignored": {
+			state:       testMigrationState(t, `{"id": "one", "name": "example", "port": 80, "tags": {"a": "b"}}`, `{}`),
+			exact:       true,
+			ignorePaths: []tfjsonpath.Path{tfjsonpath.New("name")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			values := &migrationStateValues{}
+
+			migrationSaveState{values: values}.CheckState(context.Background(), statecheck.CheckStateRequest{State: sdkState}, &statecheck.CheckStateResponse{})
+
+			resp := statecheck.CheckStateResponse{}
+
+			compare := migrationCompareState{
+				exact:       testCase.exact,
+				ignorePaths: testCase.ignorePaths,
+				values:      values,
+			}
+
+			compare.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", resp.Error)
+				}
+
+				if !strings.Contains(resp.Error.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got: %s", testCase.expectedError, resp.Error)
+				}
+			}
+
+			if resp.Error == nil && testCase.expectedError != "" {
+				t.Errorf("expected error containing: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func testMigrationState(t *testing.T, attributeValues string, sensitiveValues string) *tfjson.State {
+	t.Helper()
+
+	var values map[string]interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(attributeValues))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&values); err != nil {
+		t.Fatalf("unable to decode attribute values: %s", err)
+	}
+
+	return &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				ChildModules: []*tfjson.StateModule{
+					{
+						Address: "module.child",
+					},
+				},
+				Resources: []*tfjson.StateResource{
+					{
+						Address:         "test_resource.one",
+						AttributeValues: values,
+						Mode:            tfjson.ManagedResourceMode,
+						SensitiveValues: json.RawMessage(sensitiveValues),
+					},
+					{
+						Address: "data.test_data_source.one",
+						Mode:    tfjson.DataResourceMode,
+					},
+				},
+			},
+		},
+	}
+}
+
+// migrationTestProvider returns a provider whose resource stores the
+// configured name, standing in for both sides of a migration.
+func migrationTestProvider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_resource": {
+				CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					d.SetId("test")
+					return nil
+				},
+				DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					return nil
+				},
+				ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					return nil
+				},
+				Schema: map[string]*schema.Schema{
+					"name": {
+						ForceNew: true,
+						Required: true,
+						Type:     schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTest_TestMigration(t *testing.T) {
+	t.Parallel()
+
+	TestMigration(t, MigrationCase{
+		SDKProvider: MigrationProvider{
+			ProviderFactories: map[string]func() (*schema.Provider, error){
+				"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+					return migrationTestProvider(), nil
+				},
+			},
+		},
+		FrameworkProvider: MigrationProvider{
+			ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+				"test": func() (tfprotov5.ProviderServer, error) { //nolint:unparam // required signature
+					return schema.NewGRPCProviderServer(migrationTestProvider()), nil
+				},
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "test_resource" "test" { name = "example" }`,
+			},
+		},
+	})
+}
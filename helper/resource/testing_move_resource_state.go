@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// MoveResourceStateCase is a single set of tests to verify that a resource
+// of one type is moved to a resource of another type with a moved
+// configuration block, which calls the MoveResourceState implementation of
+// the provider of the target resource type.
+//
+// The SourceConfig is first applied to create the source resource. The
+// TargetConfig, with a generated moved block from SourceAddress to
+// TargetAddress, is then applied, which must move the resource without
+// creating, replacing, or destroying it.
+//
+// Moving resources across resource types requires Terraform 1.8.0 or later.
+// The TestCase is skipped with earlier Terraform versions.
+type MoveResourceStateCase struct {
+	// IsUnitTest and PreCheck have the same meaning as the TestCase fields
+	// of the same name.
+	IsUnitTest bool
+	PreCheck   func()
+
+	// TerraformVersionChecks is a list of checks which are run against the
+	// version of the Terraform CLI running the test, after the Terraform
+	// version check of moving resources across resource types.
+	TerraformVersionChecks []tfversion.TerraformVersionCheck
+
+	// ExternalProviders, ProviderFactories, ProtoV5ProviderFactories, and
+	// ProtoV6ProviderFactories have the same meaning as the TestCase fields
+	// of the same name and are used for both configurations.
+	ExternalProviders        map[string]ExternalProvider
+	ProviderFactories        map[string]func() (*schema.Provider, error)
+	ProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+	ProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
+	// CheckDestroy and ErrorCheck have the same meaning as the TestCase
+	// fields of the same name.
+	CheckDestroy TestCheckFunc
+	ErrorCheck   ErrorCheckFunc
+
+	// SourceConfig is the configuration which creates the source resource,
+	// such as the resource type being deprecated.
+	SourceConfig string
+
+	// SourceAddress is the resource address of the source resource, such
+	// as "examplecloud_old_thing.test".
+	SourceAddress string
+
+	// SourceConfigStateChecks are run after applying SourceConfig.
+	SourceConfigStateChecks []statecheck.StateCheck
+
+	// TargetConfig is the configuration which declares the target resource.
+	// It must not contain the moved block, which is generated from
+	// SourceAddress and TargetAddress.
+	TargetConfig string
+
+	// TargetAddress is the resource address of the target resource, such
+	// as "examplecloud_thing.test".
+	TargetAddress string
+
+	// ConfigStateChecks are run after applying TargetConfig, such as to
+	// verify the attribute values returned by MoveResourceState.
+	ConfigStateChecks []statecheck.StateCheck
+}
+
+// TestMoveResourceState performs an acceptance test verifying that the
+// MoveResourceStateCase source resource is moved to the target resource.
+// The case is run as two TestStep in a single TestCase: the first applies
+// SourceConfig, and the second applies TargetConfig with a moved block,
+// failing if the target resource is planned to be created, replaced, or
+// destroyed instead of moved.
+//
+// Test() function requirements and documentation also apply to this function.
+func TestMoveResourceState(t testing.T, c MoveResourceStateCase) {
+	t.Helper()
+
+	if err := c.validate(context.Background()); err != nil {
+		t.Fatalf("Test validation error: %s", err)
+	}
+
+	Test(t, c.testCase())
+}
+
+// validate ensures the MoveResourceStateCase is valid based on the following
+// criteria:
+//
+//   - Providers are set.
+//   - SourceConfig and TargetConfig are set.
+//   - SourceAddress and TargetAddress are set and different.
+func (c MoveResourceStateCase) validate(ctx context.Context) error {
+	if !c.providerCase().hasProviders(ctx) {
+		return fmt.Errorf("MoveResourceStateCase missing providers")
+	}
+
+	if c.SourceConfig == "" {
+		return fmt.Errorf("MoveResourceStateCase missing SourceConfig")
+	}
+
+	if c.TargetConfig == "" {
+		return fmt.Errorf("MoveResourceStateCase missing TargetConfig")
+	}
+
+	if c.SourceAddress == "" {
+		return fmt.Errorf("MoveResourceStateCase missing SourceAddress")
+	}
+
+	if c.TargetAddress == "" {
+		return fmt.Errorf("MoveResourceStateCase missing TargetAddress")
+	}
+
+	if c.SourceAddress == c.TargetAddress {
+		return fmt.Errorf("MoveResourceStateCase SourceAddress and TargetAddress must be different, got: %s", c.SourceAddress)
+	}
+
+	return nil
+}
+
+// providerCase returns a TestCase with only the provider fields set.
+func (c MoveResourceStateCase) providerCase() TestCase {
+	return TestCase{
+		ExternalProviders:        c.ExternalProviders,
+		ProviderFactories:        c.ProviderFactories,
+		ProtoV5ProviderFactories: c.ProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: c.ProtoV6ProviderFactories,
+	}
+}
+
+// testCase returns the TestCase which applies SourceConfig, followed by a
+// TestStep which moves the source resource to the target resource.
+func (c MoveResourceStateCase) testCase() TestCase {
+	testCase := c.providerCase()
+
+	testCase.IsUnitTest = c.IsUnitTest
+	testCase.PreCheck = c.PreCheck
+	testCase.TerraformVersionChecks = append(
+		[]tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_8_0)},
+		c.TerraformVersionChecks...,
+	)
+	testCase.CheckDestroy = c.CheckDestroy
+	testCase.ErrorCheck = c.ErrorCheck
+	testCase.Steps = []TestStep{
+		{
+			Config:            c.SourceConfig,
+			ConfigStateChecks: c.SourceConfigStateChecks,
+		},
+		moveResourceStateStep(c.TargetConfig, c.SourceAddress, c.TargetAddress, c.ConfigStateChecks),
+	}
+
+	return testCase
+}
+
+// moveResourceStateStep returns the TestStep which applies the given
+// configuration with a generated moved block from the source address to the
+// target address, verifying that the resource is moved and then running the
+// given state checks.
+func moveResourceStateStep(config string, sourceAddress string, targetAddress string, stateChecks []statecheck.StateCheck) TestStep {
+	return TestStep{
+		Config: fmt.Sprintf("%s\n\nmoved {\n  from = %s\n  to   = %s\n}\n", config, sourceAddress, targetAddress),
+		ConfigPlanChecks: ConfigPlanChecks{
+			PreApply: []plancheck.PlanCheck{
+				moveResourceStateExpectMoved{
+					sourceAddress: sourceAddress,
+					targetAddress: targetAddress,
+				},
+			},
+		},
+		ConfigStateChecks: append(
+			[]statecheck.StateCheck{
+				moveResourceStateExpectRemoved{
+					sourceAddress: sourceAddress,
+				},
+			},
+			stateChecks...,
+		),
+	}
+}
+
+var _ plancheck.PlanCheck = moveResourceStateExpectMoved{}
+
+// moveResourceStateExpectMoved verifies that the target resource is planned
+// to be moved, rather than created, replaced, or destroyed, and that the
+// source resource has no planned changes.
+type moveResourceStateExpectMoved struct {
+	sourceAddress string
+	targetAddress string
+}
+
+// CheckPlan implements the plan check logic.
+func (e moveResourceStateExpectMoved) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	var found bool
+
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Change == nil {
+			continue
+		}
+
+		switch rc.Address {
+		case e.sourceAddress:
+			resp.Error = fmt.Errorf("%s - expected resource to be moved to %s, got action(s): %v", e.sourceAddress, e.targetAddress, rc.Change.Actions)
+
+			return
+		case e.targetAddress:
+			if rc.Change.Actions.Create() || rc.Change.Actions.Replace() || rc.Change.Actions.Delete() {
+				resp.Error = fmt.Errorf("%s - expected resource to be moved from %s, got action(s): %v", e.targetAddress, e.sourceAddress, rc.Change.Actions)
+
+				return
+			}
+
+			found = true
+		}
+	}
+
+	if !found {
+		resp.Error = fmt.Errorf("%s - resource not found in plan", e.targetAddress)
+	}
+}
+
+var _ statecheck.StateCheck = moveResourceStateExpectRemoved{}
+
+// moveResourceStateExpectRemoved verifies that the source resource is no
+// longer in the state after the move.
+type moveResourceStateExpectRemoved struct {
+	sourceAddress string
+}
+
+// CheckState implements the state check logic.
+func (e moveResourceStateExpectRemoved) CheckState(_ context.Context, req statecheck.CheckStateRequest, resp *statecheck.CheckStateResponse) {
+	if _, ok := migrationResources(req.State)[e.sourceAddress]; ok {
+		resp.Error = fmt.Errorf("%s - expected resource to be removed from state after move", e.sourceAddress)
+	}
+}
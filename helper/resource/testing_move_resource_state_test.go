@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestMoveResourceStateCaseValidate(t *testing.T) {
+	t.Parallel()
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"test": nil, // does not need to be real
+	}
+
+	testCases := map[string]struct {
+		moveCase      MoveResourceStateCase
+		expectedError error
+	}{
+		"valid": {
+			moveCase: MoveResourceStateCase{
+				ProtoV6ProviderFactories: providerFactories,
+				SourceConfig:             "# not empty",
+				SourceAddress:            "test_old.one",
+				TargetConfig:             "# not empty",
+				TargetAddress:            "test_new.one",
+			},
+		},
+		"providers-missing": {
+			moveCase: MoveResourceStateCase{
+				SourceConfig:  "# not empty",
+				SourceAddress: "test_old.one",
+				TargetConfig:  "# not empty",
+				TargetAddress: "test_new.one",
+			},
+			expectedError: fmt.Errorf("MoveResourceStateCase missing providers"),
+		},
+		"sourceconfig-missing": {
+			moveCase: MoveResourceStateCase{
+				ProtoV6ProviderFactories: providerFactories,
+				SourceAddress:            "test_old.one",
+				TargetConfig:             "# not empty",
+				TargetAddress:            "test_new.one",
+			},
+			expectedError: fmt.Errorf("MoveResourceStateCase missing SourceConfig"),
+		},
+		"targetconfig-missing": {
+			moveCase: MoveResourceStateCase{
+				ProtoV6ProviderFactories: providerFactories,
+				SourceConfig:             "# not empty",
+				SourceAddress:            "test_old.one",
+				TargetAddress:            "test_new.one",
+			},
+			expectedError: fmt.Errorf("MoveResourceStateCase missing TargetConfig"),
+		},
+		"sourceaddress-missing": {
+			moveCase: MoveResourceStateCase{
+				ProtoV6ProviderFactories: providerFactories,
+				SourceConfig:             "# not empty",
+				TargetConfig:             "# not empty",
+				TargetAddress:            "test_new.one",
+			},
+			expectedError: fmt.Errorf("MoveResourceStateCase missing SourceAddress"),
+		},
+		"targetaddress-missing": {
+			moveCase: MoveResourceStateCase{
+				ProtoV6ProviderFactories: providerFactories,
+				SourceConfig:             "# not empty",
+				SourceAddress:            "test_old.one",
+				TargetConfig:             "# not empty",
+			},
+			expectedError: fmt.Errorf("MoveResourceStateCase missing TargetAddress"),
+		},
+		"addresses-same": {
+			moveCase: MoveResourceStateCase{
+				ProtoV6ProviderFactories: providerFactories,
+				SourceConfig:             "# not empty",
+				SourceAddress:            "test_old.one",
+				TargetConfig:             "# not empty",
+				TargetAddress:            "test_old.one",
+			},
+			expectedError: fmt.Errorf("MoveResourceStateCase SourceAddress and TargetAddress must be different, got: test_old.one"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.moveCase.validate(context.Background())
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestMoveResourceStateCaseTestCase(t *testing.T) {
+	t.Parallel()
+
+	userStateCheck := statecheck.Raw(func(_ context.Context, _ statecheck.CheckStateRequest, _ *statecheck.CheckStateResponse) {})
+
+	moveCase := MoveResourceStateCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": nil, // does not need to be real
+		},
+		TerraformVersionChecks:  []tfversion.TerraformVersionCheck{tfversion.SkipAbove(tfversion.Version1_5_0)},
+		SourceConfig:            `resource "test_old" "one" {}`,
+		SourceAddress:           "test_old.one",
+		SourceConfigStateChecks: []statecheck.StateCheck{userStateCheck},
+		TargetConfig:            `resource "test_new" "one" {}`,
+		TargetAddress:           "test_new.one",
+		ConfigStateChecks:       []statecheck.StateCheck{userStateCheck},
+	}
+
+	got := moveCase.testCase()
+
+	if len(got.ProtoV6ProviderFactories) != 1 {
+		t.Errorf("expected providers")
+	}
+
+	if len(got.TerraformVersionChecks) != 2 {
+		t.Errorf("expected 2 Terraform version checks, got %d", len(got.TerraformVersionChecks))
+	}
+
+	if len(got.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(got.Steps))
+	}
+
+	if got.Steps[0].Config != moveCase.SourceConfig {
+		t.Errorf("step 1: expected config %q, got %q", moveCase.SourceConfig, got.Steps[0].Config)
+	}
+
+	if len(got.Steps[0].ConfigStateChecks) != 1 {
+		t.Errorf("step 1: expected 1 state check, got %d", len(got.Steps[0].ConfigStateChecks))
+	}
+
+	expectedConfig := `resource "test_new" "one" {}
+
+moved {
+  from = test_old.one
+  to   = test_new.one
+}
+`
+
+	if diff := cmp.Diff(got.Steps[1].Config, expectedConfig); diff != "" {
+		t.Errorf("step 2: unexpected config difference: %s", diff)
+	}
+
+	if len(got.Steps[1].ConfigPlanChecks.PreApply) != 1 {
+		t.Errorf("step 2: expected moved plan check")
+	}
+
+	if len(got.Steps[1].ConfigStateChecks) != 2 {
+		t.Errorf("step 2: expected 2 state checks, got %d", len(got.Steps[1].ConfigStateChecks))
+	}
+}
+
+func TestMoveResourceStateExpectMoved(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"moved": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_new.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+					},
+				},
+			},
+		},
+		"moved-update": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_new.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+					},
+				},
+			},
+		},
+		"create": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_new.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("test_new.one - expected resource to be moved from test_old.one, got action(s): [create]"),
+		},
+		"replace": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_new.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("test_new.one - expected resource to be moved from test_old.one, got action(s): [delete create]"),
+		},
+		"source-delete": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_old.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}},
+					},
+					{
+						Address: "test_new.one",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("test_old.one - expected resource to be moved to test_new.one, got action(s): [delete]"),
+		},
+		"target-missing": {
+			plan:          &tfjson.Plan{},
+			expectedError: fmt.Errorf("test_new.one - resource not found in plan"),
+		},
+		"nil": {
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			check := moveResourceStateExpectMoved{
+				sourceAddress: "test_old.one",
+				targetAddress: "test_new.one",
+			}
+
+			check.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", resp.Error)
+				}
+
+				if diff := cmp.Diff(resp.Error.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestMoveResourceStateExpectRemoved(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		state         *tfjson.State
+		expectedError error
+	}{
+		"removed": {
+			state: &tfjson.State{
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						Resources: []*tfjson.StateResource{
+							{
+								Address: "test_new.one",
+								Mode:    tfjson.ManagedResourceMode,
+							},
+						},
+					},
+				},
+			},
+		},
+		"not-removed": {
+			state: &tfjson.State{
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						Resources: []*tfjson.StateResource{
+							{
+								Address: "test_old.one",
+								Mode:    tfjson.ManagedResourceMode,
+							},
+						},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("test_old.one - expected resource to be removed from state after move"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			moveResourceStateExpectRemoved{sourceAddress: "test_old.one"}.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", resp.Error)
+				}
+
+				if diff := cmp.Diff(resp.Error.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
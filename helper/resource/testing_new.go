@@ -5,12 +5,15 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	gotesting "testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	tfjson "github.com/hashicorp/terraform-json"
@@ -24,9 +27,38 @@ import (
 func runPostTestDestroy(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories, statePreDestroy *terraform.State) error {
 	t.Helper()
 
+	if c.DestroyConfig != "" {
+		logging.HelperResourceDebug(ctx, "Setting TestCase DestroyConfig before destroy")
+
+		if err := wd.SetConfig(ctx, TestStep{Config: c.DestroyConfig}.mergedConfig(ctx, c)); err != nil {
+			return fmt.Errorf("error setting DestroyConfig: %w", err)
+		}
+	}
+
+	if c.OverridePreventDestroy {
+		logging.HelperResourceDebug(ctx, "Overriding lifecycle prevent_destroy before destroy")
+
+		if err := overridePreventDestroy(ctx, t, wd, providers); err != nil {
+			return err
+		}
+	}
+
+	destroyProviders := providers
+	var diagnostics *diagnosticRecorder
+
+	if c.PostDestroyCheck != nil {
+		diagnostics = &diagnosticRecorder{}
+		destroyProviders = &providerFactories{
+			legacy:      providers.legacy,
+			protov5:     providers.protov5,
+			protov6:     providers.protov6,
+			diagnostics: diagnostics,
+		}
+	}
+
 	err := runProviderCommand(ctx, t, func() error {
 		return wd.Destroy(ctx)
-	}, wd, providers)
+	}, wd, destroyProviders)
 	if err != nil {
 		return err
 	}
@@ -42,24 +74,135 @@ func runPostTestDestroy(ctx context.Context, t testing.T, c TestCase, wd *plugin
 		logging.HelperResourceDebug(ctx, "Called TestCase CheckDestroy")
 	}
 
+	if c.PostDestroyCheck != nil {
+		logging.HelperResourceDebug(ctx, "Calling TestCase PostDestroyCheck")
+
+		if err := c.PostDestroyCheck(diagnostics.recorded()); err != nil {
+			return fmt.Errorf("PostDestroyCheck failed: %w", err)
+		}
+
+		logging.HelperResourceDebug(ctx, "Called TestCase PostDestroyCheck")
+	}
+
 	return nil
 }
 
 func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest.Helper) {
 	t.Helper()
 
+	categories, err := rerunFailedCategories()
+
+	if err != nil {
+		t.Fatalf("Error reading %s: %s", EnvTfAccRerunFailed, err)
+	}
+
+	// TestStep subtests fail the test directly, so cannot be rerun.
+	if enabled, _ := c.stepSubtests(); categories == nil || enabled {
+		runNewTestAttempt(ctx, t, c, helper, 0)
+
+		return
+	}
+
+	runWithRerun(t, categories, func(t testing.T, attempt int) {
+		runNewTestAttempt(ctx, t, c, helper, attempt)
+	})
+}
+
+// runNewTestAttempt runs the TestCase in a new working directory. The
+// attempt is zero, unless failures are rerun.
+func runNewTestAttempt(ctx context.Context, t testing.T, c TestCase, helper *plugintest.Helper, attempt int) {
+	t.Helper()
+
+	// Running TestStep as subtests requires the testing.T from the standard
+	// library, which is wrapped by the reporter below.
+	subtests, _ := t.(subtestRunner)
+
+	// Capture failure messages and write report events, if enabled. The
+	// report is finished after all other deferred cleanup, such as the final
+	// destroy.
+	reporter := newTestReporter(t, c.OnFailure != nil)
+
+	if reporter != nil {
+		reporter.attempt = attempt
+		reporter.randomSeed = c.RandomSeed
+		t = reporter
+	}
+
+	defer reporter.finish(ctx)
+
 	wd := helper.RequireNewWorkingDir(ctx, t, c.WorkingDir)
 
 	ctx = logging.TestTerraformPathContext(ctx, wd.GetHelper().TerraformExecPath())
 	ctx = logging.TestWorkingDirectoryContext(ctx, wd.GetHelper().WorkingDirectory())
 
+	credentials := newTestCaseCredentials(c.CredentialsProvider)
+
+	if err := credentials.refresh(ctx, wd); err != nil {
+		logging.HelperResourceError(ctx,
+			"TestCase error getting credentials",
+			map[string]interface{}{logging.KeyError: err},
+		)
+		wd.Close()
+		t.Fatalf("TestCase error getting credentials: %s", err)
+	}
+
 	providers := &providerFactories{
 		legacy:  c.ProviderFactories,
 		protov5: c.ProtoV5ProviderFactories,
 		protov6: c.ProtoV6ProviderFactories,
 	}
 
+	// Resources imported by ImportStatePersist TestSteps which should be
+	// removed from state, rather than destroyed, at the end of the TestCase.
+	var retainedImports []string
+
 	defer func() {
+		if err := ctx.Err(); err != nil {
+			logging.HelperResourceWarn(ctx,
+				"Test context done, attempting to destroy any remaining resources",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Logf("Test context done (%s), attempting to destroy any remaining resources", err)
+		}
+
+		// Attempt to clean up resources even if the test context was
+		// cancelled, such as after a test deadline or signal.
+		ctx := withoutCancel(ctx)
+
+		if c.SkipFinalDestroy {
+			logging.HelperResourceWarn(ctx, fmt.Sprintf("Skipping final destroy, there may be dangling resources: %s", c.SkipFinalDestroyReason))
+			t.Logf("WARNING: Skipping final destroy, there may be dangling resources: %s", c.SkipFinalDestroyReason)
+			reporter.addWarning(fmt.Sprintf("Skipped final destroy: %s", c.SkipFinalDestroyReason))
+			wd.Close()
+
+			return
+		}
+
+		if err := credentials.refresh(ctx, wd); err != nil {
+			logging.HelperResourceError(ctx,
+				"Error refreshing credentials, there may be dangling resources",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Error refreshing credentials, there may be dangling resources: %s", err)
+			return
+		}
+
+		for _, address := range retainedImports {
+			logging.HelperResourceDebug(ctx, fmt.Sprintf("Removing persisted import %s from state before destroy", address))
+
+			err := runProviderCommand(ctx, t, func() error {
+				return wd.StateRm(ctx, address)
+			}, wd, providers)
+			if err != nil {
+				logging.HelperResourceError(ctx,
+					"Error removing persisted import from state",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Error removing persisted import %s from state: %s", address, err.Error())
+				return
+			}
+		}
+
 		var statePreDestroy *terraform.State
 		var err error
 		err = runProviderCommand(ctx, t, func() error {
@@ -118,21 +261,55 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 
 	logging.HelperResourceDebug(ctx, "Starting TestSteps")
 
+	// Run each TestStep as a subtest when enabled.
+	caseName := t.Name()
+
+	switch enabled, required := c.stepSubtests(); {
+	case !enabled:
+		subtests = nil
+	case subtests == nil && required:
+		t.Fatalf("TestCase StepSubtests or TestStep Name requires a *testing.T, got %T", t)
+	}
+
 	// use this to track last step successfully applied
 	// acts as default for import tests
-	var appliedCfg string
+	var appliedCfg testStepConfig
 	var stepNumber int
 
-	for stepIndex, step := range c.Steps {
-		if stepNumber > 0 {
-			copyWorkingDir(ctx, t, stepNumber, wd)
+	// Collect failure artifacts and handle the failure before the final
+	// destroy, while the state of the failed TestStep is still available.
+	defer func() {
+		if stepNumber > 0 && t.Failed() {
+			artifactsDir := collectFailureArtifacts(withoutCancel(ctx), t, wd, providers, stepNumber)
+			handleFailure(withoutCancel(ctx), t, c, wd, providers, stepNumber, reporter.stepDiagnostics(), artifactsDir)
 		}
+	}()
 
-		stepNumber = stepIndex + 1 // 1-based indexing for humans
-		ctx = logging.TestStepNumberContext(ctx, stepNumber)
+	// budgetVeto is set when the BudgetFunc vetoes a TestStep, so the
+	// TestCase is skipped rather than only the TestStep subtest.
+	var budgetVeto *budgetVetoError
+
+	// expectedFailure is the skip message after a TestStep with
+	// ExpectedFailure failed, so the TestCase is skipped rather than only the
+	// TestStep subtest.
+	var expectedFailure string
+
+	// stepSkipped is set when the TestStep SkipFunc skips the TestStep.
+	var stepSkipped bool
+
+	// runStep runs the given TestStep with the testing.T of the TestCase or,
+	// when running TestStep as subtests, the testing.T of the subtest.
+	runStep := func(t testing.T, step TestStep) {
+		t.Helper()
 
 		logging.HelperResourceDebug(ctx, "Starting TestStep")
 
+		reporter.startStep(stepNumber, step)
+
+		stepSkipped = false
+
+		operationsBefore := helper.Operations()
+
 		if step.PreConfig != nil {
 			logging.HelperResourceDebug(ctx, "Calling TestStep PreConfig")
 			step.PreConfig()
@@ -154,13 +331,26 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 			logging.HelperResourceDebug(ctx, "Called TestStep SkipFunc")
 
 			if skip {
-				t.Logf("Skipping step %d/%d due to SkipFunc", stepNumber, len(c.Steps))
+				t.Logf("Skipping step %s due to SkipFunc", step.progress(stepNumber, len(c.Steps)))
 				logging.HelperResourceWarn(ctx, "Skipping TestStep due to SkipFunc")
-				continue
+				reporter.endStep(ctx, reportResultSkip)
+				stepSkipped = true
+				return
 			}
 		}
 
-		if step.Config != "" && !step.Destroy && len(step.Taint) > 0 {
+		if err := credentials.refresh(ctx, wd); err != nil {
+			logging.HelperResourceError(ctx,
+				"TestStep error refreshing credentials",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("TestStep %s error refreshing credentials: %s", step.progress(stepNumber, len(c.Steps)), err)
+		}
+
+		wd.SetAdditionalPlanOptions(step.additionalPlanOptions()...)
+		wd.SetAdditionalApplyOptions(step.additionalApplyOptions()...)
+
+		if step.hasConfig() && !step.Destroy && len(step.Taint) > 0 {
 			err := testStepTaint(ctx, step, wd)
 
 			if err != nil {
@@ -168,7 +358,7 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 					"TestStep error tainting resources",
 					map[string]interface{}{logging.KeyError: err},
 				)
-				t.Fatalf("TestStep %d/%d error tainting resources: %s", stepNumber, len(c.Steps), err)
+				t.Fatalf("TestStep %s error tainting resources: %s", step.progress(stepNumber, len(c.Steps)), err)
 			}
 		}
 
@@ -188,47 +378,57 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 					"TestStep error setting provider configuration",
 					map[string]interface{}{logging.KeyError: err},
 				)
-				t.Fatalf("TestStep %d/%d error setting test provider configuration: %s", stepNumber, len(c.Steps), err)
+				t.Fatalf("TestStep %s error setting test provider configuration: %s", step.progress(stepNumber, len(c.Steps)), err)
 			}
 
-			err = runProviderCommand(
-				ctx,
-				t,
-				func() error {
-					return wd.Init(ctx)
-				},
-				wd,
-				providers,
-			)
+			// Skip init when the provider requirements are unchanged since
+			// the previous TestStep, as it would install the same providers.
+			initKey := providers.initKey(providerCfg)
 
-			if err != nil {
-				logging.HelperResourceError(ctx,
-					"TestStep error running init",
-					map[string]interface{}{logging.KeyError: err},
+			if wd.InitKey() == initKey {
+				logging.HelperResourceDebug(ctx, "Skipping Terraform CLI init, provider configuration unchanged since previous TestStep")
+			} else {
+				err = runProviderCommand(
+					ctx,
+					t,
+					func() error {
+						return wd.Init(ctx)
+					},
+					wd,
+					providers,
 				)
-				t.Fatalf("TestStep %d/%d running init: %s", stepNumber, len(c.Steps), err.Error())
-				return
+
+				if err != nil {
+					logging.HelperResourceError(ctx,
+						"TestStep error running init",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("TestStep %s running init: %s", step.progress(stepNumber, len(c.Steps)), err.Error())
+					return
+				}
+
+				wd.SetInitKey(initKey)
 			}
 		}
 
 		if step.ImportState {
 			logging.HelperResourceTrace(ctx, "TestStep is ImportState mode")
 
-			err := testStepNewImportState(ctx, t, helper, wd, step, appliedCfg, providers)
+			err := testStepNewImportState(ctx, t, helper, wd, step, stepNumber, appliedCfg, providers)
 			if step.ExpectError != nil {
 				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
 				if err == nil {
 					logging.HelperResourceError(ctx,
 						"Error running import: expected an error but got none",
 					)
-					t.Fatalf("Step %d/%d error running import: expected an error but got none", stepNumber, len(c.Steps))
+					t.Fatalf("Step %s error running import: expected an error but got none", step.progress(stepNumber, len(c.Steps)))
 				}
 				if !step.ExpectError.MatchString(err.Error()) {
 					logging.HelperResourceError(ctx,
 						fmt.Sprintf("Error running import: expected an error with pattern (%s)", step.ExpectError.String()),
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d error running import, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
+					t.Fatalf("Step %s error running import, expected an error with pattern (%s), no match on: %s", step.progress(stepNumber, len(c.Steps)), step.ExpectError.String(), err)
 				}
 			} else {
 				if err != nil && c.ErrorCheck != nil {
@@ -241,33 +441,49 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 						"Error running import",
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d error running import: %s", stepNumber, len(c.Steps), err)
+					t.Fatalf("Step %s error running import: %s", step.progress(stepNumber, len(c.Steps)), err)
 				}
 			}
 
+			if err == nil && step.ImportStatePersist && step.DestroyPersistedImport != nil && !*step.DestroyPersistedImport {
+				logging.HelperResourceTrace(ctx, "Using TestStep DestroyPersistedImport, imported resource will not be destroyed")
+
+				retainedImports = append(retainedImports, step.ResourceName)
+			}
+
+			if err := step.runOperationsCheck(ctx, helper, operationsBefore); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep OperationsCheck error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %s operations check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
+			}
+
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
-			continue
+			reporter.endStep(ctx, reportResultPass)
+
+			return
 		}
 
 		if step.RefreshState {
 			logging.HelperResourceTrace(ctx, "TestStep is RefreshState mode")
 
-			err := testStepNewRefreshState(ctx, t, wd, step, providers)
+			err := testStepNewRefreshState(ctx, t, wd, step, stepNumber, providers)
 			if step.ExpectError != nil {
 				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
 				if err == nil {
 					logging.HelperResourceError(ctx,
 						"Error running refresh: expected an error but got none",
 					)
-					t.Fatalf("Step %d/%d error running refresh: expected an error but got none", stepNumber, len(c.Steps))
+					t.Fatalf("Step %s error running refresh: expected an error but got none", step.progress(stepNumber, len(c.Steps)))
 				}
 				if !step.ExpectError.MatchString(err.Error()) {
 					logging.HelperResourceError(ctx,
 						fmt.Sprintf("Error running refresh: expected an error with pattern (%s)", step.ExpectError.String()),
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d error running refresh, expected an error with pattern (%s), no match on: %s", stepNumber, len(c.Steps), step.ExpectError.String(), err)
+					t.Fatalf("Step %s error running refresh, expected an error with pattern (%s), no match on: %s", step.progress(stepNumber, len(c.Steps)), step.ExpectError.String(), err)
 				}
 			} else {
 				if err != nil && c.ErrorCheck != nil {
@@ -280,19 +496,120 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 						"Error running refresh",
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d error running refresh: %s", stepNumber, len(c.Steps), err)
+					t.Fatalf("Step %s error running refresh: %s", step.progress(stepNumber, len(c.Steps)), err)
 				}
 			}
 
+			if err := step.runOperationsCheck(ctx, helper, operationsBefore); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep OperationsCheck error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %s operations check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
+			}
+
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
-			continue
+			reporter.endStep(ctx, reportResultPass)
+
+			return
 		}
 
-		if step.Config != "" {
+		if step.hasConfig() {
 			logging.HelperResourceTrace(ctx, "TestStep is Config mode")
 
-			err := testStepNewConfig(ctx, t, c, wd, step, providers)
+			stepProviders := providers
+			var diagnostics *diagnosticRecorder
+			var crashes *providerCrashRecorder
+			var consistency *providerConsistencyRecorder
+
+			if len(step.ExpectDiagnosticAttributePaths) > 0 {
+				diagnostics = &diagnosticRecorder{}
+			}
+
+			if step.ExpectProviderCrash {
+				crashes = &providerCrashRecorder{}
+			}
+
+			// An expected provider inconsistency would also be reported by
+			// the consistency check.
+			if c.CheckProviderConsistency && step.ExpectProviderInconsistency == nil {
+				consistency = &providerConsistencyRecorder{}
+			}
+
+			if diagnostics != nil || crashes != nil || consistency != nil {
+				stepProviders = &providerFactories{
+					legacy:      providers.legacy,
+					protov5:     providers.protov5,
+					protov6:     providers.protov6,
+					diagnostics: diagnostics,
+					crashes:     crashes,
+					consistency: consistency,
+				}
+			}
+
+			err := testStepNewConfig(ctx, t, c, wd, step, stepNumber, stepProviders)
+
+			if consistency != nil {
+				logging.HelperResourceDebug(ctx, "Checking TestCase CheckProviderConsistency")
+
+				if err := consistency.check(); err != nil {
+					logging.HelperResourceError(ctx,
+						"TestCase CheckProviderConsistency error",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %s provider consistency check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
+				}
+			}
+
+			var veto *budgetVetoError
+
+			if errors.As(err, &veto) {
+				budgetVeto = veto
+				reporter.endStep(ctx, reportResultSkip)
+				t.Skip(veto.Error())
+
+				return
+			}
+
+			if crashes != nil {
+				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectProviderCrash")
+
+				err = crashes.wrap(err)
+
+				if err := crashes.check(err); err != nil {
+					logging.HelperResourceError(ctx,
+						"TestStep ExpectProviderCrash error",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %s provider crash check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
+				}
+
+				// The expected crash error is only returned for matching
+				// with ExpectError.
+				if step.ExpectError == nil {
+					err = nil
+				}
+			}
+
+			if step.ExpectProviderInconsistency != nil {
+				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectProviderInconsistency")
+
+				if err := step.ExpectProviderInconsistency.check(err); err != nil {
+					logging.HelperResourceError(ctx,
+						"TestStep ExpectProviderInconsistency error",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %s provider inconsistency check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
+				}
+
+				// The expected inconsistency error is only returned for
+				// matching with ExpectError.
+				if step.ExpectError == nil {
+					err = nil
+				}
+			}
+
 			if step.ExpectError != nil {
 				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectError")
 
@@ -300,14 +617,14 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 					logging.HelperResourceError(ctx,
 						"Expected an error but got none",
 					)
-					t.Fatalf("Step %d/%d, expected an error but got none", stepNumber, len(c.Steps))
+					t.Fatalf("Step %s, expected an error but got none", step.progress(stepNumber, len(c.Steps)))
 				}
 				if !step.ExpectError.MatchString(err.Error()) {
 					logging.HelperResourceError(ctx,
 						fmt.Sprintf("Expected an error with pattern (%s)", step.ExpectError.String()),
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d, expected an error with pattern, no match on: %s", stepNumber, len(c.Steps), err)
+					t.Fatalf("Step %s, expected an error with pattern, no match on: %s", step.progress(stepNumber, len(c.Steps)), step.withConfigSourceLines(ctx, c, err))
 				}
 			} else {
 				if err != nil && c.ErrorCheck != nil {
@@ -318,22 +635,125 @@ func runNewTest(ctx context.Context, t testing.T, c TestCase, helper *plugintest
 					logging.HelperResourceDebug(ctx, "Called TestCase ErrorCheck")
 				}
 				if err != nil {
+					err = withProviderInconsistencies(err)
+					err = step.withConfigSourceLines(ctx, c, err)
+
 					logging.HelperResourceError(ctx,
 						"Unexpected error",
 						map[string]interface{}{logging.KeyError: err},
 					)
-					t.Fatalf("Step %d/%d error: %s", stepNumber, len(c.Steps), err)
+					t.Fatalf("Step %s error: %s", step.progress(stepNumber, len(c.Steps)), err)
+				}
+			}
+
+			if diagnostics != nil {
+				logging.HelperResourceDebug(ctx, "Checking TestStep ExpectDiagnosticAttributePaths")
+
+				if err := diagnostics.check(step.ExpectDiagnosticAttributePaths); err != nil {
+					logging.HelperResourceError(ctx,
+						"TestStep ExpectDiagnosticAttributePaths error",
+						map[string]interface{}{logging.KeyError: err},
+					)
+					t.Fatalf("Step %s diagnostic attribute path check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
 				}
 			}
 
-			appliedCfg = step.mergedConfig(ctx, c)
+			// The configuration was already successfully resolved when
+			// running the TestStep, so any error can be ignored.
+			appliedCfg, _ = step.stepConfig(ctx, c, t.Name(), stepNumber)
+
+			if err := step.runOperationsCheck(ctx, helper, operationsBefore); err != nil {
+				logging.HelperResourceError(ctx,
+					"TestStep OperationsCheck error",
+					map[string]interface{}{logging.KeyError: err},
+				)
+				t.Fatalf("Step %s operations check failed: %s", step.progress(stepNumber, len(c.Steps)), err)
+			}
 
 			logging.HelperResourceDebug(ctx, "Finished TestStep")
 
+			reporter.endStep(ctx, reportResultPass)
+
+			return
+		}
+
+		t.Fatalf("Step %s, unsupported test mode", step.progress(stepNumber, len(c.Steps)))
+	}
+
+	// runTestStep runs the given TestStep with runStep, handling the
+	// ExpectedFailure of the TestStep, if any.
+	runTestStep := func(t testing.T, step TestStep) {
+		t.Helper()
+
+		if step.ExpectedFailure == "" {
+			runStep(t, step)
+
+			return
+		}
+
+		expectedFailure = runExpectedFailureStep(ctx, t, reporter, step, step.progress(stepNumber, len(c.Steps)), func(t testing.T) bool {
+			runStep(t, step)
+
+			return !stepSkipped
+		})
+
+		if expectedFailure != "" {
+			t.Skip(expectedFailure)
+		}
+	}
+
+	for stepIndex, step := range c.Steps {
+		if stepNumber > 0 {
+			copyWorkingDir(ctx, t, stepNumber, wd)
+		}
+
+		stepNumber = stepIndex + 1 // 1-based indexing for humans
+		ctx = logging.TestStepNumberContext(ctx, stepNumber)
+
+		if err := ctx.Err(); err != nil {
+			logging.HelperResourceError(ctx,
+				"TestCase context done before TestStep",
+				map[string]interface{}{logging.KeyError: err},
+			)
+			t.Fatalf("Step %s not run, test context done: %s", step.progress(stepNumber, len(c.Steps)), err)
+		}
+
+		if subtests == nil {
+			runTestStep(t, step)
+
 			continue
 		}
 
-		t.Fatalf("Step %d/%d, unsupported test mode", stepNumber, len(c.Steps))
+		var stepRan bool
+
+		stepPassed := subtests.Run(step.subtestName(stepNumber), func(subtest *gotesting.T) {
+			subtest.Helper()
+
+			stepRan = true
+
+			runTestStep(reporter.wrapStep(stepSubtest{T: subtest, caseName: caseName}), step)
+		})
+
+		if !stepRan {
+			logging.HelperResourceWarn(ctx, "Skipping TestStep not selected by -run flag")
+			t.Logf("Step %s not run, not selected by -run flag", step.progress(stepNumber, len(c.Steps)))
+
+			continue
+		}
+
+		// Subsequent TestStep depend on the failed TestStep, so stop here
+		// and run the deferred destroy.
+		if !stepPassed {
+			t.FailNow()
+		}
+
+		if budgetVeto != nil {
+			t.Skip(budgetVeto.Error())
+		}
+
+		if expectedFailure != "" {
+			t.Skip(expectedFailure)
+		}
 	}
 
 	if stepNumber > 0 {
@@ -370,7 +790,7 @@ func planIsEmpty(plan *tfjson.Plan) bool {
 	return true
 }
 
-func testIDRefresh(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, r *terraform.ResourceState, providers *providerFactories) error {
+func testIDRefresh(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, cfg testStepConfig, r *terraform.ResourceState, providers *providerFactories) error {
 	t.Helper()
 
 	// Build the state. The state is just the resource with an ID. There
@@ -386,7 +806,7 @@ func testIDRefresh(ctx context.Context, t testing.T, c TestCase, wd *plugintest.
 		t.Fatalf("Error setting import test config: %s", err)
 	}
 	defer func() {
-		err = wd.SetConfig(ctx, step.Config)
+		err = cfg.write(ctx, wd)
 		if err != nil {
 			t.Fatalf("Error resetting test config: %s", err)
 		}
@@ -475,3 +895,31 @@ func copyWorkingDir(ctx context.Context, t testing.T, stepNumber int, wd *plugin
 
 	t.Logf("Working directory and files have been copied to: %s", dest)
 }
+
+// withoutCancel returns a context which carries the values of the given
+// context, such as loggers, but is never cancelled and has no deadline.
+func withoutCancel(ctx context.Context) context.Context {
+	return valuesOnlyContext{ctx}
+}
+
+// valuesOnlyContext is a context.Context implementation that only returns
+// values from the parent context.
+type valuesOnlyContext struct {
+	parent context.Context
+}
+
+func (valuesOnlyContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (valuesOnlyContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (valuesOnlyContext) Err() error {
+	return nil
+}
+
+func (c valuesOnlyContext) Value(key any) any {
+	return c.parent.Value(key)
+}
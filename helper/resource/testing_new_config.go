@@ -11,20 +11,83 @@ import (
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/mitchellh/go-testing-interface"
 
+	"github.com/hashicorp/terraform-plugin-testing/graphcheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
 	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
 )
 
-func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, providers *providerFactories) error {
+func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, step TestStep, stepNumber int, providers *providerFactories) error {
 	t.Helper()
 
-	err := wd.SetConfig(ctx, step.mergedConfig(ctx, c))
+	cfg, err := step.stepConfig(ctx, c, t.Name(), stepNumber)
+	if err != nil {
+		return err
+	}
+
+	// Sensitive ConfigVariables values must be redacted in every plan.
+	sensitiveValuesPlanChecks := step.sensitiveValuesPlanChecks()
+	preApply := append(sensitiveValuesPlanChecks, step.ConfigPlanChecks.PreApply...)
+
+	err = cfg.write(ctx, wd)
 	if err != nil {
 		return fmt.Errorf("Error setting config: %w", err)
 	}
 
+	// Configuration directories, files, and generated modules can declare
+	// providers and modules which are not installed by the TestCase or
+	// TestStep provider configuration.
+	if cfg.directory != "" || cfg.file != "" || len(cfg.modules) > 0 {
+		err = runProviderCommand(ctx, t, func() error {
+			return wd.Init(ctx)
+		}, wd, providers)
+		if err != nil {
+			return fmt.Errorf("Error running init: %w", err)
+		}
+	}
+
+	if len(step.ExpectDeprecatedAttributes) > 0 {
+		logging.HelperResourceTrace(ctx, "Using TestStep ExpectDeprecatedAttributes")
+
+		var output *tfjson.ValidateOutput
+		err = runProviderCommand(ctx, t, func() error {
+			var err error
+			output, err = wd.Validate(ctx)
+			return err
+		}, wd, providers)
+		if err != nil {
+			return fmt.Errorf("Error running validate: %w", err)
+		}
+
+		err = checkDeprecatedAttributes(output, step.ExpectDeprecatedAttributes)
+		if err != nil {
+			return fmt.Errorf("Deprecation warning check failed: %w", err)
+		}
+	}
+
+	// Run graph checks
+	if len(step.ConfigGraphChecks) > 0 {
+		var graph *graphcheck.Graph
+		err = runProviderCommand(ctx, t, func() error {
+			output, err := wd.Graph(ctx)
+			if err != nil {
+				return err
+			}
+
+			graph, err = graphcheck.ParseGraph(output)
+			return err
+		}, wd, providers)
+		if err != nil {
+			return fmt.Errorf("Error retrieving graph: %w", err)
+		}
+
+		err = runGraphChecks(ctx, t, graph, stepNumber, step.Name, step.ConfigGraphChecks)
+		if err != nil {
+			return fmt.Errorf("Graph check(s) failed:\n%w", err)
+		}
+	}
+
 	// require a refresh before applying
 	// failing to do this will result in data sources not being updated
 	err = runProviderCommand(ctx, t, func() error {
@@ -51,21 +114,86 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 			return fmt.Errorf("Error running pre-apply plan: %w", err)
 		}
 
+		var budget BudgetFunc
+
+		if !step.Destroy {
+			budget = registeredBudgetFunc()
+		}
+
+		// Run pre-apply and pre-destroy plan checks and the test budget
+		if len(preApply) > 0 || len(step.DestroyPlanChecks.PreDestroy) > 0 || budget != nil {
+			var plan *tfjson.Plan
+			err = runProviderCommand(ctx, t, func() error {
+				var err error
+				plan, err = wd.SavedPlan(ctx)
+				return err
+			}, wd, providers)
+			if err != nil {
+				return fmt.Errorf("Error retrieving pre-apply plan: %w", err)
+			}
+
+			renderedPlan, err := savedRenderedPlan(ctx, t, wd, providers, preApply, step.DestroyPlanChecks.PreDestroy)
+			if err != nil {
+				return err
+			}
+
+			if len(preApply) > 0 {
+				err = runPlanChecks(ctx, t, plan, renderedPlan, stepNumber, step.Name, preApply)
+				if err != nil {
+					return fmt.Errorf("Pre-apply plan check(s) failed:\n%w", err)
+				}
+			}
+
+			if len(step.DestroyPlanChecks.PreDestroy) > 0 {
+				err = runPlanChecks(ctx, t, plan, renderedPlan, stepNumber, step.Name, step.DestroyPlanChecks.PreDestroy)
+				if err != nil {
+					return fmt.Errorf("Pre-destroy plan check(s) failed:\n%w", err)
+				}
+			}
+
+			if budget != nil {
+				err = checkBudget(ctx, budget, BudgetRequest{
+					TestName:       t.Name(),
+					StepNumber:     stepNumber,
+					StepName:       step.Name,
+					PlannedCreates: plannedCreates(plan),
+					Plan:           plan,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		// We need to keep a copy of the state prior to destroying such
 		// that the destroy steps can verify their behavior in the
 		// check function
 		var stateBeforeApplication *terraform.State
+		var priorState *tfjson.State
 		err = runProviderCommand(ctx, t, func() error {
-			stateBeforeApplication, err = getState(ctx, t, wd)
+			priorState, err = wd.State(ctx)
 			if err != nil {
 				return err
 			}
+			stateBeforeApplication, err = shimStateFromJson(priorState)
+			if err != nil {
+				t.Fatal(err)
+			}
 			return nil
 		}, wd, providers)
 		if err != nil {
 			return fmt.Errorf("Error retrieving pre-apply state: %w", err)
 		}
 
+		// Record the apply before it happens, so resources which remain
+		// after a crashed run can be traced back to the test.
+		if !step.Destroy {
+			err = writeJournalEntry(ctx, t.Name(), stepNumber, cfg, wd.BaseDir())
+			if err != nil {
+				return fmt.Errorf("Error writing journal entry: %w", err)
+			}
+		}
+
 		// Apply the diff, creating real resources
 		err = runProviderCommand(ctx, t, func() error {
 			return wd.Apply(ctx)
@@ -77,6 +205,47 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 			return fmt.Errorf("Error running apply: %w", err)
 		}
 
+		// Some resources only converge after a subsequent apply, so keep
+		// applying while the plan is not empty, up to the configured limit.
+		for applies := 1; applies < step.ConvergenceApplies; applies++ {
+			err = runProviderCommand(ctx, t, func() error {
+				return wd.CreatePlan(ctx)
+			}, wd, providers)
+			if err != nil {
+				return fmt.Errorf("Error running convergence plan: %w", err)
+			}
+
+			var plan *tfjson.Plan
+			err = runProviderCommand(ctx, t, func() error {
+				var err error
+				plan, err = wd.SavedPlan(ctx)
+				return err
+			}, wd, providers)
+			if err != nil {
+				return fmt.Errorf("Error retrieving convergence plan: %w", err)
+			}
+
+			if planIsEmpty(plan) {
+				logging.HelperResourceDebug(ctx, fmt.Sprintf("Converged after %d applies", applies))
+
+				break
+			}
+
+			logging.HelperResourceDebug(ctx, "Running Terraform CLI apply for convergence")
+
+			err = writeJournalEntry(ctx, t.Name(), stepNumber, cfg, wd.BaseDir())
+			if err != nil {
+				return fmt.Errorf("Error writing journal entry: %w", err)
+			}
+
+			err = runProviderCommand(ctx, t, func() error {
+				return wd.Apply(ctx)
+			}, wd, providers)
+			if err != nil {
+				return fmt.Errorf("Error running convergence apply: %w", err)
+			}
+		}
+
 		// Get the new state
 		var state *terraform.State
 		err = runProviderCommand(ctx, t, func() error {
@@ -105,6 +274,26 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 				}
 			}
 		}
+
+		// Run any configured state checks
+		if len(step.ConfigStateChecks) > 0 {
+			logging.HelperResourceTrace(ctx, "Using TestStep ConfigStateChecks")
+
+			var stateJSON *tfjson.State
+			err = runProviderCommand(ctx, t, func() error {
+				var err error
+				stateJSON, err = wd.State(ctx)
+				return err
+			}, wd, providers)
+			if err != nil {
+				return fmt.Errorf("Error retrieving state after apply: %w", err)
+			}
+
+			err = runStateChecks(ctx, t, stateJSON, priorState, stepNumber, step.Name, step.ConfigStateChecks)
+			if err != nil {
+				return fmt.Errorf("Post-apply state check(s) failed:\n%w", err)
+			}
+		}
 	}
 
 	// Test for perpetual diffs by performing a plan, a refresh, and another plan
@@ -131,7 +320,22 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return fmt.Errorf("Error retrieving post-apply plan: %w", err)
 	}
 
-	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan {
+	// Run post-apply, pre-refresh plan checks
+	postApplyPreRefresh := append(sensitiveValuesPlanChecks, step.ConfigPlanChecks.postApplyPreRefresh()...)
+
+	if len(postApplyPreRefresh) > 0 {
+		renderedPlan, err := savedRenderedPlan(ctx, t, wd, providers, postApplyPreRefresh)
+		if err != nil {
+			return err
+		}
+
+		err = runPlanChecks(ctx, t, plan, renderedPlan, stepNumber, step.Name, postApplyPreRefresh)
+		if err != nil {
+			return fmt.Errorf("Post-apply plan check(s) failed:\n%w", err)
+		}
+	}
+
+	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan && !expectsNonEmptyPlan(postApplyPreRefresh) {
 		var stdout string
 		err = runProviderCommand(ctx, t, func() error {
 			var err error
@@ -141,6 +345,9 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		if err != nil {
 			return fmt.Errorf("Error retrieving formatted plan output: %w", err)
 		}
+		if step.ConvergenceApplies > 1 {
+			return fmt.Errorf("After applying this test step %d times, the plan was not empty.\nstdout:\n\n%s", step.ConvergenceApplies, stdout)
+		}
 		return fmt.Errorf("After applying this test step, the plan was not empty.\nstdout:\n\n%s", stdout)
 	}
 
@@ -174,8 +381,23 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return fmt.Errorf("Error retrieving second post-apply plan: %w", err)
 	}
 
+	// Run post-apply, post-refresh plan checks
+	postApplyPostRefresh := append(sensitiveValuesPlanChecks, step.ConfigPlanChecks.PostApplyPostRefresh...)
+
+	if len(postApplyPostRefresh) > 0 {
+		renderedPlan, err := savedRenderedPlan(ctx, t, wd, providers, postApplyPostRefresh)
+		if err != nil {
+			return err
+		}
+
+		err = runPlanChecks(ctx, t, plan, renderedPlan, stepNumber, step.Name, postApplyPostRefresh)
+		if err != nil {
+			return fmt.Errorf("Post-apply refresh plan check(s) failed:\n%w", err)
+		}
+	}
+
 	// check if plan is empty
-	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan {
+	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan && !expectsNonEmptyPlan(step.ConfigPlanChecks.PostApplyPostRefresh) {
 		var stdout string
 		err = runProviderCommand(ctx, t, func() error {
 			var err error
@@ -190,6 +412,12 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		return errors.New("Expected a non-empty plan, but got an empty plan")
 	}
 
+	// Sensitive ConfigVariables values must not appear in the Terraform logs
+	err = checkLogsRedacted(wd.LogPath(), step.ConfigVariables.SensitiveValues())
+	if err != nil {
+		return fmt.Errorf("Sensitive value check failed: %w", err)
+	}
+
 	// ID-ONLY REFRESH
 	// If we've never checked an id-only refresh and our state isn't
 	// empty, find the first resource and test it.
@@ -234,12 +462,18 @@ func testStepNewConfig(ctx context.Context, t testing.T, c TestCase, wd *plugint
 		// this fails. If refresh isn't read-only, then this will have
 		// caught a different bug.
 		if idRefreshCheck != nil {
-			if err := testIDRefresh(ctx, t, c, wd, step, idRefreshCheck, providers); err != nil {
+			if err := testIDRefresh(ctx, t, c, wd, step, cfg, idRefreshCheck, providers); err != nil {
 				return fmt.Errorf(
 					"[ERROR] Test: ID-only test failed: %s", err)
 			}
 		}
 	}
 
+	if c.RefreshVerify != nil && !step.Destroy && !step.PlanOnly {
+		if err := testRefreshVerify(ctx, t, c, wd, providers); err != nil {
+			return fmt.Errorf("RefreshVerify test failed: %w", err)
+		}
+	}
+
 	return nil
 }
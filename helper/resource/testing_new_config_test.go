@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// convergenceTestProvider returns a provider whose resource only reflects the
+// configured value after the second apply, simulating an attribute which is
+// updated by a remote asynchronous process.
+func convergenceTestProvider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_resource": {
+				CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					d.SetId("test")
+					_ = d.Set("value", "pending")
+					return nil
+				},
+				UpdateContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					return nil
+				},
+				DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					return nil
+				},
+				ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+					return nil
+				},
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Optional: true,
+						Type:     schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTest_TestStep_ConvergenceApplies(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return convergenceTestProvider(), nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config:             `resource "test_resource" "test" { value = "converged" }`,
+				ConvergenceApplies: 2,
+				Check:              TestCheckResourceAttr("test_resource.test", "value", "converged"),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConvergenceApplies_NotConverged(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return convergenceTestProvider(), nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config:      `resource "test_resource" "test" { value = "converged" }`,
+				ExpectError: regexp.MustCompile(`After applying this test step, the plan was not empty`),
+			},
+		},
+	})
+}
+
+func TestTest_TestCase_SeparateConfigPreamble(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return convergenceTestProvider(), nil
+			},
+		},
+		SeparateConfigPreamble: true,
+		Steps: []TestStep{
+			{
+				Config:      `resource "test_resource" "test" { value = var.missing }`,
+				ExpectError: regexp.MustCompile(`terraform_plugin_test.tf line 1`),
+			},
+			{
+				Config:             `resource "test_resource" "test" { value = "converged" }`,
+				ConvergenceApplies: 2,
+				Check:              TestCheckResourceAttr("test_resource.test", "value", "converged"),
+			},
+		},
+	})
+}
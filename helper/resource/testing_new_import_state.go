@@ -18,7 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
 )
 
-func testStepNewImportState(ctx context.Context, t testing.T, helper *plugintest.Helper, wd *plugintest.WorkingDir, step TestStep, cfg string, providers *providerFactories) error {
+func testStepNewImportState(ctx context.Context, t testing.T, helper *plugintest.Helper, wd *plugintest.WorkingDir, step TestStep, stepNumber int, cfg testStepConfig, providers *providerFactories) error {
 	t.Helper()
 
 	if step.ResourceName == "" {
@@ -79,13 +79,25 @@ func testStepNewImportState(ctx context.Context, t testing.T, helper *plugintest
 	logging.HelperResourceTrace(ctx, fmt.Sprintf("Using import identifier: %s", importId))
 
 	// Create working directory for import tests
-	if step.Config == "" {
+	if step.hasConfig() {
+		cfg = testStepConfig{
+			raw:       step.Config,
+			directory: step.configDirectory(t.Name(), stepNumber),
+			file:      step.configFile(t.Name(), stepNumber),
+			variables: step.ConfigVariables,
+		}
+	} else {
 		logging.HelperResourceTrace(ctx, "Using prior TestStep Config for import")
 
-		step.Config = cfg
-		if step.Config == "" {
+		if cfg.isEmpty() {
 			t.Fatal("Cannot import state with no specified config")
 		}
+
+		if step.ConfigVariables != nil {
+			logging.HelperResourceTrace(ctx, "Using TestStep ConfigVariables for import")
+
+			cfg.variables = step.ConfigVariables
+		}
 	}
 
 	var importWd *plugintest.WorkingDir
@@ -98,7 +110,7 @@ func testStepNewImportState(ctx context.Context, t testing.T, helper *plugintest
 		defer importWd.Close()
 	}
 
-	err = importWd.SetConfig(ctx, step.Config)
+	err = cfg.write(ctx, importWd)
 	if err != nil {
 		t.Fatalf("Error setting test config: %s", err)
 	}
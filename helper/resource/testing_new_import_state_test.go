@@ -6,6 +6,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -197,3 +198,87 @@ func TestTest_TestStep_ImportStateVerifyIgnore(t *testing.T) {
 		},
 	})
 }
+
+func TestTest_TestStep_ImportStatePersist_DestroyPersistedImport(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		destroyPersistedImport *bool
+		expectedDeletes        int32
+	}{
+		"unset": {
+			expectedDeletes: 1,
+		},
+		"true": {
+			destroyPersistedImport: boolPointer(true),
+			expectedDeletes:        1,
+		},
+		"false": {
+			destroyPersistedImport: boolPointer(false),
+			expectedDeletes:        0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var deletes int32
+
+			Test(t, TestCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"examplecloud": func() (*schema.Provider, error) { //nolint:unparam // required signature
+						return &schema.Provider{
+							ResourcesMap: map[string]*schema.Resource{
+								"examplecloud_thing": {
+									CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+										d.SetId("resource-test")
+
+										return nil
+									},
+									DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+										atomic.AddInt32(&deletes, 1)
+
+										return nil
+									},
+									ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+										return nil
+									},
+									Schema: map[string]*schema.Schema{
+										"id": {
+											Computed: true,
+											Type:     schema.TypeString,
+										},
+									},
+									Importer: &schema.ResourceImporter{
+										StateContext: schema.ImportStatePassthroughContext,
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				Steps: []TestStep{
+					{
+						Config:                 `resource "examplecloud_thing" "test" {}`,
+						ResourceName:           "examplecloud_thing.test",
+						ImportState:            true,
+						ImportStateId:          "resource-test",
+						ImportStatePersist:     true,
+						DestroyPersistedImport: testCase.destroyPersistedImport,
+					},
+				},
+			})
+
+			if got := atomic.LoadInt32(&deletes); got != testCase.expectedDeletes {
+				t.Errorf("expected %d deletes, got: %d", testCase.expectedDeletes, got)
+			}
+		})
+	}
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
 )
 
-func testStepNewRefreshState(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, step TestStep, providers *providerFactories) error {
+func testStepNewRefreshState(ctx context.Context, t testing.T, wd *plugintest.WorkingDir, step TestStep, stepNumber int, providers *providerFactories) error {
 	t.Helper()
 
 	var err error
@@ -80,7 +80,20 @@ func testStepNewRefreshState(ctx context.Context, t testing.T, wd *plugintest.Wo
 		return fmt.Errorf("Error retrieving post-apply plan: %w", err)
 	}
 
-	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan {
+	// Run post-refresh plan checks
+	if len(step.RefreshPlanChecks.PostRefresh) > 0 {
+		renderedPlan, err := savedRenderedPlan(ctx, t, wd, providers, step.RefreshPlanChecks.PostRefresh)
+		if err != nil {
+			return err
+		}
+
+		err = runPlanChecks(ctx, t, plan, renderedPlan, stepNumber, step.Name, step.RefreshPlanChecks.PostRefresh)
+		if err != nil {
+			return fmt.Errorf("Post-refresh plan check(s) failed:\n%w", err)
+		}
+	}
+
+	if !planIsEmpty(plan) && !step.ExpectNonEmptyPlan && !expectsNonEmptyPlan(step.RefreshPlanChecks.PostRefresh) {
 		var stdout string
 		err = runProviderCommand(ctx, t, func() error {
 			var err error
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// testRefreshVerify refreshes only the RefreshVerify resource and compares
+// its attribute values in state before and after the refresh.
+func testRefreshVerify(ctx context.Context, t testing.T, c TestCase, wd *plugintest.WorkingDir, providers *providerFactories) error {
+	t.Helper()
+
+	address := c.RefreshVerify.ResourceAddress
+
+	logging.HelperResourceTrace(ctx, fmt.Sprintf("Using TestCase RefreshVerify: %s", address))
+
+	var stateBeforeRefresh *tfjson.State
+	err := runProviderCommand(ctx, t, func() error {
+		var err error
+		stateBeforeRefresh, err = wd.State(ctx)
+		return err
+	}, wd, providers)
+	if err != nil {
+		return fmt.Errorf("Error retrieving state before refresh: %w", err)
+	}
+
+	expected := stateResourceByAddress(stateBeforeRefresh, address)
+	if expected == nil {
+		return fmt.Errorf("RefreshVerify resource %s not found in state", address)
+	}
+
+	err = runProviderCommand(ctx, t, func() error {
+		return wd.RefreshTarget(ctx, address)
+	}, wd, providers)
+	if err != nil {
+		return fmt.Errorf("Error running refresh: %w", err)
+	}
+
+	var stateAfterRefresh *tfjson.State
+	err = runProviderCommand(ctx, t, func() error {
+		var err error
+		stateAfterRefresh, err = wd.State(ctx)
+		return err
+	}, wd, providers)
+	if err != nil {
+		return fmt.Errorf("Error retrieving state after refresh: %w", err)
+	}
+
+	actual := stateResourceByAddress(stateAfterRefresh, address)
+	if actual == nil {
+		return fmt.Errorf("RefreshVerify resource %s not found in state after refresh", address)
+	}
+
+	ignorePaths := make([]string, 0, len(c.RefreshVerify.IgnorePaths))
+
+	for _, ignorePath := range c.RefreshVerify.IgnorePaths {
+		ignorePaths = append(ignorePaths, ignorePath.String())
+	}
+
+	if diff := refreshVerifyDiff(expected.AttributeValues, actual.AttributeValues, ignorePaths); diff != "" {
+		return fmt.Errorf("RefreshVerify attributes not equivalent. Difference is shown below. The - symbol indicates attributes before refresh.\n\n%s", diff)
+	}
+
+	return nil
+}
+
+// refreshVerifyDiff returns the difference between attribute values, ignoring
+// any values at or beneath the given paths.
+func refreshVerifyDiff(expected, actual map[string]interface{}, ignorePaths []string) string {
+	ignore := cmp.FilterPath(func(p cmp.Path) bool {
+		pathString := attributeValuesPathString(p)
+
+		for _, ignorePath := range ignorePaths {
+			if pathString == ignorePath || strings.HasPrefix(pathString, ignorePath+".") || strings.HasPrefix(pathString, ignorePath+"[") {
+				return true
+			}
+		}
+
+		return false
+	}, cmp.Ignore())
+
+	return cmp.Diff(expected, actual, ignore)
+}
+
+// attributeValuesPathString converts a go-cmp path within attribute values
+// into the same form as tfjsonpath.Path String(), such as "list.0.nested".
+func attributeValuesPathString(p cmp.Path) string {
+	var path tfjsonpath.Path
+
+	// Attribute values are a map, so the first step is always a map key.
+	first := true
+
+	for _, ps := range p {
+		switch s := ps.(type) {
+		case cmp.MapIndex:
+			key := s.Key().String()
+
+			if first {
+				path = tfjsonpath.New(key)
+				first = false
+
+				continue
+			}
+
+			path = path.AtMapKey(key)
+		case cmp.SliceIndex:
+			key, _ := s.SplitKeys()
+
+			// Use the expected side index, falling back to the actual side
+			// when the element only exists after refresh.
+			if key < 0 {
+				_, key = s.SplitKeys()
+			}
+
+			path = path.AtSliceIndex(key)
+		}
+	}
+
+	return path.String()
+}
+
+// stateResourceByAddress returns the resource with the given address from
+// any module in the state, or nil if not found.
+func stateResourceByAddress(state *tfjson.State, address string) *tfjson.StateResource {
+	if state == nil || state.Values == nil {
+		return nil
+	}
+
+	return stateModuleResourceByAddress(state.Values.RootModule, address)
+}
+
+func stateModuleResourceByAddress(module *tfjson.StateModule, address string) *tfjson.StateResource {
+	if module == nil {
+		return nil
+	}
+
+	for _, resource := range module.Resources {
+		if resource.Address == address {
+			return resource
+		}
+	}
+
+	for _, childModule := range module.ChildModules {
+		if resource := stateModuleResourceByAddress(childModule, address); resource != nil {
+			return resource
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestRefreshVerifyDiff(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]interface{}{
+		"id":   "test",
+		"tags": map[string]interface{}{"a": "one", "b": "two"},
+		"list": []interface{}{
+			map[string]interface{}{"nested": "before"},
+		},
+	}
+
+	tests := map[string]struct {
+		actual      map[string]interface{}
+		ignorePaths []tfjsonpath.Path
+		expectDiff  bool
+	}{
+		"equal": {
+			actual: map[string]interface{}{
+				"id":   "test",
+				"tags": map[string]interface{}{"a": "one", "b": "two"},
+				"list": []interface{}{
+					map[string]interface{}{"nested": "before"},
+				},
+			},
+		},
+		"different": {
+			actual: map[string]interface{}{
+				"id":   "test",
+				"tags": map[string]interface{}{"a": "one", "b": "changed"},
+				"list": []interface{}{
+					map[string]interface{}{"nested": "before"},
+				},
+			},
+			expectDiff: true,
+		},
+		"different-ignored": {
+			actual: map[string]interface{}{
+				"id":   "test",
+				"tags": map[string]interface{}{"a": "one", "b": "changed"},
+				"list": []interface{}{
+					map[string]interface{}{"nested": "after"},
+				},
+			},
+			ignorePaths: []tfjsonpath.Path{
+				tfjsonpath.New("tags").AtMapKey("b"),
+				tfjsonpath.New("list").AtSliceIndex(0).AtMapKey("nested"),
+			},
+		},
+		"different-ignored-parent": {
+			actual: map[string]interface{}{
+				"id":   "test",
+				"tags": map[string]interface{}{"a": "changed"},
+				"list": []interface{}{
+					map[string]interface{}{"nested": "before"},
+				},
+			},
+			ignorePaths: []tfjsonpath.Path{
+				tfjsonpath.New("tags"),
+			},
+		},
+		"different-ignored-quoted-key": {
+			actual: map[string]interface{}{
+				"id":   "test",
+				"tags": map[string]interface{}{"a": "one", "b": "two", "example.com/owner": "example"},
+				"list": []interface{}{
+					map[string]interface{}{"nested": "before"},
+				},
+			},
+			ignorePaths: []tfjsonpath.Path{
+				tfjsonpath.New("tags").AtMapKey("example.com/owner"),
+			},
+		},
+		"different-ignored-other": {
+			actual: map[string]interface{}{
+				"id":   "changed",
+				"tags": map[string]interface{}{"a": "one", "b": "two"},
+				"list": []interface{}{
+					map[string]interface{}{"nested": "before"},
+				},
+			},
+			ignorePaths: []tfjsonpath.Path{
+				tfjsonpath.New("i"),
+			},
+			expectDiff: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ignorePaths := make([]string, 0, len(test.ignorePaths))
+
+			for _, ignorePath := range test.ignorePaths {
+				ignorePaths = append(ignorePaths, ignorePath.String())
+			}
+
+			diff := refreshVerifyDiff(expected, test.actual, ignorePaths)
+
+			if test.expectDiff && diff == "" {
+				t.Errorf("expected difference, got none")
+			}
+
+			if !test.expectDiff && diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStateResourceByAddress(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{Address: "test_resource.root"},
+				},
+				ChildModules: []*tfjson.StateModule{
+					{
+						Address: "module.child",
+						Resources: []*tfjson.StateResource{
+							{Address: "module.child.test_resource.child"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, address := range []string{"test_resource.root", "module.child.test_resource.child"} {
+		got := stateResourceByAddress(state, address)
+
+		if got == nil || got.Address != address {
+			t.Errorf("expected resource %s, got: %v", address, got)
+		}
+	}
+
+	if got := stateResourceByAddress(state, "test_resource.missing"); got != nil {
+		t.Errorf("expected no resource, got: %v", got)
+	}
+
+	if got := stateResourceByAddress(&tfjson.State{}, "test_resource.root"); got != nil {
+		t.Errorf("expected no resource for empty state, got: %v", got)
+	}
+}
+
+func TestTest_TestCase_RefreshVerify(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		RefreshVerify: &RefreshVerify{
+			ResourceAddress: "random_string.one",
+			IgnorePaths: []tfjsonpath.Path{
+				tfjsonpath.New("keepers"),
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "one" {
+					length = 16
+				}`,
+			},
+		},
+	})
+}
@@ -4,6 +4,7 @@
 package resource
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -1127,3 +1128,75 @@ func TestShimState(t *testing.T) {
 		})
 	}
 }
+
+func TestWithoutCancel(t *testing.T) {
+	t.Parallel()
+
+	type testKey struct{}
+
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), testKey{}, "value"))
+	cancel()
+
+	ctx := withoutCancel(parent)
+
+	if err := ctx.Err(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("unexpected deadline")
+	}
+
+	if got := ctx.Value(testKey{}); got != "value" {
+		t.Errorf("expected parent value, got: %v", got)
+	}
+}
+
+func TestTest_TestCase_DestroyConfig(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		// The final destroy fails with the last TestStep configuration, as
+		// prevent_destroy applies to the resource.
+		DestroyConfig: `resource "random_string" "test" {
+			length = 16
+		}`,
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "test" {
+					length = 16
+
+					lifecycle {
+						prevent_destroy = true
+					}
+				}`,
+			},
+		},
+	})
+}
+
+func TestTest_TestCase_SkipFinalDestroy(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		SkipFinalDestroy:       true,
+		SkipFinalDestroyReason: "random_string is only stored in state",
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "test" {
+					length = 16
+				}`,
+			},
+		},
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// seedRandom seeds the helper/acctest package with the seed of the TestCase
+// and returns it. The seed is written to the test output if the TestCase
+// fails.
+func seedRandom(ctx context.Context, t testing.T, seed int64) int64 {
+	t.Helper()
+
+	seed, baseSeed, err := randomSeed(seed, t.Name())
+
+	if err != nil {
+		t.Fatalf("Error choosing random seed: %s", err)
+	}
+
+	acctest.SeedRandom(seed)
+
+	logging.HelperResourceDebug(ctx, fmt.Sprintf("Seeded random values with seed %d", seed))
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		if baseSeed == 0 {
+			t.Logf("Random values of the TestCase were generated with seed %d. Set the TestCase RandomSeed field to reproduce them.", seed)
+
+			return
+		}
+
+		t.Logf("Random values of the TestCase were generated with seed %d. Set the TestCase RandomSeed field to %d, or the %s environment variable to %d, to reproduce them.", seed, seed, EnvTfAccRandomSeed, baseSeed)
+	})
+
+	return seed
+}
+
+// randomSeed returns the given seed, if not zero, otherwise a seed derived
+// from the test name and a base seed, which is the seed of the
+// TF_ACC_RANDOM_SEED environment variable, if set, otherwise a new seed. Each
+// test of a run with the same base seed therefore generates different random
+// values. The base seed is also returned, or zero if the given seed is used.
+func randomSeed(seed int64, testName string) (int64, int64, error) {
+	if seed != 0 {
+		return seed, 0, nil
+	}
+
+	baseSeed := time.Now().UnixNano()
+
+	if v := os.Getenv(EnvTfAccRandomSeed); v != "" {
+		var err error
+
+		baseSeed, err = strconv.ParseInt(v, 10, 64)
+
+		if err != nil || baseSeed == 0 {
+			return 0, 0, fmt.Errorf("%s must be a non-zero integer, got: %s", EnvTfAccRandomSeed, v)
+		}
+	}
+
+	h := fnv.New64a()
+
+	fmt.Fprintf(h, "%d\x00%s", baseSeed, testName)
+
+	seed = int64(h.Sum64())
+
+	// Zero is reserved for an unset RandomSeed field.
+	if seed == 0 {
+		seed = 1
+	}
+
+	return seed, baseSeed, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+)
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestRandomSeed(t *testing.T) {
+	testCases := map[string]struct {
+		seed             int64
+		testName         string
+		env              string
+		expected         int64
+		expectedBaseSeed int64
+		expectedError    error
+	}{
+		"seed": {
+			seed:     1234,
+			testName: "TestExample",
+			env:      "5678",
+			expected: 1234,
+		},
+		"env": {
+			testName:         "TestExample",
+			env:              "5678",
+			expected:         -1430299184797270837,
+			expectedBaseSeed: 5678,
+		},
+		"env-negative": {
+			testName:         "TestExample",
+			env:              "-5678",
+			expected:         336842885178742894,
+			expectedBaseSeed: -5678,
+		},
+		"env-test-name": {
+			testName:         "TestOther",
+			env:              "5678",
+			expected:         -5521653490852655177,
+			expectedBaseSeed: 5678,
+		},
+		"env-invalid": {
+			testName:      "TestExample",
+			env:           "invalid",
+			expectedError: fmt.Errorf("TF_ACC_RANDOM_SEED must be a non-zero integer, got: invalid"),
+		},
+		"env-zero": {
+			testName:      "TestExample",
+			env:           "0",
+			expectedError: fmt.Errorf("TF_ACC_RANDOM_SEED must be a non-zero integer, got: 0"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvTfAccRandomSeed, testCase.env)
+
+			got, gotBaseSeed, err := randomSeed(testCase.seed, testCase.testName)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if err.Error() != testCase.expectedError.Error() {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected seed %d, got %d", testCase.expected, got)
+			}
+
+			if gotBaseSeed != testCase.expectedBaseSeed {
+				t.Errorf("expected base seed %d, got %d", testCase.expectedBaseSeed, gotBaseSeed)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestRandomSeed_Unset(t *testing.T) {
+	t.Setenv(EnvTfAccRandomSeed, "")
+
+	got, gotBaseSeed, err := randomSeed(0, "TestExample")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got == 0 || gotBaseSeed == 0 {
+		t.Errorf("expected new seed, got seed %d and base seed %d", got, gotBaseSeed)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with the shared random source
+func TestTest_TestCase_RandomSeed(t *testing.T) {
+	var generated []string
+
+	for i := 0; i < 2; i++ {
+		UnitTest(t, TestCase{
+			ProviderFactories: map[string]func() (*schema.Provider, error){
+				"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+					return migrationTestProvider(), nil
+				},
+			},
+			PreCheck: func() {
+				generated = append(generated, acctest.RandString(10))
+			},
+			RandomSeed: 1234,
+			Steps: []TestStep{
+				{
+					Config: `resource "test_resource" "test" { name = "create" }`,
+				},
+			},
+		})
+	}
+
+	if len(generated) != 2 || generated[0] != generated[1] {
+		t.Errorf("expected the same random values for the same seed, got: %q", generated)
+	}
+}
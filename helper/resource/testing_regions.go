@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	gotesting "testing"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+)
+
+// RegionVariable is the name of the input variable which TestRegions and
+// ParallelTestRegions set to the region of each TestCase.
+const RegionVariable = "region"
+
+// TestRegions runs a TestCase for each of the given regions, such as cloud
+// provider regions or zones, as subtests named after the region. The given
+// function returns the TestCase for a region.
+//
+// The region is also set as the "region" input variable of each Config,
+// ConfigDirectory, or ConfigFile TestStep, unless the TestStep
+// ConfigVariables already contains the variable, so configurations can
+// declare it with:
+//
+//	variable "region" {
+//	  type = string
+//	}
+//
+// Each TestCase has its own working directory, so the TestCase of each region
+// is independent. A *testing.T from the standard library is required to run
+// the subtests. Test() function requirements and documentation also apply
+// to this function.
+func TestRegions(t testing.T, regions []string, f func(region string) TestCase) {
+	t.Helper()
+
+	runRegions(t, regions, f, false)
+}
+
+// ParallelTestRegions is a variant of TestRegions which runs the TestCase of
+// each region in parallel with each other and other ParallelTest.
+//
+// ParallelTest() function requirements and documentation also apply to this
+// function.
+func ParallelTestRegions(t testing.T, regions []string, f func(region string) TestCase) {
+	t.Helper()
+
+	runRegions(t, regions, f, true)
+}
+
+// runRegions runs the TestCase of each region as a subtest.
+func runRegions(t testing.T, regions []string, f func(region string) TestCase, parallel bool) {
+	t.Helper()
+
+	runner, ok := t.(subtestRunner)
+
+	if !ok {
+		t.Fatalf("TestRegions requires a *testing.T, got %T", t)
+
+		return
+	}
+
+	if len(regions) == 0 {
+		t.Fatal("TestRegions requires at least one region")
+
+		return
+	}
+
+	for _, region := range regions {
+		region := region
+
+		runner.Run(region, func(t *gotesting.T) {
+			t.Helper()
+
+			if parallel {
+				t.Parallel()
+			}
+
+			Test(t, f(region).withRegion(region))
+		})
+	}
+}
+
+// withRegion returns a copy of the TestCase where the region input variable
+// is set for each TestStep with configuration, unless already set.
+func (c TestCase) withRegion(region string) TestCase {
+	steps := make([]TestStep, len(c.Steps))
+
+	for i, step := range c.Steps {
+		if step.hasConfig() {
+			if _, ok := step.ConfigVariables[RegionVariable]; !ok {
+				variables := make(config.Variables, len(step.ConfigVariables)+1)
+
+				for name, variable := range step.ConfigVariables {
+					variables[name] = variable
+				}
+
+				variables[RegionVariable] = config.StringVariable(region)
+				step.ConfigVariables = variables
+			}
+		}
+
+		steps[i] = step
+	}
+
+	c.Steps = steps
+
+	return c
+}
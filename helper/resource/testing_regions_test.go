@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+)
+
+func TestTestCaseWithRegion(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testCase TestCase
+		expected []config.Variables
+	}{
+		"config": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{
+						Config: `resource "test_resource" "test" {}`,
+					},
+				},
+			},
+			expected: []config.Variables{
+				{
+					"region": config.StringVariable("us-east-1"),
+				},
+			},
+		},
+		"config-variables": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{
+						ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_id"),
+						ConfigVariables: config.Variables{
+							"name": config.StringVariable("example"),
+						},
+					},
+				},
+			},
+			expected: []config.Variables{
+				{
+					"name":   config.StringVariable("example"),
+					"region": config.StringVariable("us-east-1"),
+				},
+			},
+		},
+		"config-variables-region": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{
+						Config: `resource "test_resource" "test" {}`,
+						ConfigVariables: config.Variables{
+							"region": config.StringVariable("us-west-2"),
+						},
+					},
+				},
+			},
+			expected: []config.Variables{
+				{
+					"region": config.StringVariable("us-west-2"),
+				},
+			},
+		},
+		"refresh-state": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{
+						Config: `resource "test_resource" "test" {}`,
+					},
+					{
+						RefreshState: true,
+					},
+				},
+			},
+			expected: []config.Variables{
+				{
+					"region": config.StringVariable("us-east-1"),
+				},
+				nil,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testCase.withRegion("us-east-1")
+
+			var gotVariables []config.Variables
+
+			for _, step := range got.Steps {
+				gotVariables = append(gotVariables, step.ConfigVariables)
+			}
+
+			if diff := cmp.Diff(gotVariables, testCase.expected, equateVariables); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTestCaseWithRegion_Copy(t *testing.T) {
+	t.Parallel()
+
+	variables := config.Variables{
+		"name": config.StringVariable("example"),
+	}
+
+	testCase := TestCase{
+		Steps: []TestStep{
+			{
+				Config:          `resource "test_resource" "test" {}`,
+				ConfigVariables: variables,
+			},
+		},
+	}
+
+	testCase.withRegion("us-east-1")
+
+	if _, ok := variables["region"]; ok {
+		t.Error("expected original ConfigVariables to be unchanged")
+	}
+
+	if testCase.Steps[0].ConfigVariables["region"] != nil {
+		t.Error("expected original TestStep to be unchanged")
+	}
+}
@@ -0,0 +1,762 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+const (
+	// reportPhaseTestCase is the phase of report events for a whole TestCase.
+	reportPhaseTestCase = "testcase"
+
+	// reportPhaseConfig is the phase of report events for Config TestStep.
+	reportPhaseConfig = "config"
+
+	// reportPhaseImport is the phase of report events for ImportState
+	// TestStep.
+	reportPhaseImport = "import"
+
+	// reportPhaseRefresh is the phase of report events for RefreshState
+	// TestStep.
+	reportPhaseRefresh = "refresh"
+
+	// reportResultExpectedFailure is the result of a failed TestStep with
+	// ExpectedFailure.
+	reportResultExpectedFailure = "expected-failure"
+
+	// reportResultFail is the result of a failed TestCase or TestStep.
+	reportResultFail = "fail"
+
+	// reportResultFlakyPass is the result of a TestCase which passed when
+	// rerun after a failure.
+	reportResultFlakyPass = "flaky-pass"
+
+	// reportResultPass is the result of a passed TestCase or TestStep.
+	reportResultPass = "pass"
+
+	// reportResultSkip is the result of a skipped TestCase or TestStep.
+	reportResultSkip = "skip"
+
+	// reportResultUnexpectedlyFixed is the result of a passed TestStep with
+	// ExpectedFailure.
+	reportResultUnexpectedlyFixed = "unexpectedly-fixed"
+
+	// reportFailureCheck is the failure category of a Check function, plan
+	// check, state check, or import or refresh verification failure.
+	reportFailureCheck = "check_failure"
+
+	// reportFailureInitNetwork is the failure category of a Terraform init
+	// failure, such as a provider installation error, or a network error.
+	reportFailureInitNetwork = "init_network"
+
+	// reportFailureNonEmptyPlan is the failure category of an unexpected
+	// non-empty plan after apply or refresh.
+	reportFailureNonEmptyPlan = "non_empty_plan"
+
+	// reportFailureOther is the failure category of failures which do not
+	// match any other category.
+	reportFailureOther = "other"
+
+	// reportFailureProviderInconsistency is the failure category of a
+	// Terraform provider inconsistent result after apply or inconsistent
+	// final plan error.
+	reportFailureProviderInconsistency = "provider_inconsistency"
+
+	// reportFailureTimeout is the failure category of a timeout, such as a
+	// context deadline or a resource waiting for a state.
+	reportFailureTimeout = "timeout"
+)
+
+// reportFailureCategories are the failure categories and the failure message
+// substrings which classify them, in order of precedence. Init and network
+// failures precede timeouts, as network errors often include timeouts, such
+// as "i/o timeout".
+var reportFailureCategories = []struct {
+	category   string
+	substrings []string
+}{
+	{
+		category: reportFailureProviderInconsistency,
+		substrings: []string{
+			"Provider produced inconsistent result after apply",
+			"Provider produced inconsistent final plan",
+		},
+	},
+	{
+		category: reportFailureInitNetwork,
+		substrings: []string{
+			"Error running init",
+			"Failed to install provider",
+			"Failed to query available provider packages",
+			"connection refused",
+			"connection reset by peer",
+			"dial tcp",
+			"i/o timeout",
+			"no such host",
+			"TLS handshake timeout",
+		},
+	},
+	{
+		category: reportFailureTimeout,
+		substrings: []string{
+			"context deadline exceeded",
+			"timeout while waiting",
+			"timed out",
+		},
+	},
+	{
+		category: reportFailureNonEmptyPlan,
+		substrings: []string{
+			"the plan was not empty",
+			"a followup plan was not empty",
+		},
+	},
+	{
+		category: reportFailureCheck,
+		substrings: []string{
+			"Check failed:",
+			"check(s) failed:",
+			"attributes not equivalent",
+			"RefreshVerify",
+			"PostDestroyCheck failed:",
+		},
+	},
+}
+
+// reportMutex prevents concurrent TestCase from interleaving report events
+// when writing to the same report file.
+var reportMutex sync.Mutex
+
+// reportEvent is a record of a finished TestCase or TestStep, written to the
+// report file set by the TF_ACC_REPORT_PATH environment variable.
+type reportEvent struct {
+	// TestName is the name of the Go test running the TestCase.
+	TestName string `json:"test_name"`
+
+	// StepNumber is the 1-based index of the TestStep in the TestCase, or
+	// zero for TestCase events.
+	StepNumber int `json:"step_number,omitempty"`
+
+	// Phase is testcase for TestCase events, otherwise the TestStep mode of
+	// config, import, or refresh.
+	Phase string `json:"phase"`
+
+	// Result is pass, fail, or skip. The TestCase event of a TestCase which
+	// passed when rerun after a failure has the flaky-pass result. The
+	// TestStep event of a TestStep with ExpectedFailure has the
+	// expected-failure result if it failed, or the unexpectedly-fixed result
+	// if it passed.
+	Result string `json:"result"`
+
+	// ExpectedFailure is the issue reference of the ExpectedFailure of the
+	// TestStep, if any.
+	ExpectedFailure string `json:"expected_failure,omitempty"`
+
+	// Attempt is 1 for the first run and 2 for the rerun of a failed TestCase
+	// when enabled by the TF_ACC_RERUN_FAILED environment variable,
+	// otherwise zero.
+	Attempt int `json:"attempt,omitempty"`
+
+	// Duration is the elapsed time in seconds.
+	Duration float64 `json:"duration"`
+
+	// Diagnostics are the failure messages reported during the TestCase or
+	// TestStep.
+	Diagnostics []string `json:"diagnostics,omitempty"`
+
+	// FailureCategory classifies the diagnostics of a failed TestCase or
+	// TestStep as provider_inconsistency, init_network, timeout,
+	// non_empty_plan, check_failure, or other.
+	FailureCategory string `json:"failure_category,omitempty"`
+
+	// Warnings are messages reported during the TestCase which do not fail
+	// it, such as a skipped final destroy.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// RandomSeed is the seed of the random values generated by the
+	// helper/acctest package during the TestCase, or zero for TestStep
+	// events.
+	RandomSeed int64 `json:"random_seed,omitempty"`
+
+	// Timestamp is when the TestCase or TestStep finished, in UTC.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// testReporter writes report events for a TestCase and its TestStep to the
+// report file. It wraps the testing.T of the TestCase to capture failure
+// messages as diagnostics.
+type testReporter struct {
+	testing.T
+
+	path string
+
+	// attempt is the run of the TestCase when failures are rerun, otherwise
+	// zero.
+	attempt int
+
+	// randomSeed is the seed of the random values of the TestCase.
+	randomSeed int64
+
+	mu          sync.Mutex
+	caseStart   time.Time
+	diagnostics []string
+	warnings    []string
+
+	step      *reportEvent
+	stepStart time.Time
+}
+
+// newTestReporter returns a testReporter wrapping the given testing.T, if
+// enabled by the TF_ACC_REPORT_PATH environment variable or if failure
+// messages must be captured, such as for the TestCase OnFailure function,
+// otherwise nil. Report events are only written if the environment variable
+// is set.
+func newTestReporter(t testing.T, captureFailures bool) *testReporter {
+	reportPath := os.Getenv(EnvTfAccReportPath)
+
+	if reportPath == "" && !captureFailures {
+		return nil
+	}
+
+	return &testReporter{
+		T:         t,
+		path:      reportPath,
+		caseStart: time.Now(),
+	}
+}
+
+func (r *testReporter) Error(args ...interface{}) {
+	r.T.Helper()
+	r.addDiagnostic(fmt.Sprint(args...))
+	r.T.Error(args...)
+}
+
+func (r *testReporter) Errorf(format string, args ...interface{}) {
+	r.T.Helper()
+	r.addDiagnostic(fmt.Sprintf(format, args...))
+	r.T.Errorf(format, args...)
+}
+
+func (r *testReporter) Fatal(args ...interface{}) {
+	r.T.Helper()
+	r.addDiagnostic(fmt.Sprint(args...))
+	r.T.Fatal(args...)
+}
+
+func (r *testReporter) Fatalf(format string, args ...interface{}) {
+	r.T.Helper()
+	r.addDiagnostic(fmt.Sprintf(format, args...))
+	r.T.Fatalf(format, args...)
+}
+
+// wrapStep returns the given testing.T of a TestStep subtest wrapped to also
+// capture failure messages as diagnostics, or the testing.T if the reporter
+// is not enabled.
+func (r *testReporter) wrapStep(t testing.T) testing.T {
+	if r == nil {
+		return t
+	}
+
+	return &testStepReporter{
+		T:        t,
+		reporter: r,
+	}
+}
+
+// addDiagnostic saves a failure message for the current TestStep, if any,
+// and the TestCase.
+func (r *testReporter) addDiagnostic(diagnostic string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.diagnostics = append(r.diagnostics, diagnostic)
+
+	if r.step != nil {
+		r.step.Diagnostics = append(r.step.Diagnostics, diagnostic)
+	}
+}
+
+// addWarning saves a warning message for the TestCase.
+func (r *testReporter) addWarning(warning string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.warnings = append(r.warnings, warning)
+}
+
+// startStep begins timing the given TestStep.
+func (r *testReporter) startStep(stepNumber int, step TestStep) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.step = &reportEvent{
+		TestName:        r.T.Name(),
+		StepNumber:      stepNumber,
+		Phase:           step.reportPhase(),
+		Attempt:         r.attempt,
+		ExpectedFailure: step.ExpectedFailure,
+	}
+	r.stepStart = time.Now()
+}
+
+// addStepDiagnostic saves a failure message for the current TestStep only,
+// such as the failure of a TestStep with ExpectedFailure, which does not fail
+// the TestCase.
+func (r *testReporter) addStepDiagnostic(diagnostic string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.step != nil {
+		r.step.Diagnostics = append(r.step.Diagnostics, diagnostic)
+	}
+}
+
+// stepDiagnostics returns the failure messages of the current TestStep.
+func (r *testReporter) stepDiagnostics() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.step == nil {
+		return nil
+	}
+
+	return append([]string(nil), r.step.Diagnostics...)
+}
+
+// endStep writes the report event for the current TestStep with the given
+// result.
+func (r *testReporter) endStep(ctx context.Context, result string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	event := r.step
+	r.step = nil
+	r.mu.Unlock()
+
+	if event == nil {
+		return
+	}
+
+	// Non-fatal failure messages also fail the TestStep.
+	if result == reportResultPass && len(event.Diagnostics) > 0 {
+		result = reportResultFail
+	}
+
+	if event.ExpectedFailure != "" {
+		switch result {
+		case reportResultFail:
+			result = reportResultExpectedFailure
+		case reportResultPass:
+			result = reportResultUnexpectedlyFixed
+		}
+	}
+
+	if result == reportResultFail {
+		event.FailureCategory = classifyFailure(event.Diagnostics)
+	}
+
+	event.Result = result
+	event.Duration = time.Since(r.stepStart).Seconds()
+	event.Timestamp = time.Now().UTC()
+
+	r.write(ctx, *event)
+}
+
+// finish writes the report event for a TestStep which did not end, such as
+// after a failure, and the report event for the TestCase.
+func (r *testReporter) finish(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	r.endStep(ctx, reportResultFail)
+
+	result := reportResultPass
+
+	switch {
+	case r.T.Failed():
+		result = reportResultFail
+	case r.T.Skipped():
+		result = reportResultSkip
+	case r.attempt > 1:
+		result = reportResultFlakyPass
+	}
+
+	r.mu.Lock()
+	diagnostics := r.diagnostics
+	warnings := r.warnings
+	r.mu.Unlock()
+
+	var failureCategory string
+
+	if result == reportResultFail {
+		failureCategory = classifyFailure(diagnostics)
+	}
+
+	r.write(ctx, reportEvent{
+		TestName:        r.T.Name(),
+		Phase:           reportPhaseTestCase,
+		Result:          result,
+		Attempt:         r.attempt,
+		Duration:        time.Since(r.caseStart).Seconds(),
+		Diagnostics:     diagnostics,
+		FailureCategory: failureCategory,
+		Warnings:        warnings,
+		RandomSeed:      r.randomSeed,
+		Timestamp:       time.Now().UTC(),
+	})
+}
+
+// classifyFailure returns the failure category of the first diagnostic
+// matching a category, or other if none match.
+func classifyFailure(diagnostics []string) string {
+	for _, diagnostic := range diagnostics {
+		for _, c := range reportFailureCategories {
+			for _, substring := range c.substrings {
+				if strings.Contains(diagnostic, substring) {
+					return c.category
+				}
+			}
+		}
+	}
+
+	return reportFailureOther
+}
+
+// reportPhase returns the report event phase of the TestStep mode, which is
+// config, import, or refresh.
+func (s TestStep) reportPhase() string {
+	switch {
+	case s.ImportState:
+		return reportPhaseImport
+	case s.RefreshState:
+		return reportPhaseRefresh
+	default:
+		return reportPhaseConfig
+	}
+}
+
+// testStepReporter wraps the testing.T of a TestStep subtest to capture
+// failure messages as diagnostics of the testReporter for the TestCase.
+type testStepReporter struct {
+	testing.T
+
+	reporter *testReporter
+}
+
+func (r *testStepReporter) Error(args ...interface{}) {
+	r.T.Helper()
+	r.reporter.addDiagnostic(fmt.Sprint(args...))
+	r.T.Error(args...)
+}
+
+func (r *testStepReporter) Errorf(format string, args ...interface{}) {
+	r.T.Helper()
+	r.reporter.addDiagnostic(fmt.Sprintf(format, args...))
+	r.T.Errorf(format, args...)
+}
+
+func (r *testStepReporter) Fatal(args ...interface{}) {
+	r.T.Helper()
+	r.reporter.addDiagnostic(fmt.Sprint(args...))
+	r.T.Fatal(args...)
+}
+
+func (r *testStepReporter) Fatalf(format string, args ...interface{}) {
+	r.T.Helper()
+	r.reporter.addDiagnostic(fmt.Sprintf(format, args...))
+	r.T.Fatalf(format, args...)
+}
+
+// write appends the event to the report file, if enabled. Errors are logged
+// rather than failing the test, as the report does not affect the test
+// result.
+func (r *testReporter) write(ctx context.Context, event reportEvent) {
+	if r.path == "" {
+		return
+	}
+
+	if err := writeReportEvent(r.path, event); err != nil {
+		logging.HelperResourceError(ctx,
+			"Unable to write test report event",
+			map[string]interface{}{logging.KeyError: err},
+		)
+	}
+}
+
+// writeReportEvent appends the event as a single line of JSON to the report
+// file.
+func writeReportEvent(reportPath string, event reportEvent) error {
+	line, err := json.Marshal(event)
+
+	if err != nil {
+		return fmt.Errorf("unable to encode report event: %w", err)
+	}
+
+	reportMutex.Lock()
+	defer reportMutex.Unlock()
+
+	f, err := os.OpenFile(reportPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return fmt.Errorf("unable to open report file: %w", err)
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write report event: %w", err)
+	}
+
+	return nil
+}
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is a TestCase in a JUnit XML report.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a TestStep in a JUnit XML report.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+// junitFailure is the failure of a TestStep in a JUnit XML report.
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr,omitempty"`
+	Contents string `xml:",chardata"`
+}
+
+// junitSkipped marks a skipped TestStep in a JUnit XML report.
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnitReport converts a report written to the file set by the
+// TF_ACC_REPORT_PATH environment variable into JUnit XML. Each TestCase is
+// written as a JUnit test suite and each TestStep as a JUnit test case. A
+// TestCase which failed outside of any TestStep, such as during the final
+// destroy, has an additional JUnit test case named TestCase with the failure.
+// The failure category of the report event is the JUnit failure type.
+// TestStep which failed with ExpectedFailure are written as skipped.
+// Only the events of the last attempt of a TestCase rerun after a failure
+// are written.
+//
+// The TestMain() function of this package calls WriteJUnitReport
+// automatically when the TF_ACC_REPORT_JUNIT_PATH environment variable is
+// also set.
+func WriteJUnitReport(w io.Writer, r io.Reader) error {
+	var suites []*junitTestSuite
+	suitesByName := make(map[string]*junitTestSuite)
+	stepFailures := make(map[string]bool)
+
+	var events []reportEvent
+
+	// The last attempt of each TestCase, so events of earlier attempts which
+	// were rerun are omitted.
+	lastAttempts := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var event reportEvent
+
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("unable to decode report event: %w", err)
+		}
+
+		if event.Attempt > lastAttempts[event.TestName] {
+			lastAttempts[event.TestName] = event.Attempt
+		}
+
+		events = append(events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read report: %w", err)
+	}
+
+	for _, event := range events {
+		if event.Attempt < lastAttempts[event.TestName] {
+			continue
+		}
+
+		suite, ok := suitesByName[event.TestName]
+
+		if !ok {
+			suite = &junitTestSuite{
+				Name: event.TestName,
+				Time: junitTime(0),
+			}
+			suitesByName[event.TestName] = suite
+			suites = append(suites, suite)
+		}
+
+		if event.Phase == reportPhaseTestCase {
+			suite.Time = junitTime(event.Duration)
+			suite.Timestamp = event.Timestamp.Format(time.RFC3339)
+
+			if event.Result != reportResultFail || stepFailures[event.TestName] {
+				continue
+			}
+		}
+
+		testCase := junitTestCase{
+			Name:      junitTestCaseName(event),
+			ClassName: event.TestName,
+			Time:      junitTime(event.Duration),
+		}
+
+		switch event.Result {
+		case reportResultFail:
+			stepFailures[event.TestName] = true
+			suite.Failures++
+
+			testCase.Failure = &junitFailure{
+				Message:  junitFailureMessage(event.Diagnostics),
+				Type:     event.FailureCategory,
+				Contents: strings.Join(event.Diagnostics, "\n\n"),
+			}
+		case reportResultSkip:
+			suite.Skipped++
+
+			testCase.Skipped = &junitSkipped{}
+		case reportResultExpectedFailure:
+			suite.Skipped++
+
+			testCase.Skipped = &junitSkipped{
+				Message: fmt.Sprintf("expected failure (%s)", event.ExpectedFailure),
+			}
+		}
+
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	report := junitTestSuites{
+		TestSuites: make([]junitTestSuite, 0, len(suites)),
+	}
+
+	for _, suite := range suites {
+		report.TestSuites = append(report.TestSuites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("unable to write JUnit report: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("unable to write JUnit report: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("unable to write JUnit report: %w", err)
+	}
+
+	return nil
+}
+
+// writeJUnitReportFile converts the report file into a JUnit XML file.
+func writeJUnitReportFile(reportPath string, junitPath string) error {
+	r, err := os.Open(reportPath)
+
+	if err != nil {
+		return fmt.Errorf("unable to open report file: %w", err)
+	}
+
+	defer r.Close()
+
+	w, err := os.Create(junitPath)
+
+	if err != nil {
+		return fmt.Errorf("unable to create JUnit report file: %w", err)
+	}
+
+	defer w.Close()
+
+	return WriteJUnitReport(w, r)
+}
+
+// junitTestCaseName returns the JUnit test case name of a report event, such
+// as "step 1 (config)", or TestCase for failures outside of any TestStep.
+func junitTestCaseName(event reportEvent) string {
+	if event.StepNumber == 0 {
+		return "TestCase"
+	}
+
+	return fmt.Sprintf("step %d (%s)", event.StepNumber, event.Phase)
+}
+
+// junitFailureMessage returns the first line of the first diagnostic.
+func junitFailureMessage(diagnostics []string) string {
+	if len(diagnostics) == 0 {
+		return "failed"
+	}
+
+	message, _, _ := strings.Cut(diagnostics[0], "\n")
+
+	return message
+}
+
+// junitTime formats a duration in seconds for JUnit XML.
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
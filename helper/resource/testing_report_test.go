@@ -0,0 +1,491 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestReporter(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	t.Setenv(EnvTfAccReportPath, reportPath)
+
+	ctx := context.Background()
+	reporter := newTestReporter(&mockT{}, false)
+
+	if reporter == nil {
+		t.Fatal("expected reporter")
+	}
+
+	reporter.randomSeed = 1234
+
+	reporter.startStep(1, TestStep{Config: "# not empty"})
+	reporter.endStep(ctx, reportResultPass)
+
+	reporter.startStep(2, TestStep{SkipFunc: func() (bool, error) { return true, nil }})
+	reporter.endStep(ctx, reportResultSkip)
+
+	reporter.startStep(3, TestStep{ImportState: true})
+	reporter.Errorf("Step %d/%d error running import: %s", 3, 3, "boom")
+	reporter.finish(ctx)
+
+	got := readReportEvents(t, reportPath)
+	expected := []reportEvent{
+		{
+			TestName:   "MockedName",
+			StepNumber: 1,
+			Phase:      reportPhaseConfig,
+			Result:     reportResultPass,
+		},
+		{
+			TestName:   "MockedName",
+			StepNumber: 2,
+			Phase:      reportPhaseConfig,
+			Result:     reportResultSkip,
+		},
+		{
+			TestName:        "MockedName",
+			StepNumber:      3,
+			Phase:           reportPhaseImport,
+			Result:          reportResultFail,
+			Diagnostics:     []string{"Step 3/3 error running import: boom"},
+			FailureCategory: reportFailureOther,
+		},
+		{
+			TestName:        "MockedName",
+			Phase:           reportPhaseTestCase,
+			Result:          reportResultFail,
+			Diagnostics:     []string{"Step 3/3 error running import: boom"},
+			FailureCategory: reportFailureOther,
+			RandomSeed:      1234,
+		},
+	}
+
+	if diff := cmp.Diff(got, expected, cmpopts.IgnoreFields(reportEvent{}, "Duration", "Timestamp")); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	for i, event := range got {
+		if event.Timestamp.IsZero() {
+			t.Errorf("event %d: expected timestamp", i)
+		}
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestReporter_WrapStep(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	t.Setenv(EnvTfAccReportPath, reportPath)
+
+	ctx := context.Background()
+	reporter := newTestReporter(&mockT{}, false)
+
+	if reporter == nil {
+		t.Fatal("expected reporter")
+	}
+
+	reporter.startStep(1, TestStep{Config: "# not empty"})
+	reporter.wrapStep(&mockT{}).Errorf("Step %d/%d error: %s", 1, 1, "boom")
+	reporter.endStep(ctx, reportResultPass)
+
+	got := readReportEvents(t, reportPath)
+	expected := []reportEvent{
+		{
+			TestName:        "MockedName",
+			StepNumber:      1,
+			Phase:           reportPhaseConfig,
+			Result:          reportResultFail,
+			Diagnostics:     []string{"Step 1/1 error: boom"},
+			FailureCategory: reportFailureOther,
+		},
+	}
+
+	if diff := cmp.Diff(got, expected, cmpopts.IgnoreFields(reportEvent{}, "Duration", "Timestamp")); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestReporter_AddWarning(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	t.Setenv(EnvTfAccReportPath, reportPath)
+
+	ctx := context.Background()
+	reporter := newTestReporter(&mockT{}, false)
+
+	if reporter == nil {
+		t.Fatal("expected reporter")
+	}
+
+	reporter.startStep(1, TestStep{Config: "# not empty"})
+	reporter.endStep(ctx, reportResultPass)
+	reporter.addWarning("Skipped final destroy: shared infrastructure")
+	reporter.finish(ctx)
+
+	got := readReportEvents(t, reportPath)
+	expected := []reportEvent{
+		{
+			TestName:   "MockedName",
+			StepNumber: 1,
+			Phase:      reportPhaseConfig,
+			Result:     reportResultPass,
+		},
+		{
+			TestName: "MockedName",
+			Phase:    reportPhaseTestCase,
+			Result:   reportResultPass,
+			Warnings: []string{"Skipped final destroy: shared infrastructure"},
+		},
+	}
+
+	if diff := cmp.Diff(got, expected, cmpopts.IgnoreFields(reportEvent{}, "Duration", "Timestamp")); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestReporter_Rerun(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	t.Setenv(EnvTfAccReportPath, reportPath)
+
+	ctx := context.Background()
+	reporter := newTestReporter(&mockT{}, false)
+
+	if reporter == nil {
+		t.Fatal("expected reporter")
+	}
+
+	reporter.attempt = 2
+	reporter.startStep(1, TestStep{Config: "# not empty"})
+	reporter.endStep(ctx, reportResultPass)
+	reporter.finish(ctx)
+
+	got := readReportEvents(t, reportPath)
+	expected := []reportEvent{
+		{
+			TestName:   "MockedName",
+			StepNumber: 1,
+			Phase:      reportPhaseConfig,
+			Result:     reportResultPass,
+			Attempt:    2,
+		},
+		{
+			TestName: "MockedName",
+			Phase:    reportPhaseTestCase,
+			Result:   reportResultFlakyPass,
+			Attempt:  2,
+		},
+	}
+
+	if diff := cmp.Diff(got, expected, cmpopts.IgnoreFields(reportEvent{}, "Duration", "Timestamp")); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestReporter_Disabled(t *testing.T) {
+	t.Setenv(EnvTfAccReportPath, "")
+
+	reporter := newTestReporter(&mockT{}, false)
+
+	if reporter != nil {
+		t.Fatal("expected no reporter")
+	}
+
+	// Methods are safe to call on a nil reporter.
+	reporter.startStep(1, TestStep{})
+	reporter.endStep(context.Background(), reportResultPass)
+	reporter.addWarning("warning")
+	reporter.finish(context.Background())
+
+	stepT := &mockT{}
+
+	if got := reporter.wrapStep(stepT); got != stepT {
+		t.Errorf("expected unwrapped testing.T, got %T", got)
+	}
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	report := strings.Join([]string{
+		`{"test_name":"TestAccPass","step_number":1,"phase":"config","result":"pass","duration":1.5,"timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccFail","step_number":1,"phase":"config","result":"pass","duration":1,"timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccFail","step_number":2,"phase":"import","result":"fail","duration":0.25,"diagnostics":["Step 2/2 error running import: boom\nmore detail"],"failure_category":"other","timestamp":"2023-01-01T00:00:02Z"}`,
+		`{"test_name":"TestAccFail","phase":"testcase","result":"fail","duration":2,"diagnostics":["Step 2/2 error running import: boom\nmore detail"],"timestamp":"2023-01-01T00:00:03Z"}`,
+		`{"test_name":"TestAccPass","phase":"testcase","result":"pass","duration":2,"timestamp":"2023-01-01T00:00:02Z"}`,
+		`{"test_name":"TestAccDestroy","step_number":1,"phase":"refresh","result":"skip","duration":0,"timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccDestroy","phase":"testcase","result":"fail","duration":3,"diagnostics":["Error running post-test destroy"],"timestamp":"2023-01-01T00:00:03Z"}`,
+		`{"test_name":"TestAccKnownBroken","step_number":1,"phase":"config","result":"expected-failure","expected_failure":"https://example.com/issues/123","duration":1,"diagnostics":["Step 1/2 error: boom"],"timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccKnownBroken","phase":"testcase","result":"skip","duration":2,"timestamp":"2023-01-01T00:00:02Z"}`,
+		``,
+	}, "\n")
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="TestAccPass" tests="1" failures="0" skipped="0" time="2.000" timestamp="2023-01-01T00:00:02Z">
+    <testcase name="step 1 (config)" classname="TestAccPass" time="1.500"></testcase>
+  </testsuite>
+  <testsuite name="TestAccFail" tests="2" failures="1" skipped="0" time="2.000" timestamp="2023-01-01T00:00:03Z">
+    <testcase name="step 1 (config)" classname="TestAccFail" time="1.000"></testcase>
+    <testcase name="step 2 (import)" classname="TestAccFail" time="0.250">
+      <failure message="Step 2/2 error running import: boom" type="other">Step 2/2 error running import: boom&#xA;more detail</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="TestAccDestroy" tests="2" failures="1" skipped="1" time="3.000" timestamp="2023-01-01T00:00:03Z">
+    <testcase name="step 1 (refresh)" classname="TestAccDestroy" time="0.000">
+      <skipped></skipped>
+    </testcase>
+    <testcase name="TestCase" classname="TestAccDestroy" time="3.000">
+      <failure message="Error running post-test destroy">Error running post-test destroy</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="TestAccKnownBroken" tests="1" failures="0" skipped="1" time="2.000" timestamp="2023-01-01T00:00:02Z">
+    <testcase name="step 1 (config)" classname="TestAccKnownBroken" time="1.000">
+      <skipped message="expected failure (https://example.com/issues/123)"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+	var got bytes.Buffer
+
+	if err := WriteJUnitReport(&got, strings.NewReader(report)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(got.String(), expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestWriteJUnitReport_Rerun(t *testing.T) {
+	t.Parallel()
+
+	report := strings.Join([]string{
+		`{"test_name":"TestAccFlaky","step_number":1,"phase":"config","result":"fail","attempt":1,"duration":1,"diagnostics":["Step 1/1 error: timeout while waiting"],"failure_category":"timeout","timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccFlaky","phase":"testcase","result":"fail","attempt":1,"duration":1,"diagnostics":["Step 1/1 error: timeout while waiting"],"failure_category":"timeout","timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccFlaky","step_number":1,"phase":"config","result":"pass","attempt":2,"duration":1.5,"timestamp":"2023-01-01T00:00:03Z"}`,
+		`{"test_name":"TestAccFlaky","phase":"testcase","result":"flaky-pass","attempt":2,"duration":2,"timestamp":"2023-01-01T00:00:03Z"}`,
+		`{"test_name":"TestAccFail","step_number":1,"phase":"config","result":"fail","attempt":1,"duration":1,"diagnostics":["Check failed: boom"],"failure_category":"check_failure","timestamp":"2023-01-01T00:00:01Z"}`,
+		`{"test_name":"TestAccFail","phase":"testcase","result":"fail","attempt":1,"duration":1,"diagnostics":["Check failed: boom"],"failure_category":"check_failure","timestamp":"2023-01-01T00:00:01Z"}`,
+		``,
+	}, "\n")
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="TestAccFlaky" tests="1" failures="0" skipped="0" time="2.000" timestamp="2023-01-01T00:00:03Z">
+    <testcase name="step 1 (config)" classname="TestAccFlaky" time="1.500"></testcase>
+  </testsuite>
+  <testsuite name="TestAccFail" tests="1" failures="1" skipped="0" time="1.000" timestamp="2023-01-01T00:00:01Z">
+    <testcase name="step 1 (config)" classname="TestAccFail" time="1.000">
+      <failure message="Check failed: boom" type="check_failure">Check failed: boom</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+	var got bytes.Buffer
+
+	if err := WriteJUnitReport(&got, strings.NewReader(report)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(got.String(), expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestWriteJUnitReport_InvalidEvent(t *testing.T) {
+	t.Parallel()
+
+	err := WriteJUnitReport(&bytes.Buffer{}, strings.NewReader("not json\n"))
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(err.Error(), "unable to decode report event") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func readReportEvents(t *testing.T, reportPath string) []reportEvent {
+	t.Helper()
+
+	f, err := os.Open(reportPath)
+
+	if err != nil {
+		t.Fatalf("unable to open report: %s", err)
+	}
+
+	defer f.Close()
+
+	var events []reportEvent
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var event reportEvent
+
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("unable to decode report event: %s", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestReporter_CaptureFailures(t *testing.T) {
+	t.Setenv(EnvTfAccReportPath, "")
+
+	if reporter := newTestReporter(&mockT{}, false); reporter != nil {
+		t.Fatal("expected no reporter")
+	}
+
+	reporter := newTestReporter(&mockT{}, true)
+
+	if reporter == nil {
+		t.Fatal("expected reporter")
+	}
+
+	reporter.startStep(1, TestStep{ImportState: true})
+	reporter.addDiagnostic("Step 1/1 error running import: boom")
+
+	if diff := cmp.Diff(reporter.stepDiagnostics(), []string{"Step 1/1 error running import: boom"}); diff != "" {
+		t.Errorf("unexpected step diagnostics difference: %s", diff)
+	}
+
+	// Report events are not written without a report path.
+	reporter.finish(context.Background())
+
+	if got := reporter.stepDiagnostics(); got != nil {
+		t.Errorf("expected no step diagnostics after finish, got %q", got)
+	}
+}
+
+func TestClassifyFailure(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diagnostics []string
+		expected    string
+	}{
+		"check": {
+			diagnostics: []string{"Check failed: Check 1/1 error: example_widget.test: Attribute 'name' expected \"a\", got \"b\""},
+			expected:    reportFailureCheck,
+		},
+		"first-matching-diagnostic": {
+			diagnostics: []string{
+				"Step 1/1 error: unexpected",
+				"Step 1/1 error: Error running apply: context deadline exceeded",
+				"Post-apply state check(s) failed:\nexample_widget.test - value mismatch",
+			},
+			expected: reportFailureTimeout,
+		},
+		"import-verify": {
+			diagnostics: []string{"ImportStateVerify attributes not equivalent. Difference is shown below."},
+			expected:    reportFailureCheck,
+		},
+		"init": {
+			diagnostics: []string{"Error running init: exit status 1\n\nError: Failed to query available provider packages"},
+			expected:    reportFailureInitNetwork,
+		},
+		"network-timeout": {
+			diagnostics: []string{"Step 1/1 error: Post \"https://example.com\": dial tcp 192.0.2.1:443: i/o timeout"},
+			expected:    reportFailureInitNetwork,
+		},
+		"non-empty-plan": {
+			diagnostics: []string{"After applying this test step, the plan was not empty.\nstdout:\n\n"},
+			expected:    reportFailureNonEmptyPlan,
+		},
+		"none": {
+			expected: reportFailureOther,
+		},
+		"other": {
+			diagnostics: []string{"Step 1/1 error: Error: Missing required argument"},
+			expected:    reportFailureOther,
+		},
+		"plan-check": {
+			diagnostics: []string{"Pre-apply plan check(s) failed:\nexample_widget.test - expected Create, got action(s): [update]"},
+			expected:    reportFailureCheck,
+		},
+		"provider-inconsistency": {
+			diagnostics: []string{"Step 1/1 error: Error running apply: exit status 1\n\nError: Provider produced inconsistent result after apply"},
+			expected:    reportFailureProviderInconsistency,
+		},
+		"timeout": {
+			diagnostics: []string{"Step 1/1 error: Error running apply: exit status 1\n\nError: timeout while waiting for state to become 'available'"},
+			expected:    reportFailureTimeout,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := classifyFailure(testCase.diagnostics)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestTestStepReportPhase(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testStep TestStep
+		expected string
+	}{
+		"config": {
+			testStep: TestStep{Config: "# not empty"},
+			expected: reportPhaseConfig,
+		},
+		"import": {
+			testStep: TestStep{ImportState: true},
+			expected: reportPhaseImport,
+		},
+		"refresh": {
+			testStep: TestStep{RefreshState: true},
+			expected: reportPhaseRefresh,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testStep.reportPhase()
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
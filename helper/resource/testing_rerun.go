@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/go-testing-interface"
+)
+
+// rerunFailedCategories returns the failure categories of a TestCase which
+// are rerun, as set by the TF_ACC_RERUN_FAILED environment variable, or nil
+// if failures are not rerun.
+func rerunFailedCategories() (map[string]bool, error) {
+	value := os.Getenv(EnvTfAccRerunFailed)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	categories := make(map[string]bool)
+
+	for _, c := range reportFailureCategories {
+		categories[c.category] = value == "1"
+	}
+
+	categories[reportFailureOther] = value == "1"
+
+	if value == "1" {
+		return categories, nil
+	}
+
+	for _, category := range strings.Split(value, ",") {
+		category = strings.TrimSpace(category)
+
+		if _, ok := categories[category]; !ok {
+			return nil, fmt.Errorf("unknown failure category %q", category)
+		}
+
+		categories[category] = true
+	}
+
+	return categories, nil
+}
+
+// runWithRerun runs the given function of a TestCase with a testing.T which
+// records failures instead of failing the test. If the first attempt fails
+// in one of the given failure categories, the function is run again with the
+// testing.T of the test, otherwise the recorded failures are reported. The
+// attempt number is passed to the function for reporting.
+func runWithRerun(t testing.T, categories map[string]bool, f func(t testing.T, attempt int)) {
+	t.Helper()
+
+	attempt := &testAttemptT{T: t}
+
+	// Failing an attempt exits its goroutine, as the testing.T of the test
+	// would, without ending the test.
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		f(attempt, 1)
+	}()
+
+	<-done
+
+	// The testing.T of the test may have been failed directly, such as by a
+	// TestCheckFunc with its own reference.
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	attempt.mu.Lock()
+	failures := attempt.failures
+	failed := attempt.failed
+	skipped := attempt.skipped
+	skipMessage := attempt.skipMessage
+	attempt.mu.Unlock()
+
+	if !failed {
+		if skipped {
+			t.Skip(skipMessage)
+		}
+
+		return
+	}
+
+	category := classifyFailure(failures)
+
+	if !categories[category] {
+		for _, failure := range failures {
+			t.Error(failure)
+		}
+
+		t.FailNow()
+	}
+
+	t.Logf("TestCase failed with %s failure, rerunning due to %s:\n\n%s", category, EnvTfAccRerunFailed, strings.Join(failures, "\n\n"))
+
+	f(t, 2)
+}
+
+var _ testing.T = &testAttemptT{}
+
+// testAttemptT wraps the testing.T of a test to record the failures and skip
+// of an attempt of a TestCase, which may be rerun, rather than ending the
+// test. Other calls, such as logging, are passed to the testing.T.
+type testAttemptT struct {
+	testing.T
+
+	mu          sync.Mutex
+	failed      bool
+	failures    []string
+	skipped     bool
+	skipMessage string
+}
+
+func (t *testAttemptT) Error(args ...interface{}) {
+	t.addFailure(fmt.Sprint(args...))
+}
+
+func (t *testAttemptT) Errorf(format string, args ...interface{}) {
+	t.addFailure(fmt.Sprintf(format, args...))
+}
+
+func (t *testAttemptT) Fail() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failed = true
+}
+
+func (t *testAttemptT) FailNow() {
+	t.Fail()
+	runtime.Goexit()
+}
+
+func (t *testAttemptT) Failed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.failed
+}
+
+func (t *testAttemptT) Fatal(args ...interface{}) {
+	t.addFailure(fmt.Sprint(args...))
+	runtime.Goexit()
+}
+
+func (t *testAttemptT) Fatalf(format string, args ...interface{}) {
+	t.addFailure(fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func (t *testAttemptT) Skip(args ...interface{}) {
+	t.skip(fmt.Sprint(args...))
+}
+
+func (t *testAttemptT) SkipNow() {
+	t.skip("")
+}
+
+func (t *testAttemptT) Skipf(format string, args ...interface{}) {
+	t.skip(fmt.Sprintf(format, args...))
+}
+
+func (t *testAttemptT) Skipped() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.skipped
+}
+
+// addFailure saves a failure message and marks the attempt as failed.
+func (t *testAttemptT) addFailure(failure string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failed = true
+	t.failures = append(t.failures, failure)
+}
+
+// skip saves the skip message and exits the attempt.
+func (t *testAttemptT) skip(message string) {
+	t.mu.Lock()
+	t.skipped = true
+	t.skipMessage = message
+	t.mu.Unlock()
+
+	runtime.Goexit()
+}
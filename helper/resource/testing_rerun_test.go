@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	testinginterface "github.com/mitchellh/go-testing-interface"
+)
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestRerunFailedCategories(t *testing.T) {
+	testCases := map[string]struct {
+		value         string
+		expected      map[string]bool
+		expectedError error
+	}{
+		"disabled": {
+			value: "",
+		},
+		"all": {
+			value: "1",
+			expected: map[string]bool{
+				reportFailureCheck:                 true,
+				reportFailureInitNetwork:           true,
+				reportFailureNonEmptyPlan:          true,
+				reportFailureOther:                 true,
+				reportFailureProviderInconsistency: true,
+				reportFailureTimeout:               true,
+			},
+		},
+		"categories": {
+			value: "timeout, init_network",
+			expected: map[string]bool{
+				reportFailureCheck:                 false,
+				reportFailureInitNetwork:           true,
+				reportFailureNonEmptyPlan:          false,
+				reportFailureOther:                 false,
+				reportFailureProviderInconsistency: false,
+				reportFailureTimeout:               true,
+			},
+		},
+		"unknown-category": {
+			value:         "timeout,flaky",
+			expectedError: fmt.Errorf("unknown failure category \"flaky\""),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvTfAccRerunFailed, testCase.value)
+
+			got, err := rerunFailedCategories()
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRunWithRerun(t *testing.T) {
+	t.Parallel()
+
+	categories := map[string]bool{
+		reportFailureTimeout: true,
+	}
+
+	testCases := map[string]struct {
+		attempts         func(t testinginterface.T, attempt int)
+		expectedAttempts []int
+		expectedFailed   bool
+		expectedSkipped  bool
+	}{
+		"pass": {
+			attempts:         func(t testinginterface.T, attempt int) {},
+			expectedAttempts: []int{1},
+		},
+		"skip": {
+			attempts: func(t testinginterface.T, attempt int) {
+				t.Skip("skipped")
+				t.Fatal("not reached")
+			},
+			expectedAttempts: []int{1},
+			expectedSkipped:  true,
+		},
+		"rerun-pass": {
+			attempts: func(t testinginterface.T, attempt int) {
+				if attempt == 1 {
+					t.Fatalf("Step 1/1 error: %s", "context deadline exceeded")
+				}
+			},
+			expectedAttempts: []int{1, 2},
+		},
+		"rerun-fail": {
+			attempts: func(t testinginterface.T, attempt int) {
+				t.Errorf("Step 1/1 error: %s", "context deadline exceeded")
+			},
+			expectedAttempts: []int{1, 2},
+			expectedFailed:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mt := &mockT{}
+
+			var attempts []int
+
+			runWithRerun(mt, categories, func(t testinginterface.T, attempt int) {
+				attempts = append(attempts, attempt)
+				testCase.attempts(t, attempt)
+			})
+
+			if diff := cmp.Diff(attempts, testCase.expectedAttempts); diff != "" {
+				t.Errorf("unexpected attempts difference: %s", diff)
+			}
+
+			if mt.Failed() != testCase.expectedFailed {
+				t.Errorf("expected failed %t, got %t", testCase.expectedFailed, mt.Failed())
+			}
+
+			if mt.Skipped() != testCase.expectedSkipped {
+				t.Errorf("expected skipped %t, got %t", testCase.expectedSkipped, mt.Skipped())
+			}
+		})
+	}
+}
+
+func TestRunWithRerun_NotEligible(t *testing.T) {
+	t.Parallel()
+
+	mt := &mockT{}
+
+	var attempts []int
+
+	testExpectTFatal(t, func() {
+		runWithRerun(mt, map[string]bool{reportFailureTimeout: true}, func(t testinginterface.T, attempt int) {
+			attempts = append(attempts, attempt)
+			t.Fatal("After applying this test step, the plan was not empty.")
+		})
+	})
+
+	if diff := cmp.Diff(attempts, []int{1}); diff != "" {
+		t.Errorf("unexpected attempts difference: %s", diff)
+	}
+
+	if !mt.Failed() {
+		t.Error("expected failed")
+	}
+}
@@ -8,6 +8,7 @@ package resource
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -160,6 +161,203 @@ func TestMatchTypeSetElemNestedAttrs(name, attr string, values map[string]*regex
 	}
 }
 
+// TestCheckTypeSetElems ensures the elements of a list or set block stored in
+// state for the given name and key combination are exactly the given elements,
+// in any order. Each element is compared by its nested attribute values rather
+// than its index, so the check is not affected by set element hashing order.
+// State value checking is only recommended for testing Computed attributes and
+// attribute defaults.
+//
+// For managed resources, the name parameter is a combination of the resource
+// type, a period (.), and the name label. The name for the below example
+// configuration would be "myprovider_thing.example".
+//
+//	resource "myprovider_thing" "example" { ... }
+//
+// For data sources, the name parameter is a combination of the keyword "data",
+// a period (.), the data source type, a period (.), and the name label. The
+// name for the below example configuration would be
+// "data.myprovider_thing.example".
+//
+//	data "myprovider_thing" "example" { ... }
+//
+// The key parameter is an attribute path in Terraform CLI 0.11 and earlier
+// "flatmap" syntax. Keys start with the attribute name of a top-level
+// attribute and must end with the sentinel value '*' for the element index.
+// Earlier list or set indexes in the key may also use the sentinel value, in
+// which case the elements of all matching lists or sets are compared together.
+//
+// The values parameter contains a map of nested attribute names to attribute
+// values for each expected element. The check fails unless the number of
+// elements in state equals the number of expected elements, and each expected
+// element matches a different element in state. An element in state matches
+// if it contains all of the expected nested attribute values, so attributes
+// which are not relevant to the test can be omitted. An expected empty string
+// value matches both an unset and an empty nested attribute.
+//
+//	[]map[string]string{
+//	  {"name": "first", "port": "80"},
+//	  {"name": "second", "port": "443"},
+//	}
+func TestCheckTypeSetElems(name, attr string, values []map[string]string) TestCheckFunc {
+	return func(s *terraform.State) error {
+		is, err := primaryInstanceState(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testCheckTypeSetElems(is, name, attr, len(values), func(expectedIndex int, element map[string]string) bool {
+			for key, value := range values[expectedIndex] {
+				if element[key] != value {
+					return false
+				}
+			}
+
+			return true
+		}, func(expectedIndex int) string {
+			return fmt.Sprintf("%#v", values[expectedIndex])
+		})
+	}
+}
+
+// TestMatchTypeSetElems ensures the elements of a list or set block stored in
+// state for the given name and key combination are exactly the given elements,
+// in any order, comparing nested attribute values by regular expressions. It
+// otherwise behaves the same as TestCheckTypeSetElems, with a nil regular
+// expression matching both an unset and an empty nested attribute.
+//
+//	[]map[string]*regexp.Regexp{
+//	  {"name": regexp.MustCompile(`^first`)},
+//	  {"name": regexp.MustCompile(`^second`)},
+//	}
+func TestMatchTypeSetElems(name, attr string, values []map[string]*regexp.Regexp) TestCheckFunc {
+	return func(s *terraform.State) error {
+		is, err := primaryInstanceState(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testCheckTypeSetElems(is, name, attr, len(values), func(expectedIndex int, element map[string]string) bool {
+			for key, r := range values[expectedIndex] {
+				if r == nil {
+					if element[key] != "" {
+						return false
+					}
+
+					continue
+				}
+
+				if !r.MatchString(element[key]) {
+					return false
+				}
+			}
+
+			return true
+		}, func(expectedIndex int) string {
+			return fmt.Sprintf("%v", values[expectedIndex])
+		})
+	}
+}
+
+// testCheckTypeSetElems verifies the number of list or set elements in state
+// matches the number of expected elements and that each expected element,
+// according to the matches function, can be paired with a different element
+// in state. The describe function returns the expected element for errors.
+func testCheckTypeSetElems(is *terraform.InstanceState, name string, attr string, expectedCount int, matches func(int, map[string]string) bool, describe func(int) string) error {
+	attrParts := strings.Split(attr, ".")
+	if attrParts[len(attrParts)-1] != sentinelIndex {
+		return fmt.Errorf("%q does not end with the special value %q", attr, sentinelIndex)
+	}
+
+	elements := testTypeSetElemsInState(is, attrParts)
+
+	if len(elements) != expectedCount {
+		return fmt.Errorf("%q expected %d TypeSet elements %q, got %d in state: %#v", name, expectedCount, attr, len(elements), is.Attributes)
+	}
+
+	ids := make([]string, 0, len(elements))
+
+	for id := range elements {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	// Pair each expected element with a different element in state, using
+	// augmenting paths so an element matching multiple expected elements
+	// does not prevent a complete pairing.
+	pairedExpected := make(map[string]int, len(ids))
+
+	var pair func(expectedIndex int, visited map[string]bool) bool
+
+	pair = func(expectedIndex int, visited map[string]bool) bool {
+		for _, id := range ids {
+			if visited[id] || !matches(expectedIndex, elements[id]) {
+				continue
+			}
+
+			visited[id] = true
+
+			otherIndex, paired := pairedExpected[id]
+
+			if !paired || pair(otherIndex, visited) {
+				pairedExpected[id] = expectedIndex
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for expectedIndex := 0; expectedIndex < expectedCount; expectedIndex++ {
+		if !pair(expectedIndex, make(map[string]bool)) {
+			return fmt.Errorf("%q no TypeSet element %q, with nested attrs %s, distinct from other expected elements in state: %#v", name, attr, describe(expectedIndex), is.Attributes)
+		}
+	}
+
+	return nil
+}
+
+// testTypeSetElemsInState returns the nested attribute values of each list or
+// set element in state matching the attribute path, by the element flatmap
+// address.
+func testTypeSetElemsInState(is *terraform.InstanceState, attrParts []string) map[string]map[string]string {
+	elements := make(map[string]map[string]string)
+
+	for stateKey, stateValue := range is.Attributes {
+		stateKeyParts := strings.Split(stateKey, ".")
+
+		if len(stateKeyParts) <= len(attrParts) {
+			continue
+		}
+
+		var pathMatch bool
+		for i := range attrParts {
+			if attrParts[i] != stateKeyParts[i] && attrParts[i] != sentinelIndex {
+				break
+			}
+			if i == len(attrParts)-1 {
+				pathMatch = true
+			}
+		}
+		if !pathMatch {
+			continue
+		}
+
+		id := strings.Join(stateKeyParts[:len(attrParts)], ".")
+		nestedAttr := strings.Join(stateKeyParts[len(attrParts):], ".")
+
+		if _, ok := elements[id]; !ok {
+			elements[id] = make(map[string]string)
+		}
+
+		elements[id][nestedAttr] = stateValue
+	}
+
+	return elements
+}
+
 // TestCheckTypeSetElemAttr is a TestCheckFunc that accepts a resource
 // name, an attribute path, which should use the sentinel value '*' for indexing
 // into a TypeSet. The function verifies that an element matches the provided
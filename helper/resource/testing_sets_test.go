@@ -2619,3 +2619,189 @@ func TestTestCheckTypeSetElemNestedAttrs(t *testing.T) {
 		})
 	}
 }
+
+func TestTestCheckTypeSetElems(t *testing.T) {
+	t.Parallel()
+
+	state := testTypeSetElemsState(map[string]string{
+		"id":                     "11111",
+		"test.#":                 "3",
+		"test.1234.name":         "first",
+		"test.1234.port":         "80",
+		"test.5678.name":         "second",
+		"test.5678.port":         "443",
+		"test.9012.name":         "second",
+		"test.9012.port":         "8443",
+		"nested.#":               "2",
+		"nested.0.inner.#":       "1",
+		"nested.0.inner.1.value": "a",
+		"nested.1.inner.#":       "1",
+		"nested.1.inner.2.value": "b",
+	})
+
+	testCases := map[string]struct {
+		attr          string
+		values        []map[string]string
+		expectedError string
+	}{
+		"exact": {
+			attr: "test.*",
+			values: []map[string]string{
+				{"name": "second", "port": "8443"},
+				{"name": "first", "port": "80"},
+				{"name": "second", "port": "443"},
+			},
+		},
+		"subset-attributes": {
+			attr: "test.*",
+			values: []map[string]string{
+				{"name": "second"},
+				{"name": "first"},
+				{"port": "443"},
+			},
+		},
+		"multiple-sentinels": {
+			attr: "nested.*.inner.*",
+			values: []map[string]string{
+				{"value": "b"},
+				{"value": "a"},
+			},
+		},
+		"no-sentinel": {
+			attr:          "test",
+			values:        []map[string]string{},
+			expectedError: `"test" does not end with the special value "*"`,
+		},
+		"too-few-expected": {
+			attr: "test.*",
+			values: []map[string]string{
+				{"name": "first"},
+				{"name": "second"},
+			},
+			expectedError: `"example_thing.test" expected 2 TypeSet elements "test.*", got 3 in state`,
+		},
+		"duplicate-expected": {
+			attr: "test.*",
+			values: []map[string]string{
+				{"name": "first"},
+				{"name": "first"},
+				{"name": "second"},
+			},
+			expectedError: `"example_thing.test" no TypeSet element "test.*", with nested attrs map[string]string{"name":"first"}, distinct from other expected elements in state`,
+		},
+		"value-mismatch": {
+			attr: "test.*",
+			values: []map[string]string{
+				{"name": "first"},
+				{"name": "second"},
+				{"name": "third"},
+			},
+			expectedError: `"example_thing.test" no TypeSet element "test.*", with nested attrs map[string]string{"name":"third"}, distinct from other expected elements in state`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := TestCheckTypeSetElems("example_thing.test", testCase.attr, testCase.values)(state)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got: %s", testCase.expectedError, err)
+				}
+			}
+
+			if err == nil && testCase.expectedError != "" {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestTestMatchTypeSetElems(t *testing.T) {
+	t.Parallel()
+
+	state := testTypeSetElemsState(map[string]string{
+		"id":             "11111",
+		"test.#":         "2",
+		"test.1234.name": "first-example",
+		"test.1234.note": "",
+		"test.5678.name": "second-example",
+	})
+
+	testCases := map[string]struct {
+		values        []map[string]*regexp.Regexp
+		expectedError string
+	}{
+		"match": {
+			values: []map[string]*regexp.Regexp{
+				{"name": regexp.MustCompile(`^second`)},
+				{"name": regexp.MustCompile(`^first`), "note": nil},
+			},
+		},
+		"mismatch": {
+			values: []map[string]*regexp.Regexp{
+				{"name": regexp.MustCompile(`^first`)},
+				{"name": regexp.MustCompile(`^third`)},
+			},
+			expectedError: `"example_thing.test" no TypeSet element "test.*", with nested attrs map[name:^third], distinct from other expected elements in state`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := TestMatchTypeSetElems("example_thing.test", "test.*", testCase.values)(state)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got: %s", testCase.expectedError, err)
+				}
+			}
+
+			if err == nil && testCase.expectedError != "" {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func testTypeSetElemsState(attributes map[string]string) *terraform.State {
+	return &terraform.State{
+		Version: 3,
+		Modules: []*terraform.ModuleState{
+			{
+				Path:    []string{"root"},
+				Outputs: map[string]*terraform.OutputState{},
+				Resources: map[string]*terraform.ResourceState{
+					"example_thing.test": {
+						Type:     "example_thing",
+						Provider: "example",
+						Primary: &terraform.InstanceState{
+							ID: "11111",
+							Meta: map[string]interface{}{
+								"schema_version": 0,
+							},
+							Attributes: attributes,
+						},
+					},
+				},
+				Dependencies: []string{},
+			},
+		},
+	}
+}
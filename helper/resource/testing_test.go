@@ -4,6 +4,7 @@
 package resource
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -90,6 +91,29 @@ func TestParallelTest(t *testing.T) {
 	}
 }
 
+func TestTestWithContext_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testExpectTFatal(t, func() {
+		TestWithContext(ctx, new(mockT), TestCase{
+			IsUnitTest: true,
+			ProviderFactories: map[string]func() (*schema.Provider, error){
+				"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+					return nil, nil
+				},
+			},
+			Steps: []TestStep{
+				{
+					Config: "# not empty",
+				},
+			},
+		})
+	})
+}
+
 func TestComposeAggregateTestCheckFunc(t *testing.T) {
 	t.Parallel()
 
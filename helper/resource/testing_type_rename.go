@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TypeRenameCase is a single set of tests to verify that resources created
+// with a resource type of a previous provider release, such as the last
+// release of the previous major version, are moved to the renamed resource
+// type of the provider under test with a moved configuration block.
+//
+// The PreviousConfig is first applied with PreviousProvider. The
+// CurrentConfig, with a generated moved block from PreviousAddress to
+// CurrentAddress, is then applied with CurrentProvider, which must move the
+// resource without creating, replacing, or destroying it.
+//
+// Moving resources across resource types requires Terraform 1.8.0 or later.
+// The TestCase is skipped with earlier Terraform versions.
+type TypeRenameCase struct {
+	// IsUnitTest and PreCheck have the same meaning as the TestCase fields
+	// of the same name.
+	IsUnitTest bool
+	PreCheck   func()
+
+	// TerraformVersionChecks is a list of checks which are run against the
+	// version of the Terraform CLI running the test, after the Terraform
+	// version check of moving resources across resource types.
+	TerraformVersionChecks []tfversion.TerraformVersionCheck
+
+	// PreviousProvider is the provider release with the previous resource
+	// type name, typically ExternalProviders with a VersionConstraint.
+	PreviousProvider MigrationProvider
+
+	// CurrentProvider is the provider under test with the renamed resource
+	// type, typically ProtoV5ProviderFactories or ProtoV6ProviderFactories.
+	CurrentProvider MigrationProvider
+
+	// CheckDestroy and ErrorCheck have the same meaning as the TestCase
+	// fields of the same name.
+	CheckDestroy TestCheckFunc
+	ErrorCheck   ErrorCheckFunc
+
+	// PreviousConfig is the configuration which creates the resource with
+	// the previous resource type name.
+	PreviousConfig string
+
+	// PreviousAddress is the resource address in PreviousConfig, such as
+	// "examplecloud_thing.test".
+	PreviousAddress string
+
+	// PreviousConfigStateChecks are run after applying PreviousConfig.
+	PreviousConfigStateChecks []statecheck.StateCheck
+
+	// CurrentConfig is the configuration which declares the resource with
+	// the renamed resource type. It must not contain the moved block, which
+	// is generated from PreviousAddress and CurrentAddress.
+	CurrentConfig string
+
+	// CurrentAddress is the resource address in CurrentConfig, such as
+	// "examplecloud_widget.test".
+	CurrentAddress string
+
+	// ConfigStateChecks are run after applying CurrentConfig, such as to
+	// verify that attribute values were preserved by the move.
+	ConfigStateChecks []statecheck.StateCheck
+}
+
+// TestTypeRename performs an acceptance test verifying that the resource
+// created with TypeRenameCase PreviousProvider is moved to the renamed
+// resource type of CurrentProvider. The case is run as two TestStep in a
+// single TestCase: the first applies PreviousConfig with PreviousProvider,
+// and the second applies CurrentConfig with a moved block with
+// CurrentProvider, failing if the resource is planned to be created,
+// replaced, or destroyed instead of moved.
+//
+// Test() function requirements and documentation also apply to this function.
+func TestTypeRename(t testing.T, c TypeRenameCase) {
+	t.Helper()
+
+	if err := c.validate(); err != nil {
+		t.Fatalf("Test validation error: %s", err)
+	}
+
+	Test(t, c.testCase())
+}
+
+// validate ensures the TypeRenameCase is valid based on the following
+// criteria:
+//
+//   - PreviousProvider and CurrentProvider are set.
+//   - PreviousConfig and CurrentConfig are set.
+//   - PreviousAddress and CurrentAddress are set and different.
+func (c TypeRenameCase) validate() error {
+	if c.PreviousProvider.isEmpty() {
+		return fmt.Errorf("TypeRenameCase missing PreviousProvider")
+	}
+
+	if c.CurrentProvider.isEmpty() {
+		return fmt.Errorf("TypeRenameCase missing CurrentProvider")
+	}
+
+	if c.PreviousConfig == "" {
+		return fmt.Errorf("TypeRenameCase missing PreviousConfig")
+	}
+
+	if c.CurrentConfig == "" {
+		return fmt.Errorf("TypeRenameCase missing CurrentConfig")
+	}
+
+	if c.PreviousAddress == "" {
+		return fmt.Errorf("TypeRenameCase missing PreviousAddress")
+	}
+
+	if c.CurrentAddress == "" {
+		return fmt.Errorf("TypeRenameCase missing CurrentAddress")
+	}
+
+	if c.PreviousAddress == c.CurrentAddress {
+		return fmt.Errorf("TypeRenameCase PreviousAddress and CurrentAddress must be different, got: %s", c.PreviousAddress)
+	}
+
+	return nil
+}
+
+// testCase returns the TestCase which applies PreviousConfig with
+// PreviousProvider, followed by a TestStep which moves the resource with
+// CurrentProvider.
+func (c TypeRenameCase) testCase() TestCase {
+	previousStep := TestStep{
+		Config:                   c.PreviousConfig,
+		ConfigStateChecks:        c.PreviousConfigStateChecks,
+		ExternalProviders:        c.PreviousProvider.ExternalProviders,
+		ProviderFactories:        c.PreviousProvider.ProviderFactories,
+		ProtoV5ProviderFactories: c.PreviousProvider.ProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: c.PreviousProvider.ProtoV6ProviderFactories,
+	}
+
+	currentStep := moveResourceStateStep(c.CurrentConfig, c.PreviousAddress, c.CurrentAddress, c.ConfigStateChecks)
+	currentStep.ExternalProviders = c.CurrentProvider.ExternalProviders
+	currentStep.ProviderFactories = c.CurrentProvider.ProviderFactories
+	currentStep.ProtoV5ProviderFactories = c.CurrentProvider.ProtoV5ProviderFactories
+	currentStep.ProtoV6ProviderFactories = c.CurrentProvider.ProtoV6ProviderFactories
+
+	return TestCase{
+		IsUnitTest: c.IsUnitTest,
+		PreCheck:   c.PreCheck,
+		TerraformVersionChecks: append(
+			[]tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_8_0)},
+			c.TerraformVersionChecks...,
+		),
+		CheckDestroy: c.CheckDestroy,
+		ErrorCheck:   c.ErrorCheck,
+		Steps:        []TestStep{previousStep, currentStep},
+	}
+}
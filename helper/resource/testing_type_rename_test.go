@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestTypeRenameCaseValidate(t *testing.T) {
+	t.Parallel()
+
+	previousProvider := MigrationProvider{
+		ExternalProviders: map[string]ExternalProvider{
+			"test": {}, // does not need to be real
+		},
+	}
+	currentProvider := MigrationProvider{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"test": nil, // does not need to be real
+		},
+	}
+
+	testCases := map[string]struct {
+		typeRenameCase TypeRenameCase
+		expectedError  error
+	}{
+		"valid": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				CurrentProvider:  currentProvider,
+				PreviousConfig:   "# not empty",
+				PreviousAddress:  "test_old.one",
+				CurrentConfig:    "# not empty",
+				CurrentAddress:   "test_new.one",
+			},
+		},
+		"previousprovider-missing": {
+			typeRenameCase: TypeRenameCase{
+				CurrentProvider: currentProvider,
+				PreviousConfig:  "# not empty",
+				PreviousAddress: "test_old.one",
+				CurrentConfig:   "# not empty",
+				CurrentAddress:  "test_new.one",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase missing PreviousProvider"),
+		},
+		"currentprovider-missing": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				PreviousConfig:   "# not empty",
+				PreviousAddress:  "test_old.one",
+				CurrentConfig:    "# not empty",
+				CurrentAddress:   "test_new.one",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase missing CurrentProvider"),
+		},
+		"previousconfig-missing": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				CurrentProvider:  currentProvider,
+				PreviousAddress:  "test_old.one",
+				CurrentConfig:    "# not empty",
+				CurrentAddress:   "test_new.one",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase missing PreviousConfig"),
+		},
+		"currentconfig-missing": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				CurrentProvider:  currentProvider,
+				PreviousConfig:   "# not empty",
+				PreviousAddress:  "test_old.one",
+				CurrentAddress:   "test_new.one",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase missing CurrentConfig"),
+		},
+		"previousaddress-missing": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				CurrentProvider:  currentProvider,
+				PreviousConfig:   "# not empty",
+				CurrentConfig:    "# not empty",
+				CurrentAddress:   "test_new.one",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase missing PreviousAddress"),
+		},
+		"currentaddress-missing": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				CurrentProvider:  currentProvider,
+				PreviousConfig:   "# not empty",
+				PreviousAddress:  "test_old.one",
+				CurrentConfig:    "# not empty",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase missing CurrentAddress"),
+		},
+		"addresses-same": {
+			typeRenameCase: TypeRenameCase{
+				PreviousProvider: previousProvider,
+				CurrentProvider:  currentProvider,
+				PreviousConfig:   "# not empty",
+				PreviousAddress:  "test_old.one",
+				CurrentConfig:    "# not empty",
+				CurrentAddress:   "test_old.one",
+			},
+			expectedError: fmt.Errorf("TypeRenameCase PreviousAddress and CurrentAddress must be different, got: test_old.one"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.typeRenameCase.validate()
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestTypeRenameCaseTestCase(t *testing.T) {
+	t.Parallel()
+
+	typeRenameCase := TypeRenameCase{
+		PreviousProvider: MigrationProvider{
+			ExternalProviders: map[string]ExternalProvider{
+				"test": {Source: "registry.terraform.io/hashicorp/test", VersionConstraint: "1.0.0"},
+			},
+		},
+		CurrentProvider: MigrationProvider{
+			ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+				"test": nil, // does not need to be real
+			},
+		},
+		PreviousConfig:  `resource "test_old" "one" {}`,
+		PreviousAddress: "test_old.one",
+		CurrentConfig:   `resource "test_new" "one" {}`,
+		CurrentAddress:  "test_new.one",
+	}
+
+	got := typeRenameCase.testCase()
+
+	if len(got.TerraformVersionChecks) != 1 {
+		t.Errorf("expected 1 Terraform version check, got %d", len(got.TerraformVersionChecks))
+	}
+
+	if len(got.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(got.Steps))
+	}
+
+	if got.Steps[0].Config != typeRenameCase.PreviousConfig {
+		t.Errorf("step 1: expected config %q, got %q", typeRenameCase.PreviousConfig, got.Steps[0].Config)
+	}
+
+	if len(got.Steps[0].ExternalProviders) != 1 || len(got.Steps[0].ProtoV6ProviderFactories) != 0 {
+		t.Errorf("step 1: expected PreviousProvider")
+	}
+
+	expectedConfig := `resource "test_new" "one" {}
+
+moved {
+  from = test_old.one
+  to   = test_new.one
+}
+`
+
+	if diff := cmp.Diff(got.Steps[1].Config, expectedConfig); diff != "" {
+		t.Errorf("step 2: unexpected config difference: %s", diff)
+	}
+
+	if len(got.Steps[1].ExternalProviders) != 0 || len(got.Steps[1].ProtoV6ProviderFactories) != 1 {
+		t.Errorf("step 2: expected CurrentProvider")
+	}
+
+	if len(got.Steps[1].ConfigPlanChecks.PreApply) != 1 {
+		t.Errorf("step 2: expected moved plan check")
+	}
+}
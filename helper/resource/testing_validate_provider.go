@@ -0,0 +1,540 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-testing-interface"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/schemacheck"
+)
+
+// ProviderValidationCase is the set of providers to validate with
+// ValidateProvider, along with any schema checks for conventions of the
+// provider, such as naming and descriptions. Providers are keyed by local
+// name, such as "examplecloud", the same as TestCase provider factories.
+type ProviderValidationCase struct {
+	// ProviderFactories are terraform-plugin-sdk providers, which are
+	// validated with the terraform-plugin-sdk InternalValidate method before
+	// their schemas are fetched.
+	ProviderFactories map[string]func() (*schema.Provider, error)
+
+	// ProtoV5ProviderFactories are protocol version 5 provider servers, such
+	// as terraform-plugin-framework or terraform-plugin-mux providers, which
+	// are validated by fetching their schemas.
+	ProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
+	// ProtoV6ProviderFactories are protocol version 6 provider servers, such
+	// as terraform-plugin-framework or terraform-plugin-mux providers, which
+	// are validated by fetching their schemas.
+	ProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
+	// SchemaChecks are run against the schemas of each provider, such as
+	// schemacheck.ExpectSnakeCaseNames() or custom lint rules.
+	SchemaChecks []schemacheck.SchemaCheck
+}
+
+// ValidateProvider validates the providers of the ProviderValidationCase
+// without running Terraform. Each provider is started in the test process
+// and its schemas are fetched, failing the test on any error diagnostics,
+// such as invalid terraform-plugin-framework schema definitions. Providers
+// implemented with terraform-plugin-sdk are also validated with the
+// InternalValidate method. The schemas are then checked with each of the
+// SchemaChecks.
+//
+// Unlike Test, ValidateProvider does not require the TF_ACC environment
+// variable or Terraform CLI, so it can always run as a unit test:
+//
+//	func TestProvider(t *testing.T) {
+//		resource.ValidateProvider(t, resource.ProviderValidationCase{
+//			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+//			SchemaChecks: []schemacheck.SchemaCheck{
+//				schemacheck.ExpectSnakeCaseNames(),
+//				schemacheck.ExpectDescriptions(),
+//			},
+//		})
+//	}
+func ValidateProvider(t testing.T, c ProviderValidationCase) {
+	t.Helper()
+
+	ctx := logging.InitTestContext(context.Background(), t)
+
+	if len(c.ProviderFactories) == 0 && len(c.ProtoV5ProviderFactories) == 0 && len(c.ProtoV6ProviderFactories) == 0 {
+		t.Fatalf("Provider validation error: ProviderValidationCase missing provider factories")
+	}
+
+	for _, err := range c.validate(ctx) {
+		t.Error(err)
+	}
+}
+
+// validate returns the validation and schema check errors of every
+// provider, sorted by provider name.
+func (c ProviderValidationCase) validate(ctx context.Context) []error {
+	requests := make(map[string]schemacheck.CheckSchemaRequest)
+
+	var errs []error
+
+	for name, factory := range c.ProviderFactories {
+		providerSchema, capabilities, err := validateSDKProvider(ctx, factory)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Provider %s validation error: %w", name, err))
+
+			continue
+		}
+
+		requests[name] = schemacheck.CheckSchemaRequest{
+			ProviderName:       name,
+			Schema:             providerSchema,
+			ServerCapabilities: capabilities,
+		}
+	}
+
+	for name, factory := range c.ProtoV5ProviderFactories {
+		providerSchema, capabilities, err := protoV5ProviderSchema(ctx, factory)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Provider %s validation error: %w", name, err))
+
+			continue
+		}
+
+		requests[name] = schemacheck.CheckSchemaRequest{
+			ProviderName:       name,
+			Schema:             providerSchema,
+			ServerCapabilities: capabilities,
+		}
+	}
+
+	for name, factory := range c.ProtoV6ProviderFactories {
+		providerSchema, capabilities, err := protoV6ProviderSchema(ctx, factory)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Provider %s validation error: %w", name, err))
+
+			continue
+		}
+
+		requests[name] = schemacheck.CheckSchemaRequest{
+			ProviderName:       name,
+			Schema:             providerSchema,
+			ServerCapabilities: capabilities,
+		}
+	}
+
+	names := make([]string, 0, len(requests))
+
+	for name := range requests {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for i, schemaCheck := range c.SchemaChecks {
+			resp := schemacheck.CheckSchemaResponse{}
+
+			schemaCheck.CheckSchema(ctx, requests[name], &resp)
+
+			if resp.Error != nil {
+				errs = append(errs, fmt.Errorf("Provider %s schema check %d/%d error: %w", name, i+1, len(c.SchemaChecks), resp.Error))
+			}
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	return errs
+}
+
+// validateSDKProvider runs the terraform-plugin-sdk InternalValidate method
+// of the provider, then returns its schemas and server capabilities.
+func validateSDKProvider(ctx context.Context, factory func() (*schema.Provider, error)) (*tfjson.ProviderSchema, schemacheck.ServerCapabilities, error) {
+	provider, err := factory()
+
+	if err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("unable to create provider: %w", err)
+	}
+
+	if err := provider.InternalValidate(); err != nil {
+		return nil, schemacheck.ServerCapabilities{}, err
+	}
+
+	return protoV5ProviderSchema(ctx, func() (tfprotov5.ProviderServer, error) {
+		return schema.NewGRPCProviderServer(provider), nil
+	})
+}
+
+// protoV5ProviderSchema returns the schemas and server capabilities of the
+// protocol version 5 provider server, or an error if any error diagnostics
+// are returned.
+func protoV5ProviderSchema(ctx context.Context, factory func() (tfprotov5.ProviderServer, error)) (*tfjson.ProviderSchema, schemacheck.ServerCapabilities, error) {
+	server, err := factory()
+
+	if err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("unable to create provider: %w", err)
+	}
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+
+	if err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("unable to get provider schema: %w", err)
+	}
+
+	for _, diag := range resp.Diagnostics {
+		if diag != nil && diag.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("%s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	result := &tfjson.ProviderSchema{
+		ResourceSchemas:   make(map[string]*tfjson.Schema, len(resp.ResourceSchemas)),
+		DataSourceSchemas: make(map[string]*tfjson.Schema, len(resp.DataSourceSchemas)),
+	}
+
+	if result.ConfigSchema, err = protoV5Schema(resp.Provider); err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("provider schema: %w", err)
+	}
+
+	for typeName, s := range resp.ResourceSchemas {
+		if result.ResourceSchemas[typeName], err = protoV5Schema(s); err != nil {
+			return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("resource %s schema: %w", typeName, err)
+		}
+	}
+
+	for typeName, s := range resp.DataSourceSchemas {
+		if result.DataSourceSchemas[typeName], err = protoV5Schema(s); err != nil {
+			return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("data source %s schema: %w", typeName, err)
+		}
+	}
+
+	var capabilities schemacheck.ServerCapabilities
+
+	if resp.ServerCapabilities != nil {
+		capabilities.PlanDestroy = resp.ServerCapabilities.PlanDestroy
+	}
+
+	return result, capabilities, nil
+}
+
+// protoV6ProviderSchema returns the schemas and server capabilities of the
+// protocol version 6 provider server, or an error if any error diagnostics
+// are returned.
+func protoV6ProviderSchema(ctx context.Context, factory func() (tfprotov6.ProviderServer, error)) (*tfjson.ProviderSchema, schemacheck.ServerCapabilities, error) {
+	server, err := factory()
+
+	if err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("unable to create provider: %w", err)
+	}
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("unable to get provider schema: %w", err)
+	}
+
+	for _, diag := range resp.Diagnostics {
+		if diag != nil && diag.Severity == tfprotov6.DiagnosticSeverityError {
+			return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("%s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	result := &tfjson.ProviderSchema{
+		ResourceSchemas:   make(map[string]*tfjson.Schema, len(resp.ResourceSchemas)),
+		DataSourceSchemas: make(map[string]*tfjson.Schema, len(resp.DataSourceSchemas)),
+	}
+
+	if result.ConfigSchema, err = protoV6Schema(resp.Provider); err != nil {
+		return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("provider schema: %w", err)
+	}
+
+	for typeName, s := range resp.ResourceSchemas {
+		if result.ResourceSchemas[typeName], err = protoV6Schema(s); err != nil {
+			return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("resource %s schema: %w", typeName, err)
+		}
+	}
+
+	for typeName, s := range resp.DataSourceSchemas {
+		if result.DataSourceSchemas[typeName], err = protoV6Schema(s); err != nil {
+			return nil, schemacheck.ServerCapabilities{}, fmt.Errorf("data source %s schema: %w", typeName, err)
+		}
+	}
+
+	var capabilities schemacheck.ServerCapabilities
+
+	if resp.ServerCapabilities != nil {
+		capabilities.PlanDestroy = resp.ServerCapabilities.PlanDestroy
+	}
+
+	return result, capabilities, nil
+}
+
+// protoV5Schema converts a protocol version 5 schema into the
+// terraform-json schema, as output by the `terraform providers schema -json`
+// command.
+func protoV5Schema(s *tfprotov5.Schema) (*tfjson.Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	block, err := protoV5SchemaBlock(s.Block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfjson.Schema{
+		Version: uint64(s.Version),
+		Block:   block,
+	}, nil
+}
+
+func protoV5SchemaBlock(b *tfprotov5.SchemaBlock) (*tfjson.SchemaBlock, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	result := &tfjson.SchemaBlock{
+		Attributes:      make(map[string]*tfjson.SchemaAttribute, len(b.Attributes)),
+		NestedBlocks:    make(map[string]*tfjson.SchemaBlockType, len(b.BlockTypes)),
+		Description:     b.Description,
+		DescriptionKind: protoV5DescriptionKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+
+	for _, a := range b.Attributes {
+		attributeType, err := schemaAttributeType(a.Type)
+
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", a.Name, err)
+		}
+
+		result.Attributes[a.Name] = &tfjson.SchemaAttribute{
+			AttributeType:   attributeType,
+			Description:     a.Description,
+			DescriptionKind: protoV5DescriptionKind(a.DescriptionKind),
+			Deprecated:      a.Deprecated,
+			Required:        a.Required,
+			Optional:        a.Optional,
+			Computed:        a.Computed,
+			Sensitive:       a.Sensitive,
+		}
+	}
+
+	for _, nb := range b.BlockTypes {
+		block, err := protoV5SchemaBlock(nb.Block)
+
+		if err != nil {
+			return nil, fmt.Errorf("block %s: %w", nb.TypeName, err)
+		}
+
+		result.NestedBlocks[nb.TypeName] = &tfjson.SchemaBlockType{
+			NestingMode: protoV5NestingMode(nb.Nesting),
+			Block:       block,
+			MinItems:    uint64(nb.MinItems),
+			MaxItems:    uint64(nb.MaxItems),
+		}
+	}
+
+	return result, nil
+}
+
+func protoV5DescriptionKind(k tfprotov5.StringKind) tfjson.SchemaDescriptionKind {
+	if k == tfprotov5.StringKindMarkdown {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+
+	return tfjson.SchemaDescriptionKindPlain
+}
+
+func protoV5NestingMode(m tfprotov5.SchemaNestedBlockNestingMode) tfjson.SchemaNestingMode {
+	switch m {
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return tfjson.SchemaNestingModeSingle
+	case tfprotov5.SchemaNestedBlockNestingModeGroup:
+		return tfjson.SchemaNestingModeGroup
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return tfjson.SchemaNestingModeList
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return tfjson.SchemaNestingModeSet
+	case tfprotov5.SchemaNestedBlockNestingModeMap:
+		return tfjson.SchemaNestingModeMap
+	default:
+		return ""
+	}
+}
+
+// protoV6Schema converts a protocol version 6 schema into the
+// terraform-json schema, as output by the `terraform providers schema -json`
+// command.
+func protoV6Schema(s *tfprotov6.Schema) (*tfjson.Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	block, err := protoV6SchemaBlock(s.Block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfjson.Schema{
+		Version: uint64(s.Version),
+		Block:   block,
+	}, nil
+}
+
+func protoV6SchemaBlock(b *tfprotov6.SchemaBlock) (*tfjson.SchemaBlock, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	attributes, err := protoV6SchemaAttributes(b.Attributes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &tfjson.SchemaBlock{
+		Attributes:      attributes,
+		NestedBlocks:    make(map[string]*tfjson.SchemaBlockType, len(b.BlockTypes)),
+		Description:     b.Description,
+		DescriptionKind: protoV6DescriptionKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+
+	for _, nb := range b.BlockTypes {
+		block, err := protoV6SchemaBlock(nb.Block)
+
+		if err != nil {
+			return nil, fmt.Errorf("block %s: %w", nb.TypeName, err)
+		}
+
+		result.NestedBlocks[nb.TypeName] = &tfjson.SchemaBlockType{
+			NestingMode: protoV6NestingMode(nb.Nesting),
+			Block:       block,
+			MinItems:    uint64(nb.MinItems),
+			MaxItems:    uint64(nb.MaxItems),
+		}
+	}
+
+	return result, nil
+}
+
+func protoV6SchemaAttributes(attributes []*tfprotov6.SchemaAttribute) (map[string]*tfjson.SchemaAttribute, error) {
+	result := make(map[string]*tfjson.SchemaAttribute, len(attributes))
+
+	for _, a := range attributes {
+		attribute := &tfjson.SchemaAttribute{
+			Description:     a.Description,
+			DescriptionKind: protoV6DescriptionKind(a.DescriptionKind),
+			Deprecated:      a.Deprecated,
+			Required:        a.Required,
+			Optional:        a.Optional,
+			Computed:        a.Computed,
+			Sensitive:       a.Sensitive,
+		}
+
+		if a.NestedType != nil {
+			nestedAttributes, err := protoV6SchemaAttributes(a.NestedType.Attributes)
+
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", a.Name, err)
+			}
+
+			attribute.AttributeNestedType = &tfjson.SchemaNestedAttributeType{
+				Attributes:  nestedAttributes,
+				NestingMode: protoV6ObjectNestingMode(a.NestedType.Nesting),
+			}
+		} else {
+			attributeType, err := schemaAttributeType(a.Type)
+
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", a.Name, err)
+			}
+
+			attribute.AttributeType = attributeType
+		}
+
+		result[a.Name] = attribute
+	}
+
+	return result, nil
+}
+
+func protoV6DescriptionKind(k tfprotov6.StringKind) tfjson.SchemaDescriptionKind {
+	if k == tfprotov6.StringKindMarkdown {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+
+	return tfjson.SchemaDescriptionKindPlain
+}
+
+func protoV6NestingMode(m tfprotov6.SchemaNestedBlockNestingMode) tfjson.SchemaNestingMode {
+	switch m {
+	case tfprotov6.SchemaNestedBlockNestingModeSingle:
+		return tfjson.SchemaNestingModeSingle
+	case tfprotov6.SchemaNestedBlockNestingModeGroup:
+		return tfjson.SchemaNestingModeGroup
+	case tfprotov6.SchemaNestedBlockNestingModeList:
+		return tfjson.SchemaNestingModeList
+	case tfprotov6.SchemaNestedBlockNestingModeSet:
+		return tfjson.SchemaNestingModeSet
+	case tfprotov6.SchemaNestedBlockNestingModeMap:
+		return tfjson.SchemaNestingModeMap
+	default:
+		return ""
+	}
+}
+
+func protoV6ObjectNestingMode(m tfprotov6.SchemaObjectNestingMode) tfjson.SchemaNestingMode {
+	switch m {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		return tfjson.SchemaNestingModeSingle
+	case tfprotov6.SchemaObjectNestingModeList:
+		return tfjson.SchemaNestingModeList
+	case tfprotov6.SchemaObjectNestingModeSet:
+		return tfjson.SchemaNestingModeSet
+	case tfprotov6.SchemaObjectNestingModeMap:
+		return tfjson.SchemaNestingModeMap
+	default:
+		return ""
+	}
+}
+
+// schemaAttributeType converts the tftypes.Type of an attribute into the
+// cty.Type of the terraform-json schema, using their shared JSON type
+// representation.
+func schemaAttributeType(t tftypes.Type) (cty.Type, error) {
+	if t == nil {
+		return cty.NilType, fmt.Errorf("missing type")
+	}
+
+	b, err := t.MarshalJSON()
+
+	if err != nil {
+		return cty.NilType, fmt.Errorf("unable to marshal type: %w", err)
+	}
+
+	var result cty.Type
+
+	if err := result.UnmarshalJSON(b); err != nil {
+		return cty.NilType, fmt.Errorf("unable to unmarshal type: %w", err)
+	}
+
+	return result, nil
+}
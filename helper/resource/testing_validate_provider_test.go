@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-testing/schemacheck"
+)
+
+func TestProviderValidationCaseValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		providerValidationCase ProviderValidationCase
+		expectedErrors         []string
+	}{
+		"sdk-valid": {
+			providerValidationCase: ProviderValidationCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"examplecloud": func() (*schema.Provider, error) {
+						return testValidateSDKProvider(false), nil
+					},
+				},
+				SchemaChecks: []schemacheck.SchemaCheck{
+					schemacheck.ExpectSnakeCaseNames(),
+					schemacheck.ExpectTypeNamePrefix(),
+					schemacheck.ExpectDescriptions(),
+				},
+			},
+		},
+		"sdk-internal-validate": {
+			providerValidationCase: ProviderValidationCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"examplecloud": func() (*schema.Provider, error) {
+						return testValidateSDKProvider(true), nil
+					},
+				},
+			},
+			expectedErrors: []string{
+				"Provider examplecloud validation error: 1 error occurred:\n\t* resource examplecloud_thing: name: Optional or Required must be set, not both\n\n",
+			},
+		},
+		"sdk-factory-error": {
+			providerValidationCase: ProviderValidationCase{
+				ProviderFactories: map[string]func() (*schema.Provider, error){
+					"examplecloud": func() (*schema.Provider, error) {
+						return nil, fmt.Errorf("test error")
+					},
+				},
+			},
+			expectedErrors: []string{
+				"Provider examplecloud validation error: unable to create provider: test error",
+			},
+		},
+		"protov5-schema-checks": {
+			providerValidationCase: ProviderValidationCase{
+				ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+					"other": func() (tfprotov5.ProviderServer, error) {
+						return schema.NewGRPCProviderServer(testValidateSDKProvider(false)), nil
+					},
+				},
+				SchemaChecks: []schemacheck.SchemaCheck{
+					schemacheck.ExpectSnakeCaseNames(),
+					schemacheck.ExpectTypeNamePrefix(),
+				},
+			},
+			expectedErrors: []string{
+				"Provider other schema check 2/2 error: 1 error occurred:\n\t* resource examplecloud_thing - expected type name prefix other_\n\n",
+			},
+		},
+		"server-capabilities": {
+			providerValidationCase: ProviderValidationCase{
+				ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+					"examplecloud": func() (tfprotov5.ProviderServer, error) {
+						return schema.NewGRPCProviderServer(testValidateSDKProvider(false)), nil
+					},
+				},
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"other": func() (tfprotov6.ProviderServer, error) {
+						return testValidateProtoV6ProviderServer{}, nil
+					},
+				},
+				SchemaChecks: []schemacheck.SchemaCheck{
+					schemacheck.ExpectServerCapabilities(schemacheck.ServerCapabilities{PlanDestroy: true}),
+				},
+			},
+			expectedErrors: []string{
+				"Provider examplecloud schema check 1/1 error: examplecloud - expected server capability plan_destroy, but it was not advertised",
+			},
+		},
+		"protov6-diagnostics": {
+			providerValidationCase: ProviderValidationCase{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"examplecloud": func() (tfprotov6.ProviderServer, error) {
+						return testValidateProtoV6ProviderServer{
+							diagnostics: []*tfprotov6.Diagnostic{
+								{
+									Severity: tfprotov6.DiagnosticSeverityError,
+									Summary:  "Invalid Attribute Name",
+									Detail:   "Attribute names must be lowercase.",
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			expectedErrors: []string{
+				"Provider examplecloud validation error: Invalid Attribute Name: Attribute names must be lowercase.",
+			},
+		},
+		"protov6-schema-checks": {
+			providerValidationCase: ProviderValidationCase{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"examplecloud": func() (tfprotov6.ProviderServer, error) {
+						return testValidateProtoV6ProviderServer{}, nil
+					},
+				},
+				SchemaChecks: []schemacheck.SchemaCheck{
+					schemacheck.ExpectSnakeCaseNames(),
+					schemacheck.ExpectDescriptions(),
+				},
+			},
+			expectedErrors: []string{
+				"Provider examplecloud schema check 1/2 error: 1 error occurred:\n\t* resource examplecloud_thing attribute rules.portRange - expected snake case name, got: portRange\n\n",
+				"Provider examplecloud schema check 2/2 error: 1 error occurred:\n\t* resource examplecloud_thing attribute rules.portRange - expected description\n\n",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+
+			for _, err := range testCase.providerValidationCase.validate(context.Background()) {
+				got = append(got, err.Error())
+			}
+
+			if diff := cmp.Diff(got, testCase.expectedErrors); diff != "" {
+				t.Errorf("unexpected errors difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProtoV6ProviderSchema(t *testing.T) {
+	t.Parallel()
+
+	got, gotCapabilities, err := protoV6ProviderSchema(context.Background(), func() (tfprotov6.ProviderServer, error) {
+		return testValidateProtoV6ProviderServer{}, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(gotCapabilities, schemacheck.ServerCapabilities{PlanDestroy: true}); diff != "" {
+		t.Errorf("unexpected server capabilities difference: %s", diff)
+	}
+
+	expected := &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"examplecloud_thing": {
+				Version: 1,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"rules": {
+							AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"portRange": {
+										AttributeType:   cty.List(cty.Number),
+										DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+										Optional:        true,
+									},
+								},
+								NestingMode: tfjson.SchemaNestingModeList,
+							},
+							Description:     "Rules of the thing.",
+							DescriptionKind: tfjson.SchemaDescriptionKindMarkdown,
+							Optional:        true,
+						},
+					},
+					NestedBlocks:    map[string]*tfjson.SchemaBlockType{},
+					Description:     "Manages a thing.",
+					DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{},
+	}
+
+	if diff := cmp.Diff(got, expected, cmp.Comparer(func(x, y cty.Type) bool { return x.Equals(y) })); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+// testValidateSDKProvider returns a terraform-plugin-sdk provider with a
+// single resource, which fails InternalValidate if invalid is true.
+func testValidateSDKProvider(invalid bool) *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"examplecloud_thing": {
+				Description: "Manages a thing.",
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Description: "Identifier of the thing.",
+						Computed:    true,
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the thing.",
+						Required:    true,
+						Optional:    invalid,
+						ForceNew:    true,
+					},
+				},
+				CreateContext: schema.NoopContext,
+				ReadContext:   schema.NoopContext,
+				DeleteContext: schema.NoopContext,
+			},
+		},
+	}
+}
+
+// testValidateProtoV6ProviderServer returns a resource schema with a nested
+// attribute and the plan_destroy server capability from GetProviderSchema,
+// along with any diagnostics. Other methods
+// are not implemented.
+type testValidateProtoV6ProviderServer struct {
+	tfprotov6.ProviderServer
+
+	diagnostics []*tfprotov6.Diagnostic
+}
+
+func (s testValidateProtoV6ProviderServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{
+		ServerCapabilities: &tfprotov6.ServerCapabilities{
+			PlanDestroy: true,
+		},
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"examplecloud_thing": {
+				Version: 1,
+				Block: &tfprotov6.SchemaBlock{
+					Description: "Manages a thing.",
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name: "rules",
+							NestedType: &tfprotov6.SchemaObject{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "portRange",
+										Type:     tftypes.List{ElementType: tftypes.Number},
+										Optional: true,
+									},
+								},
+								Nesting: tfprotov6.SchemaObjectNestingModeList,
+							},
+							Description:     "Rules of the thing.",
+							DescriptionKind: tfprotov6.StringKindMarkdown,
+							Optional:        true,
+						},
+					},
+				},
+			},
+		},
+		Diagnostics: s.diagnostics,
+	}, nil
+}
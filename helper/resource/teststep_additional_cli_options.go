@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+// AdditionalCLIOptions allows an intentionally limited set of options to be
+// passed to the Terraform CLI commands run by the TestStep.
+type AdditionalCLIOptions struct {
+	// Apply contains options for the Terraform apply commands.
+	Apply ApplyOptions
+
+	// Plan contains options for the Terraform plan commands.
+	Plan PlanOptions
+}
+
+// ApplyOptions contains options for the Terraform apply commands run by the
+// TestStep.
+type ApplyOptions struct {
+	// AllowDeferral passes the -allow-deferral flag, which allows providers
+	// to defer resource changes, such as when the provider configuration is
+	// unknown. Terraform then sends the deferral_allowed client capability
+	// to providers.
+	//
+	// The flag is experimental and requires an alpha or development build of
+	// Terraform 1.9 or later.
+	AllowDeferral bool
+}
+
+// PlanOptions contains options for the Terraform plan commands run by the
+// TestStep.
+type PlanOptions struct {
+	// AllowDeferral passes the -allow-deferral flag, which allows providers
+	// to defer resource changes, such as when the provider configuration is
+	// unknown. Terraform then sends the deferral_allowed client capability
+	// to providers. Deferred changes can be verified with the
+	// plancheck.ExpectDeferredChange and plancheck.ExpectNoDeferredChanges
+	// plan checks.
+	//
+	// The flag is experimental and requires an alpha or development build of
+	// Terraform 1.9 or later.
+	AllowDeferral bool
+}
+
+// additionalPlanOptions returns the terraform-exec options for the Terraform
+// plan commands run by the TestStep.
+func (s TestStep) additionalPlanOptions() []tfexec.PlanOption {
+	if s.AdditionalCLIOptions == nil {
+		return nil
+	}
+
+	var opts []tfexec.PlanOption
+
+	if s.AdditionalCLIOptions.Plan.AllowDeferral {
+		opts = append(opts, tfexec.AllowDeferral(true))
+	}
+
+	return opts
+}
+
+// additionalApplyOptions returns the terraform-exec options for the
+// Terraform apply commands run by the TestStep.
+func (s TestStep) additionalApplyOptions() []tfexec.ApplyOption {
+	if s.AdditionalCLIOptions == nil {
+		return nil
+	}
+
+	var opts []tfexec.ApplyOption
+
+	if s.AdditionalCLIOptions.Apply.AllowDeferral {
+		opts = append(opts, tfexec.AllowDeferral(true))
+	}
+
+	return opts
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-exec/tfexec"
+)
+
+func TestTestStepAdditionalCLIOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		testStep             TestStep
+		expectedPlanOptions  []tfexec.PlanOption
+		expectedApplyOptions []tfexec.ApplyOption
+	}{
+		"none": {
+			testStep: TestStep{},
+		},
+		"empty": {
+			testStep: TestStep{
+				AdditionalCLIOptions: &AdditionalCLIOptions{},
+			},
+		},
+		"plan-allow-deferral": {
+			testStep: TestStep{
+				AdditionalCLIOptions: &AdditionalCLIOptions{
+					Plan: PlanOptions{AllowDeferral: true},
+				},
+			},
+			expectedPlanOptions: []tfexec.PlanOption{tfexec.AllowDeferral(true)},
+		},
+		"plan-and-apply-allow-deferral": {
+			testStep: TestStep{
+				AdditionalCLIOptions: &AdditionalCLIOptions{
+					Apply: ApplyOptions{AllowDeferral: true},
+					Plan:  PlanOptions{AllowDeferral: true},
+				},
+			},
+			expectedPlanOptions:  []tfexec.PlanOption{tfexec.AllowDeferral(true)},
+			expectedApplyOptions: []tfexec.ApplyOption{tfexec.AllowDeferral(true)},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			allowUnexported := cmp.AllowUnexported(tfexec.AllowDeferralOption{})
+
+			if diff := cmp.Diff(test.testStep.additionalPlanOptions(), test.expectedPlanOptions, allowUnexported); diff != "" {
+				t.Errorf("unexpected plan options difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(test.testStep.additionalApplyOptions(), test.expectedApplyOptions, allowUnexported); diff != "" {
+				t.Errorf("unexpected apply options difference: %s", diff)
+			}
+		})
+	}
+}
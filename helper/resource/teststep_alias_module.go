@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// defaultAliasModuleName is the module name of a TestStep ConfigAliasModule
+// without a Name.
+const defaultAliasModuleName = "test"
+
+// aliasModuleIdentifierRegex matches Terraform identifiers, such as module
+// names, provider local names, and provider aliases.
+var aliasModuleIdentifierRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// AliasModule configures the generated modules of the TestStep
+// ConfigAliasModule field.
+type AliasModule struct {
+	// Name is the name of the module call in the generated root module,
+	// which is used in resource addresses, such as
+	// module.test.examplecloud_thing.test. Defaults to "test".
+	Name string
+
+	// Providers maps each provider configuration address used in the child
+	// module, such as "examplecloud.alternate", to a provider configuration
+	// address of the root module, such as "examplecloud.secondary" or
+	// "examplecloud". Aliased child module addresses are declared in the
+	// configuration_aliases of the child module.
+	Providers map[string]string
+
+	// RootConfig is additional configuration of the generated root module,
+	// such as provider configuration blocks. If RootConfig does not contain a
+	// provider configuration block, empty provider configuration blocks are
+	// generated for each provider and alias used in Providers.
+	RootConfig string
+}
+
+// name returns the module name, or the default if not set.
+func (m AliasModule) name() string {
+	if m.Name == "" {
+		return defaultAliasModuleName
+	}
+
+	return m.Name
+}
+
+// validate ensures the module name and provider configuration addresses
+// are valid Terraform identifiers.
+func (m AliasModule) validate() error {
+	if !aliasModuleIdentifierRegex.MatchString(m.name()) {
+		return fmt.Errorf("invalid module name %q", m.name())
+	}
+
+	if len(m.Providers) == 0 {
+		return fmt.Errorf("Providers must not be empty")
+	}
+
+	for moduleAddress, rootAddress := range m.Providers {
+		moduleName, _, err := parseProviderConfigAddress(moduleAddress)
+
+		if err != nil {
+			return err
+		}
+
+		rootName, _, err := parseProviderConfigAddress(rootAddress)
+
+		if err != nil {
+			return err
+		}
+
+		if moduleName != rootName {
+			return fmt.Errorf("module provider %q and root provider %q must have the same provider name", moduleAddress, rootAddress)
+		}
+	}
+
+	return nil
+}
+
+// parseProviderConfigAddress returns the provider local name and alias,
+// if any, of a provider configuration address, such as "examplecloud" or
+// "examplecloud.alternate".
+func parseProviderConfigAddress(address string) (string, string, error) {
+	name, alias, hasAlias := strings.Cut(address, ".")
+
+	if !aliasModuleIdentifierRegex.MatchString(name) || (hasAlias && !aliasModuleIdentifierRegex.MatchString(alias)) {
+		return "", "", fmt.Errorf("invalid provider configuration address %q", address)
+	}
+
+	return name, alias, nil
+}
+
+// aliasModuleConfig returns the generated root module configuration, which
+// calls the child module with the ConfigAliasModule provider mappings, and
+// the child module configuration, which declares the configuration_aliases
+// of the provider mappings with the TestStep Config.
+func (s TestStep) aliasModuleConfig(ctx context.Context, c TestCase) (string, string) {
+	m := s.ConfigAliasModule
+
+	moduleAddresses := make([]string, 0, len(m.Providers))
+
+	for moduleAddress := range m.Providers {
+		moduleAddresses = append(moduleAddresses, moduleAddress)
+	}
+
+	sort.Strings(moduleAddresses)
+
+	// Provider local names and configuration aliases of the child module,
+	// and aliased provider configurations of the root module.
+	var providerNames []string
+	configurationAliases := make(map[string][]string)
+	rootAliases := make(map[string]bool)
+
+	for _, moduleAddress := range moduleAddresses {
+		name, alias, _ := parseProviderConfigAddress(moduleAddress)
+
+		if _, ok := configurationAliases[name]; !ok {
+			providerNames = append(providerNames, name)
+			configurationAliases[name] = nil
+		}
+
+		if alias != "" {
+			configurationAliases[name] = append(configurationAliases[name], moduleAddress)
+		}
+
+		if _, rootAlias, _ := parseProviderConfigAddress(m.Providers[moduleAddress]); rootAlias != "" {
+			rootAliases[m.Providers[moduleAddress]] = true
+		}
+	}
+
+	var module strings.Builder
+
+	module.WriteString("terraform {\n  required_providers {\n")
+
+	for _, name := range providerNames {
+		module.WriteString(fmt.Sprintf("    %s = {\n", name))
+
+		if source := s.providerSource(c, name); source != "" {
+			module.WriteString(fmt.Sprintf("      source = %q\n", source))
+		}
+
+		if aliases := configurationAliases[name]; len(aliases) > 0 {
+			module.WriteString(fmt.Sprintf("      configuration_aliases = [%s]\n", strings.Join(aliases, ", ")))
+		}
+
+		module.WriteString("    }\n")
+	}
+
+	module.WriteString("  }\n}\n\n")
+	module.WriteString(s.Config)
+
+	var root strings.Builder
+
+	skipProviderBlock := configProviderBlockRegex.MatchString(m.RootConfig)
+
+	if c.hasProviders(ctx) {
+		root.WriteString(c.providerConfig(ctx, skipProviderBlock))
+	} else {
+		root.WriteString(s.providerConfig(ctx, skipProviderBlock))
+	}
+
+	if !skipProviderBlock {
+		aliases := make([]string, 0, len(rootAliases))
+
+		for rootAddress := range rootAliases {
+			aliases = append(aliases, rootAddress)
+		}
+
+		sort.Strings(aliases)
+
+		for _, rootAddress := range aliases {
+			name, alias, _ := parseProviderConfigAddress(rootAddress)
+
+			root.WriteString(fmt.Sprintf("provider %q {\n  alias = %q\n}\n", name, alias))
+		}
+	}
+
+	root.WriteString(m.RootConfig)
+	root.WriteString(fmt.Sprintf("\nmodule %q {\n  source = %q\n\n  providers = {\n", m.name(), "./"+path.Join(plugintest.ModulesDirName, m.name())))
+
+	for _, moduleAddress := range moduleAddresses {
+		root.WriteString(fmt.Sprintf("    %s = %s\n", moduleAddress, m.Providers[moduleAddress]))
+	}
+
+	root.WriteString("  }\n}\n")
+
+	return root.String(), module.String()
+}
+
+// providerSource returns the source address of the ExternalProviders entry
+// of the TestStep or TestCase with the given provider local name, if any.
+// Other providers use the default source address of the local name.
+func (s TestStep) providerSource(c TestCase, name string) string {
+	if p, ok := s.ExternalProviders[name]; ok {
+		return p.Source
+	}
+
+	return c.ExternalProviders[name].Source
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestStepAliasModuleConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testCase       TestCase
+		testStep       TestStep
+		expectedRoot   string
+		expectedModule string
+	}{
+		"protov6providerfactories": {
+			testCase: TestCase{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"examplecloud": nil,
+				},
+			},
+			testStep: TestStep{
+				Config: `resource "examplecloud_thing" "test" {
+  provider = examplecloud.alternate
+}
+`,
+				ConfigAliasModule: &AliasModule{
+					Providers: map[string]string{
+						"examplecloud":           "examplecloud",
+						"examplecloud.alternate": "examplecloud.secondary",
+					},
+				},
+			},
+			expectedRoot: `provider "examplecloud" {
+  alias = "secondary"
+}
+
+module "test" {
+  source = "./terraform_plugin_test_modules/test"
+
+  providers = {
+    examplecloud = examplecloud
+    examplecloud.alternate = examplecloud.secondary
+  }
+}
+`,
+			expectedModule: `terraform {
+  required_providers {
+    examplecloud = {
+      configuration_aliases = [examplecloud.alternate]
+    }
+  }
+}
+
+resource "examplecloud_thing" "test" {
+  provider = examplecloud.alternate
+}
+`,
+		},
+		"externalproviders-rootconfig": {
+			testCase: TestCase{
+				ExternalProviders: map[string]ExternalProvider{
+					"random": {
+						Source: "registry.terraform.io/hashicorp/random",
+					},
+				},
+			},
+			testStep: TestStep{
+				Config: `resource "random_string" "test" {
+  provider = random.alternate
+  length   = 8
+}
+`,
+				ConfigAliasModule: &AliasModule{
+					Name: "child",
+					Providers: map[string]string{
+						"random.alternate": "random.secondary",
+					},
+					RootConfig: `provider "random" {
+  alias = "secondary"
+}
+`,
+				},
+			},
+			expectedRoot: `
+terraform {
+  required_providers {
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+    }
+  }
+}
+
+
+provider "random" {
+  alias = "secondary"
+}
+
+module "child" {
+  source = "./terraform_plugin_test_modules/child"
+
+  providers = {
+    random.alternate = random.secondary
+  }
+}
+`,
+			expectedModule: `terraform {
+  required_providers {
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+      configuration_aliases = [random.alternate]
+    }
+  }
+}
+
+resource "random_string" "test" {
+  provider = random.alternate
+  length   = 8
+}
+`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root, module := testCase.testStep.aliasModuleConfig(context.Background(), testCase.testCase)
+
+			if diff := cmp.Diff(root, testCase.expectedRoot); diff != "" {
+				t.Errorf("unexpected root module difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(module, testCase.expectedModule); diff != "" {
+				t.Errorf("unexpected child module difference: %s", diff)
+			}
+		})
+	}
+}
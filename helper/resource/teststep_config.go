@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// testStepConfig is the Terraform configuration of a TestStep, which is
+// either inline configuration, a directory of configuration files, or a
+// single configuration file.
+type testStepConfig struct {
+	// raw is inline configuration, such as from the TestStep Config field.
+	raw string
+
+	// preamble is generated configuration written into a separate file
+	// alongside the inline configuration, such as the terraform and provider
+	// configuration blocks when the TestCase SeparateConfigPreamble field
+	// is enabled.
+	preamble string
+
+	// directory is the path to a directory of configuration files, such as
+	// from the TestStep ConfigDirectory field.
+	directory string
+
+	// file is the path to a configuration file, such as from the TestStep
+	// ConfigFile field.
+	file string
+
+	// variables are the input variable values for the configuration, such
+	// as from the TestStep ConfigVariables field.
+	variables config.Variables
+
+	// modules are the configurations of generated child modules, keyed by
+	// module directory name, such as from the TestStep ConfigAliasModule
+	// field.
+	modules map[string]string
+}
+
+// isEmpty returns true if neither inline configuration nor a directory is
+// set.
+func (c testStepConfig) isEmpty() bool {
+	return c.raw == "" && c.directory == "" && c.file == ""
+}
+
+// hash returns the hex encoded SHA-256 hash of the configuration contents,
+// for journal entries. The contents of directories and files are read, so the
+// hash changes when the configuration changes, rather than only when its path
+// changes. Inline configuration includes any preamble, and all configurations
+// include any generated modules.
+func (c testStepConfig) hash() (string, error) {
+	h := sha256.New()
+
+	// Each part is written with its name and length, so the boundaries
+	// between parts are unambiguous.
+	writePart := func(name string, contents []byte) {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(contents))
+		h.Write(contents)
+	}
+
+	switch {
+	case c.directory != "":
+		err := filepath.WalkDir(c.directory, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(c.directory, path)
+			if err != nil {
+				return err
+			}
+
+			writePart("directory/"+filepath.ToSlash(rel), contents)
+
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to read configuration directory %q: %w", c.directory, err)
+		}
+	case c.file != "":
+		contents, err := os.ReadFile(c.file)
+		if err != nil {
+			return "", fmt.Errorf("unable to read configuration file %q: %w", c.file, err)
+		}
+
+		writePart("file/"+filepath.Base(c.file), contents)
+	default:
+		writePart("config", []byte(c.raw))
+		writePart("preamble", []byte(c.preamble))
+	}
+
+	moduleNames := make([]string, 0, len(c.modules))
+
+	for name := range c.modules {
+		moduleNames = append(moduleNames, name)
+	}
+
+	sort.Strings(moduleNames)
+
+	for _, name := range moduleNames {
+		writePart("module/"+name, []byte(c.modules[name]))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// write sets the configuration and any input variables in the working
+// directory.
+func (c testStepConfig) write(ctx context.Context, wd *plugintest.WorkingDir) error {
+	var err error
+
+	switch {
+	case c.directory != "":
+		err = wd.SetConfigDirectory(ctx, c.directory)
+	case c.file != "":
+		err = wd.SetConfigFile(ctx, c.file)
+	default:
+		err = wd.SetConfig(ctx, c.raw)
+
+		if err == nil {
+			err = wd.SetPreambleConfig(ctx, c.preamble)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := wd.SetModuleConfigs(ctx, c.modules); err != nil {
+		return err
+	}
+
+	return wd.SetVariables(ctx, c.variables)
+}
+
+// hasConfig returns true if the TestStep has set Config, ConfigDirectory, or
+// ConfigFile.
+func (s TestStep) hasConfig() bool {
+	return s.Config != "" || s.ConfigDirectory != nil || s.ConfigFile != nil
+}
+
+// hasExternalConfig returns true if the TestStep has set ConfigDirectory or
+// ConfigFile, where configuration is sourced from outside of the test code.
+func (s TestStep) hasExternalConfig() bool {
+	return s.ConfigDirectory != nil || s.ConfigFile != nil
+}
+
+// configDirectory returns the directory from the TestStep ConfigDirectory
+// field, if set, for the given test name and step number.
+func (s TestStep) configDirectory(testName string, stepNumber int) string {
+	return s.ConfigDirectory.Exec(config.TestStepConfigRequest{
+		StepNumber: stepNumber,
+		TestName:   testName,
+	})
+}
+
+// configFile returns the file from the TestStep ConfigFile field, if set, for
+// the given test name and step number.
+func (s TestStep) configFile(testName string, stepNumber int) string {
+	return s.ConfigFile.Exec(config.TestStepConfigRequest{
+		StepNumber: stepNumber,
+		TestName:   testName,
+	})
+}
+
+// stepConfig returns the configuration of the TestStep. Inline configuration
+// includes any necessary terraform configuration blocks, in a separate
+// preamble if the TestCase SeparateConfigPreamble field is enabled, while
+// directories and files must declare any providers within their
+// configuration.
+func (s TestStep) stepConfig(ctx context.Context, c TestCase, testName string, stepNumber int) (testStepConfig, error) {
+	if s.ConfigDirectory != nil {
+		directory := s.configDirectory(testName, stepNumber)
+
+		if directory == "" {
+			return testStepConfig{}, fmt.Errorf("TestStep ConfigDirectory returned an empty directory")
+		}
+
+		return testStepConfig{directory: directory, variables: s.ConfigVariables}, nil
+	}
+
+	if s.ConfigFile != nil {
+		file := s.configFile(testName, stepNumber)
+
+		if file == "" {
+			return testStepConfig{}, fmt.Errorf("TestStep ConfigFile returned an empty file")
+		}
+
+		return testStepConfig{file: file, variables: s.ConfigVariables}, nil
+	}
+
+	if s.ConfigAliasModule != nil {
+		root, module := s.aliasModuleConfig(ctx, c)
+
+		return testStepConfig{
+			raw:       root,
+			variables: s.ConfigVariables,
+			modules: map[string]string{
+				s.ConfigAliasModule.name(): module,
+			},
+		}, nil
+	}
+
+	if c.SeparateConfigPreamble {
+		return testStepConfig{
+			raw:       s.Config,
+			preamble:  s.configPreamble(ctx, c),
+			variables: s.ConfigVariables,
+		}, nil
+	}
+
+	return testStepConfig{raw: s.mergedConfig(ctx, c), variables: s.ConfigVariables}, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// configFileLineRegex matches the source locations of Terraform diagnostics
+// in the inline configuration file, such as:
+//
+//	on terraform_plugin_test.tf line 12, in resource "examplecloud_thing" "test":
+var configFileLineRegex = regexp.MustCompile(`on ` + regexp.QuoteMeta(plugintest.ConfigFileName) + ` line (\d+)`)
+
+// testSourceKey is the context key of the Go source location of the test
+// which called Test, ParallelTest, or TestWithContext.
+type testSourceKey struct{}
+
+// testSourceContext returns the context with the Go source location of the
+// test, if found in the call stack of the caller.
+func testSourceContext(ctx context.Context) context.Context {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+
+		if strings.HasSuffix(frame.File, "_test.go") {
+			return context.WithValue(ctx, testSourceKey{}, fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line))
+		}
+
+		if !more {
+			return ctx
+		}
+	}
+}
+
+// testSource returns the Go source location of the test, such as
+// "resource_thing_test.go:42", or an empty string if it is unknown.
+func testSource(ctx context.Context) string {
+	source, _ := ctx.Value(testSourceKey{}).(string)
+
+	return source
+}
+
+// configSourceLine is a line of the inline configuration file referenced by a
+// Terraform diagnostic and the corresponding line of the TestStep Config.
+type configSourceLine struct {
+	fileLine   int
+	configLine int
+}
+
+// configSourceError is an unexpected error of a TestStep with Terraform
+// diagnostics in the inline configuration file. As the file also contains
+// the generated terraform and provider configuration blocks, the
+// corresponding lines of the TestStep Config and the Go source location of
+// the test are summarized after the Terraform error.
+type configSourceError struct {
+	lines  []configSourceLine
+	source string
+	err    error
+}
+
+// withConfigSourceLines returns the error wrapped with the lines of the
+// TestStep Config referenced by its Terraform diagnostics, or the error
+// itself if there are none.
+func (s TestStep) withConfigSourceLines(ctx context.Context, testCase TestCase, err error) error {
+	if err == nil || s.Config == "" || s.ConfigAliasModule != nil || s.hasExternalConfig() {
+		return err
+	}
+
+	// The preamble is written into a separate file, so lines are unchanged.
+	var offset int
+
+	if !testCase.SeparateConfigPreamble {
+		offset = strings.Count(s.configPreamble(ctx, testCase), "\n")
+	}
+
+	lines := configSourceLines(err.Error(), offset, strings.Count(s.Config, "\n")+1)
+
+	if len(lines) == 0 {
+		return err
+	}
+
+	return &configSourceError{
+		lines:  lines,
+		source: testSource(ctx),
+		err:    err,
+	}
+}
+
+// configSourceLines returns the unique lines of the inline configuration
+// file referenced by the Terraform diagnostics in the error message, in
+// order of appearance, with the corresponding lines of the TestStep Config.
+// Lines within the preamble of generated configuration blocks are skipped.
+func configSourceLines(message string, offset int, configLines int) []configSourceLine {
+	var lines []configSourceLine
+
+	seen := make(map[int]bool)
+
+	for _, match := range configFileLineRegex.FindAllStringSubmatch(message, -1) {
+		fileLine, err := strconv.Atoi(match[1])
+
+		if err != nil || seen[fileLine] {
+			continue
+		}
+
+		seen[fileLine] = true
+
+		configLine := fileLine - offset
+
+		if configLine < 1 || configLine > configLines {
+			continue
+		}
+
+		lines = append(lines, configSourceLine{
+			fileLine:   fileLine,
+			configLine: configLine,
+		})
+	}
+
+	return lines
+}
+
+func (e *configSourceError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.err.Error())
+	b.WriteString("\n\nTestStep Config lines")
+
+	if e.source != "" {
+		fmt.Fprintf(&b, " (test at %s)", e.source)
+	}
+
+	b.WriteString(":\n")
+
+	for _, line := range e.lines {
+		fmt.Fprintf(&b, "  %s line %d: Config line %d\n", plugintest.ConfigFileName, line.fileLine, line.configLine)
+	}
+
+	return b.String()
+}
+
+func (e *configSourceError) Unwrap() error {
+	return e.err
+}
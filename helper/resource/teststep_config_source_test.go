@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+)
+
+func TestTestStepWithConfigSourceLines(t *testing.T) {
+	t.Parallel()
+
+	externalProviders := map[string]ExternalProvider{
+		"random": {
+			Source:            "registry.terraform.io/hashicorp/random",
+			VersionConstraint: "3.5.1",
+		},
+	}
+
+	// The generated terraform and provider configuration blocks are 12 lines.
+	diagnosticError := fmt.Errorf(`exit status 1
+
+Error: Reference to undeclared input variable
+
+  on terraform_plugin_test.tf line 14, in resource "random_string" "test":
+  14:   length = var.length
+
+Error: Reference to undeclared input variable
+
+  on terraform_plugin_test.tf line 14, in resource "random_string" "test":
+  14:   length = var.length`)
+
+	testCases := map[string]struct {
+		testCase      TestCase
+		testStep      TestStep
+		err           error
+		expectedError string
+	}{
+		"nil": {
+			testCase: TestCase{ExternalProviders: externalProviders},
+			testStep: TestStep{
+				Config: "resource \"random_string\" \"test\" {\n  length = var.length\n}\n",
+			},
+		},
+		"no-diagnostics": {
+			testCase: TestCase{ExternalProviders: externalProviders},
+			testStep: TestStep{
+				Config: "resource \"random_string\" \"test\" {\n  length = var.length\n}\n",
+			},
+			err:           errors.New("test error"),
+			expectedError: "test error",
+		},
+		"config": {
+			testCase: TestCase{ExternalProviders: externalProviders},
+			testStep: TestStep{
+				Config: "resource \"random_string\" \"test\" {\n  length = var.length\n}\n",
+			},
+			err: diagnosticError,
+			expectedError: diagnosticError.Error() + `
+
+TestStep Config lines (test at teststep_config_source_test.go:42):
+  terraform_plugin_test.tf line 14: Config line 2
+`,
+		},
+		"config-preamble-line": {
+			testCase: TestCase{ExternalProviders: externalProviders},
+			testStep: TestStep{
+				Config: "resource \"random_string\" \"test\" {\n  length = var.length\n}\n",
+			},
+			err:           errors.New("on terraform_plugin_test.tf line 3, in terraform:"),
+			expectedError: "on terraform_plugin_test.tf line 3, in terraform:",
+		},
+		"separateconfigpreamble": {
+			testCase: TestCase{
+				ExternalProviders:      externalProviders,
+				SeparateConfigPreamble: true,
+			},
+			testStep: TestStep{
+				Config: strings.Repeat("\n", 13) + "resource \"random_string\" \"test\" {\n  length = var.length\n}\n",
+			},
+			err: diagnosticError,
+			expectedError: diagnosticError.Error() + `
+
+TestStep Config lines (test at teststep_config_source_test.go:42):
+  terraform_plugin_test.tf line 14: Config line 14
+`,
+		},
+		"configdirectory": {
+			testCase: TestCase{ExternalProviders: externalProviders},
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string_variable"),
+			},
+			err:           diagnosticError,
+			expectedError: diagnosticError.Error(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), testSourceKey{}, "teststep_config_source_test.go:42")
+
+			err := testCase.testStep.withConfigSourceLines(ctx, testCase.testCase, testCase.err)
+
+			if err == nil {
+				if testCase.expectedError != "" {
+					t.Fatalf("expected error: %s", testCase.expectedError)
+				}
+
+				return
+			}
+
+			if diff := cmp.Diff(err.Error(), testCase.expectedError); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+
+			if !errors.Is(err, testCase.err) {
+				t.Errorf("expected wrapped error: %s", testCase.err)
+			}
+		})
+	}
+}
+
+func TestTestSourceContext(t *testing.T) {
+	t.Parallel()
+
+	got := testSource(testSourceContext(context.Background()))
+
+	if !strings.HasPrefix(got, "teststep_config_source_test.go:") {
+		t.Errorf("expected test source location, got: %q", got)
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+)
+
+func TestTestStepStepConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		testStep      TestStep
+		expected      testStepConfig
+		expectedError error
+	}{
+		"config": {
+			testStep: TestStep{
+				Config: `resource "test_resource" "test" {}`,
+			},
+			expected: testStepConfig{
+				raw: `resource "test_resource" "test" {}`,
+			},
+		},
+		"configdirectory": {
+			testStep: TestStep{
+				ConfigDirectory: func(req config.TestStepConfigRequest) string {
+					return fmt.Sprintf("testdata/%s/%d", req.TestName, req.StepNumber)
+				},
+			},
+			expected: testStepConfig{
+				directory: "testdata/TestExample/2",
+			},
+		},
+		"configfile": {
+			testStep: TestStep{
+				ConfigFile: func(req config.TestStepConfigRequest) string {
+					return fmt.Sprintf("testdata/%s/%d/main.tf", req.TestName, req.StepNumber)
+				},
+			},
+			expected: testStepConfig{
+				file: "testdata/TestExample/2/main.tf",
+			},
+		},
+		"configfile-configvariables": {
+			testStep: TestStep{
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string_variable/main.tf"),
+				ConfigVariables: config.Variables{
+					"length": config.IntegerVariable(8),
+				},
+			},
+			expected: testStepConfig{
+				file: "testdata/fixtures/random_string_variable/main.tf",
+				variables: config.Variables{
+					"length": config.IntegerVariable(8),
+				},
+			},
+		},
+		"configfile-empty": {
+			testStep: TestStep{
+				ConfigFile: config.StaticFile(""),
+			},
+			expectedError: fmt.Errorf("TestStep ConfigFile returned an empty file"),
+		},
+		"configdirectory-empty": {
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory(""),
+			},
+			expectedError: fmt.Errorf("TestStep ConfigDirectory returned an empty directory"),
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := test.testStep.stepConfig(context.Background(), TestCase{}, "TestExample", 2)
+
+			if err != nil {
+				if test.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if err.Error() != test.expectedError.Error() {
+					t.Fatalf("expected error %q, got: %s", test.expectedError, err)
+				}
+
+				return
+			}
+
+			if test.expectedError != nil {
+				t.Fatalf("expected error: %s", test.expectedError)
+			}
+
+			if diff := cmp.Diff(got, test.expected, cmp.AllowUnexported(testStepConfig{}), equateVariables); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+// equateVariables compares config.Variables by their JSON encoding, as the
+// variable implementations have unexported fields.
+var equateVariables = cmp.Comparer(func(x, y config.Variables) bool {
+	xJSON, xErr := x.MarshalJSON()
+	yJSON, yErr := y.MarshalJSON()
+
+	return xErr == nil && yErr == nil && string(xJSON) == string(yJSON)
+})
+
+func TestTest_TestStep_ConfigDirectory(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		Steps: []TestStep{
+			{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string"),
+				Check:           TestCheckResourceAttr("random_string.test", "length", "8"),
+			},
+			{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string_module"),
+				Check:           TestCheckResourceAttr("module.random.random_string.test", "length", "12"),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConfigDirectory_TestNameDirectory(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		Steps: []TestStep{
+			{
+				ConfigDirectory: config.TestNameDirectory(),
+				Check:           TestCheckResourceAttr("random_string.test", "length", "8"),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConfigDirectory_TestStepDirectory(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		Steps: []TestStep{
+			{
+				ConfigDirectory: config.TestStepDirectory(),
+				Check:           TestCheckResourceAttr("random_string.test", "length", "8"),
+			},
+			{
+				ConfigDirectory: config.TestStepDirectory(),
+				Check:           TestCheckResourceAttr("random_string.test", "length", "12"),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConfigFile_TestStepFile(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		Steps: []TestStep{
+			{
+				ConfigFile: config.TestStepFile("main.tf"),
+				Check:      TestCheckResourceAttr("random_string.test", "length", "8"),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConfigFile(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		Steps: []TestStep{
+			{
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string/main.tf"),
+				Check:      TestCheckResourceAttr("random_string.test", "length", "8"),
+			},
+			{
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string_module/modules/random/main.tf"),
+				Check:      TestCheckResourceAttr("random_string.test", "length", "12"),
+			},
+		},
+	})
+}
+
+func TestTest_TestStep_ConfigVariables(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		Steps: []TestStep{
+			{
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string_variable/main.tf"),
+				ConfigVariables: config.Variables{
+					"length": config.IntegerVariable(8),
+				},
+				Check: TestCheckResourceAttr("random_string.test", "length", "8"),
+			},
+			{
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string_variable/main.tf"),
+				ConfigVariables: config.Variables{
+					"length": config.IntegerVariable(12),
+				},
+				Check: TestCheckResourceAttr("random_string.test", "length", "12"),
+			},
+		},
+	})
+}
+
+func TestTestStepStepConfig_SeparateConfigPreamble(t *testing.T) {
+	t.Parallel()
+
+	testCase := TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source:            "registry.terraform.io/hashicorp/random",
+				VersionConstraint: "3.5.1",
+			},
+		},
+		SeparateConfigPreamble: true,
+	}
+
+	testStep := TestStep{
+		Config: `resource "random_string" "test" {}`,
+	}
+
+	got, err := testStep.stepConfig(context.Background(), testCase, "TestExample", 1)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := testStepConfig{
+		raw: `resource "random_string" "test" {}`,
+		preamble: `
+terraform {
+  required_providers {
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+      version = "3.5.1"
+    }
+  }
+}
+
+provider "random" {}
+
+`,
+	}
+
+	if diff := cmp.Diff(got, expected, cmp.AllowUnexported(testStepConfig{}), equateVariables); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	if diff := cmp.Diff(got.preamble+got.raw, testStep.mergedConfig(context.Background(), testCase)); diff != "" {
+		t.Errorf("unexpected difference with merged configuration: %s", diff)
+	}
+}
+
+func TestTestStepConfigHash(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.tf")
+
+	if err := os.WriteFile(file, []byte(`resource "test" "test" {}`), 0600); err != nil {
+		t.Fatalf("unable to write configuration file: %s", err)
+	}
+
+	hash := func(cfg testStepConfig) string {
+		t.Helper()
+
+		got, err := cfg.hash()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return got
+	}
+
+	raw := hash(testStepConfig{raw: `resource "test" "test" {}`})
+	preamble := hash(testStepConfig{raw: `resource "test" "test" {}`, preamble: `provider "test" {}`})
+	modules := hash(testStepConfig{raw: `resource "test" "test" {}`, modules: map[string]string{"child": `resource "test" "test" {}`}})
+	directory := hash(testStepConfig{directory: dir})
+	configFile := hash(testStepConfig{file: file})
+
+	if raw == preamble || raw == modules || preamble == modules {
+		t.Errorf("expected preamble and modules to change the inline configuration hash")
+	}
+
+	if raw == directory || raw == configFile || directory == configFile {
+		t.Errorf("expected directory and file hashes to differ from the inline configuration hash")
+	}
+
+	if err := os.WriteFile(file, []byte(`resource "test" "other" {}`), 0600); err != nil {
+		t.Fatalf("unable to write configuration file: %s", err)
+	}
+
+	if got := hash(testStepConfig{directory: dir}); got == directory {
+		t.Errorf("expected directory hash to change with the directory contents")
+	}
+
+	if got := hash(testStepConfig{file: file}); got == configFile {
+		t.Errorf("expected file hash to change with the file contents")
+	}
+
+	if _, err := (testStepConfig{file: filepath.Join(dir, "missing.tf")}).hash(); err == nil {
+		t.Errorf("expected error for missing configuration file")
+	}
+}
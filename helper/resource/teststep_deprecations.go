@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+var (
+	// diagnosticContextBlockRegexp matches the resource or data source block
+	// of a diagnostic snippet context, such as resource "example" "test".
+	diagnosticContextBlockRegexp = regexp.MustCompile(`^(resource|data) "([^"]+)" "([^"]+)"`)
+
+	// diagnosticCodeAttributeRegexp matches the attribute name of a line of
+	// diagnostic snippet code, such as old_name = "example".
+	diagnosticCodeAttributeRegexp = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+)\s*=`)
+)
+
+// deprecatedAttributes returns the sorted attribute addresses, such as
+// example_widget.test.old_name, of all deprecation warning diagnostics in
+// the validate output.
+func deprecatedAttributes(output *tfjson.ValidateOutput) []string {
+	if output == nil {
+		return nil
+	}
+
+	var result []string
+
+	for _, diag := range output.Diagnostics {
+		if diag.Severity != tfjson.DiagnosticSeverityWarning {
+			continue
+		}
+
+		if !strings.Contains(strings.ToLower(diag.Summary+diag.Detail), "deprecat") {
+			continue
+		}
+
+		if address, ok := diagnosticAttributeAddress(diag); ok {
+			result = append(result, address)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// diagnosticAttributeAddress returns the attribute address of the diagnostic
+// source location, if it is a top-level attribute of a resource or data
+// source block.
+func diagnosticAttributeAddress(diag tfjson.Diagnostic) (string, bool) {
+	if diag.Range == nil || diag.Snippet == nil || diag.Snippet.Context == nil {
+		return "", false
+	}
+
+	block := diagnosticContextBlockRegexp.FindStringSubmatch(*diag.Snippet.Context)
+
+	if block == nil {
+		return "", false
+	}
+
+	lines := strings.Split(diag.Snippet.Code, "\n")
+	lineIndex := diag.Range.Start.Line - diag.Snippet.StartLine
+
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return "", false
+	}
+
+	attribute := diagnosticCodeAttributeRegexp.FindStringSubmatch(lines[lineIndex])
+
+	if attribute == nil {
+		return "", false
+	}
+
+	address := block[2] + "." + block[3] + "." + attribute[1]
+
+	if block[1] == "data" {
+		address = "data." + address
+	}
+
+	return address, true
+}
+
+// checkDeprecatedAttributes returns an error if any of the expected
+// attributes did not produce a deprecation warning in the validate output.
+func checkDeprecatedAttributes(output *tfjson.ValidateOutput, expected []string) error {
+	got := deprecatedAttributes(output)
+	found := make(map[string]bool, len(got))
+
+	for _, address := range got {
+		found[address] = true
+	}
+
+	var missing []string
+
+	for _, address := range expected {
+		if !found[address] {
+			missing = append(missing, address)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if len(got) == 0 {
+		return fmt.Errorf("expected deprecation warnings for: %s, got no deprecation warnings", strings.Join(missing, ", "))
+	}
+
+	return fmt.Errorf("expected deprecation warnings for: %s, got deprecation warnings for: %s", strings.Join(missing, ", "), strings.Join(got, ", "))
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+const testValidateOutputJSON = `{
+	"format_version": "1.0",
+	"valid": true,
+	"error_count": 0,
+	"warning_count": 3,
+	"diagnostics": [
+		{
+			"severity": "warning",
+			"summary": "Argument is deprecated",
+			"detail": "Use new_name instead.",
+			"range": {
+				"filename": "terraform_plugin_test.tf",
+				"start": {"line": 3, "column": 3, "byte": 40},
+				"end": {"line": 3, "column": 22, "byte": 59}
+			},
+			"snippet": {
+				"context": "resource \"example_widget\" \"test\"",
+				"code": "  old_name = \"example\"",
+				"start_line": 3,
+				"highlight_start_offset": 2,
+				"highlight_end_offset": 21,
+				"values": []
+			}
+		},
+		{
+			"severity": "warning",
+			"summary": "Deprecated attribute",
+			"range": {
+				"filename": "terraform_plugin_test.tf",
+				"start": {"line": 8, "column": 3, "byte": 120},
+				"end": {"line": 8, "column": 20, "byte": 137}
+			},
+			"snippet": {
+				"context": "data \"example_widget\" \"test\"",
+				"code": "data \"example_widget\" \"test\" {\n  legacy = true",
+				"start_line": 7,
+				"highlight_start_offset": 33,
+				"highlight_end_offset": 46,
+				"values": []
+			}
+		},
+		{
+			"severity": "warning",
+			"summary": "Provider warning",
+			"detail": "Something else happened.",
+			"range": {
+				"filename": "terraform_plugin_test.tf",
+				"start": {"line": 4, "column": 3, "byte": 62},
+				"end": {"line": 4, "column": 15, "byte": 74}
+			},
+			"snippet": {
+				"context": "resource \"example_widget\" \"test\"",
+				"code": "  other = \"value\"",
+				"start_line": 4,
+				"highlight_start_offset": 2,
+				"highlight_end_offset": 14,
+				"values": []
+			}
+		}
+	]
+}`
+
+func testValidateOutput(t *testing.T) *tfjson.ValidateOutput {
+	t.Helper()
+
+	var output tfjson.ValidateOutput
+
+	if err := json.Unmarshal([]byte(testValidateOutputJSON), &output); err != nil {
+		t.Fatalf("unable to decode validate output: %s", err)
+	}
+
+	return &output
+}
+
+func TestDeprecatedAttributes(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		output   *tfjson.ValidateOutput
+		expected []string
+	}{
+		"nil": {
+			output:   nil,
+			expected: nil,
+		},
+		"no-diagnostics": {
+			output:   &tfjson.ValidateOutput{Valid: true},
+			expected: nil,
+		},
+		"diagnostics": {
+			output: testValidateOutput(t),
+			expected: []string{
+				"data.example_widget.test.legacy",
+				"example_widget.test.old_name",
+			},
+		},
+		"error-severity": {
+			output: &tfjson.ValidateOutput{
+				Diagnostics: []tfjson.Diagnostic{
+					{
+						Severity: tfjson.DiagnosticSeverityError,
+						Summary:  "Argument is deprecated",
+					},
+				},
+			},
+			expected: nil,
+		},
+		"missing-snippet": {
+			output: &tfjson.ValidateOutput{
+				Diagnostics: []tfjson.Diagnostic{
+					{
+						Severity: tfjson.DiagnosticSeverityWarning,
+						Summary:  "Argument is deprecated",
+						Range:    &tfjson.Range{},
+					},
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := deprecatedAttributes(testCase.output)
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckDeprecatedAttributes(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		output        *tfjson.ValidateOutput
+		expected      []string
+		expectedError error
+	}{
+		"found": {
+			output:   testValidateOutput(t),
+			expected: []string{"example_widget.test.old_name"},
+		},
+		"found-all": {
+			output: testValidateOutput(t),
+			expected: []string{
+				"data.example_widget.test.legacy",
+				"example_widget.test.old_name",
+			},
+		},
+		"missing": {
+			output: testValidateOutput(t),
+			expected: []string{
+				"example_widget.test.old_name",
+				"example_widget.test.other",
+			},
+			expectedError: fmt.Errorf("expected deprecation warnings for: example_widget.test.other, got deprecation warnings for: data.example_widget.test.legacy, example_widget.test.old_name"),
+		},
+		"no-warnings": {
+			output:        &tfjson.ValidateOutput{Valid: true},
+			expected:      []string{"example_widget.test.old_name"},
+			expectedError: fmt.Errorf("expected deprecation warnings for: example_widget.test.old_name, got no deprecation warnings"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkDeprecatedAttributes(testCase.output, testCase.expected)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+			}
+
+			if err == nil && testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
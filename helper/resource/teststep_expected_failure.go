@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+)
+
+// runExpectedFailureStep runs the given function of a TestStep with
+// ExpectedFailure with a testing.T which records failures instead of failing
+// the test. The function returns false if the TestStep did not run, such as
+// due to the TestStep SkipFunc.
+//
+// If the TestStep failed, it is reported with the expected-failure result
+// and the skip message for the remaining TestStep is returned, otherwise an
+// empty string. If the TestStep passed, the test fails as unexpectedly fixed.
+func runExpectedFailureStep(ctx context.Context, t testing.T, reporter *testReporter, step TestStep, progress string, f func(t testing.T) bool) string {
+	t.Helper()
+
+	attempt := &expectedFailureT{
+		testAttemptT: &testAttemptT{T: t},
+		reporter:     reporter,
+	}
+
+	var ran bool
+
+	// Failing the TestStep exits its goroutine, as the testing.T of the
+	// test would, without ending the test.
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ran = f(attempt)
+	}()
+
+	<-done
+
+	// The testing.T of the test may have been failed directly, such as by a
+	// TestCheckFunc with its own reference.
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	attempt.mu.Lock()
+	failures := attempt.failures
+	failed := attempt.failed
+	skipped := attempt.skipped
+	skipMessage := attempt.skipMessage
+	attempt.mu.Unlock()
+
+	switch {
+	case failed:
+		logging.HelperResourceWarn(ctx,
+			"TestStep failed as expected due to ExpectedFailure",
+			map[string]interface{}{logging.KeyError: strings.Join(failures, "\n\n")},
+		)
+
+		// The TestStep result is reported as expected-failure.
+		reporter.endStep(ctx, reportResultFail)
+
+		return fmt.Sprintf("Step %s failed as expected due to ExpectedFailure (%s), skipping remaining TestStep:\n\n%s", progress, step.ExpectedFailure, strings.Join(failures, "\n\n"))
+	case skipped:
+		t.Skip(skipMessage)
+	case ran:
+		logging.HelperResourceError(ctx, "TestStep unexpectedly fixed, passed with ExpectedFailure")
+		t.Errorf("Step %s unexpectedly fixed, passed with ExpectedFailure (%s), remove ExpectedFailure if the issue is resolved", progress, step.ExpectedFailure)
+	}
+
+	return ""
+}
+
+var _ testing.T = &expectedFailureT{}
+
+// expectedFailureT wraps the testing.T of a TestStep with ExpectedFailure to
+// record the failures of the TestStep rather than failing the test. Failure
+// messages are also saved as diagnostics of the TestStep report event.
+type expectedFailureT struct {
+	*testAttemptT
+
+	reporter *testReporter
+}
+
+func (t *expectedFailureT) Error(args ...interface{}) {
+	t.reporter.addStepDiagnostic(fmt.Sprint(args...))
+	t.testAttemptT.Error(args...)
+}
+
+func (t *expectedFailureT) Errorf(format string, args ...interface{}) {
+	t.reporter.addStepDiagnostic(fmt.Sprintf(format, args...))
+	t.testAttemptT.Errorf(format, args...)
+}
+
+func (t *expectedFailureT) Fatal(args ...interface{}) {
+	t.reporter.addStepDiagnostic(fmt.Sprint(args...))
+	t.testAttemptT.Fatal(args...)
+}
+
+func (t *expectedFailureT) Fatalf(format string, args ...interface{}) {
+	t.reporter.addStepDiagnostic(fmt.Sprintf(format, args...))
+	t.testAttemptT.Fatalf(format, args...)
+}
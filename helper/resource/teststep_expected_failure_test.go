@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	testinginterface "github.com/mitchellh/go-testing-interface"
+)
+
+func TestRunExpectedFailureStep(t *testing.T) {
+	t.Parallel()
+
+	step := TestStep{
+		ExpectedFailure: "https://example.com/issues/123",
+	}
+
+	testCases := map[string]struct {
+		f               func(t testinginterface.T) bool
+		expected        string
+		expectedFailed  bool
+		expectedSkipped bool
+	}{
+		"fatal": {
+			f: func(t testinginterface.T) bool {
+				t.Fatalf("Step %d/%d error: %s", 1, 2, "boom")
+
+				return true
+			},
+			expected: "Step 1/2 failed as expected due to ExpectedFailure (https://example.com/issues/123), skipping remaining TestStep:\n\nStep 1/2 error: boom",
+		},
+		"error": {
+			f: func(t testinginterface.T) bool {
+				t.Error("first")
+				t.Error("second")
+
+				return true
+			},
+			expected: "Step 1/2 failed as expected due to ExpectedFailure (https://example.com/issues/123), skipping remaining TestStep:\n\nfirst\n\nsecond",
+		},
+		"pass": {
+			f: func(t testinginterface.T) bool {
+				return true
+			},
+			expectedFailed: true,
+		},
+		"not-run": {
+			f: func(t testinginterface.T) bool {
+				return false
+			},
+		},
+		"skip": {
+			f: func(t testinginterface.T) bool {
+				t.Skip("skipped")
+				t.Fatal("not reached")
+
+				return true
+			},
+			expectedSkipped: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mt := &mockT{}
+
+			got := runExpectedFailureStep(context.Background(), mt, nil, step, "1/2", testCase.f)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+
+			if mt.Failed() != testCase.expectedFailed {
+				t.Errorf("expected failed %t, got %t", testCase.expectedFailed, mt.Failed())
+			}
+
+			if mt.Skipped() != testCase.expectedSkipped {
+				t.Errorf("expected skipped %t, got %t", testCase.expectedSkipped, mt.Skipped())
+			}
+		})
+	}
+}
+
+func TestRunExpectedFailureStep_Report(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	t.Setenv(EnvTfAccReportPath, reportPath)
+
+	ctx := context.Background()
+	mt := &mockT{}
+	reporter := newTestReporter(mt, false)
+
+	if reporter == nil {
+		t.Fatal("expected reporter")
+	}
+
+	step := TestStep{
+		Config:          "# not empty",
+		ExpectedFailure: "https://example.com/issues/123",
+	}
+
+	reporter.startStep(1, step)
+	runExpectedFailureStep(ctx, reporter, reporter, step, "1/2", func(t testinginterface.T) bool {
+		t.Fatal("Step 1/2 error: boom")
+
+		return true
+	})
+
+	reporter.startStep(2, step)
+	runExpectedFailureStep(ctx, reporter, reporter, step, "2/2", func(t testinginterface.T) bool {
+		reporter.endStep(ctx, reportResultPass)
+
+		return true
+	})
+
+	got := readReportEvents(t, reportPath)
+	expected := []reportEvent{
+		{
+			TestName:        "MockedName",
+			StepNumber:      1,
+			Phase:           reportPhaseConfig,
+			Result:          reportResultExpectedFailure,
+			ExpectedFailure: "https://example.com/issues/123",
+			Diagnostics:     []string{"Step 1/2 error: boom"},
+		},
+		{
+			TestName:        "MockedName",
+			StepNumber:      2,
+			Phase:           reportPhaseConfig,
+			Result:          reportResultUnexpectedlyFixed,
+			ExpectedFailure: "https://example.com/issues/123",
+		},
+	}
+
+	if diff := cmp.Diff(got, expected, cmpopts.IgnoreFields(reportEvent{}, "Duration", "Timestamp")); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	// Only the unexpectedly fixed TestStep fails the test.
+	if !mt.Failed() {
+		t.Error("expected failed")
+	}
+}
+
+func TestTest_TestStep_ExpectedFailure(t *testing.T) {
+	t.Parallel()
+
+	var secondStepRan, skipped bool
+
+	t.Run("known-broken", func(t *testing.T) {
+		defer func() {
+			skipped = t.Skipped()
+		}()
+
+		UnitTest(t, TestCase{
+			ProviderFactories: map[string]func() (*schema.Provider, error){
+				"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+					return migrationTestProvider(), nil
+				},
+			},
+			Steps: []TestStep{
+				{
+					// The required name argument is missing.
+					Config:          `resource "test_resource" "test" {}`,
+					ExpectedFailure: "https://example.com/issues/123",
+				},
+				{
+					PreConfig: func() {
+						secondStepRan = true
+					},
+					Config: `resource "test_resource" "test" { name = "create" }`,
+				},
+			},
+		})
+	})
+
+	if !skipped {
+		t.Error("expected the TestCase to be skipped")
+	}
+
+	if secondStepRan {
+		t.Error("expected the remaining TestStep to be skipped")
+	}
+}
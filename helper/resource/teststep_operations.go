@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+// TestStepOperations contains the number of each Terraform CLI command the
+// testing framework invoked while running a TestStep. Commands invoked by
+// the TestCase, such as the initial init and the final destroy, are not
+// included.
+type TestStepOperations struct {
+	Apply           int
+	Destroy         int
+	Graph           int
+	Import          int
+	Init            int
+	Plan            int
+	ProvidersSchema int
+	Refresh         int
+	Show            int
+	StateRm         int
+	Taint           int
+	Validate        int
+}
+
+// String returns a human readable summary of the operations, such as
+// "1 init, 2 plan, 1 apply".
+func (o TestStepOperations) String() string {
+	var result string
+
+	for _, op := range []struct {
+		name  string
+		count int
+	}{
+		{"init", o.Init},
+		{"taint", o.Taint},
+		{"refresh", o.Refresh},
+		{"plan", o.Plan},
+		{"apply", o.Apply},
+		{"import", o.Import},
+		{"destroy", o.Destroy},
+		{"state rm", o.StateRm},
+		{"show", o.Show},
+		{"providers schema", o.ProvidersSchema},
+		{"graph", o.Graph},
+		{"validate", o.Validate},
+	} {
+		if op.count == 0 {
+			continue
+		}
+
+		if result != "" {
+			result += ", "
+		}
+
+		result += fmt.Sprintf("%d %s", op.count, op.name)
+	}
+
+	if result == "" {
+		return "no operations"
+	}
+
+	return result
+}
+
+// OperationsCheckFunc is the callback type used to verify the Terraform CLI
+// commands the testing framework invoked while running a TestStep.
+type OperationsCheckFunc func(TestStepOperations) error
+
+// newTestStepOperations returns the difference between two
+// (plugintest.Helper).Operations() snapshots.
+func newTestStepOperations(before, after map[plugintest.Operation]int) TestStepOperations {
+	count := func(op plugintest.Operation) int {
+		return after[op] - before[op]
+	}
+
+	return TestStepOperations{
+		Apply:           count(plugintest.OperationApply),
+		Destroy:         count(plugintest.OperationDestroy),
+		Graph:           count(plugintest.OperationGraph),
+		Import:          count(plugintest.OperationImport),
+		Init:            count(plugintest.OperationInit),
+		Plan:            count(plugintest.OperationPlan),
+		ProvidersSchema: count(plugintest.OperationProvidersSchema),
+		Refresh:         count(plugintest.OperationRefresh),
+		Show:            count(plugintest.OperationShow),
+		StateRm:         count(plugintest.OperationStateRm),
+		Taint:           count(plugintest.OperationTaint),
+		Validate:        count(plugintest.OperationValidate),
+	}
+}
+
+// runOperationsCheck logs the Terraform CLI commands invoked since the given
+// snapshot and calls the TestStep OperationsCheck, if set.
+func (s TestStep) runOperationsCheck(ctx context.Context, helper *plugintest.Helper, before map[plugintest.Operation]int) error {
+	operations := newTestStepOperations(before, helper.Operations())
+
+	logging.HelperResourceDebug(ctx, fmt.Sprintf("TestStep invoked Terraform CLI operations: %s", operations))
+
+	if s.OperationsCheck == nil {
+		return nil
+	}
+
+	logging.HelperResourceDebug(ctx, "Calling TestStep OperationsCheck")
+
+	if err := s.OperationsCheck(operations); err != nil {
+		return err
+	}
+
+	logging.HelperResourceDebug(ctx, "Called TestStep OperationsCheck")
+
+	return nil
+}
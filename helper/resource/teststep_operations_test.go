@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/plugintest"
+)
+
+func TestNewTestStepOperations(t *testing.T) {
+	t.Parallel()
+
+	before := map[plugintest.Operation]int{
+		plugintest.OperationInit: 1,
+		plugintest.OperationShow: 2,
+	}
+	after := map[plugintest.Operation]int{
+		plugintest.OperationApply:    1,
+		plugintest.OperationGraph:    1,
+		plugintest.OperationInit:     1,
+		plugintest.OperationPlan:     2,
+		plugintest.OperationRefresh:  2,
+		plugintest.OperationShow:     5,
+		plugintest.OperationStateRm:  1,
+		plugintest.OperationValidate: 1,
+	}
+
+	got := newTestStepOperations(before, after)
+	expected := TestStepOperations{
+		Apply:    1,
+		Graph:    1,
+		Plan:     2,
+		Refresh:  2,
+		Show:     3,
+		StateRm:  1,
+		Validate: 1,
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestTestStepOperationsString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		operations TestStepOperations
+		expected   string
+	}{
+		"none": {
+			operations: TestStepOperations{},
+			expected:   "no operations",
+		},
+		"validate": {
+			operations: TestStepOperations{
+				Plan:     1,
+				Validate: 1,
+			},
+			expected: "1 plan, 1 validate",
+		},
+		"graph": {
+			operations: TestStepOperations{
+				Graph: 1,
+				Plan:  1,
+			},
+			expected: "1 plan, 1 graph",
+		},
+		"state-rm": {
+			operations: TestStepOperations{
+				Destroy: 1,
+				StateRm: 1,
+			},
+			expected: "1 destroy, 1 state rm",
+		},
+		"multiple": {
+			operations: TestStepOperations{
+				Apply: 1,
+				Init:  1,
+				Plan:  2,
+			},
+			expected: "1 init, 2 plan, 1 apply",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.operations.String()
+
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestTest_TestStep_OperationsCheck(t *testing.T) {
+	t.Parallel()
+
+	var got []TestStepOperations
+	record := func(operations TestStepOperations) error {
+		got = append(got, operations)
+		return nil
+	}
+
+	UnitTest(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"examplecloud": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"examplecloud_thing": {
+							CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								d.SetId("resource-test")
+
+								return nil
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							Schema: map[string]*schema.Schema{
+								"id": {
+									Computed: true,
+									Type:     schema.TypeString,
+								},
+							},
+							Importer: &schema.ResourceImporter{
+								StateContext: schema.ImportStatePassthroughContext,
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config:          `resource "examplecloud_thing" "test" {}`,
+				OperationsCheck: record,
+			},
+			{
+				Config:          `resource "examplecloud_thing" "test" {}`,
+				PlanOnly:        true,
+				OperationsCheck: record,
+			},
+			{
+				ResourceName:    "examplecloud_thing.test",
+				ImportState:     true,
+				OperationsCheck: record,
+			},
+			{
+				RefreshState:    true,
+				OperationsCheck: record,
+			},
+		},
+	})
+
+	expected := []TestStepOperations{
+		// Config
+		{
+			Apply:   1,
+			Plan:    3,
+			Refresh: 2,
+			Show:    5,
+		},
+		// PlanOnly
+		{
+			Plan:    2,
+			Refresh: 2,
+			Show:    2,
+		},
+		// ImportState
+		{
+			Import: 1,
+			Init:   1,
+			Show:   2,
+		},
+		// RefreshState
+		{
+			Plan:    1,
+			Refresh: 1,
+			Show:    3,
+		},
+	}
+
+	if diff := cmp.Diff(got, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
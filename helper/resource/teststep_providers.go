@@ -5,9 +5,16 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 var configProviderBlockRegex = regexp.MustCompile(`provider "?[a-zA-Z0-9_-]+"? {`)
@@ -32,27 +39,121 @@ func (s TestStep) configHasTerraformBlock(_ context.Context) bool {
 // step configuration to prevent errors with providers outside the
 // registry.terraform.io hostname or outside the hashicorp namespace.
 func (s TestStep) mergedConfig(ctx context.Context, testCase TestCase) string {
+	return s.configPreamble(ctx, testCase) + s.Config
+}
+
+// configPreamble returns the generated terraform and provider configuration
+// blocks which mergedConfig prepends to the TestStep Config.
+func (s TestStep) configPreamble(ctx context.Context, testCase TestCase) string {
 	var config strings.Builder
 
+	// Errors are returned by TestStep validation.
+	providerConfigBlocks, _ := s.providerConfigBlocks()
+
 	// Prevent issues with existing configurations containing the terraform
 	// configuration block.
 	if s.configHasTerraformBlock(ctx) {
-		config.WriteString(s.Config)
-
-		return config.String()
+		return providerConfigBlocks
 	}
 
+	// Generated ProviderConfig blocks replace the empty provider blocks.
+	skipProviderBlock := s.configHasProviderBlock(ctx) || providerConfigBlocks != ""
+
 	if testCase.hasProviders(ctx) {
-		config.WriteString(testCase.providerConfig(ctx, s.configHasProviderBlock(ctx)))
+		config.WriteString(testCase.providerConfig(ctx, skipProviderBlock))
 	} else {
-		config.WriteString(s.providerConfig(ctx, s.configHasProviderBlock(ctx)))
+		config.WriteString(s.providerConfig(ctx, skipProviderBlock))
 	}
 
-	config.WriteString(s.Config)
+	config.WriteString(providerConfigBlocks)
 
 	return config.String()
 }
 
+// providerConfigBlocks returns the provider configuration blocks generated
+// from the TestStep ProviderConfig, sorted by provider configuration address,
+// or an error if an address, attribute name, or value is invalid.
+func (s TestStep) providerConfigBlocks() (string, error) {
+	if len(s.ProviderConfig) == 0 {
+		return "", nil
+	}
+
+	addresses := make([]string, 0, len(s.ProviderConfig))
+
+	for address := range s.ProviderConfig {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	file := hclwrite.NewEmptyFile()
+
+	for _, address := range addresses {
+		name, alias, err := parseProviderConfigAddress(address)
+
+		if err != nil {
+			return "", err
+		}
+
+		attributes := s.ProviderConfig[address]
+		names := make([]string, 0, len(attributes))
+
+		for attributeName := range attributes {
+			if !hclsyntax.ValidIdentifier(attributeName) || attributeName == "alias" {
+				return "", fmt.Errorf("provider %q has invalid attribute name %q", address, attributeName)
+			}
+
+			names = append(names, attributeName)
+		}
+
+		sort.Strings(names)
+
+		body := file.Body().AppendNewBlock("provider", []string{name}).Body()
+
+		if alias != "" {
+			body.SetAttributeValue("alias", cty.StringVal(alias))
+		}
+
+		for _, attributeName := range names {
+			value, err := providerConfigValue(attributes[attributeName])
+
+			if err != nil {
+				return "", fmt.Errorf("provider %q attribute %q: %w", address, attributeName, err)
+			}
+
+			body.SetAttributeValue(attributeName, value)
+		}
+
+		file.Body().AppendNewline()
+	}
+
+	return string(file.Bytes()), nil
+}
+
+// providerConfigValue returns the Terraform value of a ProviderConfig
+// attribute value, based on its JSON encoding.
+func providerConfigValue(value interface{}) (cty.Value, error) {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("unable to encode value: %w", err)
+	}
+
+	valueType, err := ctyjson.ImpliedType(b)
+
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("unable to determine value type: %w", err)
+	}
+
+	result, err := ctyjson.Unmarshal(b, valueType)
+
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("unable to decode value: %w", err)
+	}
+
+	return result, nil
+}
+
 // providerConfig takes the list of providers in a TestStep and returns a
 // config with only empty provider blocks. This is useful for Import, where no
 // config is provided, but the providers must be defined.
@@ -746,6 +746,84 @@ resource "test_test" "test" {}
 `,
 			},
 			expected: `
+resource "test_test" "test" {}
+`,
+		},
+		"teststep-providerconfig": {
+			testCase: TestCase{},
+			testStep: TestStep{
+				ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+					"test": nil,
+				},
+				ProviderConfig: map[string]map[string]interface{}{
+					"test": {
+						"endpoint":    "http://127.0.0.1:8080",
+						"max_retries": 1,
+						"skip_checks": true,
+						"headers": map[string]string{
+							"X-Test": "${not_interpolated}",
+						},
+					},
+					"test.alternate": {
+						"regions": []string{"one", "two"},
+					},
+				},
+				Config: `
+resource "test_test" "test" {}
+`,
+			},
+			expected: `
+provider "test" {
+  endpoint = "http://127.0.0.1:8080"
+  headers = {
+    X-Test = "$${not_interpolated}"
+  }
+  max_retries = 1
+  skip_checks = true
+}
+
+provider "test" {
+  alias   = "alternate"
+  regions = ["one", "two"]
+}
+
+
+resource "test_test" "test" {}
+`,
+		},
+		"teststep-providerconfig-externalproviders": {
+			testCase: TestCase{},
+			testStep: TestStep{
+				ExternalProviders: map[string]ExternalProvider{
+					"test": {
+						Source: "registry.terraform.io/hashicorp/test",
+					},
+					"other": {},
+				},
+				ProviderConfig: map[string]map[string]interface{}{
+					"test": {
+						"endpoint": "http://127.0.0.1:8080",
+					},
+				},
+				Config: `
+resource "test_test" "test" {}
+`,
+			},
+			expected: `
+terraform {
+  required_providers {
+    test = {
+      source = "registry.terraform.io/hashicorp/test"
+    }
+  }
+}
+
+
+provider "test" {
+  endpoint = "http://127.0.0.1:8080"
+}
+
+
 resource "test_test" "test" {}
 `,
 		},
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// sensitiveValuesPlanChecks returns a plan check that the
+// config.SensitiveStringVariable values of the ConfigVariables are redacted
+// in the plan, or nil if there are no sensitive values. The check is run
+// against every plan of the TestStep.
+func (s TestStep) sensitiveValuesPlanChecks() []plancheck.PlanCheck {
+	values := s.ConfigVariables.SensitiveValues()
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return []plancheck.PlanCheck{plancheck.ExpectSensitiveValuesRedacted(values...)}
+}
+
+// checkLogsRedacted returns an error if the Terraform log file at the given
+// path contains any of the sensitive values. The sensitive values are not
+// included in the error. An empty path, when logging to a file is not
+// enabled, is not checked.
+func checkLogsRedacted(logPath string, values []string) error {
+	if logPath == "" || len(values) == 0 {
+		return nil
+	}
+
+	logs, err := os.ReadFile(logPath)
+
+	if err != nil {
+		return fmt.Errorf("unable to read Terraform logs: %w", err)
+	}
+
+	for _, value := range values {
+		if strings.Contains(string(logs), value) {
+			return fmt.Errorf("Terraform logs at %s contain an unredacted sensitive ConfigVariables value", logPath)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestTestStepSensitiveValuesPlanChecks(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		step          TestStep
+		expectedCount int
+	}{
+		"no-variables": {
+			step: TestStep{
+				ConfigPlanChecks: ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			expectedCount: 0,
+		},
+		"no-sensitive-variables": {
+			step: TestStep{
+				ConfigVariables: config.Variables{
+					"name": config.StringVariable("example"),
+				},
+			},
+			expectedCount: 0,
+		},
+		"sensitive-variables": {
+			step: TestStep{
+				ConfigPlanChecks: ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				ConfigVariables: config.Variables{
+					"password": config.SensitiveStringVariable("secret"),
+				},
+			},
+			expectedCount: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.step.sensitiveValuesPlanChecks()
+
+			if len(got) != testCase.expectedCount {
+				t.Errorf("expected %d plan checks, got %d", testCase.expectedCount, len(got))
+			}
+		})
+	}
+}
+
+func TestTest_TestStep_ConfigVariables_SensitiveValuesRedacted_PlanOnly(t *testing.T) {
+	t.Parallel()
+
+	UnitTest(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"examplecloud": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"examplecloud_thing": {
+							CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								d.SetId("resource-test")
+
+								return nil
+							},
+							DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+								return nil
+							},
+							Schema: map[string]*schema.Schema{
+								"name": {
+									ForceNew: true,
+									Optional: true,
+									Type:     schema.TypeString,
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Config: `
+					variable "password" {
+						type      = string
+						sensitive = true
+					}
+
+					resource "examplecloud_thing" "test" {
+						name = nonsensitive(var.password)
+					}
+				`,
+				ConfigVariables: config.Variables{
+					"password": config.SensitiveStringVariable("secret"),
+				},
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ExpectError:        regexp.MustCompile(`examplecloud_thing.test - sensitive value is not redacted in planned value at path name`),
+			},
+		},
+	})
+}
+
+func TestCheckLogsRedacted(t *testing.T) {
+	t.Parallel()
+
+	logPath := filepath.Join(t.TempDir(), "terraform.log")
+
+	if err := os.WriteFile(logPath, []byte("[DEBUG] password = secret\n"), 0600); err != nil {
+		t.Fatalf("unable to write log file: %s", err)
+	}
+
+	testCases := map[string]struct {
+		logPath       string
+		values        []string
+		expectedError error
+	}{
+		"no-log-path": {
+			values: []string{"secret"},
+		},
+		"no-values": {
+			logPath: logPath,
+		},
+		"redacted": {
+			logPath: logPath,
+			values:  []string{"token"},
+		},
+		"unredacted": {
+			logPath:       logPath,
+			values:        []string{"token", "secret"},
+			expectedError: fmt.Errorf("Terraform logs at %s contain an unredacted sensitive ConfigVariables value", logPath),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkLogsRedacted(testCase.logPath, testCase.values)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Errorf("expected error: %s", testCase.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mitchellh/go-testing-interface"
+)
+
+// stepSubtests returns true if each TestStep is run as a subtest, which is
+// required when the StepSubtests field is enabled or any TestStep has set
+// the Name field, and otherwise optional when enabled by the
+// TF_ACC_STEP_SUBTESTS environment variable.
+func (c TestCase) stepSubtests() (enabled bool, required bool) {
+	if c.StepSubtests || c.hasStepNames() {
+		return true, true
+	}
+
+	return os.Getenv(EnvTfAccStepSubtests) != "", false
+}
+
+// hasStepNames returns true if any TestStep has set the Name field, which
+// enables running each TestStep as a subtest.
+func (c TestCase) hasStepNames() bool {
+	for _, step := range c.Steps {
+		if step.Name != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subtestName returns the name of the subtest running the TestStep, which is
+// the Name field or step_N, where N is the 1-based index of the TestStep.
+func (s TestStep) subtestName(stepNumber int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	return "step_" + strconv.Itoa(stepNumber)
+}
+
+// stepSubtest is the testing.T of a TestStep subtest. The TestCase test name
+// is returned by Name, so that configuration directories and files, journal
+// entries, and check requests are unaffected by running the TestStep as a
+// subtest.
+type stepSubtest struct {
+	testing.T
+
+	caseName string
+}
+
+func (t stepSubtest) Name() string {
+	return t.caseName
+}
+
+// progress returns the 1-based index of the TestStep and the number of
+// TestStep in the TestCase for failure messages, such as 7/15, followed by
+// the Name in parentheses if set, such as 7/15 (update).
+func (s TestStep) progress(stepNumber int, stepCount int) string {
+	if s.Name != "" {
+		return fmt.Sprintf("%d/%d (%s)", stepNumber, stepCount, s.Name)
+	}
+
+	return fmt.Sprintf("%d/%d", stepNumber, stepCount)
+}
@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestTestCaseHasStepNames(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testCase TestCase
+		expected bool
+	}{
+		"none": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{Config: "# not empty"},
+					{Config: "# not empty"},
+				},
+			},
+			expected: false,
+		},
+		"some": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{Config: "# not empty"},
+					{Config: "# not empty", Name: "update"},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testCase.hasStepNames()
+
+			if got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestTestCaseStepSubtests(t *testing.T) {
+	testCases := map[string]struct {
+		testCase         TestCase
+		env              string
+		expectedEnabled  bool
+		expectedRequired bool
+	}{
+		"none": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{Config: "# not empty"},
+				},
+			},
+		},
+		"env": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{Config: "# not empty"},
+				},
+			},
+			env:             "1",
+			expectedEnabled: true,
+		},
+		"field": {
+			testCase: TestCase{
+				StepSubtests: true,
+				Steps: []TestStep{
+					{Config: "# not empty"},
+				},
+			},
+			expectedEnabled:  true,
+			expectedRequired: true,
+		},
+		"name": {
+			testCase: TestCase{
+				Steps: []TestStep{
+					{Config: "# not empty", Name: "create"},
+				},
+			},
+			env:              "1",
+			expectedEnabled:  true,
+			expectedRequired: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvTfAccStepSubtests, testCase.env)
+
+			enabled, required := testCase.testCase.stepSubtests()
+
+			if enabled != testCase.expectedEnabled {
+				t.Errorf("expected enabled %t, got %t", testCase.expectedEnabled, enabled)
+			}
+
+			if required != testCase.expectedRequired {
+				t.Errorf("expected required %t, got %t", testCase.expectedRequired, required)
+			}
+		})
+	}
+}
+
+func TestTestStepSubtestName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testStep   TestStep
+		stepNumber int
+		expected   string
+	}{
+		"name": {
+			testStep:   TestStep{Name: "update"},
+			stepNumber: 2,
+			expected:   "update",
+		},
+		"no-name": {
+			testStep:   TestStep{},
+			stepNumber: 2,
+			expected:   "step_2",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testStep.subtestName(testCase.stepNumber)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestTestStepProgress(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		testStep   TestStep
+		stepNumber int
+		stepCount  int
+		expected   string
+	}{
+		"name": {
+			testStep:   TestStep{Name: "update"},
+			stepNumber: 7,
+			stepCount:  15,
+			expected:   "7/15 (update)",
+		},
+		"no-name": {
+			testStep:   TestStep{},
+			stepNumber: 7,
+			stepCount:  15,
+			expected:   "7/15",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.testStep.progress(testCase.stepNumber, testCase.stepCount)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestStepSubtestName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		got := stepSubtest{T: t, caseName: "TestExample"}.Name()
+
+		if got != "TestExample" {
+			t.Errorf("expected %q, got %q", "TestExample", got)
+		}
+	})
+}
+
+func TestTest_TestStep_Name(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return migrationTestProvider(), nil
+			},
+		},
+		Steps: []TestStep{
+			{
+				Name:   "create",
+				Config: `resource "test_resource" "test" { name = "create" }`,
+				Check:  TestCheckResourceAttr("test_resource.test", "name", "create"),
+			},
+			{
+				Name:   "update",
+				Config: `resource "test_resource" "test" { name = "update" }`,
+				Check:  TestCheckResourceAttr("test_resource.test", "name", "update"),
+			},
+			{
+				// Runs as the step_3 subtest.
+				Config:   `resource "test_resource" "test" { name = "update" }`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestTest_TestCase_StepSubtests(t *testing.T) {
+	t.Parallel()
+
+	Test(t, TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"test": func() (*schema.Provider, error) { //nolint:unparam // required signature
+				return migrationTestProvider(), nil
+			},
+		},
+		StepSubtests: true,
+		Steps: []TestStep{
+			{
+				// Runs as the step_1 subtest.
+				Config: `resource "test_resource" "test" { name = "create" }`,
+				Check:  TestCheckResourceAttr("test_resource.test", "name", "create"),
+			},
+			{
+				// Runs as the step_2 subtest.
+				Config:   `resource "test_resource" "test" { name = "create" }`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
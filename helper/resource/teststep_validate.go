@@ -6,6 +6,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
 )
@@ -19,6 +20,10 @@ type testStepValidateRequest struct {
 	// ExternalProviders, ProtoV5ProviderFactories, ProtoV6ProviderFactories,
 	// or ProviderFactories.
 	TestCaseHasProviders bool
+
+	// TestCaseHasExternalProviders is enabled if the TestCase has set
+	// ExternalProviders.
+	TestCaseHasExternalProviders bool
 }
 
 // hasProviders returns true if the TestStep has set any of the
@@ -46,8 +51,29 @@ func (s TestStep) hasProviders(_ context.Context) bool {
 
 // validate ensures the TestStep is valid based on the following criteria:
 //
-//   - Config or ImportState or RefreshState is set.
+//   - Config or ConfigDirectory or ConfigFile or ImportState or RefreshState
+//     is set.
+//   - Only one of Config, ConfigDirectory, or ConfigFile is set.
 //   - Config and RefreshState are not both set.
+//   - ConfigDirectory and RefreshState are not both set.
+//   - ConfigFile and RefreshState are not both set.
+//   - ConfigVariables and RefreshState are not both set.
+//   - ConfigGraphChecks are only set with Config, ConfigDirectory, or
+//     ConfigFile and without ImportState.
+//   - ExpectDeprecatedAttributes is only set with Config, ConfigDirectory,
+//     or ConfigFile and without ImportState.
+//   - ExpectDiagnosticAttributePaths is only set with Config,
+//     ConfigDirectory, or ConfigFile and without ImportState.
+//   - ExpectProviderCrash is only set with Config, ConfigDirectory, or
+//     ConfigFile and without ImportState.
+//   - ExpectProviderInconsistency is only set with Config, ConfigDirectory,
+//     or ConfigFile and without ImportState or PlanOnly.
+//   - ConfigAliasModule is only set with Config and without ImportState,
+//     and is valid.
+//   - ProviderConfig is only set with Config and without
+//     ConfigAliasModule, and is valid.
+//   - ExternalProviders are not set in the TestCase or TestStep when
+//     ConfigDirectory or ConfigFile is set.
 //   - RefreshState and Destroy are not both set.
 //   - RefreshState is not the first TestStep.
 //   - Providers are not specified (ExternalProviders,
@@ -55,17 +81,45 @@ func (s TestStep) hasProviders(_ context.Context) bool {
 //     if specified at the TestCase level.
 //   - Providers are specified (ExternalProviders, ProtoV5ProviderFactories,
 //     ProtoV6ProviderFactories, ProviderFactories) if not specified at the
-//     TestCase level.
+//     TestCase level and ConfigDirectory or ConfigFile is not set.
 //   - No overlapping ExternalProviders and ProviderFactories entries
 //   - ResourceName is not empty when ImportState is true, ImportStateIdFunc
 //     is not set, and ImportStateId is not set.
+//   - DestroyPersistedImport is only set when ImportState and
+//     ImportStatePersist are true.
+//   - ConfigPlanChecks.PreApply are only set when PlanOnly is false.
+//   - RefreshPlanChecks.PostRefresh are only set when RefreshState is true.
+//   - DestroyPlanChecks.PreDestroy are only set when Destroy is true and
+//     PlanOnly is false.
+//   - ConvergenceApplies is not negative.
+//   - ConvergenceApplies is not greater than 1 when Destroy or PlanOnly is
+//     true.
+//   - Name does not contain a slash.
 func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) error {
 	ctx = logging.TestStepNumberContext(ctx, req.StepNumber)
 
 	logging.HelperResourceTrace(ctx, "Validating TestStep")
 
-	if s.Config == "" && !s.ImportState && !s.RefreshState {
-		err := fmt.Errorf("TestStep missing Config or ImportState or RefreshState")
+	if !s.hasConfig() && !s.ImportState && !s.RefreshState {
+		err := fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.Config != "" && s.ConfigDirectory != nil {
+		err := fmt.Errorf("TestStep cannot have Config and ConfigDirectory")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.Config != "" && s.ConfigFile != nil {
+		err := fmt.Errorf("TestStep cannot have Config and ConfigFile")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigDirectory != nil && s.ConfigFile != nil {
+		err := fmt.Errorf("TestStep cannot have ConfigDirectory and ConfigFile")
 		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
 		return err
 	}
@@ -76,6 +130,94 @@ func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) err
 		return err
 	}
 
+	if s.ConfigDirectory != nil && s.RefreshState {
+		err := fmt.Errorf("TestStep cannot have ConfigDirectory and RefreshState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigFile != nil && s.RefreshState {
+		err := fmt.Errorf("TestStep cannot have ConfigFile and RefreshState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigVariables != nil && s.RefreshState {
+		err := fmt.Errorf("TestStep cannot have ConfigVariables and RefreshState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigDirectory != nil && (req.TestCaseHasExternalProviders || len(s.ExternalProviders) > 0) {
+		err := fmt.Errorf("TestStep ConfigDirectory cannot be used with ExternalProviders, providers must be declared in the configuration files")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigFile != nil && (req.TestCaseHasExternalProviders || len(s.ExternalProviders) > 0) {
+		err := fmt.Errorf("TestStep ConfigFile cannot be used with ExternalProviders, providers must be declared in the configuration file")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.ConfigGraphChecks) > 0 && (!s.hasConfig() || s.ImportState) {
+		err := fmt.Errorf("TestStep ConfigGraphChecks requires Config, ConfigDirectory, or ConfigFile without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.ExpectDeprecatedAttributes) > 0 && (!s.hasConfig() || s.ImportState) {
+		err := fmt.Errorf("TestStep ExpectDeprecatedAttributes requires Config, ConfigDirectory, or ConfigFile without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.ExpectDiagnosticAttributePaths) > 0 && (!s.hasConfig() || s.ImportState) {
+		err := fmt.Errorf("TestStep ExpectDiagnosticAttributePaths requires Config, ConfigDirectory, or ConfigFile without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ExpectProviderCrash && (!s.hasConfig() || s.ImportState) {
+		err := fmt.Errorf("TestStep ExpectProviderCrash requires Config, ConfigDirectory, or ConfigFile without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ExpectProviderInconsistency != nil && (!s.hasConfig() || s.ImportState || s.PlanOnly) {
+		err := fmt.Errorf("TestStep ExpectProviderInconsistency requires Config, ConfigDirectory, or ConfigFile without ImportState or PlanOnly")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigAliasModule != nil && (s.Config == "" || s.ImportState) {
+		err := fmt.Errorf("TestStep ConfigAliasModule requires Config without ImportState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConfigAliasModule != nil {
+		if err := s.ConfigAliasModule.validate(); err != nil {
+			err = fmt.Errorf("TestStep ConfigAliasModule: %w", err)
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
+	if len(s.ProviderConfig) > 0 && (s.Config == "" || s.ConfigAliasModule != nil) {
+		err := fmt.Errorf("TestStep ProviderConfig requires Config without ConfigAliasModule")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.ProviderConfig) > 0 {
+		if _, err := s.providerConfigBlocks(); err != nil {
+			err = fmt.Errorf("TestStep ProviderConfig: %w", err)
+			logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+			return err
+		}
+	}
+
 	if s.RefreshState && s.Destroy {
 		err := fmt.Errorf("TestStep cannot have RefreshState and Destroy")
 		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
@@ -110,7 +252,7 @@ func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) err
 		return err
 	}
 
-	if !req.TestCaseHasProviders && !hasProviders {
+	if !req.TestCaseHasProviders && !hasProviders && !s.hasExternalConfig() {
 		err := fmt.Errorf("Providers must be specified at the TestCase level or in all TestStep")
 		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
 		return err
@@ -124,5 +266,53 @@ func (s TestStep) validate(ctx context.Context, req testStepValidateRequest) err
 		}
 	}
 
+	if s.DestroyPersistedImport != nil && (!s.ImportState || !s.ImportStatePersist) {
+		err := fmt.Errorf("TestStep DestroyPersistedImport must only be set with ImportState and ImportStatePersist")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.ConfigPlanChecks.PreApply) > 0 && s.PlanOnly {
+		err := fmt.Errorf("TestStep ConfigPlanChecks.PreApply cannot be run with PlanOnly")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.RefreshPlanChecks.PostRefresh) > 0 && !s.RefreshState {
+		err := fmt.Errorf("TestStep RefreshPlanChecks.PostRefresh must only be set with RefreshState")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.DestroyPlanChecks.PreDestroy) > 0 && !s.Destroy {
+		err := fmt.Errorf("TestStep DestroyPlanChecks.PreDestroy must only be set with Destroy")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if len(s.DestroyPlanChecks.PreDestroy) > 0 && s.PlanOnly {
+		err := fmt.Errorf("TestStep DestroyPlanChecks.PreDestroy cannot be run with PlanOnly")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConvergenceApplies < 0 {
+		err := fmt.Errorf("TestStep ConvergenceApplies must not be negative")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if s.ConvergenceApplies > 1 && (s.Destroy || s.PlanOnly) {
+		err := fmt.Errorf("TestStep ConvergenceApplies cannot be set with Destroy or PlanOnly")
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
+	if strings.Contains(s.Name, "/") {
+		err := fmt.Errorf("TestStep Name %q cannot contain /", s.Name)
+		logging.HelperResourceError(ctx, "TestStep validation error", map[string]interface{}{logging.KeyError: err})
+		return err
+	}
+
 	return nil
 }
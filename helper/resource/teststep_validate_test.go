@@ -13,6 +13,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/graphcheck"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestTestStepHasProviders(t *testing.T) {
@@ -86,7 +91,7 @@ func TestTestStepValidate(t *testing.T) {
 		"config-and-importstate-and-refreshstate-missing": {
 			testStep:                TestStep{},
 			testStepValidateRequest: testStepValidateRequest{},
-			expectedError:           fmt.Errorf("TestStep missing Config or ImportState or RefreshState"),
+			expectedError:           fmt.Errorf("TestStep missing Config or ConfigDirectory or ConfigFile or ImportState or RefreshState"),
 		},
 		"config-and-refreshstate-both-set": {
 			testStep: TestStep{
@@ -95,6 +100,254 @@ func TestTestStepValidate(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("TestStep cannot have Config and RefreshState"),
 		},
+		"config-and-configdirectory-both-set": {
+			testStep: TestStep{
+				Config:          "# not empty",
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string"),
+			},
+			expectedError: fmt.Errorf("TestStep cannot have Config and ConfigDirectory"),
+		},
+		"config-and-configfile-both-set": {
+			testStep: TestStep{
+				Config:     "# not empty",
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string/main.tf"),
+			},
+			expectedError: fmt.Errorf("TestStep cannot have Config and ConfigFile"),
+		},
+		"configdirectory-and-configfile-both-set": {
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string"),
+				ConfigFile:      config.StaticFile("testdata/fixtures/random_string/main.tf"),
+			},
+			expectedError: fmt.Errorf("TestStep cannot have ConfigDirectory and ConfigFile"),
+		},
+		"configfile-and-refreshstate-both-set": {
+			testStep: TestStep{
+				ConfigFile:   config.StaticFile("testdata/fixtures/random_string/main.tf"),
+				RefreshState: true,
+			},
+			expectedError: fmt.Errorf("TestStep cannot have ConfigFile and RefreshState"),
+		},
+		"configfile-teststep-externalproviders": {
+			testStep: TestStep{
+				ConfigFile: config.StaticFile("testdata/fixtures/random_string/main.tf"),
+				ExternalProviders: map[string]ExternalProvider{
+					"test": {}, // does not need to be real
+				},
+			},
+			expectedError: fmt.Errorf("TestStep ConfigFile cannot be used with ExternalProviders, providers must be declared in the configuration file"),
+		},
+		"configvariables-and-refreshstate-both-set": {
+			testStep: TestStep{
+				ConfigVariables: config.Variables{
+					"name": config.StringVariable("value"),
+				},
+				RefreshState: true,
+			},
+			expectedError: fmt.Errorf("TestStep cannot have ConfigVariables and RefreshState"),
+		},
+		"expectdeprecatedattributes-importstate": {
+			testStep: TestStep{
+				Config:                     "# not empty",
+				ExpectDeprecatedAttributes: []string{"random_string.test.number"},
+				ImportState:                true,
+			},
+			expectedError: fmt.Errorf("TestStep ExpectDeprecatedAttributes requires Config, ConfigDirectory, or ConfigFile without ImportState"),
+		},
+		"expectdeprecatedattributes-refreshstate": {
+			testStep: TestStep{
+				ExpectDeprecatedAttributes: []string{"random_string.test.number"},
+				RefreshState:               true,
+			},
+			expectedError: fmt.Errorf("TestStep ExpectDeprecatedAttributes requires Config, ConfigDirectory, or ConfigFile without ImportState"),
+		},
+		"expectdiagnosticattributepaths-importstate": {
+			testStep: TestStep{
+				Config:                         "# not empty",
+				ExpectDiagnosticAttributePaths: []tfjsonpath.Path{tfjsonpath.New("length")},
+				ImportState:                    true,
+			},
+			expectedError: fmt.Errorf("TestStep ExpectDiagnosticAttributePaths requires Config, ConfigDirectory, or ConfigFile without ImportState"),
+		},
+		"expectprovidercrash-importstate": {
+			testStep: TestStep{
+				ExpectProviderCrash: true,
+				ImportState:         true,
+			},
+			expectedError: fmt.Errorf("TestStep ExpectProviderCrash requires Config, ConfigDirectory, or ConfigFile without ImportState"),
+		},
+		"expectprovidercrash-refreshstate": {
+			testStep: TestStep{
+				ExpectProviderCrash: true,
+				RefreshState:        true,
+			},
+			testStepValidateRequest: testStepValidateRequest{StepNumber: 2},
+			expectedError:           fmt.Errorf("TestStep ExpectProviderCrash requires Config, ConfigDirectory, or ConfigFile without ImportState"),
+		},
+		"expectproviderinconsistency-importstate": {
+			testStep: TestStep{
+				ExpectProviderInconsistency: &ProviderInconsistency{},
+				ImportState:                 true,
+			},
+			expectedError: fmt.Errorf("TestStep ExpectProviderInconsistency requires Config, ConfigDirectory, or ConfigFile without ImportState or PlanOnly"),
+		},
+		"expectproviderinconsistency-planonly": {
+			testStep: TestStep{
+				Config:                      "# not empty",
+				ExpectProviderInconsistency: &ProviderInconsistency{},
+				PlanOnly:                    true,
+			},
+			expectedError: fmt.Errorf("TestStep ExpectProviderInconsistency requires Config, ConfigDirectory, or ConfigFile without ImportState or PlanOnly"),
+		},
+		"configaliasmodule-importstate": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigAliasModule: &AliasModule{
+					Providers: map[string]string{"examplecloud.alternate": "examplecloud"},
+				},
+				ImportState: true,
+			},
+			expectedError: fmt.Errorf("TestStep ConfigAliasModule requires Config without ImportState"),
+		},
+		"providerconfig-no-config": {
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory("# not empty"),
+				ProviderConfig: map[string]map[string]interface{}{
+					"examplecloud": {"endpoint": "http://127.0.0.1"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ProviderConfig requires Config without ConfigAliasModule"),
+		},
+		"providerconfig-configaliasmodule": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigAliasModule: &AliasModule{
+					Providers: map[string]string{"examplecloud.alternate": "examplecloud"},
+				},
+				ProviderConfig: map[string]map[string]interface{}{
+					"examplecloud": {"endpoint": "http://127.0.0.1"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ProviderConfig requires Config without ConfigAliasModule"),
+		},
+		"providerconfig-invalid-address": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ProviderConfig: map[string]map[string]interface{}{
+					"examplecloud.alternate.extra": {"endpoint": "http://127.0.0.1"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ProviderConfig: invalid provider configuration address \"examplecloud.alternate.extra\""),
+		},
+		"providerconfig-invalid-attribute-name": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ProviderConfig: map[string]map[string]interface{}{
+					"examplecloud": {"alias": "alternate"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ProviderConfig: provider \"examplecloud\" has invalid attribute name \"alias\""),
+		},
+		"providerconfig-invalid-value": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ProviderConfig: map[string]map[string]interface{}{
+					"examplecloud": {"endpoint": func() {}},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ProviderConfig: provider \"examplecloud\" attribute \"endpoint\": unable to encode value: json: unsupported type: func()"),
+		},
+		"configaliasmodule-invalid-name": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigAliasModule: &AliasModule{
+					Name:      "1test",
+					Providers: map[string]string{"examplecloud.alternate": "examplecloud"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ConfigAliasModule: invalid module name \"1test\""),
+		},
+		"configaliasmodule-no-providers": {
+			testStep: TestStep{
+				Config:            "# not empty",
+				ConfigAliasModule: &AliasModule{},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ConfigAliasModule: Providers must not be empty"),
+		},
+		"configaliasmodule-invalid-address": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigAliasModule: &AliasModule{
+					Providers: map[string]string{"examplecloud.alternate.extra": "examplecloud"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ConfigAliasModule: invalid provider configuration address \"examplecloud.alternate.extra\""),
+		},
+		"configaliasmodule-provider-name-mismatch": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigAliasModule: &AliasModule{
+					Providers: map[string]string{"examplecloud.alternate": "othercloud.secondary"},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+			expectedError:           fmt.Errorf("TestStep ConfigAliasModule: module provider \"examplecloud.alternate\" and root provider \"othercloud.secondary\" must have the same provider name"),
+		},
+		"configaliasmodule-valid": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigAliasModule: &AliasModule{
+					Providers: map[string]string{
+						"examplecloud":           "examplecloud",
+						"examplecloud.alternate": "examplecloud.secondary",
+					},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{TestCaseHasProviders: true},
+		},
+		"configgraphchecks-importstate": {
+			testStep: TestStep{
+				ConfigGraphChecks: []graphcheck.GraphCheck{
+					graphcheck.ExpectDependency("example_widget.b", "example_widget.a"),
+				},
+				ImportState: true,
+			},
+			expectedError: fmt.Errorf("TestStep ConfigGraphChecks requires Config, ConfigDirectory, or ConfigFile without ImportState"),
+		},
+		"configdirectory-and-refreshstate-both-set": {
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string"),
+				RefreshState:    true,
+			},
+			expectedError: fmt.Errorf("TestStep cannot have ConfigDirectory and RefreshState"),
+		},
+		"configdirectory-testcase-externalproviders": {
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string"),
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasExternalProviders: true,
+				TestCaseHasProviders:         true,
+			},
+			expectedError: fmt.Errorf("TestStep ConfigDirectory cannot be used with ExternalProviders, providers must be declared in the configuration files"),
+		},
+		"configdirectory-teststep-externalproviders": {
+			testStep: TestStep{
+				ConfigDirectory: config.StaticDirectory("testdata/fixtures/random_string"),
+				ExternalProviders: map[string]ExternalProvider{
+					"test": {}, // does not need to be real
+				},
+			},
+			expectedError: fmt.Errorf("TestStep ConfigDirectory cannot be used with ExternalProviders, providers must be declared in the configuration files"),
+		},
 		"refreshstate-first-step": {
 			testStep: TestStep{
 				RefreshState: true,
@@ -120,6 +373,107 @@ func TestTestStepValidate(t *testing.T) {
 			testStepValidateRequest: testStepValidateRequest{},
 			expectedError:           fmt.Errorf("TestStep cannot have RefreshState and Destroy"),
 		},
+		"configplanchecks-preapply-planonly": {
+			testStep: TestStep{
+				Config: "# not empty",
+				ConfigPlanChecks: ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						&planCheckSpy{},
+					},
+				},
+				PlanOnly: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep ConfigPlanChecks.PreApply cannot be run with PlanOnly"),
+		},
+		"refreshplanchecks-postrefresh-refreshstate-missing": {
+			testStep: TestStep{
+				Config: "# not empty",
+				RefreshPlanChecks: RefreshPlanChecks{
+					PostRefresh: []plancheck.PlanCheck{
+						&planCheckSpy{},
+					},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep RefreshPlanChecks.PostRefresh must only be set with RefreshState"),
+		},
+		"destroyplanchecks-predestroy-destroy-missing": {
+			testStep: TestStep{
+				Config: "# not empty",
+				DestroyPlanChecks: DestroyPlanChecks{
+					PreDestroy: []plancheck.PlanCheck{
+						&planCheckSpy{},
+					},
+				},
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep DestroyPlanChecks.PreDestroy must only be set with Destroy"),
+		},
+		"destroyplanchecks-predestroy-planonly": {
+			testStep: TestStep{
+				Config:  "# not empty",
+				Destroy: true,
+				DestroyPlanChecks: DestroyPlanChecks{
+					PreDestroy: []plancheck.PlanCheck{
+						&planCheckSpy{},
+					},
+				},
+				PlanOnly: true,
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep DestroyPlanChecks.PreDestroy cannot be run with PlanOnly"),
+		},
+		"convergenceapplies-negative": {
+			testStep: TestStep{
+				Config:             "# not empty",
+				ConvergenceApplies: -1,
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep ConvergenceApplies must not be negative"),
+		},
+		"convergenceapplies-destroy": {
+			testStep: TestStep{
+				Config:             "# not empty",
+				ConvergenceApplies: 2,
+				Destroy:            true,
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep ConvergenceApplies cannot be set with Destroy or PlanOnly"),
+		},
+		"name-slash": {
+			testStep: TestStep{
+				Config: "# not empty",
+				Name:   "create/update",
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep Name \"create/update\" cannot contain /"),
+		},
+		"convergenceapplies-planonly": {
+			testStep: TestStep{
+				Config:             "# not empty",
+				ConvergenceApplies: 2,
+				PlanOnly:           true,
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep ConvergenceApplies cannot be set with Destroy or PlanOnly"),
+		},
 		"externalproviders-overlapping-providerfactories": {
 			testStep: TestStep{
 				Config: "# not empty",
@@ -145,6 +499,17 @@ func TestTestStepValidate(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("Providers must only be specified either at the TestCase or TestStep level"),
 		},
+		"destroypersistedimport-missing-importstatepersist": {
+			testStep: TestStep{
+				ImportState:            true,
+				ResourceName:           "test_resource.test",
+				DestroyPersistedImport: new(bool),
+			},
+			testStepValidateRequest: testStepValidateRequest{
+				TestCaseHasProviders: true,
+			},
+			expectedError: fmt.Errorf("TestStep DestroyPersistedImport must only be set with ImportState and ImportStatePersist"),
+		},
 		"importstate-missing-resourcename": {
 			testStep: TestStep{
 				ImportState: true,
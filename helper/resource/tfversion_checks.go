@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-version"
+	"github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// runTFVersionChecks calls each of the given Terraform version checks in
+// order, failing the test on the first error or skipping the test on the first
+// skip. It returns true if the remainder of the test should not run.
+func runTFVersionChecks(ctx context.Context, t testing.T, terraformVersion *version.Version, versionChecks []tfversion.TerraformVersionCheck) bool {
+	t.Helper()
+
+	for _, versionCheck := range versionChecks {
+		resp := tfversion.CheckTerraformVersionResponse{}
+		versionCheck.CheckTerraformVersion(ctx, tfversion.CheckTerraformVersionRequest{TerraformVersion: terraformVersion}, &resp)
+
+		if resp.Error != nil {
+			logging.HelperResourceError(ctx,
+				"Terraform version check failed",
+				map[string]interface{}{logging.KeyError: resp.Error},
+			)
+			t.Fatalf("Failed Terraform version check: %s", resp.Error)
+
+			return true
+		}
+
+		if resp.Skip != "" {
+			logging.HelperResourceDebug(ctx, "Skipping TestCase due to Terraform version check")
+			t.Skip(resp.Skip)
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	testinginterface "github.com/mitchellh/go-testing-interface"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// tfVersionCheckT records test failures instead of panicking, so the
+// failure path of runTFVersionChecks can be verified.
+type tfVersionCheckT struct {
+	testinginterface.RuntimeT
+
+	fatal string
+}
+
+func (t *tfVersionCheckT) Fatalf(format string, args ...interface{}) {
+	t.fatal = fmt.Sprintf(format, args...)
+}
+
+func TestRunTFVersionChecks(t *testing.T) {
+	t.Parallel()
+
+	terraformVersion := version.Must(version.NewVersion("1.2.0"))
+
+	testCases := map[string]struct {
+		versionChecks  []tfversion.TerraformVersionCheck
+		expectedHalted bool
+		expectedFatal  string
+	}{
+		"none": {},
+		"pass": {
+			versionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.RequireAbove(tfversion.Version1_0_0),
+				tfversion.SkipBelow(tfversion.Version1_1_0),
+			},
+		},
+		"skip": {
+			versionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.RequireAbove(tfversion.Version1_0_0),
+				tfversion.SkipBelow(tfversion.Version1_3_0),
+			},
+			expectedHalted: true,
+		},
+		"error": {
+			versionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.RequireAbove(tfversion.Version1_3_0),
+				tfversion.SkipBelow(tfversion.Version1_3_0),
+			},
+			expectedHalted: true,
+			expectedFatal:  "Failed Terraform version check: expected Terraform CLI version above 1.3.0 but detected version is 1.2.0",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mt := &tfVersionCheckT{}
+
+			halted := runTFVersionChecks(context.Background(), mt, terraformVersion, testCase.versionChecks)
+
+			if halted != testCase.expectedHalted {
+				t.Errorf("expected halted %t, got %t", testCase.expectedHalted, halted)
+			}
+
+			if mt.fatal != testCase.expectedFatal {
+				t.Errorf("expected fatal %q, got %q", testCase.expectedFatal, mt.fatal)
+			}
+
+			if mt.Skipped() && testCase.expectedFatal != "" {
+				t.Errorf("expected no skip after failure")
+			}
+		})
+	}
+}
+
+func TestTest_TerraformVersionChecks_Skip(t *testing.T) {
+	t.Parallel()
+
+	// All supported Terraform CLI versions are above 0.12.26, so the
+	// invalid configuration is never applied.
+	Test(t, TestCase{
+		ExternalProviders: map[string]ExternalProvider{
+			"random": {
+				Source: "registry.terraform.io/hashicorp/random",
+			},
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipAbove(tfversion.Version0_12_26),
+		},
+		Steps: []TestStep{
+			{
+				Config: `resource "random_string" "test" {}`,
+			},
+		},
+	})
+}
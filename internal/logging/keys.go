@@ -25,6 +25,9 @@ const (
 	// registry.terraform.io/hashicorp/random
 	KeyProviderAddress = "tf_provider_addr"
 
+	// The provider protocol version used to serve a provider.
+	KeyProviderProtocolVersion = "tf_provider_protocol_version"
+
 	// The type of resource being operated on, such as "random_pet"
 	KeyResourceType = "tf_resource_type"
 
@@ -58,6 +61,9 @@ const (
 	// Terraform plan output generated during a TestStep.
 	KeyTestTerraformPlan = "test_terraform_plan"
 
+	// The Terraform CLI version a TestCase is run against.
+	KeyTestTerraformVersion = "test_terraform_version"
+
 	// The working directory of the acceptance test.
 	KeyTestWorkingDirectory = "test_working_directory"
 )
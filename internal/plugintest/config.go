@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/go-version"
@@ -35,16 +36,74 @@ type Config struct {
 // discover a reasonable test helper configuration.
 func DiscoverConfig(ctx context.Context, sourceDir string) (*Config, error) {
 	tfVersion := strings.TrimPrefix(os.Getenv(EnvTfAccTerraformVersion), "v")
+	tfVersionConstraint := os.Getenv(EnvTfAccTerraformVersionConstraint)
 	tfPath := os.Getenv(EnvTfAccTerraformPath)
+	tofuPath := os.Getenv(EnvTfAccTofuPath)
 
-	tempDir := os.Getenv(EnvTfAccTempDir)
-	tfDir, err := os.MkdirTemp(tempDir, "plugintest-terraform")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	return discoverConfig(ctx, sourceDir, tfVersion, tfVersionConstraint, tfPath, tofuPath)
+}
+
+// DiscoverVersionConfig is a variant of DiscoverConfig which finds or
+// installs the given Terraform CLI version, such as 1.5.0, regardless of the
+// TF_ACC_TERRAFORM_PATH, TF_ACC_TERRAFORM_VERSION,
+// TF_ACC_TERRAFORM_VERSION_CONSTRAINT, and TF_ACC_TOFU_PATH environment
+// variables. The TF_ACC_TERRAFORM_CACHE_DIR and TF_ACC_TERRAFORM_OFFLINE
+// environment variables are still honored.
+func DiscoverVersionConfig(ctx context.Context, sourceDir string, tfVersion string) (*Config, error) {
+	tfVersion = strings.TrimPrefix(tfVersion, "v")
+
+	if tfVersion == "" {
+		return nil, fmt.Errorf("missing Terraform version")
+	}
+
+	return discoverConfig(ctx, sourceDir, tfVersion, "", "", "")
+}
+
+// discoverConfig finds or installs Terraform CLI based on the given exact
+// path, version, or version constraint, in that order of precedence.
+func discoverConfig(ctx context.Context, sourceDir, tfVersion, tfVersionConstraint, tfPath, tofuPath string) (*Config, error) {
+	tfCacheDir := os.Getenv(EnvTfAccTerraformCacheDir)
+	tfOffline := os.Getenv(EnvTfAccTerraformOffline) != ""
+
+	// Installations into the cache directory are kept after the test run,
+	// otherwise a temporary directory is created and later removed.
+	var tfDir, execTempDir string
+
+	if tfCacheDir == "" {
+		tempDir := os.Getenv(EnvTfAccTempDir)
+		dir, err := os.MkdirTemp(tempDir, "plugintest-terraform")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp dir: %w", err)
+		}
+
+		tfDir = dir
+		execTempDir = dir
+	}
+
+	// installDir returns the directory for installing Terraform CLI, which
+	// is a subdirectory of the cache directory, if configured.
+	installDir := func(name string) (string, error) {
+		if tfCacheDir == "" {
+			return tfDir, nil
+		}
+
+		dir := filepath.Join(tfCacheDir, name)
+
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return "", fmt.Errorf("failed to create Terraform CLI cache directory: %w", err)
+		}
+
+		return dir, nil
 	}
 
 	var sources []src.Source
 	switch {
+	case tofuPath != "":
+		logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential OpenTofu CLI source of exact path: %s", tofuPath))
+
+		sources = append(sources, &fs.AnyVersion{
+			ExactBinPath: tofuPath,
+		})
 	case tfPath != "":
 		logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of exact path: %s", tfPath))
 
@@ -58,29 +117,101 @@ func DiscoverConfig(ctx context.Context, sourceDir string) (*Config, error) {
 			return nil, fmt.Errorf("invalid Terraform version: %w", err)
 		}
 
-		logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of releases.hashicorp.com exact version %q for installation in: %s", tfVersion, tfDir))
+		dir, err := installDir(tfVersion.String())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if tfCacheDir != "" {
+			logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of cached exact version %q in: %s", tfVersion, dir))
+
+			sources = append(sources, &fs.ExactVersion{
+				Product:    product.Terraform,
+				Version:    tfVersion,
+				ExtraPaths: []string{dir},
+			})
+		}
+
+		if !tfOffline {
+			logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of releases.hashicorp.com exact version %q for installation in: %s", tfVersion, dir))
+
+			sources = append(sources, &releases.ExactVersion{
+				InstallDir: dir,
+				Product:    product.Terraform,
+				Version:    tfVersion,
+			})
+		}
+	case tfVersionConstraint != "":
+		constraints, err := version.NewConstraint(tfVersionConstraint)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid Terraform version constraint: %w", err)
+		}
+
+		dir, err := installDir("constraint-" + constraintDirName(constraints))
+
+		if err != nil {
+			return nil, err
+		}
 
-		sources = append(sources, &releases.ExactVersion{
-			InstallDir: tfDir,
-			Product:    product.Terraform,
-			Version:    tfVersion,
+		logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of local filesystem PATH lookup matching version constraint %q", constraints))
+
+		sources = append(sources, &fs.Version{
+			Product:     product.Terraform,
+			Constraints: constraints,
+			ExtraPaths:  []string{dir},
 		})
+
+		if !tfOffline {
+			logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of releases.hashicorp.com latest version matching version constraint %q for installation in: %s", constraints, dir))
+
+			sources = append(sources, &releases.LatestVersion{
+				Constraints: constraints,
+				InstallDir:  dir,
+				Product:     product.Terraform,
+			})
+		}
 	default:
+		dir, err := installDir("latest")
+
+		if err != nil {
+			return nil, err
+		}
+
 		logging.HelperResourceTrace(ctx, "Adding potential Terraform CLI source of local filesystem PATH lookup")
-		logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of checkpoint.hashicorp.com latest version for installation in: %s", tfDir))
 
-		sources = append(sources, &fs.AnyVersion{
+		anyVersion := &fs.AnyVersion{
 			Product: &product.Terraform,
-		})
-		sources = append(sources, &checkpoint.LatestVersion{
-			InstallDir: tfDir,
-			Product:    product.Terraform,
-		})
+		}
+
+		if tfCacheDir != "" {
+			anyVersion.ExtraPaths = []string{dir}
+		}
+
+		sources = append(sources, anyVersion)
+
+		if !tfOffline {
+			logging.HelperResourceTrace(ctx, fmt.Sprintf("Adding potential Terraform CLI source of checkpoint.hashicorp.com latest version for installation in: %s", dir))
+
+			sources = append(sources, &checkpoint.LatestVersion{
+				InstallDir: dir,
+				Product:    product.Terraform,
+			})
+		}
 	}
 
 	installer := install.NewInstaller()
-	tfExec, err := installer.Ensure(context.Background(), sources)
+	tfExec, err := installer.Ensure(ctx, sources)
 	if err != nil {
+		if execTempDir != "" {
+			_ = os.RemoveAll(execTempDir)
+		}
+
+		if tfOffline {
+			return nil, fmt.Errorf("failed to find Terraform CLI from %+v with downloads disabled by %s: %w", sources, EnvTfAccTerraformOffline, err)
+		}
+
 		return nil, fmt.Errorf("failed to find or install Terraform CLI from %+v: %w", sources, err)
 	}
 
@@ -91,6 +222,26 @@ func DiscoverConfig(ctx context.Context, sourceDir string) (*Config, error) {
 	return &Config{
 		SourceDir:     sourceDir,
 		TerraformExec: tfExec,
-		execTempDir:   tfDir,
+		execTempDir:   execTempDir,
 	}, nil
 }
+
+// constraintDirNameReplacer replaces version constraint operators with
+// characters which are valid in directory names on all operating systems.
+var constraintDirNameReplacer = strings.NewReplacer(
+	" ", "",
+	",", "_",
+	"~>", "approx",
+	">=", "ge",
+	"<=", "le",
+	"!=", "ne",
+	">", "gt",
+	"<", "lt",
+	"=", "eq",
+)
+
+// constraintDirName returns a directory name for the given version
+// constraints, such as ge1.5.0_lt2.0.0 for >= 1.5.0, < 2.0.0.
+func constraintDirName(constraints version.Constraints) string {
+	return constraintDirNameReplacer.Replace(constraints.String())
+}
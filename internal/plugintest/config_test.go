@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestConstraintDirName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		constraint string
+		expected   string
+	}{
+		"exact": {
+			constraint: "1.5.0",
+			expected:   "1.5.0",
+		},
+		"equal": {
+			constraint: "= 1.5.0",
+			expected:   "eq1.5.0",
+		},
+		"pessimistic": {
+			constraint: "~> 1.5",
+			expected:   "approx1.5",
+		},
+		"range": {
+			constraint: ">= 1.3.0, < 2.0.0",
+			expected:   "ge1.3.0_lt2.0.0",
+		},
+		"not-equal": {
+			constraint: ">= 1.3.0, != 1.4.0",
+			expected:   "ge1.3.0_ne1.4.0",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := constraintDirName(version.MustConstraints(version.NewConstraint(testCase.constraint)))
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestDiscoverConfig_Offline(t *testing.T) {
+	cacheDir := t.TempDir()
+	binDir := filepath.Join(cacheDir, "constraint-ge1.0.0")
+
+	if err := os.MkdirAll(binDir, 0700); err != nil {
+		t.Fatalf("unable to create cache directory: %s", err)
+	}
+
+	// Stand-in for a previously cached Terraform CLI installation.
+	tfExec := filepath.Join(binDir, "terraform")
+	err := os.WriteFile(tfExec, []byte("#!/bin/sh\necho 'Terraform v1.5.0'\n"), 0700)
+
+	if err != nil {
+		t.Fatalf("unable to write fake Terraform CLI: %s", err)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv(EnvTfAccTofuPath, "")
+	t.Setenv(EnvTfAccTerraformPath, "")
+	t.Setenv(EnvTfAccTerraformVersion, "")
+	t.Setenv(EnvTfAccTerraformVersionConstraint, ">= 1.0.0")
+	t.Setenv(EnvTfAccTerraformCacheDir, cacheDir)
+	t.Setenv(EnvTfAccTerraformOffline, "1")
+
+	config, err := DiscoverConfig(context.Background(), ".")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if config.TerraformExec != tfExec {
+		t.Errorf("expected Terraform CLI %q, got %q", tfExec, config.TerraformExec)
+	}
+
+	if config.execTempDir != "" {
+		t.Errorf("expected no temporary directory for cached installation, got %q", config.execTempDir)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestDiscoverConfig_OfflineNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv(EnvTfAccTofuPath, "")
+	t.Setenv(EnvTfAccTerraformPath, "")
+	t.Setenv(EnvTfAccTerraformVersion, "")
+	t.Setenv(EnvTfAccTerraformVersionConstraint, ">= 1.0.0")
+	t.Setenv(EnvTfAccTerraformCacheDir, t.TempDir())
+	t.Setenv(EnvTfAccTerraformOffline, "1")
+
+	_, err := DiscoverConfig(context.Background(), ".")
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(err.Error(), EnvTfAccTerraformOffline) {
+		t.Errorf("expected error to mention %s, got: %s", EnvTfAccTerraformOffline, err)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestDiscoverConfig_TofuPath(t *testing.T) {
+	tofuExec := filepath.Join(t.TempDir(), "tofu")
+	err := os.WriteFile(tofuExec, []byte("#!/bin/sh\necho 'OpenTofu v1.6.0'\n"), 0700)
+
+	if err != nil {
+		t.Fatalf("unable to write fake OpenTofu CLI: %s", err)
+	}
+
+	t.Setenv(EnvTfAccTofuPath, tofuExec)
+	t.Setenv(EnvTfAccTerraformPath, "")
+	t.Setenv(EnvTfAccTerraformVersion, "1.5.0")
+	t.Setenv(EnvTfAccTerraformOffline, "1")
+
+	config, err := DiscoverConfig(context.Background(), ".")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if config.TerraformExec != tofuExec {
+		t.Errorf("expected OpenTofu CLI %q, got %q", tofuExec, config.TerraformExec)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestDiscoverVersionConfig(t *testing.T) {
+	cacheDir := t.TempDir()
+	binDir := filepath.Join(cacheDir, "1.5.0")
+
+	if err := os.MkdirAll(binDir, 0700); err != nil {
+		t.Fatalf("unable to create cache directory: %s", err)
+	}
+
+	// Stand-in for a previously cached Terraform CLI installation.
+	tfExec := filepath.Join(binDir, "terraform")
+	err := os.WriteFile(tfExec, []byte("#!/bin/sh\necho 'Terraform v1.5.0'\n"), 0700)
+
+	if err != nil {
+		t.Fatalf("unable to write fake Terraform CLI: %s", err)
+	}
+
+	// The discovery environment variables are ignored in favor of the
+	// given version.
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv(EnvTfAccTofuPath, filepath.Join(t.TempDir(), "tofu"))
+	t.Setenv(EnvTfAccTerraformPath, filepath.Join(t.TempDir(), "terraform"))
+	t.Setenv(EnvTfAccTerraformVersion, "1.3.0")
+	t.Setenv(EnvTfAccTerraformVersionConstraint, "")
+	t.Setenv(EnvTfAccTerraformCacheDir, cacheDir)
+	t.Setenv(EnvTfAccTerraformOffline, "1")
+
+	config, err := DiscoverVersionConfig(context.Background(), ".", "v1.5.0")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if config.TerraformExec != tfExec {
+		t.Errorf("expected Terraform CLI %q, got %q", tfExec, config.TerraformExec)
+	}
+}
@@ -109,6 +109,78 @@ const (
 	// checks are performed against an existing binary.
 	EnvTfAccTerraformPath = "TF_ACC_TERRAFORM_PATH"
 
+	// Acceptance testing path to OpenTofu CLI binary.
+	//
+	// Setting this value takes precedence over all other Terraform CLI
+	// discovery and installation behaviors. The binary must exist and be
+	// executable, or an error will be returned. OpenTofu is also detected
+	// when TF_ACC_TERRAFORM_PATH refers to an OpenTofu binary.
+	//
+	// When running against OpenTofu, the provider under test defaults to the
+	// registry.opentofu.org hostname rather than registry.terraform.io,
+	// unless TF_ACC_PROVIDER_HOST is set.
+	EnvTfAccTofuPath = "TF_ACC_TOFU_PATH"
+
+	// Environment variable with acceptance testing Terraform CLI version
+	// constraint, such as ~> 1.5.0 or >= 1.3.0, < 2.0.0.
+	//
+	// If set, an available Terraform binary in the operating system PATH or
+	// the TF_ACC_TERRAFORM_CACHE_DIR directory is used if it satisfies the
+	// constraint, otherwise the latest version satisfying the constraint is
+	// downloaded from releases.hashicorp.com, checksum verified, and
+	// installed.
+	//
+	// TF_ACC_TERRAFORM_PATH and TF_ACC_TERRAFORM_VERSION take precedence over
+	// this value.
+	EnvTfAccTerraformVersionConstraint = "TF_ACC_TERRAFORM_VERSION_CONSTRAINT"
+
+	// Environment variable with a comma-separated list of acceptance testing
+	// Terraform CLI versions, such as 1.0.11,1.5.7. If set, each TestCase
+	// which does not set its own TerraformVersions field is run once per
+	// version as a subtest, in its own working directory. Each version is
+	// found or installed as if TF_ACC_TERRAFORM_VERSION was set to it.
+	EnvTfAccTerraformVersions = "TF_ACC_TERRAFORM_VERSIONS"
+
+	// Environment variable with a directory used to cache Terraform CLI
+	// installations across test runs. Installed binaries are kept in a
+	// subdirectory per version, version constraint, or latest version, and
+	// are reused by later test runs rather than downloaded again. The
+	// directory is created if it does not exist.
+	//
+	// By default, Terraform CLI is installed in a temporary directory which
+	// is removed at the end of the test run.
+	EnvTfAccTerraformCacheDir = "TF_ACC_TERRAFORM_CACHE_DIR"
+
+	// Environment variable which disables downloading Terraform CLI. Can be
+	// set to any value, however "1" is conventional. If set, the Terraform
+	// binary must already exist at TF_ACC_TERRAFORM_PATH, in the
+	// TF_ACC_TERRAFORM_CACHE_DIR directory, or in the operating system PATH,
+	// otherwise an error is returned.
+	EnvTfAccTerraformOffline = "TF_ACC_TERRAFORM_OFFLINE"
+
+	// Environment variable which enables a provider plugin cache shared by
+	// all working directories in the test process, so external providers are
+	// downloaded once rather than during every init. Can be set to any value,
+	// however "1" is conventional. The cache is created in the TF_ACC_TEMP_DIR
+	// directory and sets TF_PLUGIN_CACHE_DIR when running Terraform CLI. If
+	// TF_PLUGIN_CACHE_DIR is already set, that directory is used instead.
+	// The created cache is only removed after all tests have run when using
+	// the TestMain function of the helper/resource package.
+	//
+	// Terraform CLI init commands which may install providers into the cache
+	// are run one at a time while a plugin cache directory is in use, as
+	// concurrent init commands may not safely share the directory.
+	EnvTfAccPluginCache = "TF_ACC_PLUGIN_CACHE"
+
+	// Environment variable with a directory to collect failure artifacts in.
+	// When a TestStep fails, the configuration, plan JSON, state JSON, and
+	// Terraform logs of the test working directory are written to a
+	// subdirectory per test and TestStep, so failures can be reproduced
+	// without re-running the test. Terraform logs are written to the working
+	// directory during the test, unless TF_ACC_LOG_PATH or TF_LOG_PATH_MASK
+	// is set. Defaults to disabled.
+	EnvTfAccArtifactsDir = "TF_ACC_ARTIFACTS_DIR"
+
 	// EnvTfAccPersistWorkingDir environment variable enables persisting
 	// the working directory and the files generated during execution of
 	// TestStep(s). Default is disabled, in which case the working directory
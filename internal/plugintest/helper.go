@@ -8,8 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-exec/tfexec"
 
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
@@ -43,9 +46,21 @@ type Helper struct {
 	sourceDir     string
 	terraformExec string
 
+	// terraformVersion is the version of the Terraform CLI executable,
+	// detected during InitHelper.
+	terraformVersion *version.Version
+
+	// openTofu is true if the Terraform CLI executable is OpenTofu, detected
+	// during InitHelper.
+	openTofu bool
+
 	// execTempDir is created during DiscoverConfig to store any downloaded
 	// binaries
 	execTempDir string
+
+	// operations tracks the Terraform CLI commands invoked by all working
+	// directories created from this helper.
+	operations operationCounter
 }
 
 // AutoInitHelper uses the auto-discovery behavior of DiscoverConfig to prepare
@@ -62,6 +77,18 @@ func AutoInitHelper(ctx context.Context, sourceDir string) (*Helper, error) {
 	return InitHelper(ctx, config)
 }
 
+// AutoInitVersionHelper is a variant of AutoInitHelper which uses the
+// discovery behavior of DiscoverVersionConfig to find or install the given
+// Terraform CLI version.
+func AutoInitVersionHelper(ctx context.Context, sourceDir string, tfVersion string) (*Helper, error) {
+	config, err := DiscoverVersionConfig(ctx, sourceDir, tfVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return InitHelper(ctx, config)
+}
+
 // InitHelper prepares a testing helper with the given configuration.
 //
 // For most callers it is sufficient to call AutoInitHelper instead, which
@@ -72,20 +99,66 @@ func AutoInitHelper(ctx context.Context, sourceDir string) (*Helper, error) {
 // behind in the system's temporary directory. There is currently no way to
 // automatically clean those up.
 func InitHelper(ctx context.Context, config *Config) (*Helper, error) {
+	err := pluginCache.setup()
+	if err != nil {
+		return nil, err
+	}
+
 	tempDir := os.Getenv(EnvTfAccTempDir)
 	baseDir, err := os.MkdirTemp(tempDir, "plugintest")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temporary directory for test helper: %s", err)
 	}
 
+	tf, err := tfexec.NewTerraform(baseDir, config.TerraformExec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create terraform-exec instance: %w", err)
+	}
+
+	tfVersion, _, err := tf.Version(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine Terraform CLI version: %w", err)
+	}
+
+	openTofu, err := isOpenTofu(ctx, config.TerraformExec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine Terraform CLI product: %w", err)
+	}
+
 	return &Helper{
-		baseDir:       baseDir,
-		sourceDir:     config.SourceDir,
-		terraformExec: config.TerraformExec,
-		execTempDir:   config.execTempDir,
+		baseDir:          baseDir,
+		sourceDir:        config.SourceDir,
+		terraformExec:    config.TerraformExec,
+		terraformVersion: tfVersion,
+		openTofu:         openTofu,
+		execTempDir:      config.execTempDir,
 	}, nil
 }
 
+// TerraformVersion returns the version of the Terraform CLI executable used
+// by this helper.
+func (h *Helper) TerraformVersion() *version.Version {
+	return h.terraformVersion
+}
+
+// IsOpenTofu returns true if the Terraform CLI executable used by this helper
+// is OpenTofu.
+func (h *Helper) IsOpenTofu() bool {
+	return h.openTofu
+}
+
+// isOpenTofu returns true if the plaintext version output of the given
+// executable identifies it as OpenTofu. The JSON version output of OpenTofu
+// is otherwise indistinguishable from Terraform.
+func isOpenTofu(ctx context.Context, execPath string) (bool, error) {
+	out, err := exec.CommandContext(ctx, execPath, "version").Output()
+	if err != nil {
+		return false, err
+	}
+
+	return strings.HasPrefix(strings.TrimSpace(string(out)), "OpenTofu"), nil
+}
+
 // Close cleans up temporary files and directories created to support this
 // helper, returning an error if any of the cleanup fails.
 //
@@ -166,6 +239,8 @@ func (h *Helper) NewWorkingDir(ctx context.Context, t TestControl, wd string) (*
 		return nil, err
 	}
 
+	var logLevel string
+
 	if tfAccLog != "" {
 		logging.HelperResourceTrace(
 			ctx,
@@ -175,6 +250,10 @@ func (h *Helper) NewWorkingDir(ctx context.Context, t TestControl, wd string) (*
 
 		err := tf.SetLog(tfAccLog)
 
+		if err == nil {
+			logLevel = tfAccLog
+		}
+
 		if err != nil {
 			if !errors.As(err, new(*tfexec.ErrVersionMismatch)) {
 				logging.HelperResourceError(
@@ -252,6 +331,13 @@ func (h *Helper) NewWorkingDir(ctx context.Context, t TestControl, wd string) (*
 		logPathEnvVar = EnvTfLogPathMask
 	}
 
+	// Write Terraform logs into the working directory when collecting
+	// failure artifacts, so they are available without re-running the test.
+	if logPath == "" && os.Getenv(EnvTfAccArtifactsDir) != "" {
+		logPath = filepath.Join(dir, LogFileName)
+		logPathEnvVar = EnvTfAccArtifactsDir
+	}
+
 	if logPath != "" {
 		logging.HelperResourceTrace(
 			ctx,
@@ -264,12 +350,29 @@ func (h *Helper) NewWorkingDir(ctx context.Context, t TestControl, wd string) (*
 		}
 	}
 
-	return &WorkingDir{
+	workingDirectory := &WorkingDir{
 		h:             h,
 		tf:            tf,
 		baseDir:       dir,
 		terraformExec: h.terraformExec,
-	}, nil
+		logPath:       logPath,
+		logLevel:      logLevel,
+		logCore:       tfLogCore,
+		logProvider:   tfLogProvider,
+	}
+
+	// The provider plugin cache environment variables are only set for
+	// Terraform commands, rather than the whole test process, so they
+	// cannot leak into other tests or commands.
+	if pluginCache.env() != nil {
+		logging.HelperResourceTrace(ctx, "Setting Terraform CLI provider plugin cache environment variables")
+
+		if err := workingDirectory.setTerraformEnv(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return workingDirectory, nil
 }
 
 // RequireNewWorkingDir is a variant of NewWorkingDir that takes a TestControl
@@ -287,6 +390,13 @@ func (h *Helper) RequireNewWorkingDir(ctx context.Context, t TestControl, workin
 	return wd
 }
 
+// Operations returns the number of each Terraform CLI command invoked by all
+// working directories created from this helper. The returned map is a copy and
+// callers can compare snapshots to determine the commands invoked in between.
+func (h *Helper) Operations() map[Operation]int {
+	return h.operations.snapshot()
+}
+
 // WorkingDirectory returns the working directory being used when running tests.
 func (h *Helper) WorkingDirectory() string {
 	return h.baseDir
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsOpenTofu(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		versionOutput string
+		expected      bool
+	}{
+		"opentofu": {
+			versionOutput: "OpenTofu v1.6.0\non linux_amd64",
+			expected:      true,
+		},
+		"terraform": {
+			versionOutput: "Terraform v1.5.7\non linux_amd64",
+			expected:      false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			execPath := filepath.Join(t.TempDir(), "cli")
+			script := "#!/bin/sh\nprintf '" + testCase.versionOutput + "\\n'\n"
+
+			if err := os.WriteFile(execPath, []byte(script), 0700); err != nil {
+				t.Fatalf("unable to write fake CLI: %s", err)
+			}
+
+			got, err := isOpenTofu(context.Background(), execPath)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
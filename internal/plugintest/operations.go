@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import "sync"
+
+// Operation is a Terraform CLI command invoked by a WorkingDir.
+type Operation string
+
+const (
+	OperationApply           Operation = "apply"
+	OperationDestroy         Operation = "destroy"
+	OperationGraph           Operation = "graph"
+	OperationImport          Operation = "import"
+	OperationInit            Operation = "init"
+	OperationPlan            Operation = "plan"
+	OperationProvidersSchema Operation = "providers schema"
+	OperationRefresh         Operation = "refresh"
+	OperationShow            Operation = "show"
+	OperationStateRm         Operation = "state rm"
+	OperationTaint           Operation = "taint"
+	OperationValidate        Operation = "validate"
+)
+
+// operationCounter tracks the number of Terraform CLI commands invoked by all
+// working directories created from a Helper.
+type operationCounter struct {
+	mu     sync.Mutex
+	counts map[Operation]int
+}
+
+func (c *operationCounter) record(op Operation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[Operation]int)
+	}
+
+	c.counts[op]++
+}
+
+func (c *operationCounter) snapshot() map[Operation]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make(map[Operation]int, len(c.counts))
+
+	for op, count := range c.counts {
+		result[op] = count
+	}
+
+	return result
+}
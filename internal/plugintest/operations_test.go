@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOperationCounter(t *testing.T) {
+	t.Parallel()
+
+	var counter operationCounter
+
+	if diff := cmp.Diff(counter.snapshot(), map[Operation]int{}); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	counter.record(OperationInit)
+	counter.record(OperationPlan)
+	counter.record(OperationPlan)
+
+	snapshot := counter.snapshot()
+
+	counter.record(OperationApply)
+
+	expected := map[Operation]int{
+		OperationInit: 1,
+		OperationPlan: 2,
+	}
+
+	if diff := cmp.Diff(snapshot, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
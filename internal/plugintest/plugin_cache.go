@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+const (
+	// envTfPluginCacheDir is the Terraform CLI environment variable with the
+	// provider plugin cache directory.
+	envTfPluginCacheDir = "TF_PLUGIN_CACHE_DIR"
+
+	// envTfPluginCacheMayBreakDependencyLockFile is the Terraform CLI
+	// environment variable which allows Terraform CLI 1.4 and later to use
+	// the provider plugin cache when the dependency lock file does not
+	// already contain checksums for the provider, which is always the case
+	// for new working directories.
+	envTfPluginCacheMayBreakDependencyLockFile = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
+)
+
+// pluginCache is the provider plugin cache shared by all working directories
+// in the test process.
+var pluginCache = &providerPluginCache{}
+
+// providerPluginCache manages a Terraform CLI provider plugin cache directory,
+// so external providers are downloaded once per test process rather than once
+// per working directory.
+//
+// Terraform CLI does not guarantee that concurrent init commands can safely
+// install providers into a shared plugin cache directory, so init commands
+// which may install providers are serialized while a plugin cache directory
+// is in use. Init commands with the same configuration and reattached
+// providers as an earlier successful init only link providers already in the
+// cache, so are not serialized.
+type providerPluginCache struct {
+	// installMu is held while running init commands which may install
+	// providers into the plugin cache directory.
+	installMu sync.Mutex
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// dir is the plugin cache directory created by setup, which is removed
+	// by close. It is empty if the directory is not managed by this cache,
+	// such as when TF_PLUGIN_CACHE_DIR is set outside the test process.
+	dir string
+
+	// installed contains the keys of init commands which succeeded while
+	// holding installMu, whose providers are in the plugin cache directory.
+	installed map[string]struct{}
+}
+
+// setup creates the plugin cache directory, if TF_ACC_PLUGIN_CACHE is set and
+// TF_PLUGIN_CACHE_DIR is not already set. It is safe to call multiple times.
+func (c *providerPluginCache) setup() error {
+	if os.Getenv(EnvTfAccPluginCache) == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dir != "" || os.Getenv(envTfPluginCacheDir) != "" {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp(os.Getenv(EnvTfAccTempDir), "plugintest-plugin-cache")
+	if err != nil {
+		return fmt.Errorf("failed to create provider plugin cache directory: %w", err)
+	}
+
+	c.dir = dir
+
+	return nil
+}
+
+// env returns the Terraform CLI environment variables which use the plugin
+// cache directory created by setup, or nil if there is no such directory.
+func (c *providerPluginCache) env() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dir == "" {
+		return nil
+	}
+
+	return map[string]string{
+		envTfPluginCacheDir:                        c.dir,
+		envTfPluginCacheMayBreakDependencyLockFile: "1",
+	}
+}
+
+// lockInstall serializes init commands which may install providers into a
+// plugin cache directory, whether it is managed by this cache or set outside
+// the test process. The key function, which is only called while a plugin
+// cache directory is in use, identifies the providers installed by the init
+// command. Init commands with the key of an earlier successful init do not
+// wait for the lock. The returned function must be called with the result of
+// the init command to release the lock.
+func (c *providerPluginCache) lockInstall(key func() (string, error)) (func(error), error) {
+	c.mu.Lock()
+	inUse := c.dir != "" || os.Getenv(envTfPluginCacheDir) != ""
+	c.mu.Unlock()
+
+	if !inUse {
+		return func(error) {}, nil
+	}
+
+	k, err := key()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	_, installed := c.installed[k]
+	c.mu.Unlock()
+
+	if installed {
+		return func(error) {}, nil
+	}
+
+	c.installMu.Lock()
+
+	return func(err error) {
+		if err == nil {
+			c.mu.Lock()
+
+			if c.installed == nil {
+				c.installed = make(map[string]struct{})
+			}
+
+			c.installed[k] = struct{}{}
+
+			c.mu.Unlock()
+		}
+
+		c.installMu.Unlock()
+	}, nil
+}
+
+// close removes the plugin cache directory, if it was created by setup.
+func (c *providerPluginCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.installed = nil
+
+	if c.dir == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(c.dir)
+
+	c.dir = ""
+
+	return err
+}
+
+// ClosePluginCache removes the provider plugin cache directory created for
+// the test process when TF_ACC_PLUGIN_CACHE is set. The directory is not
+// removed when the test process exits, so this must be called after all tests
+// have run, such as in TestMain. The TestMain function of the helper/resource
+// package calls it. Later tests will create a new plugin cache directory.
+func ClosePluginCache() error {
+	return pluginCache.close()
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestProviderPluginCache(t *testing.T) {
+	t.Setenv(EnvTfAccPluginCache, "1")
+	t.Setenv(EnvTfAccTempDir, t.TempDir())
+	t.Setenv(envTfPluginCacheDir, "")
+
+	cache := &providerPluginCache{}
+
+	if err := cache.setup(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dir := cache.dir
+
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("expected plugin cache directory to exist: %s", err)
+	}
+
+	env := cache.env()
+
+	if got := env[envTfPluginCacheDir]; got != dir {
+		t.Errorf("expected %s to be set to the managed directory %q, got: %q", envTfPluginCacheDir, dir, got)
+	}
+
+	if env[envTfPluginCacheMayBreakDependencyLockFile] == "" {
+		t.Errorf("expected %s to be set", envTfPluginCacheMayBreakDependencyLockFile)
+	}
+
+	if got := os.Getenv(envTfPluginCacheDir); got != "" {
+		t.Errorf("expected process %s to be unset, got: %q", envTfPluginCacheDir, got)
+	}
+
+	// Calling setup again reuses the directory.
+	if err := cache.setup(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cache.dir != dir {
+		t.Errorf("expected plugin cache directory %q to be reused, got: %q", dir, cache.dir)
+	}
+
+	if err := cache.close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected plugin cache directory to be removed, got: %v", err)
+	}
+
+	if env := cache.env(); env != nil {
+		t.Errorf("expected no environment variables, got: %v", env)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestProviderPluginCache_disabled(t *testing.T) {
+	t.Setenv(EnvTfAccPluginCache, "")
+	t.Setenv(envTfPluginCacheDir, "")
+
+	cache := &providerPluginCache{}
+
+	if err := cache.setup(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if env := cache.env(); env != nil {
+		t.Errorf("expected no environment variables, got: %v", env)
+	}
+
+	unlock, err := cache.lockInstall(func() (string, error) {
+		t.Fatal("unexpected key call without plugin cache directory")
+
+		return "", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	unlock(nil)
+
+	if err := cache.close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestProviderPluginCache_existingDir(t *testing.T) {
+	existingDir := t.TempDir()
+
+	t.Setenv(EnvTfAccPluginCache, "1")
+	t.Setenv(envTfPluginCacheDir, existingDir)
+
+	cache := &providerPluginCache{}
+
+	if err := cache.setup(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if env := cache.env(); env != nil {
+		t.Errorf("expected no environment variables, got: %v", env)
+	}
+
+	if err := cache.close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := os.Stat(existingDir); err != nil {
+		t.Errorf("expected existing plugin cache directory to be kept: %s", err)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestProviderPluginCache_lockInstall(t *testing.T) {
+	t.Setenv(EnvTfAccPluginCache, "1")
+	t.Setenv(envTfPluginCacheDir, t.TempDir())
+
+	cache := &providerPluginCache{}
+
+	key := func(k string) func() (string, error) {
+		return func() (string, error) { return k, nil }
+	}
+
+	unlock, err := cache.lockInstall(key("installed"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	unlock(nil)
+
+	unlock, err = cache.lockInstall(key("failed"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	unlock(errors.New("test init error"))
+
+	// Hold the lock, as an init installing providers would.
+	unlockOther, err := cache.lockInstall(key("other"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// An init with the key of an earlier successful init does not wait for
+	// the lock, otherwise this would deadlock.
+	unlock, err = cache.lockInstall(key("installed"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	unlock(nil)
+
+	if cache.installMu.TryLock() {
+		t.Fatal("expected lock to be held")
+	}
+
+	unlockOther(nil)
+
+	// The key of a failed init is not recorded, so a later init with the
+	// same key waits for the lock.
+	if _, installed := cache.installed["failed"]; installed {
+		t.Error("expected failed init key to not be recorded")
+	}
+
+	if _, err := cache.lockInstall(func() (string, error) { return "", errors.New("test key error") }); err == nil {
+		t.Error("expected key error")
+	}
+}
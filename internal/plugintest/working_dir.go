@@ -4,15 +4,23 @@
 package plugintest
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 	tfjson "github.com/hashicorp/terraform-json"
 
+	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/internal/logging"
 )
 
@@ -20,12 +28,20 @@ const (
 	ConfigFileName     = "terraform_plugin_test.tf"
 	ConfigFileNameJSON = ConfigFileName + ".json"
 	PlanFileName       = "tfplan"
+	LogFileName        = "terraform.log"
+	VariablesFileName  = "terraform_plugin_test.auto.tfvars.json"
+	OverrideFileName   = "terraform_plugin_test_override.tf"
+	PreambleFileName   = "providers_gen.tf"
+	ModulesDirName     = "terraform_plugin_test_modules"
 )
 
 // WorkingDir represents a distinct working directory that can be used for
 // running tests. Each test should construct its own WorkingDir by calling
 // NewWorkingDir or RequireNewWorkingDir on its package's singleton
 // plugintest.Helper.
+//
+// Changing the configuration or variables of a WorkingDir invalidates any
+// saved plan, so the methods which set them also clear the saved plan.
 type WorkingDir struct {
 	h *Helper
 
@@ -33,9 +49,16 @@ type WorkingDir struct {
 	baseDir string
 
 	// configFilename is the full filename where the latest configuration
-	// was stored; empty until SetConfig is called.
+	// was stored, or the base directory if the latest configuration was
+	// copied from a directory; empty until SetConfig, SetConfigDirectory,
+	// or SetConfigFile is called.
 	configFilename string
 
+	// copiedConfigEntries are the names of files and directories copied
+	// into the base directory by SetConfigDirectory or SetConfigFile, which
+	// are removed when the configuration is next set.
+	copiedConfigEntries []string
+
 	// tf is the instance of tfexec.Terraform used for running Terraform commands
 	tf *tfexec.Terraform
 
@@ -45,6 +68,31 @@ type WorkingDir struct {
 	// reattachInfo stores the gRPC socket info required for Terraform's
 	// plugin reattach functionality
 	reattachInfo tfexec.ReattachInfo
+
+	// logPath is the path of the Terraform log file written by commands in
+	// this working directory, or empty if Terraform logs are not written.
+	logPath string
+
+	// logLevel, logCore, and logProvider are the TF_LOG, TF_LOG_CORE, and
+	// TF_LOG_PROVIDER levels set on tf, which are only used when logPath is
+	// set.
+	logLevel    string
+	logCore     string
+	logProvider string
+
+	// initKey identifies the provider requirements of the last successful
+	// Init, as set by SetInitKey. It is reset by every Init.
+	initKey string
+
+	// env stores the additional environment variables for Terraform
+	// commands run in this working directory, as set by SetEnv.
+	env map[string]string
+
+	// planOptions and applyOptions are the additional options for the
+	// Terraform plan and apply commands run by CreatePlan and Apply, as set
+	// by SetAdditionalPlanOptions and SetAdditionalApplyOptions.
+	planOptions  []tfexec.PlanOption
+	applyOptions []tfexec.ApplyOption
 }
 
 // Close deletes the directories and files created to represent the receiving
@@ -63,6 +111,95 @@ func (wd *WorkingDir) UnsetReattachInfo() {
 	wd.reattachInfo = nil
 }
 
+// SetEnv sets additional environment variables for the Terraform commands
+// run in this working directory, replacing any previously set by SetEnv.
+//
+// Setting environment variables with (tfexec.Terraform).SetEnv() stops
+// copying os.Environ(), so the process environment at the time of this call
+// is copied into the Terraform environment. Variables which terraform-exec
+// does not allow to be set, such as TF_LOG and TF_VAR_ variables, are not
+// copied and cannot be set.
+func (wd *WorkingDir) SetEnv(ctx context.Context, env map[string]string) error {
+	if prohibited := tfexec.ProhibitedEnv(env); len(prohibited) > 0 {
+		return fmt.Errorf("unable to set environment variable %s, which is managed by the testing framework", prohibited[0])
+	}
+
+	keys := make([]string, 0, len(env))
+
+	for k := range env {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	logging.HelperResourceTrace(ctx, "Setting Terraform CLI environment variables", map[string]interface{}{"tf_env_keys": keys})
+
+	if err := wd.setTerraformEnv(env); err != nil {
+		return err
+	}
+
+	wd.env = env
+
+	return nil
+}
+
+// setTerraformEnv sets the environment variables of the Terraform commands
+// run in this working directory to the process environment, the provider
+// plugin cache environment variables, and the given additional environment
+// variables.
+func (wd *WorkingDir) setTerraformEnv(env map[string]string) error {
+	tfEnv := make(map[string]string)
+
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		tfEnv[k] = v
+	}
+
+	tfEnv = tfexec.CleanEnv(tfEnv)
+
+	for k, v := range pluginCache.env() {
+		tfEnv[k] = v
+	}
+
+	for k, v := range env {
+		tfEnv[k] = v
+	}
+
+	if err := wd.tf.SetEnv(tfEnv); err != nil {
+		return fmt.Errorf("unable to set terraform-exec environment variables: %w", err)
+	}
+
+	return nil
+}
+
+// SetAdditionalPlanOptions sets additional options, such as
+// tfexec.AllowDeferral, for the Terraform plan commands run by CreatePlan,
+// replacing any previously set.
+func (wd *WorkingDir) SetAdditionalPlanOptions(opts ...tfexec.PlanOption) {
+	wd.planOptions = opts
+}
+
+// SetAdditionalApplyOptions sets additional options, such as
+// tfexec.AllowDeferral, for the Terraform apply commands run by Apply,
+// replacing any previously set.
+func (wd *WorkingDir) SetAdditionalApplyOptions(opts ...tfexec.ApplyOption) {
+	wd.applyOptions = opts
+}
+
+// BaseDir returns the directory where Terraform CLI commands are run for
+// the WorkingDir.
+func (wd *WorkingDir) BaseDir() string {
+	return wd.baseDir
+}
+
+// LogPath returns the path of the Terraform log file written by commands in
+// this working directory, or an empty string if Terraform logs are not
+// written. The file may be shared with other working directories, such as
+// when TF_ACC_LOG_PATH is set.
+func (wd *WorkingDir) LogPath() string {
+	return wd.logPath
+}
+
 // GetHelper returns the Helper set on the WorkingDir.
 func (wd *WorkingDir) GetHelper() *Helper {
 	return wd.h
@@ -72,10 +209,20 @@ func (wd *WorkingDir) GetHelper() *Helper {
 //
 // This must be called at least once before any call to Init, Plan, Apply, or
 // Destroy to establish the configuration. Any previously-set configuration is
-// discarded and any saved plan is cleared.
+// discarded, including any configuration set by SetPreambleConfig, and any
+// saved plan is cleared.
 func (wd *WorkingDir) SetConfig(ctx context.Context, cfg string) error {
 	logging.HelperResourceTrace(ctx, "Setting Terraform configuration", map[string]any{logging.KeyTestTerraformConfiguration: cfg})
 
+	if err := wd.removeCopiedConfig(); err != nil {
+		return err
+	}
+
+	preambleFilename := filepath.Join(wd.baseDir, PreambleFileName)
+	if err := os.Remove(preambleFilename); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove %q: %w", preambleFilename, err)
+	}
+
 	outFilename := filepath.Join(wd.baseDir, ConfigFileName)
 	rmFilename := filepath.Join(wd.baseDir, ConfigFileNameJSON)
 	bCfg := []byte(cfg)
@@ -91,7 +238,6 @@ func (wd *WorkingDir) SetConfig(ctx context.Context, cfg string) error {
 	}
 	wd.configFilename = outFilename
 
-	// Changing configuration invalidates any saved plan.
 	err = wd.ClearPlan(ctx)
 	if err != nil {
 		return err
@@ -99,6 +245,219 @@ func (wd *WorkingDir) SetConfig(ctx context.Context, cfg string) error {
 	return nil
 }
 
+// SetConfigDirectory sets a new configuration for the working directory by
+// copying the files and subdirectories of the given directory, such as local
+// modules, into the working directory.
+//
+// This can be called instead of SetConfig. Any previously-set configuration
+// is discarded and any saved plan is cleared.
+func (wd *WorkingDir) SetConfigDirectory(ctx context.Context, dir string) error {
+	logging.HelperResourceTrace(ctx, fmt.Sprintf("Setting Terraform configuration from directory: %s", dir))
+
+	if err := wd.removeCopiedConfig(); err != nil {
+		return err
+	}
+
+	if err := wd.removeConfigFiles(); err != nil {
+		return err
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("unable to read configuration directory %q: %w", dir, err)
+	}
+
+	for _, dirEntry := range dirEntries {
+		srcPath := filepath.Join(dir, dirEntry.Name())
+		destPath := filepath.Join(wd.baseDir, dirEntry.Name())
+
+		if _, err := os.Lstat(destPath); err == nil {
+			return fmt.Errorf("unable to copy %q, %q already exists in working directory", srcPath, dirEntry.Name())
+		}
+
+		if dirEntry.IsDir() {
+			err = CopyDir(srcPath, destPath)
+		} else {
+			err = CopyFile(srcPath, destPath)
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to copy %q to working directory: %w", srcPath, err)
+		}
+
+		wd.copiedConfigEntries = append(wd.copiedConfigEntries, dirEntry.Name())
+	}
+
+	wd.configFilename = wd.baseDir
+
+	return wd.ClearPlan(ctx)
+}
+
+// SetConfigFile sets a new configuration for the working directory by
+// copying the given file into the working directory.
+//
+// This can be called instead of SetConfig. Any previously-set configuration
+// is discarded and any saved plan is cleared.
+func (wd *WorkingDir) SetConfigFile(ctx context.Context, file string) error {
+	logging.HelperResourceTrace(ctx, fmt.Sprintf("Setting Terraform configuration from file: %s", file))
+
+	if err := wd.removeCopiedConfig(); err != nil {
+		return err
+	}
+
+	if err := wd.removeConfigFiles(); err != nil {
+		return err
+	}
+
+	name := filepath.Base(file)
+	destPath := filepath.Join(wd.baseDir, name)
+
+	if _, err := os.Lstat(destPath); err == nil {
+		return fmt.Errorf("unable to copy %q, %q already exists in working directory", file, name)
+	}
+
+	if err := CopyFile(file, destPath); err != nil {
+		return fmt.Errorf("unable to copy %q to working directory: %w", file, err)
+	}
+
+	wd.copiedConfigEntries = append(wd.copiedConfigEntries, name)
+	wd.configFilename = destPath
+
+	return wd.ClearPlan(ctx)
+}
+
+// SetVariables sets the input variable values for the working directory by
+// writing an automatically loaded variable definitions file. If there are no
+// variables, any previously written variable definitions file is removed.
+//
+// Any saved plan is cleared.
+func (wd *WorkingDir) SetVariables(ctx context.Context, vars config.Variables) error {
+	if len(vars) == 0 {
+		return wd.writeOrRemoveFile(ctx, VariablesFileName, nil)
+	}
+
+	logging.HelperResourceTrace(ctx, "Setting Terraform input variables")
+
+	b, err := vars.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("unable to encode variables: %w", err)
+	}
+
+	return wd.writeOrRemoveFile(ctx, VariablesFileName, b)
+}
+
+// SetOverrideConfig sets override configuration for the working directory by
+// writing a Terraform override file, which is merged into the configuration
+// set by SetConfig, SetConfigDirectory, or SetConfigFile. If the given
+// configuration is empty, any previously written override file is removed.
+//
+// Any saved plan is cleared.
+func (wd *WorkingDir) SetOverrideConfig(ctx context.Context, cfg string) error {
+	if cfg != "" {
+		logging.HelperResourceTrace(ctx, "Setting Terraform override configuration", map[string]any{logging.KeyTestTerraformConfiguration: cfg})
+	}
+
+	return wd.writeOrRemoveFile(ctx, OverrideFileName, []byte(cfg))
+}
+
+// SetPreambleConfig sets generated configuration for the working directory,
+// such as the terraform and provider configuration blocks, by writing it
+// into a separate PreambleFileName file alongside the configuration set by
+// SetConfig. This keeps the line numbers of the configuration set by
+// SetConfig unchanged in Terraform diagnostics. If the given configuration
+// is empty, any previously written preamble file is removed.
+//
+// This must be called after SetConfig, which removes any previously written
+// preamble file. Any saved plan is cleared.
+func (wd *WorkingDir) SetPreambleConfig(ctx context.Context, cfg string) error {
+	if cfg != "" {
+		logging.HelperResourceTrace(ctx, "Setting Terraform preamble configuration", map[string]any{logging.KeyTestTerraformConfiguration: cfg})
+	}
+
+	return wd.writeOrRemoveFile(ctx, PreambleFileName, []byte(cfg))
+}
+
+// writeOrRemoveFile writes the contents into the named file in the working
+// directory, or removes the file if the contents are empty, and clears any
+// saved plan.
+func (wd *WorkingDir) writeOrRemoveFile(ctx context.Context, name string, contents []byte) error {
+	filename := filepath.Join(wd.baseDir, name)
+
+	if len(contents) == 0 {
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove %q: %w", filename, err)
+		}
+	} else if err := os.WriteFile(filename, contents, 0700); err != nil {
+		return err
+	}
+
+	return wd.ClearPlan(ctx)
+}
+
+// SetModuleConfigs sets the configuration of local child modules for the
+// working directory by writing each configuration, keyed by module
+// directory name, into a subdirectory of ModulesDirName, such as
+// terraform_plugin_test_modules/test. Modules are called from the root
+// module configuration with a relative source, such as
+// "./terraform_plugin_test_modules/test". Any previously-set module
+// configurations are removed.
+//
+// Any saved plan is cleared. Terraform init must be run before using new
+// module configurations.
+func (wd *WorkingDir) SetModuleConfigs(ctx context.Context, modules map[string]string) error {
+	modulesDir := filepath.Join(wd.baseDir, ModulesDirName)
+
+	if err := os.RemoveAll(modulesDir); err != nil {
+		return fmt.Errorf("unable to remove %q: %w", modulesDir, err)
+	}
+
+	for name, cfg := range modules {
+		logging.HelperResourceTrace(ctx, fmt.Sprintf("Setting Terraform module configuration: %s", name), map[string]any{logging.KeyTestTerraformConfiguration: cfg})
+
+		moduleDir := filepath.Join(modulesDir, name)
+
+		if err := os.MkdirAll(moduleDir, 0700); err != nil {
+			return fmt.Errorf("unable to create module directory %q: %w", moduleDir, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(moduleDir, ConfigFileName), []byte(cfg), 0700); err != nil {
+			return err
+		}
+	}
+
+	return wd.ClearPlan(ctx)
+}
+
+// removeConfigFiles removes any configuration files written by SetConfig or
+// SetPreambleConfig.
+func (wd *WorkingDir) removeConfigFiles() error {
+	for _, filename := range []string{ConfigFileName, ConfigFileNameJSON, PreambleFileName} {
+		rmFilename := filepath.Join(wd.baseDir, filename)
+
+		if err := os.Remove(rmFilename); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove %q: %w", rmFilename, err)
+		}
+	}
+
+	return nil
+}
+
+// removeCopiedConfig removes any files and directories copied
+// into the working directory by SetConfigDirectory or SetConfigFile.
+func (wd *WorkingDir) removeCopiedConfig() error {
+	for _, name := range wd.copiedConfigEntries {
+		rmPath := filepath.Join(wd.baseDir, name)
+
+		if err := os.RemoveAll(rmPath); err != nil {
+			return fmt.Errorf("unable to remove %q: %w", rmPath, err)
+		}
+	}
+
+	wd.copiedConfigEntries = nil
+
+	return nil
+}
+
 // ClearState deletes any Terraform state present in the working directory.
 //
 // Any remote objects tracked by the state are not destroyed first, so this
@@ -156,15 +515,95 @@ func (wd *WorkingDir) Init(ctx context.Context) error {
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI init command")
 
+	wd.h.operations.record(OperationInit)
+
+	wd.initKey = ""
+
+	unlock, err := pluginCache.lockInstall(wd.pluginCacheKey)
+	if err != nil {
+		return err
+	}
+
 	// -upgrade=true is required for per-TestStep provider version changes
 	// e.g. TestTest_TestStep_ExternalProviders_DifferentVersions
-	err := wd.tf.Init(context.Background(), tfexec.Reattach(wd.reattachInfo), tfexec.Upgrade(true))
+	err = wd.tf.Init(ctx, tfexec.Reattach(wd.reattachInfo), tfexec.Upgrade(true))
+
+	unlock(err)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI init command")
 
 	return err
 }
 
+// pluginCacheKey returns a hash of the Terraform configuration files and the
+// reattached provider addresses of the working directory, which together
+// determine the providers installed into the provider plugin cache by Init.
+func (wd *WorkingDir) pluginCacheKey() (string, error) {
+	h := sha256.New()
+
+	err := filepath.WalkDir(wd.baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".tf") && !strings.HasSuffix(path, ".tf.json") {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(wd.baseDir, path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), len(contents))
+		h.Write(contents)
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to hash configuration for provider plugin cache: %w", err)
+	}
+
+	addresses := make([]string, 0, len(wd.reattachInfo))
+
+	for address := range wd.reattachInfo {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	fmt.Fprintf(h, "reattach\x00%s", strings.Join(addresses, "\n"))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// InitKey returns the key given to SetInitKey after the last Init, or an empty
+// string if Init has not been run or the key was not set since.
+func (wd *WorkingDir) InitKey() string {
+	return wd.initKey
+}
+
+// SetInitKey records a key identifying the provider requirements of the last
+// successful Init, such as a hash of the provider configuration. Callers can
+// compare the key with InitKey to skip an Init when the provider requirements
+// are unchanged. The key is reset by the next Init.
+func (wd *WorkingDir) SetInitKey(key string) {
+	wd.initKey = key
+}
+
 func (wd *WorkingDir) planFilename() string {
 	return filepath.Join(wd.baseDir, PlanFileName)
 }
@@ -174,7 +613,12 @@ func (wd *WorkingDir) planFilename() string {
 func (wd *WorkingDir) CreatePlan(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI plan command")
 
-	hasChanges, err := wd.tf.Plan(context.Background(), tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false), tfexec.Out(PlanFileName))
+	wd.h.operations.record(OperationPlan)
+
+	args := []tfexec.PlanOption{tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false), tfexec.Out(PlanFileName)}
+	args = append(args, wd.planOptions...)
+
+	hasChanges, err := wd.tf.Plan(ctx, args...)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI plan command")
 
@@ -204,7 +648,9 @@ func (wd *WorkingDir) CreatePlan(ctx context.Context) error {
 func (wd *WorkingDir) CreateDestroyPlan(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI plan -destroy command")
 
-	hasChanges, err := wd.tf.Plan(context.Background(), tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false), tfexec.Out(PlanFileName), tfexec.Destroy(true))
+	wd.h.operations.record(OperationPlan)
+
+	hasChanges, err := wd.tf.Plan(ctx, tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false), tfexec.Out(PlanFileName), tfexec.Destroy(true))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI plan -destroy command")
 
@@ -238,10 +684,13 @@ func (wd *WorkingDir) Apply(ctx context.Context) error {
 	if wd.HasSavedPlan() {
 		args = append(args, tfexec.DirOrPlan(PlanFileName))
 	}
+	args = append(args, wd.applyOptions...)
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI apply command")
 
-	err := wd.tf.Apply(context.Background(), args...)
+	wd.h.operations.record(OperationApply)
+
+	err := wd.tf.Apply(ctx, args...)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI apply command")
 
@@ -256,7 +705,9 @@ func (wd *WorkingDir) Apply(ctx context.Context) error {
 func (wd *WorkingDir) Destroy(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI destroy command")
 
-	err := wd.tf.Destroy(context.Background(), tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false))
+	wd.h.operations.record(OperationDestroy)
+
+	err := wd.tf.Destroy(ctx, tfexec.Reattach(wd.reattachInfo), tfexec.Refresh(false))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI destroy command")
 
@@ -281,7 +732,9 @@ func (wd *WorkingDir) SavedPlan(ctx context.Context) (*tfjson.Plan, error) {
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for JSON plan")
 
-	plan, err := wd.tf.ShowPlanFile(context.Background(), wd.planFilename(), tfexec.Reattach(wd.reattachInfo))
+	wd.h.operations.record(OperationShow)
+
+	plan, err := wd.tf.ShowPlanFile(ctx, wd.planFilename(), tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for JSON plan")
 
@@ -299,7 +752,9 @@ func (wd *WorkingDir) SavedPlanRawStdout(ctx context.Context) (string, error) {
 
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for stdout plan")
 
-	stdout, err := wd.tf.ShowPlanFileRaw(context.Background(), wd.planFilename(), tfexec.Reattach(wd.reattachInfo))
+	wd.h.operations.record(OperationShow)
+
+	stdout, err := wd.tf.ShowPlanFileRaw(ctx, wd.planFilename(), tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI show command for stdout plan")
 
@@ -317,7 +772,9 @@ func (wd *WorkingDir) SavedPlanRawStdout(ctx context.Context) (string, error) {
 func (wd *WorkingDir) State(ctx context.Context) (*tfjson.State, error) {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI show command for JSON state")
 
-	state, err := wd.tf.Show(context.Background(), tfexec.Reattach(wd.reattachInfo))
+	wd.h.operations.record(OperationShow)
+
+	state, err := wd.tf.Show(ctx, tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI show command for JSON state")
 
@@ -328,18 +785,35 @@ func (wd *WorkingDir) State(ctx context.Context) (*tfjson.State, error) {
 func (wd *WorkingDir) Import(ctx context.Context, resource, id string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI import command")
 
-	err := wd.tf.Import(context.Background(), resource, id, tfexec.Config(wd.baseDir), tfexec.Reattach(wd.reattachInfo))
+	wd.h.operations.record(OperationImport)
+
+	err := wd.tf.Import(ctx, resource, id, tfexec.Config(wd.baseDir), tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI import command")
 
 	return err
 }
 
+// StateRm runs terraform state rm
+func (wd *WorkingDir) StateRm(ctx context.Context, address string) error {
+	logging.HelperResourceTrace(ctx, "Calling Terraform CLI state rm command")
+
+	wd.h.operations.record(OperationStateRm)
+
+	err := wd.tf.StateRm(ctx, address)
+
+	logging.HelperResourceTrace(ctx, "Called Terraform CLI state rm command")
+
+	return err
+}
+
 // Taint runs terraform taint
 func (wd *WorkingDir) Taint(ctx context.Context, address string) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI taint command")
 
-	err := wd.tf.Taint(context.Background(), address)
+	wd.h.operations.record(OperationTaint)
+
+	err := wd.tf.Taint(ctx, address)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI taint command")
 
@@ -350,22 +824,172 @@ func (wd *WorkingDir) Taint(ctx context.Context, address string) error {
 func (wd *WorkingDir) Refresh(ctx context.Context) error {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI refresh command")
 
-	err := wd.tf.Refresh(context.Background(), tfexec.Reattach(wd.reattachInfo))
+	wd.h.operations.record(OperationRefresh)
+
+	err := wd.tf.Refresh(ctx, tfexec.Reattach(wd.reattachInfo))
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI refresh command")
 
 	return err
 }
 
+// RefreshTarget runs terraform refresh for only the given resource address.
+func (wd *WorkingDir) RefreshTarget(ctx context.Context, address string) error {
+	logging.HelperResourceTrace(ctx, "Calling Terraform CLI refresh command with target")
+
+	wd.h.operations.record(OperationRefresh)
+
+	err := wd.tf.Refresh(ctx, tfexec.Reattach(wd.reattachInfo), tfexec.Target(address))
+
+	logging.HelperResourceTrace(ctx, "Called Terraform CLI refresh command with target")
+
+	return err
+}
+
 // Schemas returns an object describing the provider schemas.
 //
 // If the schemas cannot be read, Schemas returns an error.
 func (wd *WorkingDir) Schemas(ctx context.Context) (*tfjson.ProviderSchemas, error) {
 	logging.HelperResourceTrace(ctx, "Calling Terraform CLI providers schema command")
 
-	providerSchemas, err := wd.tf.ProvidersSchema(context.Background())
+	wd.h.operations.record(OperationProvidersSchema)
+
+	providerSchemas, err := wd.tf.ProvidersSchema(ctx)
 
 	logging.HelperResourceTrace(ctx, "Called Terraform CLI providers schema command")
 
 	return providerSchemas, err
 }
+
+// Graph runs terraform graph -type=plan and returns the DOT output, which
+// includes the dependencies between resources.
+//
+// The terraform-exec Graph command does not support reattached providers,
+// so the command is run directly.
+func (wd *WorkingDir) Graph(ctx context.Context) (string, error) {
+	logging.HelperResourceTrace(ctx, "Calling Terraform CLI graph command")
+
+	wd.h.operations.record(OperationGraph)
+
+	cmd, err := wd.reattachedCommand(ctx, "graph", "-type=plan")
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+
+	logging.HelperResourceTrace(ctx, "Called Terraform CLI graph command")
+
+	if err != nil {
+		return "", fmt.Errorf("%w\n%s", err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// reattachedCommand returns a Terraform CLI command with the given arguments
+// for commands which terraform-exec does not support with reattached
+// providers. The command runs in the working directory with the environment
+// of terraform-exec commands, including writing Terraform logs to the log
+// path of the working directory.
+func (wd *WorkingDir) reattachedCommand(ctx context.Context, args ...string) (*exec.Cmd, error) {
+	cmd := exec.CommandContext(ctx, wd.h.terraformExec, args...)
+	cmd.Dir = wd.baseDir
+	cmd.Env = append(os.Environ(),
+		"CHECKPOINT_DISABLE=1",
+		"TF_DISABLE_PLUGIN_TLS=1",
+		"TF_IN_AUTOMATION=1",
+		"TF_SKIP_PROVIDER_VERIFY=1",
+	)
+	cmd.Env = append(cmd.Env, wd.logEnv()...)
+
+	for k, v := range pluginCache.env() {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	for k, v := range wd.env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	if len(wd.reattachInfo) > 0 {
+		reattachInfo, err := json.Marshal(wd.reattachInfo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode provider reattach information: %w", err)
+		}
+
+		cmd.Env = append(cmd.Env, "TF_REATTACH_PROVIDERS="+string(reattachInfo))
+	}
+
+	return cmd, nil
+}
+
+// logEnv returns the Terraform logging environment variables for commands
+// run by reattachedCommand, matching those set by terraform-exec. Logging is
+// disabled unless the working directory has a log path, so logs cannot
+// pollute the command output.
+func (wd *WorkingDir) logEnv() []string {
+	if wd.logPath == "" {
+		return []string{
+			"TF_LOG=",
+			"TF_LOG_CORE=",
+			"TF_LOG_PATH=",
+			"TF_LOG_PROVIDER=",
+		}
+	}
+
+	logLevel := wd.logLevel
+
+	// terraform-exec defaults to TRACE when only the log path is set.
+	if logLevel == "" && wd.logCore == "" && wd.logProvider == "" {
+		logLevel = "TRACE"
+	}
+
+	return []string{
+		"TF_LOG=" + logLevel,
+		"TF_LOG_CORE=" + wd.logCore,
+		"TF_LOG_PATH=" + wd.logPath,
+		"TF_LOG_PROVIDER=" + wd.logProvider,
+	}
+}
+
+// Validate runs terraform validate and returns the machine-readable output,
+// which includes any warning diagnostics, such as deprecated attributes.
+//
+// The terraform-exec Validate command does not support reattached providers,
+// so the command is run directly.
+func (wd *WorkingDir) Validate(ctx context.Context) (*tfjson.ValidateOutput, error) {
+	logging.HelperResourceTrace(ctx, "Calling Terraform CLI validate command")
+
+	wd.h.operations.record(OperationValidate)
+
+	cmd, err := wd.reattachedCommand(ctx, "validate", "-no-color", "-json")
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Invalid configurations exit with status 1, but still return
+	// diagnostics in the machine-readable output.
+	runErr := cmd.Run()
+
+	logging.HelperResourceTrace(ctx, "Called Terraform CLI validate command")
+
+	var output tfjson.ValidateOutput
+
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("%w\n%s", runErr, stderr.String())
+		}
+
+		return nil, fmt.Errorf("unable to decode validate output: %w", err)
+	}
+
+	return &output, nil
+}
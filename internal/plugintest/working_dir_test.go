@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWorkingDirLogEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		wd       *WorkingDir
+		expected []string
+	}{
+		"no-log-path": {
+			wd: &WorkingDir{
+				logLevel: "DEBUG",
+			},
+			expected: []string{
+				"TF_LOG=",
+				"TF_LOG_CORE=",
+				"TF_LOG_PATH=",
+				"TF_LOG_PROVIDER=",
+			},
+		},
+		"log-path": {
+			wd: &WorkingDir{
+				logPath: "/tmp/terraform.log",
+			},
+			expected: []string{
+				"TF_LOG=TRACE",
+				"TF_LOG_CORE=",
+				"TF_LOG_PATH=/tmp/terraform.log",
+				"TF_LOG_PROVIDER=",
+			},
+		},
+		"log-path-levels": {
+			wd: &WorkingDir{
+				logPath:     "/tmp/terraform.log",
+				logCore:     "WARN",
+				logProvider: "DEBUG",
+			},
+			expected: []string{
+				"TF_LOG=",
+				"TF_LOG_CORE=WARN",
+				"TF_LOG_PATH=/tmp/terraform.log",
+				"TF_LOG_PROVIDER=DEBUG",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.wd.logEnv()
+
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
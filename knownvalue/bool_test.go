@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestBool_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.Bool(false),
+			expectedError: fmt.Errorf("expected bool value for Bool check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.Bool(false),
+			other: false,
+		},
+		"wrong-type": {
+			self:          knownvalue.Bool(true),
+			other:         1.23,
+			expectedError: fmt.Errorf("expected bool value for Bool check, got: float64"),
+		},
+		"not-equal": {
+			self:          knownvalue.Bool(true),
+			other:         false,
+			expectedError: fmt.Errorf("expected value true for Bool check, got: false"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBool_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.Bool(true).String()
+
+	if diff := cmp.Diff(got, "true"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
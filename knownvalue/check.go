@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+// Check defines an interface that is implemented to determine whether type and value match. Individual
+// implementations determine how the match is performed (e.g., exact match, partial match).
+//
+// Custom checks can implement this interface and be used anywhere a Check is accepted, including within
+// collection and object checks such as ListExact and ObjectPartial. Values are passed to CheckValue as
+// decoded from JSON:
+//
+//   - bool for booleans
+//   - json.Number, or float64, for numbers
+//   - string for strings
+//   - []interface{} for lists, sets, and tuples
+//   - map[string]interface{} for maps and objects
+//   - nil for null values
+type Check interface {
+	// CheckValue should assert the given known value against any expectations. Values are always
+	// ensured to be known before calling this function. Implementations should return an error when
+	// the given value does not match expectations, which describes both the expected and actual
+	// values.
+	CheckValue(value interface{}) error
+
+	// String should return a string representation of the type and value. It is used when
+	// reporting the differences of collection and object checks, see Diff.
+	String() string
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Diff returns a human-readable report of the differences between the value
+// expected by the given collection or object check, such as ObjectExact or
+// ListExact, and the value passed to its CheckValue method, in the
+// (-expected +got) format of go-cmp. Values nested within the collection or
+// object are compared structurally, so every mismatched element or attribute
+// is reported, while the CheckValue error only describes the first.
+//
+// Each other check, including custom checks, is reported with its String
+// representation, unless the value passes the check. An empty string is
+// returned if the value passes the check, or if the check is not a
+// collection or object check, as the CheckValue errors of other checks
+// already contain the expected and actual values.
+//
+// The format of the report is not stable and should not be parsed.
+func Diff(check Check, value interface{}) string {
+	switch check.(type) {
+	case listExact, listPartial, mapExact, mapPartial, objectExact, objectPartial:
+	default:
+		return ""
+	}
+
+	if check.CheckValue(value) == nil {
+		return ""
+	}
+
+	return cmp.Diff(expectedDiffValue(check), actualDiffValue(check, value))
+}
+
+// expectedDiffValue returns the representation of the value expected by the
+// check, with nested checks of collections and objects expanded and other
+// checks represented by their String.
+func expectedDiffValue(check Check) interface{} {
+	switch check := check.(type) {
+	case listExact:
+		result := make([]interface{}, 0, len(check.value))
+
+		for _, elementCheck := range check.value {
+			result = append(result, expectedDiffValue(elementCheck))
+		}
+
+		return result
+	case listPartial:
+		result := make(map[int]interface{}, len(check.value))
+
+		for i, elementCheck := range check.value {
+			result[i] = expectedDiffValue(elementCheck)
+		}
+
+		return result
+	case mapExact:
+		return expectedDiffMap(check.value)
+	case mapPartial:
+		return expectedDiffMap(check.value)
+	case objectExact:
+		return expectedDiffMap(check.value)
+	case objectPartial:
+		return expectedDiffMap(check.value)
+	default:
+		return check.String()
+	}
+}
+
+// expectedDiffMap returns the representation of the values expected by the
+// checks of a map or object.
+func expectedDiffMap(checks map[string]Check) map[string]interface{} {
+	result := make(map[string]interface{}, len(checks))
+
+	for k, check := range checks {
+		result[k] = expectedDiffValue(check)
+	}
+
+	return result
+}
+
+// actualDiffValue returns the representation of the value in the same
+// structure as expectedDiffValue, where values passing their check are
+// represented by the String of the check, so only mismatches are reported.
+func actualDiffValue(check Check, value interface{}) interface{} {
+	switch check := check.(type) {
+	case listExact:
+		values, ok := value.([]interface{})
+
+		if !ok {
+			break
+		}
+
+		result := make([]interface{}, 0, len(values))
+
+		for i, element := range values {
+			if i < len(check.value) {
+				result = append(result, actualDiffValue(check.value[i], element))
+
+				continue
+			}
+
+			result = append(result, renderDiffValue(element))
+		}
+
+		return result
+	case listPartial:
+		values, ok := value.([]interface{})
+
+		if !ok {
+			break
+		}
+
+		result := make(map[int]interface{}, len(check.value))
+
+		for i, elementCheck := range check.value {
+			if i >= 0 && i < len(values) {
+				result[i] = actualDiffValue(elementCheck, values[i])
+			}
+		}
+
+		return result
+	case mapExact:
+		return actualDiffMap(check.value, value, true)
+	case mapPartial:
+		return actualDiffMap(check.value, value, false)
+	case objectExact:
+		return actualDiffMap(check.value, value, true)
+	case objectPartial:
+		return actualDiffMap(check.value, value, false)
+	default:
+		if check.CheckValue(value) == nil {
+			return check.String()
+		}
+	}
+
+	return renderDiffValue(value)
+}
+
+// actualDiffMap returns the representation of a map or object value for the
+// checks of a map or object. Elements without a check are only included for
+// exact checks.
+func actualDiffMap(checks map[string]Check, value interface{}, exact bool) interface{} {
+	values, ok := value.(map[string]interface{})
+
+	if !ok {
+		return renderDiffValue(value)
+	}
+
+	result := make(map[string]interface{}, len(values))
+
+	for k, element := range values {
+		check, ok := checks[k]
+
+		switch {
+		case ok:
+			result[k] = actualDiffValue(check, element)
+		case exact:
+			result[k] = renderDiffValue(element)
+		}
+	}
+
+	return result
+}
+
+// renderDiffValue returns the representation of a value without a check,
+// with the same formatting as the String of the checks for its type.
+func renderDiffValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+
+		for _, element := range value {
+			result = append(result, renderDiffValue(element))
+		}
+
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+
+		for k, element := range value {
+			result[k] = renderDiffValue(element)
+		}
+
+		return result
+	default:
+		return fmt.Sprint(value)
+	}
+}
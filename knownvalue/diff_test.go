@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	value := map[string]interface{}{
+		"name":  "example",
+		"count": json.Number("2"),
+		"rule": []interface{}{
+			map[string]interface{}{
+				"port":     json.Number("443"),
+				"protocol": "tcp",
+			},
+		},
+		"tags": nil,
+	}
+
+	testCases := map[string]struct {
+		check    knownvalue.Check
+		value    interface{}
+		expected []string
+	}{
+		"pass": {
+			check: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+				"name": knownvalue.StringExact("example"),
+			}),
+			value: value,
+		},
+		"not-collection": {
+			check: knownvalue.StringExact("other"),
+			value: "example",
+		},
+		"object-exact": {
+			check: knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"name":  knownvalue.StringExact("other"),
+				"count": knownvalue.Int64Exact(2),
+				"rule": knownvalue.ListExact([]knownvalue.Check{
+					knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"port":     knownvalue.Int64Exact(80),
+						"protocol": knownvalue.StringRegexp(regexp.MustCompile(`^tcp$`)),
+					}),
+				}),
+			}),
+			value: value,
+			expected: []string{
+				`- "name": string("other")`,
+				`+ "name": string("example")`,
+				`- "port": string("80")`,
+				`+ "port": string("443")`,
+				`+ "tags": string("null")`,
+			},
+		},
+		"object-partial": {
+			check: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+				"name":    knownvalue.StringExact("example"),
+				"missing": knownvalue.Null(),
+				"rule": knownvalue.ListPartial(map[int]knownvalue.Check{
+					0: knownvalue.MapPartial(map[string]knownvalue.Check{
+						"protocol": knownvalue.StringExact("udp"),
+					}),
+				}),
+			}),
+			value: value,
+			expected: []string{
+				`- "missing": string("null")`,
+				`{"protocol": string("udp")}`,
+				`{"protocol": string("tcp")}`,
+			},
+		},
+		"wrong-type": {
+			check: knownvalue.ListExact([]knownvalue.Check{
+				knownvalue.StringExact("example"),
+			}),
+			value: "example",
+			expected: []string{
+				`- []any{string("example")}`,
+				`+ string("example")`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// The go-cmp output format, such as whitespace, is not stable.
+			got := strings.Join(strings.Fields(knownvalue.Diff(testCase.check, testCase.value)), " ")
+
+			if len(testCase.expected) == 0 && got != "" {
+				t.Fatalf("expected no difference, got: %s", got)
+			}
+
+			for _, expected := range testCase.expected {
+				if !strings.Contains(got, expected) {
+					t.Errorf("expected difference %q, got: %s", expected, got)
+				}
+			}
+		})
+	}
+}
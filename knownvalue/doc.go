@@ -0,0 +1,5 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package knownvalue contains the known value interface, and types implementing the known value interface.
+package knownvalue
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var _ Check = float64Exact{}
+
+type float64Exact struct {
+	value float64
+}
+
+// CheckValue determines whether the passed value is a number, and
+// contains a matching float64 value.
+func (v float64Exact) CheckValue(other interface{}) error {
+	otherFloat, err := float64Value(other, "Float64Exact")
+
+	if err != nil {
+		return err
+	}
+
+	if otherFloat != v.value {
+		return fmt.Errorf("expected value %s for Float64Exact check, got: %s", v.String(), strconv.FormatFloat(otherFloat, 'f', -1, 64))
+	}
+
+	return nil
+}
+
+// String returns the string representation of the float64 value.
+func (v float64Exact) String() string {
+	return strconv.FormatFloat(v.value, 'f', -1, 64)
+}
+
+// Float64Exact returns a Check for asserting equality between the
+// supplied float64 and the value passed to the CheckValue method.
+func Float64Exact(value float64) float64Exact {
+	return float64Exact{
+		value: value,
+	}
+}
+
+var _ Check = float64Near{}
+
+type float64Near struct {
+	value float64
+	delta float64
+}
+
+// CheckValue determines whether the passed value is a number, and
+// contains a float64 value within delta of the value.
+func (v float64Near) CheckValue(other interface{}) error {
+	otherFloat, err := float64Value(other, "Float64Near")
+
+	if err != nil {
+		return err
+	}
+
+	if math.Abs(otherFloat-v.value) > v.delta {
+		return fmt.Errorf("expected value %s for Float64Near check, got: %s", v.String(), strconv.FormatFloat(otherFloat, 'f', -1, 64))
+	}
+
+	return nil
+}
+
+// String returns the string representation of the float64 value and delta.
+func (v float64Near) String() string {
+	return fmt.Sprintf("%s +/- %s", strconv.FormatFloat(v.value, 'f', -1, 64), strconv.FormatFloat(v.delta, 'f', -1, 64))
+}
+
+// Float64Near returns a Check for asserting that the value passed to the
+// CheckValue method is within the supplied delta of the supplied float64,
+// inclusive, such as for computed values which are not deterministic.
+func Float64Near(value float64, delta float64) float64Near {
+	return float64Near{
+		value: value,
+		delta: math.Abs(delta),
+	}
+}
+
+// float64Value returns the float64 value of a number passed to the CheckValue
+// method of the named check.
+func float64Value(other interface{}, checkName string) (float64, error) {
+	switch otherVal := other.(type) {
+	case json.Number:
+		f, err := otherVal.Float64()
+
+		if err != nil {
+			return 0, fmt.Errorf("expected json.Number to be parseable as float64 value for %s check: %s", checkName, otherVal)
+		}
+
+		return f, nil
+	case float64:
+		return otherVal, nil
+	default:
+		return 0, fmt.Errorf("expected json.Number value for %s check, got: %T", checkName, other)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestFloat64Exact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.Float64Exact(1.234),
+			expectedError: fmt.Errorf("expected json.Number value for Float64Exact check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.Float64Exact(1.234),
+			other: json.Number("1.234"),
+		},
+		"equal-float64": {
+			self:  knownvalue.Float64Exact(1.234),
+			other: 1.234,
+		},
+		"wrong-type": {
+			self:          knownvalue.Float64Exact(1.234),
+			other:         "str",
+			expectedError: fmt.Errorf("expected json.Number value for Float64Exact check, got: string"),
+		},
+		"not-float64": {
+			self:          knownvalue.Float64Exact(1.234),
+			other:         json.Number("1e+309"),
+			expectedError: fmt.Errorf("expected json.Number to be parseable as float64 value for Float64Exact check: 1e+309"),
+		},
+		"not-equal": {
+			self:          knownvalue.Float64Exact(1.234),
+			other:         json.Number("4.321"),
+			expectedError: fmt.Errorf("expected value 1.234 for Float64Exact check, got: 4.321"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFloat64Exact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.Float64Exact(1.234567890123e+09).String()
+
+	if diff := cmp.Diff(got, "1234567890.123"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestFloat64Near_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.Float64Near(1.5, 0.1),
+			expectedError: fmt.Errorf("expected json.Number value for Float64Near check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.Float64Near(1.5, 0.1),
+			other: json.Number("1.5"),
+		},
+		"near": {
+			self:  knownvalue.Float64Near(1.5, 0.1),
+			other: json.Number("1.45"),
+		},
+		"near-float64": {
+			self:  knownvalue.Float64Near(1.5, 0.1),
+			other: float64(1.55),
+		},
+		"wrong-type": {
+			self:          knownvalue.Float64Near(1.5, 0.1),
+			other:         "str",
+			expectedError: fmt.Errorf("expected json.Number value for Float64Near check, got: string"),
+		},
+		"not-near": {
+			self:          knownvalue.Float64Near(1.5, 0.1),
+			other:         json.Number("1.7"),
+			expectedError: fmt.Errorf("expected value 1.5 +/- 0.1 for Float64Near check, got: 1.7"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFloat64Near_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.Float64Near(1.5, -0.1).String()
+
+	if diff := cmp.Diff(got, "1.5 +/- 0.1"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var _ Check = int64Exact{}
+
+type int64Exact struct {
+	value int64
+}
+
+// CheckValue determines whether the passed value is a number, and
+// contains a matching int64 value.
+func (v int64Exact) CheckValue(other interface{}) error {
+	otherInt, err := int64Value(other, "Int64Exact")
+
+	if err != nil {
+		return err
+	}
+
+	if otherInt != v.value {
+		return fmt.Errorf("expected value %d for Int64Exact check, got: %d", v.value, otherInt)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the int64 value.
+func (v int64Exact) String() string {
+	return strconv.FormatInt(v.value, 10)
+}
+
+// Int64Exact returns a Check for asserting equality between the
+// supplied int64 and the value passed to the CheckValue method.
+func Int64Exact(value int64) int64Exact {
+	return int64Exact{
+		value: value,
+	}
+}
+
+var _ Check = int64Between{}
+
+type int64Between struct {
+	minValue int64
+	maxValue int64
+}
+
+// CheckValue determines whether the passed value is a number, and
+// contains an int64 value between the minimum and maximum, inclusive.
+func (v int64Between) CheckValue(other interface{}) error {
+	otherInt, err := int64Value(other, "Int64Between")
+
+	if err != nil {
+		return err
+	}
+
+	if otherInt < v.minValue || otherInt > v.maxValue {
+		return fmt.Errorf("expected value between %d and %d for Int64Between check, got: %d", v.minValue, v.maxValue, otherInt)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the int64 range.
+func (v int64Between) String() string {
+	return fmt.Sprintf("[%d, %d]", v.minValue, v.maxValue)
+}
+
+// Int64Between returns a Check for asserting that the value passed to the
+// CheckValue method is between the supplied minimum and maximum int64
+// values, inclusive, such as for computed sizes which are not deterministic.
+func Int64Between(minValue int64, maxValue int64) int64Between {
+	return int64Between{
+		minValue: minValue,
+		maxValue: maxValue,
+	}
+}
+
+// int64Value returns the int64 value of a number passed to the CheckValue
+// method of the named check.
+func int64Value(other interface{}, checkName string) (int64, error) {
+	switch otherVal := other.(type) {
+	case json.Number:
+		i, err := otherVal.Int64()
+
+		if err != nil {
+			return 0, fmt.Errorf("expected json.Number to be parseable as int64 value for %s check: %s", checkName, otherVal)
+		}
+
+		return i, nil
+	case float64:
+		if otherVal != math.Trunc(otherVal) || otherVal < math.MinInt64 || otherVal >= math.MaxInt64 {
+			return 0, fmt.Errorf("expected json.Number to be parseable as int64 value for %s check: %s", checkName, strconv.FormatFloat(otherVal, 'f', -1, 64))
+		}
+
+		return int64(otherVal), nil
+	default:
+		return 0, fmt.Errorf("expected json.Number value for %s check, got: %T", checkName, other)
+	}
+}
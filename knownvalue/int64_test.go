@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestInt64Exact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.Int64Exact(1234),
+			expectedError: fmt.Errorf("expected json.Number value for Int64Exact check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.Int64Exact(1234),
+			other: json.Number("1234"),
+		},
+		"equal-float64": {
+			self:  knownvalue.Int64Exact(1234),
+			other: float64(1234),
+		},
+		"wrong-type": {
+			self:          knownvalue.Int64Exact(1234),
+			other:         "str",
+			expectedError: fmt.Errorf("expected json.Number value for Int64Exact check, got: string"),
+		},
+		"not-int64": {
+			self:          knownvalue.Int64Exact(1234),
+			other:         json.Number("1.23"),
+			expectedError: fmt.Errorf("expected json.Number to be parseable as int64 value for Int64Exact check: 1.23"),
+		},
+		"not-equal": {
+			self:          knownvalue.Int64Exact(1234),
+			other:         json.Number("4321"),
+			expectedError: fmt.Errorf("expected value 1234 for Int64Exact check, got: 4321"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestInt64Exact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.Int64Exact(1234567890123).String()
+
+	if diff := cmp.Diff(got, "1234567890123"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestInt64Between_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.Int64Between(10, 20),
+			expectedError: fmt.Errorf("expected json.Number value for Int64Between check, got: <nil>"),
+		},
+		"min": {
+			self:  knownvalue.Int64Between(10, 20),
+			other: json.Number("10"),
+		},
+		"max": {
+			self:  knownvalue.Int64Between(10, 20),
+			other: json.Number("20"),
+		},
+		"between-float64": {
+			self:  knownvalue.Int64Between(10, 20),
+			other: float64(15),
+		},
+		"wrong-type": {
+			self:          knownvalue.Int64Between(10, 20),
+			other:         "str",
+			expectedError: fmt.Errorf("expected json.Number value for Int64Between check, got: string"),
+		},
+		"not-int64": {
+			self:          knownvalue.Int64Between(10, 20),
+			other:         json.Number("15.5"),
+			expectedError: fmt.Errorf("expected json.Number to be parseable as int64 value for Int64Between check: 15.5"),
+		},
+		"below": {
+			self:          knownvalue.Int64Between(10, 20),
+			other:         json.Number("9"),
+			expectedError: fmt.Errorf("expected value between 10 and 20 for Int64Between check, got: 9"),
+		},
+		"above": {
+			self:          knownvalue.Int64Between(10, 20),
+			other:         json.Number("21"),
+			expectedError: fmt.Errorf("expected value between 10 and 20 for Int64Between check, got: 21"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestInt64Between_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.Int64Between(10, 20).String()
+
+	if diff := cmp.Diff(got, "[10, 20]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
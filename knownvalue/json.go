@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+var _ Check = jsonExact{}
+
+type jsonExact struct {
+	value string
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// contains JSON which is semantically equal to the value, ignoring object
+// key order and whitespace.
+func (v jsonExact) CheckValue(other interface{}) error {
+	expected, got, err := decodeJSONValues(v.value, other, "JSONExact")
+
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(expected, got) {
+		return fmt.Errorf("expected JSON value %s for JSONExact check, got: %s", v.String(), normalizedJSON(got))
+	}
+
+	return nil
+}
+
+// String returns the normalized string representation of the JSON value.
+func (v jsonExact) String() string {
+	return normalizedJSONString(v.value)
+}
+
+// JSONExact returns a Check for asserting that the value passed to the
+// CheckValue method is a string containing JSON, such as a policy document,
+// which is semantically equal to the supplied JSON string. Object key order
+// and whitespace are ignored.
+func JSONExact(value string) jsonExact {
+	return jsonExact{
+		value: value,
+	}
+}
+
+var _ Check = jsonPartial{}
+
+type jsonPartial struct {
+	value string
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// contains JSON which includes the value. Objects only need to contain the
+// keys of the value, while arrays must contain the same number of elements.
+func (v jsonPartial) CheckValue(other interface{}) error {
+	expected, got, err := decodeJSONValues(v.value, other, "JSONPartial")
+
+	if err != nil {
+		return err
+	}
+
+	if !jsonContains(got, expected) {
+		return fmt.Errorf("expected JSON value containing %s for JSONPartial check, got: %s", v.String(), normalizedJSON(got))
+	}
+
+	return nil
+}
+
+// String returns the normalized string representation of the JSON value.
+func (v jsonPartial) String() string {
+	return normalizedJSONString(v.value)
+}
+
+// JSONPartial returns a Check for asserting that the value passed to the
+// CheckValue method is a string containing JSON which includes the supplied
+// JSON string. Objects, including nested objects, only need to contain the
+// keys of the supplied JSON, while arrays must contain the same number of
+// elements in the same order. Object key order and whitespace are ignored.
+func JSONPartial(value string) jsonPartial {
+	return jsonPartial{
+		value: value,
+	}
+}
+
+// decodeJSONValues returns the decoded expected JSON and the decoded JSON
+// of the string passed to the CheckValue method of the named check.
+func decodeJSONValues(expectedJSON string, other interface{}, checkName string) (interface{}, interface{}, error) {
+	otherVal, ok := other.(string)
+
+	if !ok {
+		return nil, nil, fmt.Errorf("expected string value for %s check, got: %T", checkName, other)
+	}
+
+	var expected interface{}
+
+	if err := json.Unmarshal([]byte(expectedJSON), &expected); err != nil {
+		return nil, nil, fmt.Errorf("expected valid JSON for %s check value: %s", checkName, err)
+	}
+
+	var got interface{}
+
+	if err := json.Unmarshal([]byte(otherVal), &got); err != nil {
+		return nil, nil, fmt.Errorf("expected string value containing valid JSON for %s check: %s", checkName, err)
+	}
+
+	return expected, got, nil
+}
+
+// jsonContains returns true if the decoded JSON value contains the expected
+// decoded JSON value.
+func jsonContains(value interface{}, expected interface{}) bool {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		valueMap, ok := value.(map[string]interface{})
+
+		if !ok {
+			return false
+		}
+
+		for key, expectedElem := range expected {
+			elem, ok := valueMap[key]
+
+			if !ok || !jsonContains(elem, expectedElem) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		valueSlice, ok := value.([]interface{})
+
+		if !ok || len(valueSlice) != len(expected) {
+			return false
+		}
+
+		for i, expectedElem := range expected {
+			if !jsonContains(valueSlice[i], expectedElem) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(value, expected)
+	}
+}
+
+// normalizedJSONString returns the compact JSON encoding of the JSON string
+// with sorted object keys, or the string itself if it is not valid JSON.
+func normalizedJSONString(value string) string {
+	var decoded interface{}
+
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return value
+	}
+
+	return normalizedJSON(decoded)
+}
+
+// normalizedJSON returns the compact JSON encoding of the decoded JSON value
+// with sorted object keys.
+func normalizedJSON(value interface{}) string {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestJSONExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.JSONExact(`{"a": 1}`),
+			expectedError: fmt.Errorf("expected string value for JSONExact check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.JSONExact(`{"a": 1, "b": ["x", "y"]}`),
+			other: `{"b":["x","y"],"a":1.0}`,
+		},
+		"equal-whitespace": {
+			self:  knownvalue.JSONExact(`{"a": {"b": true}}`),
+			other: "{\n  \"a\": {\n    \"b\": true\n  }\n}",
+		},
+		"wrong-type": {
+			self:          knownvalue.JSONExact(`{"a": 1}`),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for JSONExact check, got: float64"),
+		},
+		"invalid-json": {
+			self:          knownvalue.JSONExact(`{"a": 1}`),
+			other:         `{"a":`,
+			expectedError: fmt.Errorf("expected string value containing valid JSON for JSONExact check: unexpected end of JSON input"),
+		},
+		"invalid-expected-json": {
+			self:          knownvalue.JSONExact(`{"a":`),
+			other:         `{"a": 1}`,
+			expectedError: fmt.Errorf("expected valid JSON for JSONExact check value: unexpected end of JSON input"),
+		},
+		"extra-key": {
+			self:          knownvalue.JSONExact(`{"a": 1}`),
+			other:         `{"a": 1, "b": 2}`,
+			expectedError: fmt.Errorf(`expected JSON value {"a":1} for JSONExact check, got: {"a":1,"b":2}`),
+		},
+		"array-order": {
+			self:          knownvalue.JSONExact(`["x", "y"]`),
+			other:         `["y", "x"]`,
+			expectedError: fmt.Errorf(`expected JSON value ["x","y"] for JSONExact check, got: ["y","x"]`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.JSONExact(`{"b": 2, "a": 1}`).String()
+
+	if diff := cmp.Diff(got, `{"a":1,"b":2}`); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestJSONPartial_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.JSONPartial(`{"a": 1}`),
+			expectedError: fmt.Errorf("expected string value for JSONPartial check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.JSONPartial(`{"a": 1}`),
+			other: `{"a": 1}`,
+		},
+		"subset": {
+			self:  knownvalue.JSONPartial(`{"Statement": [{"Effect": "Allow"}]}`),
+			other: `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]}`,
+		},
+		"wrong-type": {
+			self:          knownvalue.JSONPartial(`{"a": 1}`),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for JSONPartial check, got: float64"),
+		},
+		"invalid-json": {
+			self:          knownvalue.JSONPartial(`{"a": 1}`),
+			other:         `not json`,
+			expectedError: fmt.Errorf("expected string value containing valid JSON for JSONPartial check: invalid character 'o' in literal null (expecting 'u')"),
+		},
+		"missing-key": {
+			self:          knownvalue.JSONPartial(`{"a": 1, "c": 3}`),
+			other:         `{"a": 1, "b": 2}`,
+			expectedError: fmt.Errorf(`expected JSON value containing {"a":1,"c":3} for JSONPartial check, got: {"a":1,"b":2}`),
+		},
+		"different-value": {
+			self:          knownvalue.JSONPartial(`{"a": {"b": "x"}}`),
+			other:         `{"a": {"b": "y", "c": "z"}}`,
+			expectedError: fmt.Errorf(`expected JSON value containing {"a":{"b":"x"}} for JSONPartial check, got: {"a":{"b":"y","c":"z"}}`),
+		},
+		"array-length": {
+			self:          knownvalue.JSONPartial(`[{"a": 1}]`),
+			other:         `[{"a": 1}, {"a": 2}]`,
+			expectedError: fmt.Errorf(`expected JSON value containing [{"a":1}] for JSONPartial check, got: [{"a":1},{"a":2}]`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONPartial_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.JSONPartial(`{"b": 2, "a": 1}`).String()
+
+	if diff := cmp.Diff(got, `{"a":1,"b":2}`); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var _ Check = listExact{}
+
+type listExact struct {
+	value []Check
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains matching slice entries in the same sequence.
+func (v listExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for ListExact check, got: %T", other)
+	}
+
+	if len(otherVal) != len(v.value) {
+		return fmt.Errorf("expected %d %s for ListExact check, got %d %s", len(v.value), pluralize("element", len(v.value)), len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	for i := 0; i < len(v.value); i++ {
+		if err := v.value[i].CheckValue(otherVal[i]); err != nil {
+			return fmt.Errorf("list element index %d: %s", i, err)
+		}
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v listExact) String() string {
+	var listVals []string
+
+	for _, val := range v.value {
+		listVals = append(listVals, val.String())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(listVals, " "))
+}
+
+// ListExact returns a Check for asserting equality between the
+// supplied []Check and the value passed to the CheckValue method.
+// This is an order-dependent check.
+func ListExact(value []Check) listExact {
+	return listExact{
+		value: value,
+	}
+}
+
+var _ Check = listPartial{}
+
+type listPartial struct {
+	value map[int]Check
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains matching slice entries at the given indices. Other entries are
+// not checked.
+func (v listPartial) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for ListPartial check, got: %T", other)
+	}
+
+	for _, i := range sortedIndices(v.value) {
+		if i < 0 || i >= len(otherVal) {
+			return fmt.Errorf("missing element index %d for ListPartial check", i)
+		}
+
+		if err := v.value[i].CheckValue(otherVal[i]); err != nil {
+			return fmt.Errorf("list element index %d: %s", i, err)
+		}
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v listPartial) String() string {
+	var listVals []string
+
+	for _, i := range sortedIndices(v.value) {
+		listVals = append(listVals, fmt.Sprintf("%d:%s", i, v.value[i]))
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(listVals, " "))
+}
+
+// ListPartial returns a Check for asserting partial equality between the
+// supplied map[int]Check and the value passed to the CheckValue method. The
+// map keys represent the zero-based indices of the list elements to check,
+// while other elements, such as computed values, are not checked.
+func ListPartial(value map[int]Check) listPartial {
+	return listPartial{
+		value: value,
+	}
+}
+
+var _ Check = listSizeExact{}
+
+type listSizeExact struct {
+	size int
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains the expected number of elements.
+func (v listSizeExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for ListSizeExact check, got: %T", other)
+	}
+
+	if len(otherVal) != v.size {
+		return fmt.Errorf("expected %d %s for ListSizeExact check, got %d %s", v.size, pluralize("element", v.size), len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	return nil
+}
+
+// String returns the string representation of the size.
+func (v listSizeExact) String() string {
+	return strconv.Itoa(v.size)
+}
+
+// ListSizeExact returns a Check for asserting that the number of elements of
+// the list passed to the CheckValue method equals the supplied size, such
+// as for computed lists where element values are unpredictable.
+func ListSizeExact(size int) listSizeExact {
+	return listSizeExact{
+		size: size,
+	}
+}
+
+var _ Check = listSizeBetween{}
+
+type listSizeBetween struct {
+	minSize int
+	maxSize int
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains a number of elements between the minimum and maximum, inclusive.
+func (v listSizeBetween) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for ListSizeBetween check, got: %T", other)
+	}
+
+	if len(otherVal) < v.minSize || len(otherVal) > v.maxSize {
+		return fmt.Errorf("expected between %d and %d elements for ListSizeBetween check, got %d %s", v.minSize, v.maxSize, len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	return nil
+}
+
+// String returns the string representation of the size range.
+func (v listSizeBetween) String() string {
+	return fmt.Sprintf("[%d, %d]", v.minSize, v.maxSize)
+}
+
+// ListSizeBetween returns a Check for asserting that the number of elements
+// of the list passed to the CheckValue method is between the supplied
+// minimum and maximum sizes, inclusive.
+func ListSizeBetween(minSize int, maxSize int) listSizeBetween {
+	return listSizeBetween{
+		minSize: minSize,
+		maxSize: maxSize,
+	}
+}
+
+// sortedIndices returns the keys of a map[int]Check in sorted order, so that
+// checks and error messages are deterministic.
+func sortedIndices(value map[int]Check) []int {
+	indices := make([]int, 0, len(value))
+
+	for i := range value {
+		indices = append(indices, i)
+	}
+
+	sort.Ints(indices)
+
+	return indices
+}
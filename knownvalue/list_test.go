@@ -0,0 +1,294 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestListExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.ListExact([]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected []interface{} value for ListExact check, got: <nil>"),
+		},
+		"equal": {
+			self: knownvalue.ListExact([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+				knownvalue.Int64Exact(456),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.ListExact([]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for ListExact check, got: float64"),
+		},
+		"wrong-length": {
+			self: knownvalue.ListExact([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+				knownvalue.Int64Exact(456),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+			},
+			expectedError: fmt.Errorf("expected 2 elements for ListExact check, got 1 element"),
+		},
+		"not-equal": {
+			self: knownvalue.ListExact([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+				knownvalue.Int64Exact(456),
+			}),
+			other: []interface{}{
+				json.Number("456"),
+				json.Number("123"),
+			},
+			expectedError: fmt.Errorf("list element index 0: expected value 123 for Int64Exact check, got: 456"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestListExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("str"), knownvalue.Int64Exact(123)}).String()
+
+	if diff := cmp.Diff(got, "[str 123]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestListPartial_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.ListPartial(map[int]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected []interface{} value for ListPartial check, got: <nil>"),
+		},
+		"equal-subset": {
+			self: knownvalue.ListPartial(map[int]knownvalue.Check{
+				0: knownvalue.Int64Exact(123),
+				2: knownvalue.Int64Exact(789),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+				json.Number("789"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.ListPartial(map[int]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for ListPartial check, got: float64"),
+		},
+		"missing-index": {
+			self: knownvalue.ListPartial(map[int]knownvalue.Check{
+				0: knownvalue.Int64Exact(123),
+				3: knownvalue.Int64Exact(789),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+			},
+			expectedError: fmt.Errorf("missing element index 3 for ListPartial check"),
+		},
+		"not-equal": {
+			self: knownvalue.ListPartial(map[int]knownvalue.Check{
+				1: knownvalue.Int64Exact(789),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+			},
+			expectedError: fmt.Errorf("list element index 1: expected value 789 for Int64Exact check, got: 456"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestListPartial_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.ListPartial(map[int]knownvalue.Check{2: knownvalue.Int64Exact(123), 0: knownvalue.StringExact("str")}).String()
+
+	if diff := cmp.Diff(got, "[0:str 2:123]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestListSizeExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.ListSizeExact(2),
+			expectedError: fmt.Errorf("expected []interface{} value for ListSizeExact check, got: <nil>"),
+		},
+		"equal": {
+			self: knownvalue.ListSizeExact(2),
+			other: []interface{}{
+				"str",
+				"rts",
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.ListSizeExact(2),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for ListSizeExact check, got: float64"),
+		},
+		"wrong-size": {
+			self: knownvalue.ListSizeExact(2),
+			other: []interface{}{
+				"str",
+			},
+			expectedError: fmt.Errorf("expected 2 elements for ListSizeExact check, got 1 element"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestListSizeExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.ListSizeExact(2).String()
+
+	if diff := cmp.Diff(got, "2"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestListSizeBetween_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.ListSizeBetween(1, 2),
+			expectedError: fmt.Errorf("expected []interface{} value for ListSizeBetween check, got: <nil>"),
+		},
+		"minimum": {
+			self: knownvalue.ListSizeBetween(1, 2),
+			other: []interface{}{
+				"str",
+			},
+		},
+		"maximum": {
+			self: knownvalue.ListSizeBetween(1, 2),
+			other: []interface{}{
+				"str",
+				"rts",
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.ListSizeBetween(1, 2),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for ListSizeBetween check, got: float64"),
+		},
+		"below-minimum": {
+			self:          knownvalue.ListSizeBetween(1, 2),
+			other:         []interface{}{},
+			expectedError: fmt.Errorf("expected between 1 and 2 elements for ListSizeBetween check, got 0 elements"),
+		},
+		"above-maximum": {
+			self: knownvalue.ListSizeBetween(1, 2),
+			other: []interface{}{
+				"str",
+				"rts",
+				"tsr",
+			},
+			expectedError: fmt.Errorf("expected between 1 and 2 elements for ListSizeBetween check, got 3 elements"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestListSizeBetween_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.ListSizeBetween(1, 2).String()
+
+	if diff := cmp.Diff(got, "[1, 2]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var _ Check = mapExact{}
+
+type mapExact struct {
+	value map[string]Check
+}
+
+// CheckValue determines whether the passed value is of type map[string]interface{}, and
+// contains matching map entries.
+func (v mapExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.(map[string]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected map[string]interface{} value for MapExact check, got: %T", other)
+	}
+
+	if len(otherVal) != len(v.value) {
+		return fmt.Errorf("expected %d %s for MapExact check, got %d %s", len(v.value), pluralize("element", len(v.value)), len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	for _, k := range sortedKeys(v.value) {
+		otherValItem, ok := otherVal[k]
+
+		if !ok {
+			return fmt.Errorf("missing element %s for MapExact check", k)
+		}
+
+		if err := v.value[k].CheckValue(otherValItem); err != nil {
+			return fmt.Errorf("%s map element: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v mapExact) String() string {
+	return mapString(v.value)
+}
+
+// MapExact returns a Check for asserting equality between the
+// supplied map[string]Check and the value passed to the CheckValue method.
+func MapExact(value map[string]Check) mapExact {
+	return mapExact{
+		value: value,
+	}
+}
+
+var _ Check = mapPartial{}
+
+type mapPartial struct {
+	value map[string]Check
+}
+
+// CheckValue determines whether the passed value is of type map[string]interface{}, and
+// contains matching map entries for the given keys. Other entries are not
+// checked.
+func (v mapPartial) CheckValue(other interface{}) error {
+	otherVal, ok := other.(map[string]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected map[string]interface{} value for MapPartial check, got: %T", other)
+	}
+
+	for _, k := range sortedKeys(v.value) {
+		otherValItem, ok := otherVal[k]
+
+		if !ok {
+			return fmt.Errorf("missing element %s for MapPartial check", k)
+		}
+
+		if err := v.value[k].CheckValue(otherValItem); err != nil {
+			return fmt.Errorf("%s map element: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v mapPartial) String() string {
+	return mapString(v.value)
+}
+
+// MapPartial returns a Check for asserting partial equality between the
+// supplied map[string]Check and the value passed to the CheckValue method.
+// Only the elements with the given keys are checked.
+func MapPartial(value map[string]Check) mapPartial {
+	return mapPartial{
+		value: value,
+	}
+}
+
+var _ Check = mapSizeExact{}
+
+type mapSizeExact struct {
+	size int
+}
+
+// CheckValue determines whether the passed value is of type map[string]interface{}, and
+// contains the expected number of elements.
+func (v mapSizeExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.(map[string]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected map[string]interface{} value for MapSizeExact check, got: %T", other)
+	}
+
+	if len(otherVal) != v.size {
+		return fmt.Errorf("expected %d %s for MapSizeExact check, got %d %s", v.size, pluralize("element", v.size), len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	return nil
+}
+
+// String returns the string representation of the size.
+func (v mapSizeExact) String() string {
+	return strconv.Itoa(v.size)
+}
+
+// MapSizeExact returns a Check for asserting that the number of elements of
+// the map passed to the CheckValue method equals the supplied size, such
+// as for computed maps where element values are unpredictable.
+func MapSizeExact(size int) mapSizeExact {
+	return mapSizeExact{
+		size: size,
+	}
+}
+
+// mapString returns the string representation of a map[string]Check, with
+// keys in sorted order.
+func mapString(value map[string]Check) string {
+	var mapVals []string
+
+	for _, k := range sortedKeys(value) {
+		mapVals = append(mapVals, fmt.Sprintf("%s:%s", k, value[k]))
+	}
+
+	return fmt.Sprintf("map[%s]", strings.Join(mapVals, " "))
+}
+
+// sortedKeys returns the keys of a map[string]Check in sorted order, so
+// that checks and error messages are deterministic.
+func sortedKeys(value map[string]Check) []string {
+	keys := make([]string, 0, len(value))
+
+	for k := range value {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestMapExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.MapExact(map[string]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected map[string]interface{} value for MapExact check, got: <nil>"),
+		},
+		"equal": {
+			self: knownvalue.MapExact(map[string]knownvalue.Check{
+				"one": knownvalue.Int64Exact(1),
+				"two": knownvalue.Int64Exact(2),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("1"),
+				"two": json.Number("2"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.MapExact(map[string]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected map[string]interface{} value for MapExact check, got: float64"),
+		},
+		"wrong-length": {
+			self: knownvalue.MapExact(map[string]knownvalue.Check{
+				"one": knownvalue.Int64Exact(1),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("1"),
+				"two": json.Number("2"),
+			},
+			expectedError: fmt.Errorf("expected 1 element for MapExact check, got 2 elements"),
+		},
+		"missing-element": {
+			self: knownvalue.MapExact(map[string]knownvalue.Check{
+				"one":   knownvalue.Int64Exact(1),
+				"three": knownvalue.Int64Exact(3),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("1"),
+				"two": json.Number("2"),
+			},
+			expectedError: fmt.Errorf("missing element three for MapExact check"),
+		},
+		"not-equal": {
+			self: knownvalue.MapExact(map[string]knownvalue.Check{
+				"one": knownvalue.Int64Exact(1),
+				"two": knownvalue.Int64Exact(3),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("1"),
+				"two": json.Number("2"),
+			},
+			expectedError: fmt.Errorf("two map element: expected value 3 for Int64Exact check, got: 2"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.MapExact(map[string]knownvalue.Check{"two": knownvalue.Int64Exact(2), "one": knownvalue.StringExact("str")}).String()
+
+	if diff := cmp.Diff(got, "map[one:str two:2]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestMapPartial_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.MapPartial(map[string]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected map[string]interface{} value for MapPartial check, got: <nil>"),
+		},
+		"equal-subset": {
+			self: knownvalue.MapPartial(map[string]knownvalue.Check{
+				"one": knownvalue.Int64Exact(123),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("123"),
+				"two": json.Number("456"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.MapPartial(map[string]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected map[string]interface{} value for MapPartial check, got: float64"),
+		},
+		"missing-element": {
+			self: knownvalue.MapPartial(map[string]knownvalue.Check{
+				"three": knownvalue.Int64Exact(789),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("123"),
+			},
+			expectedError: fmt.Errorf("missing element three for MapPartial check"),
+		},
+		"not-equal": {
+			self: knownvalue.MapPartial(map[string]knownvalue.Check{
+				"one": knownvalue.Int64Exact(456),
+			}),
+			other: map[string]interface{}{
+				"one": json.Number("123"),
+			},
+			expectedError: fmt.Errorf("one map element: expected value 456 for Int64Exact check, got: 123"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapPartial_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.MapPartial(map[string]knownvalue.Check{"one": knownvalue.StringExact("str")}).String()
+
+	if diff := cmp.Diff(got, "map[one:str]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestMapSizeExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.MapSizeExact(2),
+			expectedError: fmt.Errorf("expected map[string]interface{} value for MapSizeExact check, got: <nil>"),
+		},
+		"equal": {
+			self: knownvalue.MapSizeExact(2),
+			other: map[string]interface{}{
+				"one": "str",
+				"two": "rts",
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.MapSizeExact(2),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected map[string]interface{} value for MapSizeExact check, got: float64"),
+		},
+		"wrong-size": {
+			self: knownvalue.MapSizeExact(2),
+			other: map[string]interface{}{
+				"one": "str",
+			},
+			expectedError: fmt.Errorf("expected 2 elements for MapSizeExact check, got 1 element"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapSizeExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.MapSizeExact(2).String()
+
+	if diff := cmp.Diff(got, "2"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
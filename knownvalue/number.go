@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+var _ Check = numberFunc{}
+
+type numberFunc struct {
+	checkFunc func(value *big.Float) error
+}
+
+// CheckValue determines whether the passed value is a number, and
+// passes the check function.
+func (v numberFunc) CheckValue(other interface{}) error {
+	var otherNumber *big.Float
+
+	switch otherVal := other.(type) {
+	case json.Number:
+		f, _, err := big.ParseFloat(otherVal.String(), 10, 512, big.ToNearestEven)
+
+		if err != nil {
+			return fmt.Errorf("expected json.Number to be parseable as *big.Float value for NumberFunc check: %s", otherVal)
+		}
+
+		otherNumber = f
+	case float64:
+		otherNumber = big.NewFloat(otherVal)
+	default:
+		return fmt.Errorf("expected json.Number value for NumberFunc check, got: %T", other)
+	}
+
+	if err := v.checkFunc(otherNumber); err != nil {
+		return fmt.Errorf("NumberFunc check error for value %s: %s", otherNumber.Text('f', -1), err)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the check.
+func (v numberFunc) String() string {
+	return "NumberFunc"
+}
+
+// NumberFunc returns a Check for asserting that the value passed to the
+// CheckValue method is a number for which the supplied function returns no
+// error, such as a computed price with a custom tolerance. The number is
+// passed to the function with arbitrary precision.
+func NumberFunc(checkFunc func(value *big.Float) error) numberFunc {
+	return numberFunc{
+		checkFunc: checkFunc,
+	}
+}
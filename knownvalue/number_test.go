@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestNumberFunc_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	positive := func(value *big.Float) error {
+		if value.Sign() <= 0 {
+			return fmt.Errorf("expected positive number")
+		}
+
+		return nil
+	}
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.NumberFunc(positive),
+			expectedError: fmt.Errorf("expected json.Number value for NumberFunc check, got: <nil>"),
+		},
+		"pass": {
+			self:  knownvalue.NumberFunc(positive),
+			other: json.Number("123456789012345678901234567890.5"),
+		},
+		"pass-float64": {
+			self:  knownvalue.NumberFunc(positive),
+			other: float64(1.5),
+		},
+		"wrong-type": {
+			self:          knownvalue.NumberFunc(positive),
+			other:         "str",
+			expectedError: fmt.Errorf("expected json.Number value for NumberFunc check, got: string"),
+		},
+		"not-number": {
+			self:          knownvalue.NumberFunc(positive),
+			other:         json.Number("str"),
+			expectedError: fmt.Errorf("expected json.Number to be parseable as *big.Float value for NumberFunc check: str"),
+		},
+		"fail": {
+			self:          knownvalue.NumberFunc(positive),
+			other:         json.Number("-1.5"),
+			expectedError: fmt.Errorf("NumberFunc check error for value -1.5: expected positive number"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNumberFunc_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.NumberFunc(func(*big.Float) error { return nil }).String()
+
+	if diff := cmp.Diff(got, "NumberFunc"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
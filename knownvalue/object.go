@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import "fmt"
+
+var _ Check = objectExact{}
+
+type objectExact struct {
+	value map[string]Check
+}
+
+// CheckValue determines whether the passed value is of type map[string]interface{}, and
+// contains matching object entries.
+func (v objectExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.(map[string]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected map[string]interface{} value for ObjectExact check, got: %T", other)
+	}
+
+	if len(otherVal) != len(v.value) {
+		return fmt.Errorf("expected %d %s for ObjectExact check, got %d %s", len(v.value), pluralize("attribute", len(v.value)), len(otherVal), pluralize("attribute", len(otherVal)))
+	}
+
+	for _, k := range sortedKeys(v.value) {
+		otherValItem, ok := otherVal[k]
+
+		if !ok {
+			return fmt.Errorf("missing attribute %s for ObjectExact check", k)
+		}
+
+		if err := v.value[k].CheckValue(otherValItem); err != nil {
+			return fmt.Errorf("%s object attribute: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v objectExact) String() string {
+	return mapString(v.value)
+}
+
+// ObjectExact returns a Check for asserting equality between the supplied
+// map[string]Check and the value passed to the CheckValue method. The map
+// keys represent object attribute names.
+func ObjectExact(value map[string]Check) objectExact {
+	return objectExact{
+		value: value,
+	}
+}
+
+var _ Check = objectPartial{}
+
+type objectPartial struct {
+	value map[string]Check
+}
+
+// CheckValue determines whether the passed value is of type map[string]interface{}, and
+// contains matching object entries for the given attributes. Other
+// attributes, such as computed attributes, are not checked.
+func (v objectPartial) CheckValue(other interface{}) error {
+	otherVal, ok := other.(map[string]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected map[string]interface{} value for ObjectPartial check, got: %T", other)
+	}
+
+	for _, k := range sortedKeys(v.value) {
+		otherValItem, ok := otherVal[k]
+
+		if !ok {
+			return fmt.Errorf("missing attribute %s for ObjectPartial check", k)
+		}
+
+		if err := v.value[k].CheckValue(otherValItem); err != nil {
+			return fmt.Errorf("%s object attribute: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v objectPartial) String() string {
+	return mapString(v.value)
+}
+
+// ObjectPartial returns a Check for asserting partial equality between the
+// supplied map[string]Check and the value passed to the CheckValue method.
+// The map keys represent object attribute names, and only the given
+// attributes are checked.
+func ObjectPartial(value map[string]Check) objectPartial {
+	return objectPartial{
+		value: value,
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestObjectExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.ObjectExact(map[string]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected map[string]interface{} value for ObjectExact check, got: <nil>"),
+		},
+		"equal": {
+			self: knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"id":      knownvalue.StringExact("abc"),
+				"enabled": knownvalue.Bool(true),
+			}),
+			other: map[string]interface{}{
+				"id":      "abc",
+				"enabled": true,
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.ObjectExact(map[string]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected map[string]interface{} value for ObjectExact check, got: float64"),
+		},
+		"wrong-length": {
+			self: knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"id": knownvalue.StringExact("abc"),
+			}),
+			other: map[string]interface{}{
+				"id":      "abc",
+				"enabled": true,
+			},
+			expectedError: fmt.Errorf("expected 1 attribute for ObjectExact check, got 2 attributes"),
+		},
+		"missing-attribute": {
+			self: knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"id":   knownvalue.StringExact("abc"),
+				"size": knownvalue.Int64Exact(1),
+			}),
+			other: map[string]interface{}{
+				"id":      "abc",
+				"enabled": true,
+			},
+			expectedError: fmt.Errorf("missing attribute size for ObjectExact check"),
+		},
+		"not-equal": {
+			self: knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"id":   knownvalue.StringExact("abc"),
+				"size": knownvalue.Int64Exact(1),
+			}),
+			other: map[string]interface{}{
+				"id":   "abc",
+				"size": json.Number("2"),
+			},
+			expectedError: fmt.Errorf("size object attribute: expected value 1 for Int64Exact check, got: 2"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.ObjectExact(map[string]knownvalue.Check{"size": knownvalue.Int64Exact(1), "id": knownvalue.StringExact("abc")}).String()
+
+	if diff := cmp.Diff(got, "map[id:abc size:1]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestObjectPartial_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.ObjectPartial(map[string]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected map[string]interface{} value for ObjectPartial check, got: <nil>"),
+		},
+		"equal-subset": {
+			self: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+				"name": knownvalue.StringExact("one"),
+			}),
+			other: map[string]interface{}{
+				"id":   "abc123",
+				"name": "one",
+				"size": json.Number("2"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.ObjectPartial(map[string]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected map[string]interface{} value for ObjectPartial check, got: float64"),
+		},
+		"missing-attribute": {
+			self: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+				"enabled": knownvalue.Bool(true),
+			}),
+			other: map[string]interface{}{
+				"name": "one",
+			},
+			expectedError: fmt.Errorf("missing attribute enabled for ObjectPartial check"),
+		},
+		"not-equal": {
+			self: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+				"name": knownvalue.StringExact("two"),
+			}),
+			other: map[string]interface{}{
+				"name": "one",
+			},
+			expectedError: fmt.Errorf("name object attribute: expected value two for StringExact check, got: one"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectPartial_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("str")}).String()
+
+	if diff := cmp.Diff(got, "map[name:str]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
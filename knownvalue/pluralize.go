@@ -0,0 +1,13 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+// pluralize returns the noun with an "s" suffix when the count is not one.
+func pluralize(noun string, count int) string {
+	if count == 1 {
+		return noun
+	}
+
+	return noun + "s"
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var _ Check = setExact{}
+
+type setExact struct {
+	value []Check
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains matching slice entries independent of the sequence.
+func (v setExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for SetExact check, got: %T", other)
+	}
+
+	if len(otherVal) != len(v.value) {
+		return fmt.Errorf("expected %d %s for SetExact check, got %d %s", len(v.value), pluralize("element", len(v.value)), len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	if i, ok := pairSetElements(v.value, otherVal); !ok {
+		return fmt.Errorf("missing value %s for SetExact check", v.value[i].String())
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v setExact) String() string {
+	var setVals []string
+
+	for _, val := range v.value {
+		setVals = append(setVals, val.String())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(setVals, " "))
+}
+
+// SetExact returns a Check for asserting equality between the
+// supplied []Check and the value passed to the CheckValue method.
+// This is an order-independent check.
+func SetExact(value []Check) setExact {
+	return setExact{
+		value: value,
+	}
+}
+
+var _ Check = setPartial{}
+
+type setPartial struct {
+	value []Check
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains a distinct matching slice entry for each check, independent of the
+// sequence. Other entries are not checked.
+func (v setPartial) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for SetPartial check, got: %T", other)
+	}
+
+	if i, ok := pairSetElements(v.value, otherVal); !ok {
+		return fmt.Errorf("missing value %s for SetPartial check", v.value[i].String())
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v setPartial) String() string {
+	var setVals []string
+
+	for _, val := range v.value {
+		setVals = append(setVals, val.String())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(setVals, " "))
+}
+
+// SetPartial returns a Check for asserting partial equality between the
+// supplied []Check and the value passed to the CheckValue method. Each check
+// must match a different element, while other elements are not checked.
+// This is an order-independent check.
+func SetPartial(value []Check) setPartial {
+	return setPartial{
+		value: value,
+	}
+}
+
+// pairSetElements pairs each check with a different element that it matches,
+// using augmenting paths so an element matching multiple checks does not
+// prevent a complete pairing. If a check cannot be paired, its index is
+// returned with false.
+func pairSetElements(checks []Check, elements []interface{}) (int, bool) {
+	matches := make([][]bool, len(checks))
+
+	for i, check := range checks {
+		matches[i] = make([]bool, len(elements))
+
+		for j, element := range elements {
+			matches[i][j] = check.CheckValue(element) == nil
+		}
+	}
+
+	// pairedCheck is the index of the check paired with each element, or -1.
+	pairedCheck := make([]int, len(elements))
+
+	for j := range pairedCheck {
+		pairedCheck[j] = -1
+	}
+
+	var pair func(checkIndex int, visited []bool) bool
+
+	pair = func(checkIndex int, visited []bool) bool {
+		for j := range elements {
+			if visited[j] || !matches[checkIndex][j] {
+				continue
+			}
+
+			visited[j] = true
+
+			if pairedCheck[j] == -1 || pair(pairedCheck[j], visited) {
+				pairedCheck[j] = checkIndex
+
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range checks {
+		if !pair(i, make([]bool, len(elements))) {
+			return i, false
+		}
+	}
+
+	return 0, true
+}
+
+var _ Check = setSizeExact{}
+
+type setSizeExact struct {
+	size int
+}
+
+// CheckValue determines whether the passed value is of type []interface{}, and
+// contains the expected number of elements.
+func (v setSizeExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.([]interface{})
+
+	if !ok {
+		return fmt.Errorf("expected []interface{} value for SetSizeExact check, got: %T", other)
+	}
+
+	if len(otherVal) != v.size {
+		return fmt.Errorf("expected %d %s for SetSizeExact check, got %d %s", v.size, pluralize("element", v.size), len(otherVal), pluralize("element", len(otherVal)))
+	}
+
+	return nil
+}
+
+// String returns the string representation of the size.
+func (v setSizeExact) String() string {
+	return strconv.Itoa(v.size)
+}
+
+// SetSizeExact returns a Check for asserting that the number of elements of
+// the set passed to the CheckValue method equals the supplied size, such
+// as for computed sets where element values are unpredictable.
+func SetSizeExact(size int) setSizeExact {
+	return setSizeExact{
+		size: size,
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestSetExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.SetExact([]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected []interface{} value for SetExact check, got: <nil>"),
+		},
+		"equal-different-order": {
+			self: knownvalue.SetExact([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+				knownvalue.Int64Exact(456),
+			}),
+			other: []interface{}{
+				json.Number("456"),
+				json.Number("123"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.SetExact([]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for SetExact check, got: float64"),
+		},
+		"wrong-length": {
+			self: knownvalue.SetExact([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+			},
+			expectedError: fmt.Errorf("expected 1 element for SetExact check, got 2 elements"),
+		},
+		"duplicate-element": {
+			self: knownvalue.SetExact([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+				knownvalue.Int64Exact(456),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("123"),
+			},
+			expectedError: fmt.Errorf("missing value 456 for SetExact check"),
+		},
+		"overlapping-checks": {
+			self: knownvalue.SetExact([]knownvalue.Check{
+				knownvalue.StringRegexp(regexp.MustCompile(".*")),
+				knownvalue.StringExact("a"),
+			}),
+			other: []interface{}{
+				"a",
+				"b",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("str"), knownvalue.Int64Exact(123)}).String()
+
+	if diff := cmp.Diff(got, "[str 123]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestSetPartial_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.SetPartial([]knownvalue.Check{}),
+			expectedError: fmt.Errorf("expected []interface{} value for SetPartial check, got: <nil>"),
+		},
+		"equal-subset-different-order": {
+			self: knownvalue.SetPartial([]knownvalue.Check{
+				knownvalue.Int64Exact(789),
+				knownvalue.Int64Exact(123),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+				json.Number("789"),
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.SetPartial([]knownvalue.Check{}),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for SetPartial check, got: float64"),
+		},
+		"duplicate-element": {
+			self: knownvalue.SetPartial([]knownvalue.Check{
+				knownvalue.Int64Exact(123),
+				knownvalue.Int64Exact(123),
+			}),
+			other: []interface{}{
+				json.Number("123"),
+				json.Number("456"),
+			},
+			expectedError: fmt.Errorf("missing value 123 for SetPartial check"),
+		},
+		"overlapping-checks": {
+			self: knownvalue.SetPartial([]knownvalue.Check{
+				knownvalue.ObjectPartial(map[string]knownvalue.Check{
+					"x": knownvalue.Int64Exact(1),
+				}),
+				knownvalue.ObjectPartial(map[string]knownvalue.Check{
+					"x": knownvalue.Int64Exact(1),
+					"y": knownvalue.Int64Exact(2),
+				}),
+			}),
+			other: []interface{}{
+				map[string]interface{}{
+					"x": json.Number("1"),
+					"y": json.Number("2"),
+				},
+				map[string]interface{}{
+					"x": json.Number("1"),
+					"y": json.Number("3"),
+				},
+			},
+		},
+		"overlapping-checks-missing": {
+			self: knownvalue.SetPartial([]knownvalue.Check{
+				knownvalue.StringRegexp(regexp.MustCompile("^a")),
+				knownvalue.StringExact("ab"),
+			}),
+			other: []interface{}{
+				"ab",
+				"b",
+			},
+			expectedError: fmt.Errorf("missing value ab for SetPartial check"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetPartial_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.SetPartial([]knownvalue.Check{knownvalue.StringExact("str"), knownvalue.Int64Exact(123)}).String()
+
+	if diff := cmp.Diff(got, "[str 123]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestSetSizeExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.SetSizeExact(2),
+			expectedError: fmt.Errorf("expected []interface{} value for SetSizeExact check, got: <nil>"),
+		},
+		"equal": {
+			self: knownvalue.SetSizeExact(2),
+			other: []interface{}{
+				"str",
+				"rts",
+			},
+		},
+		"wrong-type": {
+			self:          knownvalue.SetSizeExact(2),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected []interface{} value for SetSizeExact check, got: float64"),
+		},
+		"wrong-size": {
+			self: knownvalue.SetSizeExact(2),
+			other: []interface{}{
+				"str",
+			},
+			expectedError: fmt.Errorf("expected 2 elements for SetSizeExact check, got 1 element"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetSizeExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.SetSizeExact(2).String()
+
+	if diff := cmp.Diff(got, "2"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var _ Check = stringExact{}
+
+type stringExact struct {
+	value string
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// contains a matching sequence of bytes.
+func (v stringExact) CheckValue(other interface{}) error {
+	otherVal, ok := other.(string)
+
+	if !ok {
+		return fmt.Errorf("expected string value for StringExact check, got: %T", other)
+	}
+
+	if otherVal != v.value {
+		return fmt.Errorf("expected value %s for StringExact check, got: %s", v.value, otherVal)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the value.
+func (v stringExact) String() string {
+	return v.value
+}
+
+// StringExact returns a Check for asserting equality between the
+// supplied string and a value passed to the CheckValue method.
+func StringExact(value string) stringExact {
+	return stringExact{
+		value: value,
+	}
+}
+
+var _ Check = stringRegexp{}
+
+type stringRegexp struct {
+	regex *regexp.Regexp
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// matches the regular expression.
+func (v stringRegexp) CheckValue(other interface{}) error {
+	otherVal, ok := other.(string)
+
+	if !ok {
+		return fmt.Errorf("expected string value for StringRegexp check, got: %T", other)
+	}
+
+	if !v.regex.MatchString(otherVal) {
+		return fmt.Errorf("expected regex match %s for StringRegexp check, got: %s", v.regex, otherVal)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the regular expression.
+func (v stringRegexp) String() string {
+	return v.regex.String()
+}
+
+// StringRegexp returns a Check for asserting that the value passed to the
+// CheckValue method is a string matching the supplied regular expression,
+// such as an ID or ARN format.
+func StringRegexp(regex *regexp.Regexp) stringRegexp {
+	return stringRegexp{
+		regex: regex,
+	}
+}
+
+var _ Check = stringFunc{}
+
+type stringFunc struct {
+	checkFunc func(value string) error
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// passes the check function.
+func (v stringFunc) CheckValue(other interface{}) error {
+	otherVal, ok := other.(string)
+
+	if !ok {
+		return fmt.Errorf("expected string value for StringFunc check, got: %T", other)
+	}
+
+	if err := v.checkFunc(otherVal); err != nil {
+		return fmt.Errorf("StringFunc check error for value %s: %s", otherVal, err)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the check.
+func (v stringFunc) String() string {
+	return "StringFunc"
+}
+
+// StringFunc returns a Check for asserting that the value passed to the
+// CheckValue method is a string for which the supplied function returns no
+// error, such as a computed value derived from other values.
+func StringFunc(checkFunc func(value string) error) stringFunc {
+	return stringFunc{
+		checkFunc: checkFunc,
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestStringExact_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.StringExact("str"),
+			expectedError: fmt.Errorf("expected string value for StringExact check, got: <nil>"),
+		},
+		"equal": {
+			self:  knownvalue.StringExact("str"),
+			other: "str",
+		},
+		"wrong-type": {
+			self:          knownvalue.StringExact("str"),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for StringExact check, got: float64"),
+		},
+		"not-equal": {
+			self:          knownvalue.StringExact("str"),
+			other:         "rts",
+			expectedError: fmt.Errorf("expected value str for StringExact check, got: rts"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStringExact_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.StringExact("str").String()
+
+	if diff := cmp.Diff(got, "str"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestStringRegexp_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.StringRegexp(regexp.MustCompile("^str")),
+			expectedError: fmt.Errorf("expected string value for StringRegexp check, got: <nil>"),
+		},
+		"match": {
+			self:  knownvalue.StringRegexp(regexp.MustCompile("^str")),
+			other: "string",
+		},
+		"wrong-type": {
+			self:          knownvalue.StringRegexp(regexp.MustCompile("^str")),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for StringRegexp check, got: float64"),
+		},
+		"no-match": {
+			self:          knownvalue.StringRegexp(regexp.MustCompile("^str")),
+			other:         "rts",
+			expectedError: fmt.Errorf("expected regex match ^str for StringRegexp check, got: rts"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStringRegexp_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.StringRegexp(regexp.MustCompile("^str")).String()
+
+	if diff := cmp.Diff(got, "^str"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestStringFunc_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	hasPrefix := func(value string) error {
+		if !strings.HasPrefix(value, "str") {
+			return fmt.Errorf("expected prefix str")
+		}
+
+		return nil
+	}
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.StringFunc(hasPrefix),
+			expectedError: fmt.Errorf("expected string value for StringFunc check, got: <nil>"),
+		},
+		"pass": {
+			self:  knownvalue.StringFunc(hasPrefix),
+			other: "string",
+		},
+		"wrong-type": {
+			self:          knownvalue.StringFunc(hasPrefix),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for StringFunc check, got: float64"),
+		},
+		"fail": {
+			self:          knownvalue.StringFunc(hasPrefix),
+			other:         "rts",
+			expectedError: fmt.Errorf("StringFunc check error for value rts: expected prefix str"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStringFunc_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.StringFunc(func(string) error { return nil }).String()
+
+	if diff := cmp.Diff(got, "StringFunc"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue
+
+import (
+	"fmt"
+	"time"
+)
+
+var _ Check = timestampWithin{}
+
+type timestampWithin struct {
+	window time.Duration
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// contains an RFC3339 timestamp within the window before or after the time
+// of the check.
+func (v timestampWithin) CheckValue(other interface{}) error {
+	otherVal, ok := other.(string)
+
+	if !ok {
+		return fmt.Errorf("expected string value for TimestampWithin check, got: %T", other)
+	}
+
+	otherTime, err := time.Parse(time.RFC3339, otherVal)
+
+	if err != nil {
+		return fmt.Errorf("expected RFC3339 timestamp for TimestampWithin check, got: %s", otherVal)
+	}
+
+	now := time.Now()
+
+	if otherTime.Before(now.Add(-v.window)) || otherTime.After(now.Add(v.window)) {
+		return fmt.Errorf("expected timestamp within %s of the current time for TimestampWithin check, got: %s", v.window, otherVal)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the window.
+func (v timestampWithin) String() string {
+	return fmt.Sprintf("within %s", v.window)
+}
+
+// TimestampWithin returns a Check for asserting that the value passed to the
+// CheckValue method is an RFC3339 timestamp within the supplied window
+// before or after the time of the check, such as for computed creation
+// timestamps. Negative windows are treated as positive.
+func TimestampWithin(window time.Duration) timestampWithin {
+	if window < 0 {
+		window = -window
+	}
+
+	return timestampWithin{
+		window: window,
+	}
+}
+
+var _ Check = durationBetween{}
+
+type durationBetween struct {
+	minValue time.Duration
+	maxValue time.Duration
+}
+
+// CheckValue determines whether the passed value is of type string, and
+// contains a duration between the minimum and maximum, inclusive.
+func (v durationBetween) CheckValue(other interface{}) error {
+	otherVal, ok := other.(string)
+
+	if !ok {
+		return fmt.Errorf("expected string value for DurationBetween check, got: %T", other)
+	}
+
+	otherDuration, err := time.ParseDuration(otherVal)
+
+	if err != nil {
+		return fmt.Errorf("expected duration string for DurationBetween check, got: %s", otherVal)
+	}
+
+	if otherDuration < v.minValue || otherDuration > v.maxValue {
+		return fmt.Errorf("expected duration between %s and %s for DurationBetween check, got: %s", v.minValue, v.maxValue, otherVal)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the duration range.
+func (v durationBetween) String() string {
+	return fmt.Sprintf("[%s, %s]", v.minValue, v.maxValue)
+}
+
+// DurationBetween returns a Check for asserting that the value passed to the
+// CheckValue method is a duration string, such as "1h30m", between the
+// supplied minimum and maximum durations, inclusive.
+func DurationBetween(minValue time.Duration, maxValue time.Duration) durationBetween {
+	return durationBetween{
+		minValue: minValue,
+		maxValue: maxValue,
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package knownvalue_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+func TestTimestampWithin_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.TimestampWithin(time.Hour),
+			expectedError: fmt.Errorf("expected string value for TimestampWithin check, got: <nil>"),
+		},
+		"within": {
+			self:  knownvalue.TimestampWithin(time.Hour),
+			other: time.Now().Add(-time.Minute).Format(time.RFC3339),
+		},
+		"within-future": {
+			self:  knownvalue.TimestampWithin(time.Hour),
+			other: time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+		},
+		"within-negative-window": {
+			self:  knownvalue.TimestampWithin(-time.Hour),
+			other: time.Now().Format(time.RFC3339),
+		},
+		"wrong-type": {
+			self:          knownvalue.TimestampWithin(time.Hour),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for TimestampWithin check, got: float64"),
+		},
+		"not-timestamp": {
+			self:          knownvalue.TimestampWithin(time.Hour),
+			other:         "2006-01-02",
+			expectedError: fmt.Errorf("expected RFC3339 timestamp for TimestampWithin check, got: 2006-01-02"),
+		},
+		"not-within": {
+			self:          knownvalue.TimestampWithin(time.Hour),
+			other:         "2006-01-02T15:04:05Z",
+			expectedError: fmt.Errorf("expected timestamp within 1h0m0s of the current time for TimestampWithin check, got: 2006-01-02T15:04:05Z"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTimestampWithin_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.TimestampWithin(5 * time.Minute).String()
+
+	if diff := cmp.Diff(got, "within 5m0s"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestDurationBetween_CheckValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		self          knownvalue.Check
+		other         interface{}
+		expectedError error
+	}{
+		"nil": {
+			self:          knownvalue.DurationBetween(time.Minute, time.Hour),
+			expectedError: fmt.Errorf("expected string value for DurationBetween check, got: <nil>"),
+		},
+		"minimum": {
+			self:  knownvalue.DurationBetween(time.Minute, time.Hour),
+			other: "60s",
+		},
+		"maximum": {
+			self:  knownvalue.DurationBetween(time.Minute, time.Hour),
+			other: "1h",
+		},
+		"wrong-type": {
+			self:          knownvalue.DurationBetween(time.Minute, time.Hour),
+			other:         1.234,
+			expectedError: fmt.Errorf("expected string value for DurationBetween check, got: float64"),
+		},
+		"not-duration": {
+			self:          knownvalue.DurationBetween(time.Minute, time.Hour),
+			other:         "one hour",
+			expectedError: fmt.Errorf("expected duration string for DurationBetween check, got: one hour"),
+		},
+		"below-minimum": {
+			self:          knownvalue.DurationBetween(time.Minute, time.Hour),
+			other:         "30s",
+			expectedError: fmt.Errorf("expected duration between 1m0s and 1h0m0s for DurationBetween check, got: 30s"),
+		},
+		"above-maximum": {
+			self:          knownvalue.DurationBetween(time.Minute, time.Hour),
+			other:         "1h30m",
+			expectedError: fmt.Errorf("expected duration between 1m0s and 1h0m0s for DurationBetween check, got: 1h30m"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.self.CheckValue(testCase.other)
+
+			if diff := cmp.Diff(got, testCase.expectedError, equateErrorMessage); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDurationBetween_String(t *testing.T) {
+	t.Parallel()
+
+	got := knownvalue.DurationBetween(time.Minute, time.Hour).String()
+
+	if diff := cmp.Diff(got, "[1m0s, 1h0m0s]"); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ PlanCheckWithRenderedPlan = allCheck{}
+
+type allCheck struct {
+	planChecks []PlanCheck
+}
+
+// CheckPlan implements the plan check logic.
+func (a allCheck) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	var result *multierror.Error
+
+	for i, planCheck := range a.planChecks {
+		checkResp := CheckPlanResponse{}
+
+		planCheck.CheckPlan(ctx, req, &checkResp)
+
+		if checkResp.Error != nil {
+			result = multierror.Append(result, fmt.Errorf("plan check %d/%d error: %w", i+1, len(a.planChecks), checkResp.Error))
+		}
+	}
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// RequiresRenderedPlan implements the PlanCheckWithRenderedPlan interface.
+func (a allCheck) RequiresRenderedPlan() bool {
+	return requiresRenderedPlan(a.planChecks)
+}
+
+// All returns a plan check that runs every given plan check and passes only
+// if all of them pass, aggregating all failures into a single error. It
+// groups plan checks so they can be used where a single plan check is
+// expected, such as within Any or Not.
+func All(planChecks ...PlanCheck) PlanCheck {
+	return allCheck{
+		planChecks: planChecks,
+	}
+}
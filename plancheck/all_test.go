@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.create",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.update",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		expectedError error
+	}{
+		"none": {
+			planCheck: plancheck.All(),
+		},
+		"all-pass": {
+			planCheck: plancheck.All(
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 1),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 1),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionDestroy, 0),
+			),
+		},
+		"one-fails": {
+			planCheck: plancheck.All(
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 1),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 2),
+			),
+			expectedError: fmt.Errorf("1 error occurred:\n\t* plan check 2/2 error: expected 2 Update resource actions, got 1\n\n"),
+		},
+		"all-fail": {
+			planCheck: plancheck.All(
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 2),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 2),
+			),
+			expectedError: fmt.Errorf("2 errors occurred:\n\t* plan check 1/2 error: expected 2 Create resource actions, got 1\n\t* plan check 2/2 error: expected 2 Update resource actions, got 1\n\n"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %q", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ PlanCheckWithRenderedPlan = anyCheck{}
+
+type anyCheck struct {
+	planChecks []PlanCheck
+}
+
+// CheckPlan implements the plan check logic.
+func (a anyCheck) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	var result *multierror.Error
+
+	for i, planCheck := range a.planChecks {
+		checkResp := CheckPlanResponse{}
+
+		planCheck.CheckPlan(ctx, req, &checkResp)
+
+		if checkResp.Error == nil {
+			return
+		}
+
+		result = multierror.Append(result, fmt.Errorf("plan check %d/%d error: %w", i+1, len(a.planChecks), checkResp.Error))
+	}
+
+	if result == nil {
+		resp.Error = fmt.Errorf("no plan checks given")
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("expected any plan check to pass, but none did: %w", result)
+}
+
+// RequiresRenderedPlan implements the PlanCheckWithRenderedPlan interface.
+func (a anyCheck) RequiresRenderedPlan() bool {
+	return requiresRenderedPlan(a.planChecks)
+}
+
+// Any returns a plan check that runs the given plan checks in order and
+// passes as soon as one of them passes. If none of the plan checks pass, the
+// failures of all of them are aggregated into a single error.
+func Any(planChecks ...PlanCheck) PlanCheck {
+	return anyCheck{
+		planChecks: planChecks,
+	}
+}
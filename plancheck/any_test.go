@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.create",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.update",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		expectedError error
+	}{
+		"none": {
+			planCheck:     plancheck.Any(),
+			expectedError: fmt.Errorf("no plan checks given"),
+		},
+		"first-passes": {
+			planCheck: plancheck.Any(
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 1),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 2),
+			),
+		},
+		"last-passes": {
+			planCheck: plancheck.Any(
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 2),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 1),
+			),
+		},
+		"none-pass": {
+			planCheck: plancheck.Any(
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 2),
+				plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 2),
+			),
+			expectedError: fmt.Errorf("expected any plan check to pass, but none did: 2 errors occurred:\n\t* plan check 1/2 error: expected 2 Create resource actions, got 1\n\t* plan check 2/2 error: expected 2 Update resource actions, got 1\n\n"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %q", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+// DeferredReason is the reason, recorded in the plan, why Terraform deferred
+// a resource change to a later plan and apply.
+//
+// More information about deferred actions can be found at: https://developer.hashicorp.com/terraform/language/resources/behavior
+type DeferredReason string
+
+const (
+	// DeferredReasonResourceConfigUnknown occurs when the resource
+	// configuration is partially unknown, and the values must be known
+	// before the change can be planned.
+	DeferredReasonResourceConfigUnknown DeferredReason = "resource_config_unknown"
+
+	// DeferredReasonProviderConfigUnknown occurs when the provider
+	// configuration is partially unknown, and the values must be known
+	// before the change can be planned.
+	DeferredReasonProviderConfigUnknown DeferredReason = "provider_config_unknown"
+
+	// DeferredReasonAbsentPrereq occurs when a dependency of the resource,
+	// such as another deferred resource, has not been created yet.
+	DeferredReasonAbsentPrereq DeferredReason = "absent_prereq"
+)
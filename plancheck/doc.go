@@ -0,0 +1,5 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package plancheck contains the plan check interface, request/response structs, and common plan check implementations.
+package plancheck
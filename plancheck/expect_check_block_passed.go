@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+var _ PlanCheck = expectCheckBlockPassed{}
+
+type expectCheckBlockPassed struct {
+	checkAddress string
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectCheckBlockPassed) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	for _, check := range req.Plan.Checks {
+		if check.Address.ToDisplay != e.checkAddress {
+			continue
+		}
+
+		if check.Status != tfjson.CheckStatusPass {
+			resp.Error = fmt.Errorf("%s - expected check block to pass, got status: %s%s", e.checkAddress, check.Status, checkProblems(check))
+		}
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s - Check block not found in plan Checks", e.checkAddress)
+}
+
+// ExpectCheckBlockPassed returns a plan check that asserts that the check
+// block with the given address, such as "check.health" or
+// "module.example.check.health", passed in the plan. The check fails if the
+// check block status is fail, error, or unknown, such as when the assertions
+// depend on values which are unknown until apply. Requires Terraform 1.5 or
+// later.
+func ExpectCheckBlockPassed(checkAddress string) PlanCheck {
+	return expectCheckBlockPassed{
+		checkAddress: checkAddress,
+	}
+}
+
+// checkProblems returns the failure messages of the check instances, if any,
+// formatted for an error message.
+func checkProblems(check tfjson.CheckResultStatic) string {
+	var messages []string
+
+	for _, instance := range check.Instances {
+		for _, problem := range instance.Problems {
+			messages = append(messages, problem.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return ""
+	}
+
+	return "\n\n" + strings.Join(messages, "\n")
+}
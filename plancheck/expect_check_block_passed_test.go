@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectCheckBlockPassed(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		Checks: []tfjson.CheckResultStatic{
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.pass", Kind: tfjson.CheckKindCheckBlock, Name: "pass"},
+				Status:  tfjson.CheckStatusPass,
+			},
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "module.example.check.pass", Kind: tfjson.CheckKindCheckBlock, Module: "module.example", Name: "pass"},
+				Status:  tfjson.CheckStatusPass,
+			},
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.fail", Kind: tfjson.CheckKindCheckBlock, Name: "fail"},
+				Status:  tfjson.CheckStatusFail,
+				Instances: []tfjson.CheckResultDynamic{
+					{
+						Address: tfjson.CheckDynamicAddress{ToDisplay: "check.fail"},
+						Status:  tfjson.CheckStatusFail,
+						Problems: []tfjson.CheckResultProblem{
+							{Message: "Widget is not healthy."},
+							{Message: "Widget has no owner."},
+						},
+					},
+				},
+			},
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.error", Kind: tfjson.CheckKindCheckBlock, Name: "error"},
+				Status:  tfjson.CheckStatusError,
+			},
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.unknown", Kind: tfjson.CheckKindCheckBlock, Name: "unknown"},
+				Status:  tfjson.CheckStatusUnknown,
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"pass": {
+			planCheck: plancheck.ExpectCheckBlockPassed("check.pass"),
+			plan:      plan,
+		},
+		"pass-module": {
+			planCheck: plancheck.ExpectCheckBlockPassed("module.example.check.pass"),
+			plan:      plan,
+		},
+		"fail": {
+			planCheck:     plancheck.ExpectCheckBlockPassed("check.fail"),
+			plan:          plan,
+			expectedError: fmt.Errorf("check.fail - expected check block to pass, got status: fail\n\nWidget is not healthy.\nWidget has no owner."),
+		},
+		"error": {
+			planCheck:     plancheck.ExpectCheckBlockPassed("check.error"),
+			plan:          plan,
+			expectedError: fmt.Errorf("check.error - expected check block to pass, got status: error"),
+		},
+		"unknown": {
+			planCheck:     plancheck.ExpectCheckBlockPassed("check.unknown"),
+			plan:          plan,
+			expectedError: fmt.Errorf("check.unknown - expected check block to pass, got status: unknown"),
+		},
+		"not-found": {
+			planCheck:     plancheck.ExpectCheckBlockPassed("check.missing"),
+			plan:          plan,
+			expectedError: fmt.Errorf("check.missing - Check block not found in plan Checks"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectCheckBlockPassed("check.pass"),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
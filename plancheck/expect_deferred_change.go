@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectDeferredChange{}
+
+type expectDeferredChange struct {
+	resourceAddress string
+	reason          DeferredReason
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectDeferredChange) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	for _, dc := range req.Plan.DeferredChanges {
+		if dc.ResourceChange == nil || dc.ResourceChange.Address != e.resourceAddress {
+			continue
+		}
+
+		if DeferredReason(dc.Reason) != e.reason {
+			resp.Error = fmt.Errorf("%s - expected deferred reason %s, got: %s", e.resourceAddress, e.reason, dc.Reason)
+		}
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s - Resource not found in plan DeferredChanges", e.resourceAddress)
+}
+
+// ExpectDeferredChange returns a plan check that asserts that the change for
+// the given resource address, such as "example_widget.test", is deferred in
+// the plan with the given reason.
+//
+// Deferred changes require the TestStep AdditionalCLIOptions to allow
+// deferral, which requires an experimental build of Terraform 1.9 or later.
+func ExpectDeferredChange(resourceAddress string, reason DeferredReason) PlanCheck {
+	return expectDeferredChange{
+		resourceAddress: resourceAddress,
+		reason:          reason,
+	}
+}
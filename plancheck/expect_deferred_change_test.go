@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectDeferredChange(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		DeferredChanges: []*tfjson.DeferredResourceChange{
+			{
+				Reason: "provider_config_unknown",
+				ResourceChange: &tfjson.ResourceChange{
+					Address: "test_resource.provider_unknown",
+					Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+				},
+			},
+			{
+				Reason: "resource_config_unknown",
+				ResourceChange: &tfjson.ResourceChange{
+					Address: "module.example.test_resource.config_unknown[0]",
+					Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+				},
+			},
+		},
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.not_deferred",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"provider-config-unknown": {
+			planCheck: plancheck.ExpectDeferredChange("test_resource.provider_unknown", plancheck.DeferredReasonProviderConfigUnknown),
+			plan:      plan,
+		},
+		"resource-config-unknown-module": {
+			planCheck: plancheck.ExpectDeferredChange("module.example.test_resource.config_unknown[0]", plancheck.DeferredReasonResourceConfigUnknown),
+			plan:      plan,
+		},
+		"wrong-reason": {
+			planCheck:     plancheck.ExpectDeferredChange("test_resource.provider_unknown", plancheck.DeferredReasonAbsentPrereq),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.provider_unknown - expected deferred reason absent_prereq, got: provider_config_unknown"),
+		},
+		"not-deferred": {
+			planCheck:     plancheck.ExpectDeferredChange("test_resource.not_deferred", plancheck.DeferredReasonResourceConfigUnknown),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.not_deferred - Resource not found in plan DeferredChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectDeferredChange("test_resource.provider_unknown", plancheck.DeferredReasonProviderConfigUnknown),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+var _ PlanCheck = expectEmptyPlan{}
+
+type expectEmptyPlan struct{}
+
+// CheckPlan implements the plan check logic.
+func (e expectEmptyPlan) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	var result *multierror.Error
+
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Change == nil || actionsAreNoop(rc.Change.Actions) {
+			continue
+		}
+
+		result = multierror.Append(result, fmt.Errorf("expected empty plan, but %s has planned action(s): %v", rc.Address, rc.Change.Actions))
+	}
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// ExpectEmptyPlan returns a plan check that asserts that there are no
+// resource changes in the plan, other than no-op changes. All resources with
+// planned changes are reported in the error.
+func ExpectEmptyPlan() PlanCheck {
+	return expectEmptyPlan{}
+}
+
+// actionsAreNoop returns true if all of the given actions are no-op actions.
+func actionsAreNoop(actions tfjson.Actions) bool {
+	for _, action := range actions {
+		if action != tfjson.ActionNoop {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"empty": {
+			plan: &tfjson.Plan{},
+		},
+		"noop": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_resource.noop",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+					},
+				},
+			},
+		},
+		"changes": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_resource.update",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+					},
+					{
+						Address: "test_resource.noop",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+					},
+					{
+						Address: "test_resource.replace",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("2 errors occurred:\n\t* expected empty plan, but test_resource.update has planned action(s): [update]\n\t* expected empty plan, but test_resource.replace has planned action(s): [delete create]\n\n"),
+		},
+		"nil-plan": {
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			plancheck.ExpectEmptyPlan().CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %q", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
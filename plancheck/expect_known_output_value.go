@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectKnownOutputValue{}
+
+type expectKnownOutputValue struct {
+	outputAddress string
+	knownValue    knownvalue.Check
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectKnownOutputValue) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	change, err := planOutputChange(req.Plan, e.outputAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	result, err := plannedOutputValue(e.outputAddress, change, tfjsonpath.Path{})
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(result); err != nil {
+		resp.Error = fmt.Errorf("%s - error checking output value: %w%s", e.outputAddress, err, knownValueDiff(e.knownValue, result))
+	}
+}
+
+// ExpectKnownOutputValue returns a plan check that asserts that the specified
+// output has the given known value in the planned output changes, so outputs
+// can be verified before the apply. The check fails if the value is unknown.
+func ExpectKnownOutputValue(outputAddress string, knownValue knownvalue.Check) PlanCheck {
+	return expectKnownOutputValue{
+		outputAddress: outputAddress,
+		knownValue:    knownValue,
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectKnownOutputValue(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		OutputChanges: map[string]*tfjson.Change{
+			"name": {
+				Actions:      tfjson.Actions{tfjson.ActionCreate},
+				After:        "example",
+				AfterUnknown: false,
+			},
+			"id": {
+				Actions:      tfjson.Actions{tfjson.ActionCreate},
+				AfterUnknown: true,
+			},
+			"resource": {
+				Actions: tfjson.Actions{tfjson.ActionCreate},
+				After: map[string]interface{}{
+					"name": "example",
+					"tags": []interface{}{"one", "two"},
+				},
+				AfterUnknown: map[string]interface{}{
+					"id": true,
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"string": {
+			planCheck: plancheck.ExpectKnownOutputValue("name", knownvalue.StringExact("example")),
+			plan:      plan,
+		},
+		"object": {
+			planCheck: plancheck.ExpectKnownOutputValue("resource", knownvalue.ObjectExact(map[string]knownvalue.Check{
+				"name": knownvalue.StringExact("example"),
+				"tags": knownvalue.ListExact([]knownvalue.Check{
+					knownvalue.StringExact("one"),
+					knownvalue.StringExact("two"),
+				}),
+			})),
+			plan: plan,
+		},
+		"mismatch": {
+			planCheck:     plancheck.ExpectKnownOutputValue("name", knownvalue.StringExact("other")),
+			plan:          plan,
+			expectedError: fmt.Errorf("name - error checking output value: expected value other for StringExact check, got: example"),
+		},
+		"unknown": {
+			planCheck:     plancheck.ExpectKnownOutputValue("id", knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("id - output value is unknown"),
+		},
+		"output-not-found": {
+			planCheck:     plancheck.ExpectKnownOutputValue("missing", knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("missing - Output not found in plan OutputChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectKnownOutputValue("name", knownvalue.StringExact("example")),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectKnownValue{}
+
+type expectKnownValue struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+	knownValue      knownvalue.Check
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectKnownValue) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	rc, err := planResourceChange(req.Plan, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if e.attributePath.HasAnyStep() {
+		results, err := plannedValues(rc, e.attributePath)
+
+		if err != nil {
+			resp.Error = err
+
+			return
+		}
+
+		if err := checkAnyKnownValue(e.knownValue, results); err != nil {
+			resp.Error = fmt.Errorf("%s - error checking value for attribute at path %s: %w", e.resourceAddress, e.attributePath, err)
+		}
+
+		return
+	}
+
+	result, err := plannedValue(rc, e.attributePath)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(result); err != nil {
+		resp.Error = fmt.Errorf("%s - error checking value for attribute at path %s: %w%s", e.resourceAddress, e.attributePath, err, knownValueDiff(e.knownValue, result))
+	}
+}
+
+// ExpectKnownValue returns a plan check that asserts that the specified
+// attribute at the given resource has the given known value in the planned
+// values, such as the result of a default value or plan modifier.
+//
+// Nested values are addressed with the attribute path, for example
+// tfjsonpath.New("list_attribute").AtSliceIndex(0) for the first element of
+// a list. The check fails if the value is unknown. A path with an
+// any-element step, such as tfjsonpath.New("set_attribute").AtAnySliceIndex(),
+// asserts that at least one of the matched values has the given known value,
+// such as for set elements, whose indices are not stable. Unknown values do
+// not match.
+func ExpectKnownValue(resourceAddress string, attributePath tfjsonpath.Path, knownValue knownvalue.Check) PlanCheck {
+	return expectKnownValue{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+		knownValue:      knownValue,
+	}
+}
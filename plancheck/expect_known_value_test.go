@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectKnownValue(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.test",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After: map[string]interface{}{
+						"id":      nil,
+						"name":    "example",
+						"port":    float64(8080),
+						"enabled": true,
+						"tags":    []interface{}{"one", "two"},
+					},
+					AfterUnknown: map[string]interface{}{
+						"id": true,
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"string": {
+			planCheck: plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			plan:      plan,
+		},
+		"int64": {
+			planCheck: plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("port"), knownvalue.Int64Exact(8080)),
+			plan:      plan,
+		},
+		"bool": {
+			planCheck: plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("enabled"), knownvalue.Bool(true)),
+			plan:      plan,
+		},
+		"list-element": {
+			planCheck: plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("tags").AtSliceIndex(1), knownvalue.StringExact("two")),
+			plan:      plan,
+		},
+		"any-slice-index": {
+			planCheck: plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("tags").AtAnySliceIndex(), knownvalue.StringExact("two")),
+			plan:      plan,
+		},
+		"any-slice-index-mismatch": {
+			planCheck:     plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("tags").AtAnySliceIndex(), knownvalue.StringExact("three")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - error checking value for attribute at path tags[*]: expected any of 2 value(s) to match three"),
+		},
+		"mismatch": {
+			planCheck:     plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("name"), knownvalue.StringExact("other")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - error checking value for attribute at path name: expected value other for StringExact check, got: example"),
+		},
+		"unknown": {
+			planCheck:     plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("id"), knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path id is unknown"),
+		},
+		"path-not-found": {
+			planCheck:     plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("missing"), knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - path not found: specified key missing not found in map at missing"),
+		},
+		"resource-not-found": {
+			planCheck:     plancheck.ExpectKnownValue("test_resource.missing", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.missing - Resource not found in plan ResourceChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectKnownValue("test_resource.test", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ PlanCheck = expectNoDeferredChanges{}
+
+type expectNoDeferredChanges struct{}
+
+// CheckPlan implements the plan check logic.
+func (e expectNoDeferredChanges) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	var result *multierror.Error
+
+	for _, dc := range req.Plan.DeferredChanges {
+		if dc.ResourceChange == nil {
+			result = multierror.Append(result, fmt.Errorf("expected no deferred changes, but found deferred change with reason: %s", dc.Reason))
+
+			continue
+		}
+
+		result = multierror.Append(result, fmt.Errorf("expected no deferred changes, but %s has deferred reason: %s", dc.ResourceChange.Address, dc.Reason))
+	}
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// ExpectNoDeferredChanges returns a plan check that asserts that there are no
+// deferred changes in the plan. All deferred changes are reported in the
+// error.
+func ExpectNoDeferredChanges() PlanCheck {
+	return expectNoDeferredChanges{}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectNoDeferredChanges(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"empty": {
+			plan: &tfjson.Plan{},
+		},
+		"resource-changes": {
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "test_resource.create",
+						Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+					},
+				},
+			},
+		},
+		"deferred-changes": {
+			plan: &tfjson.Plan{
+				DeferredChanges: []*tfjson.DeferredResourceChange{
+					{
+						Reason: "provider_config_unknown",
+						ResourceChange: &tfjson.ResourceChange{
+							Address: "test_resource.one",
+						},
+					},
+					{
+						Reason: "absent_prereq",
+						ResourceChange: &tfjson.ResourceChange{
+							Address: "test_resource.two",
+						},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("2 errors occurred:\n\t* expected no deferred changes, but test_resource.one has deferred reason: provider_config_unknown\n\t* expected no deferred changes, but test_resource.two has deferred reason: absent_prereq\n\n"),
+		},
+		"nil-plan": {
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			plancheck.ExpectNoDeferredChanges().CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %q", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectNonEmptyPlan{}
+
+type expectNonEmptyPlan struct{}
+
+// CheckPlan implements the plan check logic.
+func (e expectNonEmptyPlan) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Change != nil && !actionsAreNoop(rc.Change.Actions) {
+			return
+		}
+	}
+
+	resp.Error = fmt.Errorf("expected a non-empty plan, but got an empty plan")
+}
+
+// ExpectNonEmptyPlan returns a plan check that asserts that there is at
+// least one resource change in the plan, other than no-op changes.
+//
+// When used directly in the ConfigPlanChecks PostApplyPreRefresh or
+// PostApplyPostRefresh phase of a TestStep, the TestStep does not fail due
+// to a non-empty plan in that phase, replacing the TestStep
+// ExpectNonEmptyPlan field.
+func ExpectNonEmptyPlan() PlanCheck {
+	return expectNonEmptyPlan{}
+}
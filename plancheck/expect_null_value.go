@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectNullValue{}
+
+type expectNullValue struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectNullValue) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	rc, err := planResourceChange(req.Plan, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	result, err := plannedValue(rc, e.attributePath)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if result != nil {
+		resp.Error = fmt.Errorf("%s - attribute at path %s is not null", e.resourceAddress, e.attributePath)
+	}
+}
+
+// ExpectNullValue returns a plan check that asserts that the specified
+// attribute at the given resource is null in the planned values. The check
+// fails if the value is unknown, as an unknown value may not be null after
+// the apply.
+func ExpectNullValue(resourceAddress string, attributePath tfjsonpath.Path) PlanCheck {
+	return expectNullValue{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectNullValue(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.test",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After: map[string]interface{}{
+						"description": nil,
+						"id":          nil,
+						"name":        "example",
+					},
+					AfterUnknown: map[string]interface{}{
+						"id": true,
+					},
+				},
+			},
+			{
+				Address: "test_resource.no_change",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"null": {
+			planCheck: plancheck.ExpectNullValue("test_resource.test", tfjsonpath.New("description")),
+			plan:      plan,
+		},
+		"not-null": {
+			planCheck:     plancheck.ExpectNullValue("test_resource.test", tfjsonpath.New("name")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path name is not null"),
+		},
+		"unknown": {
+			planCheck:     plancheck.ExpectNullValue("test_resource.test", tfjsonpath.New("id")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path id is unknown"),
+		},
+		"path-not-found": {
+			planCheck:     plancheck.ExpectNullValue("test_resource.test", tfjsonpath.New("missing")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - path not found: specified key missing not found in map at missing"),
+		},
+		"no-change": {
+			planCheck:     plancheck.ExpectNullValue("test_resource.no_change", tfjsonpath.New("description")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.no_change - resource change has no planned actions"),
+		},
+		"resource-not-found": {
+			planCheck:     plancheck.ExpectNullValue("test_resource.missing", tfjsonpath.New("description")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.missing - Resource not found in plan ResourceChanges"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectOutputValueAtPath{}
+
+type expectOutputValueAtPath struct {
+	outputAddress string
+	outputPath    tfjsonpath.Path
+	knownValue    knownvalue.Check
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectOutputValueAtPath) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	change, err := planOutputChange(req.Plan, e.outputAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	result, err := plannedOutputValue(e.outputAddress, change, e.outputPath)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(result); err != nil {
+		resp.Error = fmt.Errorf("%s - error checking value for output at path %s: %w%s", e.outputAddress, e.outputPath, err, knownValueDiff(e.knownValue, result))
+	}
+}
+
+// ExpectOutputValueAtPath returns a plan check that asserts that the value at
+// the given path of the specified output has the given known value in the
+// planned output changes, such as an attribute of an output containing a
+// whole resource.
+//
+// Nested values are addressed with the output path, for example
+// tfjsonpath.New("list_attribute").AtSliceIndex(0) for the first element of
+// a list attribute. The check fails if the value is unknown.
+func ExpectOutputValueAtPath(outputAddress string, outputPath tfjsonpath.Path, knownValue knownvalue.Check) PlanCheck {
+	return expectOutputValueAtPath{
+		outputAddress: outputAddress,
+		outputPath:    outputPath,
+		knownValue:    knownValue,
+	}
+}
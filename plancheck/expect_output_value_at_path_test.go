@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectOutputValueAtPath(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		OutputChanges: map[string]*tfjson.Change{
+			"name": {
+				Actions:      tfjson.Actions{tfjson.ActionCreate},
+				After:        "example",
+				AfterUnknown: false,
+			},
+			"id": {
+				Actions:      tfjson.Actions{tfjson.ActionCreate},
+				AfterUnknown: true,
+			},
+			"resource": {
+				Actions: tfjson.Actions{tfjson.ActionCreate},
+				After: map[string]interface{}{
+					"name": "example",
+					"tags": []interface{}{"one", "two"},
+				},
+				AfterUnknown: map[string]interface{}{
+					"id": true,
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"attribute": {
+			planCheck: plancheck.ExpectOutputValueAtPath("resource", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			plan:      plan,
+		},
+		"list-element": {
+			planCheck: plancheck.ExpectOutputValueAtPath("resource", tfjsonpath.New("tags").AtSliceIndex(1), knownvalue.StringExact("two")),
+			plan:      plan,
+		},
+		"mismatch": {
+			planCheck:     plancheck.ExpectOutputValueAtPath("resource", tfjsonpath.New("name"), knownvalue.StringExact("other")),
+			plan:          plan,
+			expectedError: fmt.Errorf("resource - error checking value for output at path name: expected value other for StringExact check, got: example"),
+		},
+		"unknown": {
+			planCheck:     plancheck.ExpectOutputValueAtPath("resource", tfjsonpath.New("id"), knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("resource - output value at path id is unknown"),
+		},
+		"path-not-found": {
+			planCheck:     plancheck.ExpectOutputValueAtPath("resource", tfjsonpath.New("missing"), knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("resource - path not found: specified key missing not found in map at missing"),
+		},
+		"output-not-found": {
+			planCheck:     plancheck.ExpectOutputValueAtPath("missing", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			plan:          plan,
+			expectedError: fmt.Errorf("missing - Output not found in plan OutputChanges"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
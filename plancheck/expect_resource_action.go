@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectResourceAction{}
+
+type expectResourceAction struct {
+	resourceAddress string
+	actionType      ResourceActionType
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectResourceAction) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if !e.actionType.valid() {
+		resp.Error = fmt.Errorf("unrecognized ResourceActionType %q, this is an error in the provider test", e.actionType)
+
+		return
+	}
+
+	rc, err := planResourceChange(req.Plan, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if rc.Change == nil {
+		resp.Error = fmt.Errorf("%s - resource change has no planned actions", e.resourceAddress)
+
+		return
+	}
+
+	if !e.actionType.matches(rc.Change.Actions) {
+		resp.Error = fmt.Errorf("%s - expected %s, got action(s): %v", e.resourceAddress, e.actionType, rc.Change.Actions)
+	}
+}
+
+// ExpectResourceAction returns a plan check that asserts that the resource
+// change in the plan for the given resource address, such as
+// "example_widget.test" or "module.example.example_widget.test[0]", matches
+// the given action.
+//
+// ResourceActionReplace matches both ResourceActionDestroyBeforeCreate and
+// ResourceActionCreateBeforeDestroy, while ResourceActionCreate and
+// ResourceActionDestroy do not match replacements.
+func ExpectResourceAction(resourceAddress string, actionType ResourceActionType) PlanCheck {
+	return expectResourceAction{
+		resourceAddress: resourceAddress,
+		actionType:      actionType,
+	}
+}
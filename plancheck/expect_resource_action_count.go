@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectResourceActionCount{}
+
+type expectResourceActionCount struct {
+	actionType ResourceActionType
+	count      int
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectResourceActionCount) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if !e.actionType.valid() {
+		resp.Error = fmt.Errorf("unrecognized ResourceActionType %q, this is an error in the provider test", e.actionType)
+
+		return
+	}
+
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	var count int
+
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Change == nil {
+			continue
+		}
+
+		if e.actionType.matches(rc.Change.Actions) {
+			count++
+		}
+	}
+
+	if count != e.count {
+		resp.Error = fmt.Errorf("expected %d %s resource actions, got %d", e.count, e.actionType, count)
+	}
+}
+
+// ExpectResourceActionCount returns a plan check that asserts that the total
+// number of resource changes in the plan matching the given action is equal
+// to the given count, such as exactly 3 ResourceActionCreate and 0
+// ResourceActionDestroy. Data sources planned to be read are included in
+// ResourceActionRead counts.
+//
+// ResourceActionReplace matches both ResourceActionDestroyBeforeCreate and
+// ResourceActionCreateBeforeDestroy, while ResourceActionCreate and
+// ResourceActionDestroy do not match replacements.
+func ExpectResourceActionCount(actionType ResourceActionType, count int) PlanCheck {
+	return expectResourceActionCount{
+		actionType: actionType,
+		count:      count,
+	}
+}
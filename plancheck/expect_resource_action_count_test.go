@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectResourceActionCount(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.create[0]",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.create[1]",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.update",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+			{
+				Address: "test_resource.replace",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.noop",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"create": {
+			planCheck: plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 2),
+			plan:      plan,
+		},
+		"update": {
+			planCheck: plancheck.ExpectResourceActionCount(plancheck.ResourceActionUpdate, 1),
+			plan:      plan,
+		},
+		"destroy-zero": {
+			planCheck: plancheck.ExpectResourceActionCount(plancheck.ResourceActionDestroy, 0),
+			plan:      plan,
+		},
+		"replace": {
+			planCheck: plancheck.ExpectResourceActionCount(plancheck.ResourceActionReplace, 1),
+			plan:      plan,
+		},
+		"destroy-before-create": {
+			planCheck: plancheck.ExpectResourceActionCount(plancheck.ResourceActionDestroyBeforeCreate, 1),
+			plan:      plan,
+		},
+		"noop": {
+			planCheck: plancheck.ExpectResourceActionCount(plancheck.ResourceActionNoop, 1),
+			plan:      plan,
+		},
+		"create-mismatch": {
+			planCheck:     plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 3),
+			plan:          plan,
+			expectedError: fmt.Errorf("expected 3 Create resource actions, got 2"),
+		},
+		"empty-plan": {
+			planCheck:     plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 1),
+			plan:          &tfjson.Plan{},
+			expectedError: fmt.Errorf("expected 1 Create resource actions, got 0"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectResourceActionCount(plancheck.ResourceActionCreate, 1),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+		"unrecognized-action": {
+			planCheck:     plancheck.ExpectResourceActionCount(plancheck.ResourceActionType("Invalid"), 1),
+			plan:          plan,
+			expectedError: fmt.Errorf("unrecognized ResourceActionType \"Invalid\", this is an error in the provider test"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
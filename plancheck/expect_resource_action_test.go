@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectResourceAction(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.create",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.update",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+			{
+				Address: "test_resource.destroy_before_create",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}},
+			},
+			{
+				Address: "test_resource.create_before_destroy",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate, tfjson.ActionDelete}},
+			},
+			{
+				Address: "test_resource.destroy",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}},
+			},
+			{
+				Address: "module.example.test_resource.noop[0]",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+			},
+			{
+				Address: "test_resource.no_change",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"create": {
+			planCheck: plancheck.ExpectResourceAction("test_resource.create", plancheck.ResourceActionCreate),
+			plan:      plan,
+		},
+		"update": {
+			planCheck: plancheck.ExpectResourceAction("test_resource.update", plancheck.ResourceActionUpdate),
+			plan:      plan,
+		},
+		"destroy": {
+			planCheck: plancheck.ExpectResourceAction("test_resource.destroy", plancheck.ResourceActionDestroy),
+			plan:      plan,
+		},
+		"destroy-before-create": {
+			planCheck: plancheck.ExpectResourceAction("test_resource.destroy_before_create", plancheck.ResourceActionDestroyBeforeCreate),
+			plan:      plan,
+		},
+		"create-before-destroy": {
+			planCheck: plancheck.ExpectResourceAction("test_resource.create_before_destroy", plancheck.ResourceActionCreateBeforeDestroy),
+			plan:      plan,
+		},
+		"replace": {
+			planCheck: plancheck.ExpectResourceAction("test_resource.create_before_destroy", plancheck.ResourceActionReplace),
+			plan:      plan,
+		},
+		"noop-module": {
+			planCheck: plancheck.ExpectResourceAction("module.example.test_resource.noop[0]", plancheck.ResourceActionNoop),
+			plan:      plan,
+		},
+		"replace-not-create": {
+			planCheck:     plancheck.ExpectResourceAction("test_resource.destroy_before_create", plancheck.ResourceActionCreate),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.destroy_before_create - expected Create, got action(s): [delete create]"),
+		},
+		"mismatch": {
+			planCheck:     plancheck.ExpectResourceAction("test_resource.update", plancheck.ResourceActionNoop),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.update - expected NoOp, got action(s): [update]"),
+		},
+		"no-change": {
+			planCheck:     plancheck.ExpectResourceAction("test_resource.no_change", plancheck.ResourceActionNoop),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.no_change - resource change has no planned actions"),
+		},
+		"not-found": {
+			planCheck:     plancheck.ExpectResourceAction("test_resource.missing", plancheck.ResourceActionCreate),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.missing - Resource not found in plan ResourceChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectResourceAction("test_resource.create", plancheck.ResourceActionCreate),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+		"unrecognized-action": {
+			planCheck:     plancheck.ExpectResourceAction("test_resource.create", plancheck.ResourceActionType("Invalid")),
+			plan:          plan,
+			expectedError: fmt.Errorf("unrecognized ResourceActionType \"Invalid\", this is an error in the provider test"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
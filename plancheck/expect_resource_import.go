@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectResourceImport{}
+
+type expectResourceImport struct {
+	resourceAddress string
+	importID        string
+	checkImportID   bool
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectResourceImport) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	rc, err := planResourceChange(req.Plan, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if rc.Change == nil {
+		resp.Error = fmt.Errorf("%s - resource change has no planned actions", e.resourceAddress)
+
+		return
+	}
+
+	if rc.Change.Importing == nil {
+		resp.Error = fmt.Errorf("%s - resource change is not an import", e.resourceAddress)
+
+		return
+	}
+
+	if e.checkImportID && rc.Change.Importing.ID != e.importID {
+		resp.Error = fmt.Errorf("%s - expected import ID %q, got %q", e.resourceAddress, e.importID, rc.Change.Importing.ID)
+	}
+}
+
+// ExpectResourceImport returns a plan check that asserts that the resource
+// change in the plan for the given resource address, such as
+// "example_widget.test", is an import, such as from an import block in the
+// TestStep configuration. An import is in addition to the planned action of
+// the resource change, which is ResourceActionNoop if the imported resource
+// matches the configuration.
+//
+// Planned imports require Terraform 1.5 or later.
+func ExpectResourceImport(resourceAddress string) PlanCheck {
+	return expectResourceImport{
+		resourceAddress: resourceAddress,
+	}
+}
+
+// ExpectResourceImportID returns a plan check that asserts that the resource
+// change in the plan for the given resource address is an import of the
+// given import ID. Refer to ExpectResourceImport for more details.
+func ExpectResourceImportID(resourceAddress string, importID string) PlanCheck {
+	return expectResourceImport{
+		resourceAddress: resourceAddress,
+		importID:        importID,
+		checkImportID:   true,
+	}
+}
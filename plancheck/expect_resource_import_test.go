@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectResourceImport(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "example_widget.imported",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoop},
+					Importing: &tfjson.Importing{
+						ID: "widget-123",
+					},
+				},
+			},
+			{
+				Address: "example_widget.created",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+				},
+			},
+			{
+				Address: "example_widget.no_change",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"import": {
+			planCheck: plancheck.ExpectResourceImport("example_widget.imported"),
+			plan:      plan,
+		},
+		"import-id": {
+			planCheck: plancheck.ExpectResourceImportID("example_widget.imported", "widget-123"),
+			plan:      plan,
+		},
+		"import-id-mismatch": {
+			planCheck:     plancheck.ExpectResourceImportID("example_widget.imported", "widget-456"),
+			plan:          plan,
+			expectedError: fmt.Errorf("example_widget.imported - expected import ID \"widget-456\", got \"widget-123\""),
+		},
+		"not-import": {
+			planCheck:     plancheck.ExpectResourceImport("example_widget.created"),
+			plan:          plan,
+			expectedError: fmt.Errorf("example_widget.created - resource change is not an import"),
+		},
+		"not-import-id": {
+			planCheck:     plancheck.ExpectResourceImportID("example_widget.created", "widget-123"),
+			plan:          plan,
+			expectedError: fmt.Errorf("example_widget.created - resource change is not an import"),
+		},
+		"no-change": {
+			planCheck:     plancheck.ExpectResourceImport("example_widget.no_change"),
+			plan:          plan,
+			expectedError: fmt.Errorf("example_widget.no_change - resource change has no planned actions"),
+		},
+		"resource-not-found": {
+			planCheck:     plancheck.ExpectResourceImport("example_widget.missing"),
+			plan:          plan,
+			expectedError: fmt.Errorf("example_widget.missing - Resource not found in plan ResourceChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectResourceImport("example_widget.imported"),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
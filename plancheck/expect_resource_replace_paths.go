@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectResourceReplacePaths{}
+
+type expectResourceReplacePaths struct {
+	resourceAddress string
+	paths           []tfjsonpath.Path
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectResourceReplacePaths) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	rc, err := planResourceChange(req.Plan, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if rc.Change == nil {
+		resp.Error = fmt.Errorf("%s - resource change has no planned actions", e.resourceAddress)
+
+		return
+	}
+
+	if !rc.Change.Actions.Replace() {
+		resp.Error = fmt.Errorf("%s - expected Replace, got action(s): %v", e.resourceAddress, rc.Change.Actions)
+
+		return
+	}
+
+	got := make([]tfjsonpath.Path, 0, len(rc.Change.ReplacePaths))
+
+	for _, replacePath := range rc.Change.ReplacePaths {
+		path, err := replacePathToPath(replacePath)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s - %w", e.resourceAddress, err)
+
+			return
+		}
+
+		got = append(got, path)
+	}
+
+	if !pathsEqual(got, e.paths) {
+		resp.Error = fmt.Errorf("%s - expected replace paths %s, got: %s", e.resourceAddress, pathsString(e.paths), pathsString(got))
+	}
+}
+
+// ExpectResourceReplacePaths returns a plan check that asserts that the
+// resource change in the plan for the given resource address, such as
+// "example_widget.test", is a replacement forced by exactly the given
+// attribute paths, in any order. This verifies RequiresReplace plan modifiers
+// and ForceNew schema flags. Requires Terraform 1.2 or later, which records
+// the replace_paths of resource changes in the plan.
+func ExpectResourceReplacePaths(resourceAddress string, paths []tfjsonpath.Path) PlanCheck {
+	return expectResourceReplacePaths{
+		resourceAddress: resourceAddress,
+		paths:           paths,
+	}
+}
+
+// replacePathToPath converts a replace_paths element of a resource change,
+// which is a slice of integer indices and string keys, into a Path.
+func replacePathToPath(replacePath interface{}) (tfjsonpath.Path, error) {
+	steps, ok := replacePath.([]interface{})
+
+	if !ok {
+		return tfjsonpath.Path{}, fmt.Errorf("unexpected replace path type %T", replacePath)
+	}
+
+	var path tfjsonpath.Path
+
+	for _, step := range steps {
+		switch s := step.(type) {
+		case string:
+			path = path.AtMapKey(s)
+		case float64:
+			path = path.AtSliceIndex(int(s))
+		case json.Number:
+			index, err := s.Int64()
+
+			if err != nil {
+				return tfjsonpath.Path{}, fmt.Errorf("unexpected replace path index %q: %w", s, err)
+			}
+
+			path = path.AtSliceIndex(int(index))
+		default:
+			return tfjsonpath.Path{}, fmt.Errorf("unexpected replace path step type %T", step)
+		}
+	}
+
+	return path, nil
+}
+
+// pathsEqual returns true if both slices contain equal paths, in any order.
+func pathsEqual(a, b []tfjsonpath.Path) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+
+	for _, pathA := range a {
+		found := false
+
+		for i, pathB := range b {
+			if matched[i] || !pathA.Equal(pathB) {
+				continue
+			}
+
+			matched[i] = true
+			found = true
+
+			break
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pathsString returns the sorted string representations of the given paths,
+// such as "[name tags.env]".
+func pathsString(paths []tfjsonpath.Path) string {
+	result := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		result = append(result, path.String())
+	}
+
+	sort.Strings(result)
+
+	return "[" + strings.Join(result, " ") + "]"
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectResourceReplacePaths(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.replace",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+					ReplacePaths: []interface{}{
+						[]interface{}{"name"},
+						[]interface{}{"rule", float64(0), "port"},
+					},
+				},
+			},
+			{
+				Address: "test_resource.replace_json_number",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate, tfjson.ActionDelete},
+					ReplacePaths: []interface{}{
+						[]interface{}{"rule", json.Number("1")},
+					},
+				},
+			},
+			{
+				Address: "test_resource.update",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+			{
+				Address: "test_resource.invalid",
+				Change: &tfjson.Change{
+					Actions:      tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+					ReplacePaths: []interface{}{"name"},
+				},
+			},
+			{
+				Address: "test_resource.no_change",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"match": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.replace", []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+				tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("port"),
+			}),
+			plan: plan,
+		},
+		"match-different-order": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.replace", []tfjsonpath.Path{
+				tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("port"),
+				tfjsonpath.New("name"),
+			}),
+			plan: plan,
+		},
+		"match-json-number": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.replace_json_number", []tfjsonpath.Path{
+				tfjsonpath.New("rule").AtSliceIndex(1),
+			}),
+			plan: plan,
+		},
+		"missing-path": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.replace", []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+			}),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.replace - expected replace paths [name], got: [name rule.0.port]"),
+		},
+		"different-path": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.replace", []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+				tfjsonpath.New("rule").AtSliceIndex(1).AtMapKey("port"),
+			}),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.replace - expected replace paths [name rule.1.port], got: [name rule.0.port]"),
+		},
+		"not-replace": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.update", []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+			}),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.update - expected Replace, got action(s): [update]"),
+		},
+		"invalid-replace-path": {
+			planCheck: plancheck.ExpectResourceReplacePaths("test_resource.invalid", []tfjsonpath.Path{
+				tfjsonpath.New("name"),
+			}),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.invalid - unexpected replace path type string"),
+		},
+		"no-change": {
+			planCheck:     plancheck.ExpectResourceReplacePaths("test_resource.no_change", nil),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.no_change - resource change has no planned actions"),
+		},
+		"not-found": {
+			planCheck:     plancheck.ExpectResourceReplacePaths("test_resource.missing", nil),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.missing - Resource not found in plan ResourceChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectResourceReplacePaths("test_resource.replace", nil),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
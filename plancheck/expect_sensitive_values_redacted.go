@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectSensitiveValuesRedacted{}
+
+type expectSensitiveValuesRedacted struct {
+	values []string
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectSensitiveValuesRedacted) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	if req.Plan == nil {
+		resp.Error = fmt.Errorf("plan is nil")
+
+		return
+	}
+
+	var result *multierror.Error
+
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Change == nil {
+			continue
+		}
+
+		for _, path := range e.unredactedAttributePaths(rc.Change.Before, rc.Change.BeforeSensitive) {
+			result = multierror.Append(result, fmt.Errorf("%s - sensitive value is not redacted in prior value at path %s", rc.Address, path))
+		}
+
+		for _, path := range e.unredactedAttributePaths(rc.Change.After, rc.Change.AfterSensitive) {
+			result = multierror.Append(result, fmt.Errorf("%s - sensitive value is not redacted in planned value at path %s", rc.Address, path))
+		}
+	}
+
+	outputNames := make([]string, 0, len(req.Plan.OutputChanges))
+
+	for name := range req.Plan.OutputChanges {
+		outputNames = append(outputNames, name)
+	}
+
+	sort.Strings(outputNames)
+
+	for _, name := range outputNames {
+		change := req.Plan.OutputChanges[name]
+
+		if change == nil {
+			continue
+		}
+
+		if e.outputUnredacted(name, change.Before, change.BeforeSensitive) {
+			result = multierror.Append(result, fmt.Errorf("output %s - sensitive value is not redacted in prior value", name))
+		}
+
+		if e.outputUnredacted(name, change.After, change.AfterSensitive) {
+			result = multierror.Append(result, fmt.Errorf("output %s - sensitive value is not redacted in planned value", name))
+		}
+	}
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// unredactedAttributePaths returns the paths of the resource attributes
+// whose values contain any of the sensitive values without being marked
+// sensitive in the given sensitive value marks, such as the "after_sensitive"
+// object of a resource change.
+func (e expectSensitiveValuesRedacted) unredactedAttributePaths(values interface{}, sensitive interface{}) []tfjsonpath.Path {
+	attributes, ok := values.(map[string]interface{})
+
+	if !ok {
+		return nil
+	}
+
+	sensitiveAttributes, _ := sensitive.(map[string]interface{})
+
+	names := make([]string, 0, len(attributes))
+
+	for name := range attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var paths []tfjsonpath.Path
+
+	for _, name := range names {
+		paths = append(paths, e.unredactedPaths(attributes[name], sensitiveAttributes[name], tfjsonpath.New(name))...)
+	}
+
+	return paths
+}
+
+// unredactedPaths returns the paths of the value and its nested values which
+// contain any of the sensitive values without being marked sensitive.
+func (e expectSensitiveValuesRedacted) unredactedPaths(value interface{}, sensitive interface{}, path tfjsonpath.Path) []tfjsonpath.Path {
+	if isSensitive, ok := sensitive.(bool); ok && isSensitive {
+		return nil
+	}
+
+	var paths []tfjsonpath.Path
+
+	switch value := value.(type) {
+	case string:
+		if e.containsSensitiveValue(value) {
+			paths = append(paths, path)
+		}
+	case map[string]interface{}:
+		sensitiveMap, _ := sensitive.(map[string]interface{})
+
+		keys := make([]string, 0, len(value))
+
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			paths = append(paths, e.unredactedPaths(value[key], sensitiveMap[key], path.AtMapKey(key))...)
+		}
+	case []interface{}:
+		sensitiveSlice, _ := sensitive.([]interface{})
+
+		for index, elem := range value {
+			var elemSensitive interface{}
+
+			if index < len(sensitiveSlice) {
+				elemSensitive = sensitiveSlice[index]
+			}
+
+			paths = append(paths, e.unredactedPaths(elem, elemSensitive, path.AtSliceIndex(index))...)
+		}
+	}
+
+	return paths
+}
+
+// outputUnredacted returns true if the output value or any of its nested
+// values contain any of the sensitive values without being marked sensitive.
+func (e expectSensitiveValuesRedacted) outputUnredacted(name string, value interface{}, sensitive interface{}) bool {
+	return len(e.unredactedPaths(value, sensitive, tfjsonpath.New(name))) > 0
+}
+
+// containsSensitiveValue returns true if the string contains any of the
+// sensitive values.
+func (e expectSensitiveValuesRedacted) containsSensitiveValue(value string) bool {
+	for _, sensitiveValue := range e.values {
+		if sensitiveValue != "" && strings.Contains(value, sensitiveValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExpectSensitiveValuesRedacted returns a plan check that asserts that the
+// given values, such as passwords or tokens, are marked sensitive wherever
+// they appear in the resource and output changes of the plan, including as
+// part of a larger string value. Every unredacted resource attribute path and
+// output is reported in the error, without the sensitive value itself.
+//
+// Terraform includes input variable values as-is in the plan, so they are
+// not checked. The TestStep ConfigVariables field automatically adds this
+// check for each config.SensitiveStringVariable value.
+func ExpectSensitiveValuesRedacted(values ...string) PlanCheck {
+	return expectSensitiveValuesRedacted{
+		values: values,
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheck = expectUnknownOutputValue{}
+
+type expectUnknownOutputValue struct {
+	outputAddress string
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectUnknownOutputValue) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	change, err := planOutputChange(req.Plan, e.outputAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	switch afterUnknown := change.AfterUnknown.(type) {
+	case bool:
+		if !afterUnknown {
+			resp.Error = fmt.Errorf("%s - output value is known", e.outputAddress)
+		}
+	case nil:
+		resp.Error = fmt.Errorf("%s - output value is known", e.outputAddress)
+	default:
+		resp.Error = fmt.Errorf("%s - output value contains unknown values, but is not unknown", e.outputAddress)
+	}
+}
+
+// ExpectUnknownOutputValue returns a plan check that asserts that the
+// specified output has an unknown value in the planned output changes, shown
+// as "(known after apply)" in the plan output.
+//
+// An output containing a collection or object which is known, but contains
+// unknown elements or attributes, is not an unknown value.
+func ExpectUnknownOutputValue(outputAddress string) PlanCheck {
+	return expectUnknownOutputValue{
+		outputAddress: outputAddress,
+	}
+}
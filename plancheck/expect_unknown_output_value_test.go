@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestExpectUnknownOutputValue(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		OutputChanges: map[string]*tfjson.Change{
+			"name": {
+				Actions:      tfjson.Actions{tfjson.ActionCreate},
+				After:        "example",
+				AfterUnknown: false,
+			},
+			"id": {
+				Actions:      tfjson.Actions{tfjson.ActionCreate},
+				AfterUnknown: true,
+			},
+			"resource": {
+				Actions: tfjson.Actions{tfjson.ActionCreate},
+				After: map[string]interface{}{
+					"name": "example",
+					"tags": []interface{}{"one", "two"},
+				},
+				AfterUnknown: map[string]interface{}{
+					"id": true,
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"unknown": {
+			planCheck: plancheck.ExpectUnknownOutputValue("id"),
+			plan:      plan,
+		},
+		"known": {
+			planCheck:     plancheck.ExpectUnknownOutputValue("name"),
+			plan:          plan,
+			expectedError: fmt.Errorf("name - output value is known"),
+		},
+		"contains-unknown": {
+			planCheck:     plancheck.ExpectUnknownOutputValue("resource"),
+			plan:          plan,
+			expectedError: fmt.Errorf("resource - output value contains unknown values, but is not unknown"),
+		},
+		"output-not-found": {
+			planCheck:     plancheck.ExpectUnknownOutputValue("missing"),
+			plan:          plan,
+			expectedError: fmt.Errorf("missing - Output not found in plan OutputChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectUnknownOutputValue("id"),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
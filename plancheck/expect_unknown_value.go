@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ PlanCheck = expectUnknownValue{}
+
+type expectUnknownValue struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+}
+
+// CheckPlan implements the plan check logic.
+func (e expectUnknownValue) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	rc, err := planResourceChange(req.Plan, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if rc.Change == nil {
+		resp.Error = fmt.Errorf("%s - resource change has no planned actions", e.resourceAddress)
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(rc.Change.AfterUnknown, e.attributePath)
+
+	if err != nil {
+		// Known values are omitted from AfterUnknown, so only report the
+		// traversal error if the attribute is also not a known value.
+		if _, afterErr := tfjsonpath.Traverse(rc.Change.After, e.attributePath); afterErr == nil {
+			resp.Error = fmt.Errorf("%s - attribute at path %s is known", e.resourceAddress, e.attributePath)
+
+			return
+		}
+
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddress, err)
+
+		return
+	}
+
+	isUnknown, ok := result.(bool)
+
+	if !ok {
+		resp.Error = fmt.Errorf("%s - attribute at path %s contains unknown values, but is not unknown", e.resourceAddress, e.attributePath)
+
+		return
+	}
+
+	if !isUnknown {
+		resp.Error = fmt.Errorf("%s - attribute at path %s is known", e.resourceAddress, e.attributePath)
+	}
+}
+
+// ExpectUnknownValue returns a plan check that asserts that the specified
+// attribute at the given resource has an unknown value in the planned
+// values, shown as "(known after apply)" in the plan output.
+//
+// A collection or object attribute which is known, but contains unknown
+// elements or attributes, is not an unknown value. Nested values are
+// addressed with the attribute path, for example
+// tfjsonpath.New("list_attribute").AtSliceIndex(0) for the first element of
+// a list.
+func ExpectUnknownValue(resourceAddress string, attributePath tfjsonpath.Path) PlanCheck {
+	return expectUnknownValue{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectUnknownValue(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "test_resource.test",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionCreate},
+					After: map[string]interface{}{
+						"name": "example",
+						"tags": []interface{}{"one"},
+						"nested": []interface{}{
+							map[string]interface{}{
+								"name": "nested",
+							},
+						},
+					},
+					AfterUnknown: map[string]interface{}{
+						"id":   true,
+						"tags": []interface{}{false, true},
+						"nested": []interface{}{
+							map[string]interface{}{
+								"computed": true,
+							},
+						},
+					},
+				},
+			},
+			{
+				Address: "test_resource.no_change",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		plan          *tfjson.Plan
+		expectedError error
+	}{
+		"attribute": {
+			planCheck: plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("id")),
+			plan:      plan,
+		},
+		"list-element": {
+			planCheck: plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("tags").AtSliceIndex(1)),
+			plan:      plan,
+		},
+		"nested-attribute": {
+			planCheck: plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("computed")),
+			plan:      plan,
+		},
+		"known-attribute": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("name")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path name is known"),
+		},
+		"known-list-element": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("tags").AtSliceIndex(0)),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path tags.0 is known"),
+		},
+		"known-nested-attribute": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("nested").AtSliceIndex(0).AtMapKey("name")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path nested.0.name is known"),
+		},
+		"partially-unknown": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("tags")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - attribute at path tags contains unknown values, but is not unknown"),
+		},
+		"path-not-found": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("missing")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.test - path not found: specified key missing not found in map at missing"),
+		},
+		"no-change": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.no_change", tfjsonpath.New("id")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.no_change - resource change has no planned actions"),
+		},
+		"resource-not-found": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.missing", tfjsonpath.New("id")),
+			plan:          plan,
+			expectedError: fmt.Errorf("test_resource.missing - Resource not found in plan ResourceChanges"),
+		},
+		"nil-plan": {
+			planCheck:     plancheck.ExpectUnknownValue("test_resource.test", tfjsonpath.New("id")),
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: testCase.plan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+// knownValueDiff returns the differences between the value expected by the
+// known value check and the given value, formatted to be appended to the
+// error of the check, or an empty string if knownvalue.Diff reports none.
+func knownValueDiff(check knownvalue.Check, value interface{}) string {
+	diff := knownvalue.Diff(check, value)
+
+	if diff == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\nvalue differences (-expected +got):\n%s", diff)
+}
+
+// checkAnyKnownValue returns nil if any of the values, such as those matched
+// by a path with an AnySliceStep or AnyMapStep, passes the known value check.
+func checkAnyKnownValue(check knownvalue.Check, values []interface{}) error {
+	for _, value := range values {
+		if check.CheckValue(value) == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("expected any of %d value(s) to match %s", len(values), check)
+}
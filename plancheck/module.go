@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// ModuleResourceAddresses returns the addresses of the resource changes in
+// the plan for resources in the module with the given address, such as
+// "module.child" or "module.child[0].module.grandchild", and in its child
+// modules, such as "module.child.example_widget.test". An empty module
+// address returns the addresses of all resource changes in the plan.
+//
+// The addresses can be used with plan checks, such as in a loop creating an
+// ExpectResourceAction plan check for each resource of a module in a test
+// fixture. Modules without resource changes are not included in the plan, so
+// no addresses are returned for them.
+func ModuleResourceAddresses(plan *tfjson.Plan, moduleAddress string) ([]string, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is nil")
+	}
+
+	var addresses []string
+
+	for _, rc := range plan.ResourceChanges {
+		if moduleAddress != "" && rc.ModuleAddress != moduleAddress && !strings.HasPrefix(rc.ModuleAddress, moduleAddress+".") {
+			continue
+		}
+
+		addresses = append(addresses, rc.Address)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestModuleResourceAddresses(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "example_widget.root",
+			},
+			{
+				Address:       "module.child.example_widget.test",
+				ModuleAddress: "module.child",
+			},
+			{
+				Address:       "module.child.module.grandchild.example_widget.test",
+				ModuleAddress: "module.child.module.grandchild",
+			},
+			{
+				Address:       "module.children[0].example_widget.test",
+				ModuleAddress: "module.children[0]",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		plan          *tfjson.Plan
+		moduleAddress string
+		expected      []string
+		expectedError error
+	}{
+		"all": {
+			plan: plan,
+			expected: []string{
+				"example_widget.root",
+				"module.child.example_widget.test",
+				"module.child.module.grandchild.example_widget.test",
+				"module.children[0].example_widget.test",
+			},
+		},
+		"child-module": {
+			plan:          plan,
+			moduleAddress: "module.child",
+			expected: []string{
+				"module.child.example_widget.test",
+				"module.child.module.grandchild.example_widget.test",
+			},
+		},
+		"grandchild-module": {
+			plan:          plan,
+			moduleAddress: "module.child.module.grandchild",
+			expected: []string{
+				"module.child.module.grandchild.example_widget.test",
+			},
+		},
+		"module-instance": {
+			plan:          plan,
+			moduleAddress: "module.children[0]",
+			expected: []string{
+				"module.children[0].example_widget.test",
+			},
+		},
+		"module-without-changes": {
+			plan:          plan,
+			moduleAddress: "module.missing",
+		},
+		"nil-plan": {
+			moduleAddress: "module.child",
+			expectedError: fmt.Errorf("plan is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := plancheck.ModuleResourceAddresses(testCase.plan, testCase.moduleAddress)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ PlanCheckWithRenderedPlan = notCheck{}
+
+type notCheck struct {
+	planCheck PlanCheck
+}
+
+// CheckPlan implements the plan check logic.
+func (n notCheck) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	checkResp := CheckPlanResponse{}
+
+	n.planCheck.CheckPlan(ctx, req, &checkResp)
+
+	if checkResp.Error == nil {
+		resp.Error = fmt.Errorf("expected plan check to fail, but it passed")
+	}
+}
+
+// RequiresRenderedPlan implements the PlanCheckWithRenderedPlan interface.
+func (n notCheck) RequiresRenderedPlan() bool {
+	return requiresRenderedPlan([]PlanCheck{n.planCheck})
+}
+
+// Not returns a plan check that inverts the result of the given plan check,
+// passing only when the given plan check returns an error. For example,
+// Not(ExpectResourceActionCount(ResourceActionDestroy, 0)) asserts that the
+// plan contains at least one destroy.
+func Not(planCheck PlanCheck) PlanCheck {
+	return notCheck{
+		planCheck: planCheck,
+	}
+}
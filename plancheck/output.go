@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// planOutputChange returns the output change in the plan with the given
+// output address.
+func planOutputChange(plan *tfjson.Plan, outputAddress string) (*tfjson.Change, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is nil")
+	}
+
+	change, ok := plan.OutputChanges[outputAddress]
+
+	if !ok || change == nil {
+		return nil, fmt.Errorf("%s - Output not found in plan OutputChanges", outputAddress)
+	}
+
+	return change, nil
+}
+
+// plannedOutputValue returns the planned value at the given path of the
+// output change, or an error if the value is unknown. An empty path returns
+// the whole output value.
+func plannedOutputValue(outputAddress string, change *tfjson.Change, outputPath tfjsonpath.Path) (interface{}, error) {
+	// Unknown values are null or omitted in the planned values, so they
+	// must be checked first.
+	if unknown, err := tfjsonpath.Traverse(change.AfterUnknown, outputPath); err == nil {
+		if isUnknown, ok := unknown.(bool); ok && isUnknown {
+			return nil, fmt.Errorf("%s - %s is unknown", outputAddress, outputValueDescription(outputPath))
+		}
+	}
+
+	result, err := tfjsonpath.Traverse(change.After, outputPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s - %w", outputAddress, err)
+	}
+
+	return result, nil
+}
+
+// outputValueDescription returns a description of the output value at the
+// given path for error messages.
+func outputValueDescription(outputPath tfjsonpath.Path) string {
+	if outputPath.Equal(tfjsonpath.Path{}) {
+		return "output value"
+	}
+
+	return fmt.Sprintf("output value at path %s", outputPath)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"context"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PlanCheck defines an interface for implementing test logic that checks a plan file and then returns an error
+// if the plan file does not match what is expected.
+type PlanCheck interface {
+	// CheckPlan should perform the plan check.
+	CheckPlan(context.Context, CheckPlanRequest, *CheckPlanResponse)
+}
+
+// PlanCheckWithRenderedPlan is an optional interface for a PlanCheck which
+// requires the RenderedPlan field of the CheckPlanRequest, such as the plan
+// checks of the planrender package. The human-readable plan is only rendered,
+// which runs an additional terraform show command, when a plan check of the
+// phase requires it.
+type PlanCheckWithRenderedPlan interface {
+	PlanCheck
+
+	// RequiresRenderedPlan should return true if the plan check uses the
+	// RenderedPlan field of the CheckPlanRequest.
+	RequiresRenderedPlan() bool
+}
+
+// CheckPlanRequest is a request for an invoke of the CheckPlan function.
+type CheckPlanRequest struct {
+	// Plan represents a parsed plan file, retrieved via the `terraform show -json` command.
+	Plan *tfjson.Plan
+
+	// RenderedPlan is the human-readable rendering of the plan, retrieved
+	// via the `terraform show` command without color, as seen by
+	// practitioners. It is only set when a plan check of the phase
+	// implements PlanCheckWithRenderedPlan and requires it.
+	RenderedPlan string
+
+	// StepNumber is the 1-based index of the TestStep in the TestCase.
+	StepNumber int
+
+	// StepName is the Name of the TestStep, if set.
+	StepName string
+
+	// TestName is the name of the Go test running the TestCase, as
+	// returned by testing.T Name().
+	TestName string
+}
+
+// CheckPlanResponse is a response to an invoke of the CheckPlan function.
+type CheckPlanResponse struct {
+	// Error is used to report the failure of a plan check assertion and is combined with other PlanCheck errors
+	// to be reported as a test failure.
+	Error error
+}
+
+// requiresRenderedPlan returns true if any of the given plan checks requires
+// the RenderedPlan field of the CheckPlanRequest.
+func requiresRenderedPlan(planChecks []PlanCheck) bool {
+	for _, planCheck := range planChecks {
+		if p, ok := planCheck.(PlanCheckWithRenderedPlan); ok && p.RequiresRenderedPlan() {
+			return true
+		}
+	}
+
+	return false
+}
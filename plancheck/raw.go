@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import "context"
+
+var _ PlanCheck = rawCheck{}
+
+// RawFunc is the function signature for a plan check created with Raw. The
+// request contains the plan retrieved via the `terraform show -json` command
+// along with details of the running TestStep. Set the response Error field
+// to report a failure.
+type RawFunc func(context.Context, CheckPlanRequest, *CheckPlanResponse)
+
+type rawCheck struct {
+	f RawFunc
+}
+
+// CheckPlan implements the plan check logic.
+func (r rawCheck) CheckPlan(ctx context.Context, req CheckPlanRequest, resp *CheckPlanResponse) {
+	r.f(ctx, req, resp)
+}
+
+// Raw returns a plan check that calls the given function with the raw plan
+// and TestStep details. It is intended for one-off assertions which are not
+// covered by other plan checks, without needing to declare a type which
+// implements the PlanCheck interface.
+func Raw(f RawFunc) PlanCheck {
+	return rawCheck{
+		f: f,
+	}
+}
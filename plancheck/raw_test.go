@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestRaw(t *testing.T) {
+	t.Parallel()
+
+	plan := &tfjson.Plan{FormatVersion: "1.1"}
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		expectedError error
+	}{
+		"pass": {
+			planCheck: plancheck.Raw(func(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+				if req.Plan != plan {
+					resp.Error = fmt.Errorf("unexpected plan")
+				}
+			}),
+		},
+		"step-details": {
+			planCheck: plancheck.Raw(func(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+				resp.Error = fmt.Errorf("%s step %d", req.TestName, req.StepNumber)
+			}),
+			expectedError: fmt.Errorf("TestExample step 2"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := plancheck.CheckPlanRequest{
+				Plan:       plan,
+				StepNumber: 2,
+				TestName:   "TestExample",
+			}
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), req, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plancheck
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// planResourceChange returns the resource change in the plan with the given
+// resource address.
+func planResourceChange(plan *tfjson.Plan, resourceAddress string) (*tfjson.ResourceChange, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is nil")
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc.Address == resourceAddress {
+			return rc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s - Resource not found in plan ResourceChanges", resourceAddress)
+}
+
+// plannedValue returns the planned value of the attribute at the given path
+// of the resource change, or an error if the value is unknown.
+func plannedValue(rc *tfjson.ResourceChange, attributePath tfjsonpath.Path) (interface{}, error) {
+	if rc.Change == nil {
+		return nil, fmt.Errorf("%s - resource change has no planned actions", rc.Address)
+	}
+
+	// Unknown values are null or omitted in the planned values, so they
+	// must be checked first.
+	if unknown, err := tfjsonpath.Traverse(rc.Change.AfterUnknown, attributePath); err == nil {
+		if isUnknown, ok := unknown.(bool); ok && isUnknown {
+			return nil, fmt.Errorf("%s - attribute at path %s is unknown", rc.Address, attributePath)
+		}
+	}
+
+	result, err := tfjsonpath.Traverse(rc.Change.After, attributePath)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s - %w", rc.Address, err)
+	}
+
+	return result, nil
+}
+
+// plannedValues returns the planned values matched by the given path with an
+// AnySliceStep or AnyMapStep. Unknown values are null or omitted in the
+// planned values, so they never match a known value check.
+func plannedValues(rc *tfjson.ResourceChange, attributePath tfjsonpath.Path) ([]interface{}, error) {
+	if rc.Change == nil {
+		return nil, fmt.Errorf("%s - resource change has no planned actions", rc.Address)
+	}
+
+	results, err := tfjsonpath.TraverseAll(rc.Change.After, attributePath)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s - %w", rc.Address, err)
+	}
+
+	return results, nil
+}
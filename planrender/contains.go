@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planrender
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+var _ plancheck.PlanCheckWithRenderedPlan = containsCheck{}
+
+type containsCheck struct {
+	substr string
+}
+
+// CheckPlan implements the plan check logic.
+func (c containsCheck) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	if !strings.Contains(req.RenderedPlan, c.substr) {
+		resp.Error = fmt.Errorf("expected rendered plan to contain %q, got:\n\n%s", c.substr, req.RenderedPlan)
+	}
+}
+
+// RequiresRenderedPlan implements the PlanCheckWithRenderedPlan interface.
+func (c containsCheck) RequiresRenderedPlan() bool {
+	return true
+}
+
+// Contains returns a plan check that asserts that the rendered plan contains
+// the given string, such as `+ password = (sensitive value)`.
+func Contains(substr string) plancheck.PlanCheck {
+	return containsCheck{
+		substr: substr,
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planrender_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/planrender"
+)
+
+const testRenderedPlan = `
+Terraform used the selected providers to generate the following execution
+plan. Resource actions are indicated with the following symbols:
+  + create
+
+Terraform will perform the following actions:
+
+  # example_widget.test will be created
+  + resource "example_widget" "test" {
+      + id       = (known after apply)
+      + name     = "example"
+      + password = (sensitive value)
+    }
+
+Plan: 1 to add, 0 to change, 0 to destroy.
+`
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		expectedError error
+	}{
+		"contains": {
+			planCheck: planrender.Contains(`+ password = (sensitive value)`),
+		},
+		"not-contains": {
+			planCheck:     planrender.Contains(`+ password = "secret"`),
+			expectedError: fmt.Errorf("expected rendered plan to contain %q, got:\n\n%s", `+ password = "secret"`, testRenderedPlan),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{RenderedPlan: testRenderedPlan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
+
+func TestRequiresRenderedPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		planCheck plancheck.PlanCheck
+		expected  bool
+	}{
+		"contains": {
+			planCheck: planrender.Contains("example"),
+			expected:  true,
+		},
+		"all": {
+			planCheck: plancheck.All(plancheck.ExpectEmptyPlan(), planrender.Contains("example")),
+			expected:  true,
+		},
+		"any": {
+			planCheck: plancheck.Any(planrender.Snapshot("testdata/example.plan")),
+			expected:  true,
+		},
+		"not": {
+			planCheck: plancheck.Not(planrender.Contains("example")),
+			expected:  true,
+		},
+		"all-without-planrender": {
+			planCheck: plancheck.All(plancheck.ExpectEmptyPlan()),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			p, ok := testCase.planCheck.(plancheck.PlanCheckWithRenderedPlan)
+
+			if !ok {
+				t.Fatalf("expected PlanCheckWithRenderedPlan, got %T", testCase.planCheck)
+			}
+
+			if got := p.RequiresRenderedPlan(); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
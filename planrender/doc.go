@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package planrender contains plan checks which assert the human-readable
+// rendering of a plan, as shown by the `terraform show` command, such as
+// sensitive value masking and unknown value rendering which are not visible
+// in the machine-readable plan.
+package planrender
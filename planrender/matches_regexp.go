@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planrender
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+var _ plancheck.PlanCheckWithRenderedPlan = matchesRegexpCheck{}
+
+type matchesRegexpCheck struct {
+	regex *regexp.Regexp
+}
+
+// CheckPlan implements the plan check logic.
+func (c matchesRegexpCheck) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	if c.regex == nil {
+		resp.Error = fmt.Errorf("regular expression is nil")
+
+		return
+	}
+
+	if !c.regex.MatchString(req.RenderedPlan) {
+		resp.Error = fmt.Errorf("expected rendered plan to match %q, got:\n\n%s", c.regex, req.RenderedPlan)
+	}
+}
+
+// RequiresRenderedPlan implements the PlanCheckWithRenderedPlan interface.
+func (c matchesRegexpCheck) RequiresRenderedPlan() bool {
+	return true
+}
+
+// MatchesRegexp returns a plan check that asserts that the rendered plan
+// matches the given regular expression, such as
+// regexp.MustCompile(`id\s+= \(known after apply\)`).
+func MatchesRegexp(regex *regexp.Regexp) plancheck.PlanCheck {
+	return matchesRegexpCheck{
+		regex: regex,
+	}
+}
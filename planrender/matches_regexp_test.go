@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planrender_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/planrender"
+)
+
+func TestMatchesRegexp(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		planCheck     plancheck.PlanCheck
+		expectedError error
+	}{
+		"matches": {
+			planCheck: planrender.MatchesRegexp(regexp.MustCompile(`id\s+= \(known after apply\)`)),
+		},
+		"not-matches": {
+			planCheck:     planrender.MatchesRegexp(regexp.MustCompile(`name\s+= \(known after apply\)`)),
+			expectedError: fmt.Errorf("expected rendered plan to match %q, got:\n\n%s", `name\s+= \(known after apply\)`, testRenderedPlan),
+		},
+		"nil": {
+			planCheck:     planrender.MatchesRegexp(nil),
+			expectedError: fmt.Errorf("regular expression is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := plancheck.CheckPlanResponse{}
+
+			testCase.planCheck.CheckPlan(context.Background(), plancheck.CheckPlanRequest{RenderedPlan: testRenderedPlan}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planrender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// EnvTfAccUpdateSnapshots is the environment variable which, if set to any
+// value, writes the rendered plans of Snapshot plan checks to their snapshot
+// files instead of comparing them.
+const EnvTfAccUpdateSnapshots = "TF_ACC_UPDATE_SNAPSHOTS"
+
+var _ plancheck.PlanCheckWithRenderedPlan = snapshotCheck{}
+
+type snapshotCheck struct {
+	path string
+}
+
+// CheckPlan implements the plan check logic.
+func (c snapshotCheck) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	got := normalize(req.RenderedPlan)
+
+	if os.Getenv(EnvTfAccUpdateSnapshots) != "" {
+		if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+			resp.Error = fmt.Errorf("unable to create snapshot directory: %w", err)
+
+			return
+		}
+
+		if err := os.WriteFile(c.path, []byte(got), 0644); err != nil {
+			resp.Error = fmt.Errorf("unable to write snapshot %s: %w", c.path, err)
+		}
+
+		return
+	}
+
+	b, err := os.ReadFile(c.path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		resp.Error = fmt.Errorf("snapshot %s not found, set the %s environment variable to create it", c.path, EnvTfAccUpdateSnapshots)
+
+		return
+	}
+
+	if err != nil {
+		resp.Error = fmt.Errorf("unable to read snapshot %s: %w", c.path, err)
+
+		return
+	}
+
+	if diff := cmp.Diff(strings.Split(normalize(string(b)), "\n"), strings.Split(got, "\n")); diff != "" {
+		resp.Error = fmt.Errorf("rendered plan does not match snapshot %s, set the %s environment variable to update it (-snapshot +rendered):\n%s", c.path, EnvTfAccUpdateSnapshots, diff)
+	}
+}
+
+// RequiresRenderedPlan implements the PlanCheckWithRenderedPlan interface.
+func (c snapshotCheck) RequiresRenderedPlan() bool {
+	return true
+}
+
+// Snapshot returns a plan check that asserts that the rendered plan matches
+// the contents of the given snapshot file, such as
+// "testdata/TestAccExampleWidget_sensitive.plan". Line endings and trailing
+// whitespace are ignored.
+//
+// Set the TF_ACC_UPDATE_SNAPSHOTS environment variable to write the rendered
+// plan to the snapshot file instead, creating it if necessary, then review
+// the changes to the file.
+func Snapshot(path string) plancheck.PlanCheck {
+	return snapshotCheck{
+		path: path,
+	}
+}
+
+// normalize removes carriage returns and trailing whitespace from each line
+// and the end of the rendered plan, so snapshots are stable across platforms
+// and editors.
+func normalize(renderedPlan string) string {
+	lines := strings.Split(strings.ReplaceAll(renderedPlan, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planrender_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/planrender"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example.plan")
+
+	// Trailing whitespace and line endings are ignored.
+	snapshot := strings.ReplaceAll(strings.TrimPrefix(testRenderedPlan, "\n"), "\n", "  \r\n")
+
+	if err := os.WriteFile(path, []byte("\n"+snapshot), 0600); err != nil {
+		t.Fatalf("unexpected error writing snapshot: %s", err)
+	}
+
+	resp := plancheck.CheckPlanResponse{}
+
+	planrender.Snapshot(path).CheckPlan(context.Background(), plancheck.CheckPlanRequest{RenderedPlan: testRenderedPlan}, &resp)
+
+	if resp.Error != nil {
+		t.Errorf("unexpected error: %s", resp.Error)
+	}
+
+	resp = plancheck.CheckPlanResponse{}
+
+	planrender.Snapshot(path).CheckPlan(context.Background(), plancheck.CheckPlanRequest{RenderedPlan: strings.Replace(testRenderedPlan, "1 to add", "2 to add", 1)}, &resp)
+
+	if resp.Error == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(resp.Error.Error(), "rendered plan does not match snapshot") {
+		t.Errorf("unexpected error: %s", resp.Error)
+	}
+}
+
+func TestSnapshot_NotFound(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing.plan")
+
+	resp := plancheck.CheckPlanResponse{}
+
+	planrender.Snapshot(path).CheckPlan(context.Background(), plancheck.CheckPlanRequest{RenderedPlan: testRenderedPlan}, &resp)
+
+	expected := "snapshot " + path + " not found, set the TF_ACC_UPDATE_SNAPSHOTS environment variable to create it"
+
+	if resp.Error == nil || resp.Error.Error() != expected {
+		t.Errorf("expected error %q, got: %v", expected, resp.Error)
+	}
+}
+
+//nolint:paralleltest // Can't use t.Parallel with t.Setenv
+func TestSnapshot_Update(t *testing.T) {
+	t.Setenv(planrender.EnvTfAccUpdateSnapshots, "1")
+
+	path := filepath.Join(t.TempDir(), "testdata", "example.plan")
+
+	resp := plancheck.CheckPlanResponse{}
+
+	planrender.Snapshot(path).CheckPlan(context.Background(), plancheck.CheckPlanRequest{RenderedPlan: testRenderedPlan}, &resp)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	got, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("unexpected error reading snapshot: %s", err)
+	}
+
+	if string(got) != testRenderedPlan {
+		t.Errorf("expected snapshot %q, got: %q", testRenderedPlan, string(got))
+	}
+}
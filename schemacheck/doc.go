@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package schemacheck contains the schema check interface, request/response
+// structs, and common schema check implementations, which assert conventions
+// of the schemas of a provider, such as naming and descriptions, without
+// running Terraform.
+package schemacheck
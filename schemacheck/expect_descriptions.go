@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ SchemaCheck = expectDescriptions{}
+
+type expectDescriptions struct{}
+
+// CheckSchema implements the schema check logic.
+func (e expectDescriptions) CheckSchema(ctx context.Context, req CheckSchemaRequest, resp *CheckSchemaResponse) {
+	if req.Schema == nil {
+		resp.Error = fmt.Errorf("schema is nil")
+
+		return
+	}
+
+	var result *multierror.Error
+
+	walkProviderSchema(req.Schema, func(element schemaElement) {
+		if strings.TrimSpace(element.description) == "" {
+			result = multierror.Append(result, fmt.Errorf("%s - expected description", element.location))
+		}
+	})
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// ExpectDescriptions returns a schema check that asserts that all resources,
+// data sources, attributes, and nested blocks have a description, which is
+// used for documentation generation and language server hover text. The
+// description of the provider configuration block itself is not checked.
+func ExpectDescriptions() SchemaCheck {
+	return expectDescriptions{}
+}
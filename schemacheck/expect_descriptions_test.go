@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/schemacheck"
+)
+
+func TestExpectDescriptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		schema        *tfjson.ProviderSchema
+		expectedError error
+	}{
+		"valid": {
+			schema: &tfjson.ProviderSchema{
+				ConfigSchema: &tfjson.Schema{
+					Block: &tfjson.SchemaBlock{
+						Attributes: map[string]*tfjson.SchemaAttribute{
+							"region": {Description: "Region of the API endpoint."},
+						},
+					},
+				},
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {
+						Block: &tfjson.SchemaBlock{
+							Description: "Manages a thing.",
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"name": {Description: "Name of the thing."},
+							},
+							NestedBlocks: map[string]*tfjson.SchemaBlockType{
+								"rule": {
+									Block: &tfjson.SchemaBlock{
+										Description: "Rules of the thing.",
+										Attributes: map[string]*tfjson.SchemaAttribute{
+											"port": {Description: "Port of the rule."},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"missing": {
+			schema: &tfjson.ProviderSchema{
+				ConfigSchema: &tfjson.Schema{
+					Block: &tfjson.SchemaBlock{
+						Attributes: map[string]*tfjson.SchemaAttribute{
+							"region": {},
+						},
+					},
+				},
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"name": {Description: " "},
+							},
+							NestedBlocks: map[string]*tfjson.SchemaBlockType{
+								"rule": {
+									Block: &tfjson.SchemaBlock{
+										Description: "Rules of the thing.",
+										Attributes: map[string]*tfjson.SchemaAttribute{
+											"port": {},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("4 errors occurred:\n" +
+				"\t* provider attribute region - expected description\n" +
+				"\t* resource examplecloud_thing - expected description\n" +
+				"\t* resource examplecloud_thing attribute name - expected description\n" +
+				"\t* resource examplecloud_thing attribute rule.port - expected description\n\n"),
+		},
+		"nil": {
+			expectedError: fmt.Errorf("schema is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := schemacheck.CheckSchemaResponse{}
+
+			schemacheck.ExpectDescriptions().CheckSchema(context.Background(), schemacheck.CheckSchemaRequest{ProviderName: "examplecloud", Schema: testCase.schema}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ SchemaCheck = expectServerCapabilities{}
+
+type expectServerCapabilities struct {
+	capabilities ServerCapabilities
+}
+
+// CheckSchema implements the schema check logic.
+func (e expectServerCapabilities) CheckSchema(ctx context.Context, req CheckSchemaRequest, resp *CheckSchemaResponse) {
+	if e.capabilities.PlanDestroy && !req.ServerCapabilities.PlanDestroy {
+		resp.Error = fmt.Errorf("%s - expected server capability plan_destroy, but it was not advertised", req.ProviderName)
+	}
+}
+
+// ExpectServerCapabilities returns a schema check that asserts that the
+// provider advertises every server capability which is enabled in the given
+// ServerCapabilities, such as to verify that combining providers with
+// terraform-plugin-mux or migrating them to terraform-plugin-framework does
+// not remove a capability. Capabilities which are not enabled in the given
+// ServerCapabilities are not checked.
+func ExpectServerCapabilities(capabilities ServerCapabilities) SchemaCheck {
+	return expectServerCapabilities{
+		capabilities: capabilities,
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/schemacheck"
+)
+
+func TestExpectServerCapabilities(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		expected      schemacheck.ServerCapabilities
+		advertised    schemacheck.ServerCapabilities
+		expectedError error
+	}{
+		"plan-destroy": {
+			expected:   schemacheck.ServerCapabilities{PlanDestroy: true},
+			advertised: schemacheck.ServerCapabilities{PlanDestroy: true},
+		},
+		"plan-destroy-missing": {
+			expected:      schemacheck.ServerCapabilities{PlanDestroy: true},
+			advertised:    schemacheck.ServerCapabilities{},
+			expectedError: fmt.Errorf("examplecloud - expected server capability plan_destroy, but it was not advertised"),
+		},
+		"not-checked": {
+			expected:   schemacheck.ServerCapabilities{},
+			advertised: schemacheck.ServerCapabilities{PlanDestroy: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := schemacheck.CheckSchemaResponse{}
+
+			schemacheck.ExpectServerCapabilities(testCase.expected).CheckSchema(context.Background(), schemacheck.CheckSchemaRequest{ProviderName: "examplecloud", ServerCapabilities: testCase.advertised}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
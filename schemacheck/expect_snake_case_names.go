@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// snakeCaseNameRegex matches lowercase names with words separated by
+// underscores, such as "example_attribute".
+var snakeCaseNameRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+var _ SchemaCheck = expectSnakeCaseNames{}
+
+type expectSnakeCaseNames struct{}
+
+// CheckSchema implements the schema check logic.
+func (e expectSnakeCaseNames) CheckSchema(ctx context.Context, req CheckSchemaRequest, resp *CheckSchemaResponse) {
+	if req.Schema == nil {
+		resp.Error = fmt.Errorf("schema is nil")
+
+		return
+	}
+
+	var result *multierror.Error
+
+	walkProviderSchema(req.Schema, func(element schemaElement) {
+		if !snakeCaseNameRegex.MatchString(element.name) {
+			result = multierror.Append(result, fmt.Errorf("%s - expected snake case name, got: %s", element.location, element.name))
+		}
+	})
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// ExpectSnakeCaseNames returns a schema check that asserts that all resource
+// and data source type names, attribute names, and block names are lowercase
+// with words separated by underscores, such as "example_attribute".
+func ExpectSnakeCaseNames() SchemaCheck {
+	return expectSnakeCaseNames{}
+}
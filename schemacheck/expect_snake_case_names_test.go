@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/schemacheck"
+)
+
+func TestExpectSnakeCaseNames(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		schema        *tfjson.ProviderSchema
+		expectedError error
+	}{
+		"valid": {
+			schema: &tfjson.ProviderSchema{
+				ConfigSchema: &tfjson.Schema{
+					Block: &tfjson.SchemaBlock{
+						Attributes: map[string]*tfjson.SchemaAttribute{
+							"api_token": {},
+						},
+					},
+				},
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"name": {},
+								"rule_set": {
+									AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+										Attributes: map[string]*tfjson.SchemaAttribute{
+											"port2": {},
+										},
+									},
+								},
+							},
+							NestedBlocks: map[string]*tfjson.SchemaBlockType{
+								"timeouts": {
+									Block: &tfjson.SchemaBlock{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"invalid": {
+			schema: &tfjson.ProviderSchema{
+				ConfigSchema: &tfjson.Schema{
+					Block: &tfjson.SchemaBlock{
+						Attributes: map[string]*tfjson.SchemaAttribute{
+							"apiToken": {},
+						},
+					},
+				},
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_Thing": {
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"rule_set": {
+									AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+										Attributes: map[string]*tfjson.SchemaAttribute{
+											"port-range": {},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				DataSourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {
+						Block: &tfjson.SchemaBlock{
+							NestedBlocks: map[string]*tfjson.SchemaBlockType{
+								"Filter_": {
+									Block: &tfjson.SchemaBlock{},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: fmt.Errorf("4 errors occurred:\n" +
+				"\t* provider attribute apiToken - expected snake case name, got: apiToken\n" +
+				"\t* resource examplecloud_Thing - expected snake case name, got: examplecloud_Thing\n" +
+				"\t* resource examplecloud_Thing attribute rule_set.port-range - expected snake case name, got: port-range\n" +
+				"\t* data source examplecloud_thing block Filter_ - expected snake case name, got: Filter_\n\n"),
+		},
+		"nil": {
+			expectedError: fmt.Errorf("schema is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := schemacheck.CheckSchemaResponse{}
+
+			schemacheck.ExpectSnakeCaseNames().CheckSchema(context.Background(), schemacheck.CheckSchemaRequest{ProviderName: "examplecloud", Schema: testCase.schema}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
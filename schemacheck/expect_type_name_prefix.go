@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ SchemaCheck = expectTypeNamePrefix{}
+
+type expectTypeNamePrefix struct{}
+
+// CheckSchema implements the schema check logic.
+func (e expectTypeNamePrefix) CheckSchema(ctx context.Context, req CheckSchemaRequest, resp *CheckSchemaResponse) {
+	if req.Schema == nil {
+		resp.Error = fmt.Errorf("schema is nil")
+
+		return
+	}
+
+	prefix := req.ProviderName + "_"
+
+	var result *multierror.Error
+
+	walkProviderSchema(req.Schema, func(element schemaElement) {
+		if element.kind != schemaElementKindResource && element.kind != schemaElementKindDataSource {
+			return
+		}
+
+		if !strings.HasPrefix(element.name, prefix) {
+			result = multierror.Append(result, fmt.Errorf("%s - expected type name prefix %s", element.location, prefix))
+		}
+	})
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// ExpectTypeNamePrefix returns a schema check that asserts that all resource
+// and data source type names are prefixed with the provider name and an
+// underscore, such as "examplecloud_thing" for the examplecloud provider.
+func ExpectTypeNamePrefix() SchemaCheck {
+	return expectTypeNamePrefix{}
+}
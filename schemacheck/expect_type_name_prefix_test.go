@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/schemacheck"
+)
+
+func TestExpectTypeNamePrefix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		schema        *tfjson.ProviderSchema
+		expectedError error
+	}{
+		"valid": {
+			schema: &tfjson.ProviderSchema{
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {},
+				},
+				DataSourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {},
+				},
+			},
+		},
+		"invalid": {
+			schema: &tfjson.ProviderSchema{
+				ResourceSchemas: map[string]*tfjson.Schema{
+					"examplecloud_thing": {},
+					"example_thing":      {},
+				},
+				DataSourceSchemas: map[string]*tfjson.Schema{
+					"examplecloudthing": {},
+				},
+			},
+			expectedError: fmt.Errorf("2 errors occurred:\n" +
+				"\t* resource example_thing - expected type name prefix examplecloud_\n" +
+				"\t* data source examplecloudthing - expected type name prefix examplecloud_\n\n"),
+		},
+		"nil": {
+			expectedError: fmt.Errorf("schema is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := schemacheck.CheckSchemaResponse{}
+
+			schemacheck.ExpectTypeNamePrefix().CheckSchema(context.Background(), schemacheck.CheckSchemaRequest{ProviderName: "examplecloud", Schema: testCase.schema}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
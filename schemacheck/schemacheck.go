@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck
+
+import (
+	"context"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// SchemaCheck defines an interface for implementing test logic that checks
+// the schemas of a provider and then returns an error if the schemas do not
+// match what is expected.
+type SchemaCheck interface {
+	// CheckSchema should perform the schema check.
+	CheckSchema(context.Context, CheckSchemaRequest, *CheckSchemaResponse)
+}
+
+// CheckSchemaRequest is a request for an invoke of the CheckSchema function.
+type CheckSchemaRequest struct {
+	// ProviderName is the local name of the provider, such as
+	// "examplecloud", as configured in the provider factories.
+	ProviderName string
+
+	// Schema represents the provider, resource, and data source schemas
+	// returned by the provider.
+	Schema *tfjson.ProviderSchema
+
+	// ServerCapabilities are the optional protocol features advertised by
+	// the provider along with its schemas.
+	ServerCapabilities ServerCapabilities
+}
+
+// ServerCapabilities are the optional protocol features which a provider
+// advertises in its GetProviderSchema response.
+type ServerCapabilities struct {
+	// PlanDestroy signals that the provider expects PlanResourceChange to be
+	// called when a resource is going to be destroyed.
+	PlanDestroy bool
+}
+
+// CheckSchemaResponse is a response to an invoke of the CheckSchema function.
+type CheckSchemaResponse struct {
+	// Error is used to report the failure of a schema check assertion and is
+	// combined with other SchemaCheck errors to be reported as a test failure.
+	Error error
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacheck
+
+import (
+	"fmt"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// schemaElementKind is the kind of a schemaElement.
+type schemaElementKind string
+
+const (
+	schemaElementKindAttribute  schemaElementKind = "attribute"
+	schemaElementKindBlock      schemaElementKind = "block"
+	schemaElementKindDataSource schemaElementKind = "data source"
+	schemaElementKindResource   schemaElementKind = "resource"
+)
+
+// schemaElement is a resource, data source, attribute, or nested block of a
+// provider schema, as visited by walkProviderSchema.
+type schemaElement struct {
+	// kind is the kind of the element.
+	kind schemaElementKind
+
+	// name is the resource or data source type name, or the attribute or
+	// block name.
+	name string
+
+	// location describes where the element is declared for error messages,
+	// such as "resource examplecloud_thing attribute rule.ports".
+	location string
+
+	// description is the description of the element.
+	description string
+}
+
+// walkProviderSchema calls the given function with each resource, data
+// source, attribute, and nested block of the provider schema, in a stable
+// order. Nested attributes and blocks are visited after their parent.
+func walkProviderSchema(schema *tfjson.ProviderSchema, f func(schemaElement)) {
+	if schema == nil {
+		return
+	}
+
+	if schema.ConfigSchema != nil {
+		walkSchemaBlock(schema.ConfigSchema.Block, "provider", "", f)
+	}
+
+	walkSchemas(schema.ResourceSchemas, schemaElementKindResource, f)
+	walkSchemas(schema.DataSourceSchemas, schemaElementKindDataSource, f)
+}
+
+// walkSchemas visits the resource or data source schemas by type name.
+func walkSchemas(schemas map[string]*tfjson.Schema, kind schemaElementKind, f func(schemaElement)) {
+	typeNames := make([]string, 0, len(schemas))
+
+	for typeName := range schemas {
+		typeNames = append(typeNames, typeName)
+	}
+
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		location := fmt.Sprintf("%s %s", kind, typeName)
+		element := schemaElement{
+			kind:     kind,
+			name:     typeName,
+			location: location,
+		}
+
+		var block *tfjson.SchemaBlock
+
+		if schema := schemas[typeName]; schema != nil {
+			block = schema.Block
+		}
+
+		if block != nil {
+			element.description = block.Description
+		}
+
+		f(element)
+
+		walkSchemaBlock(block, location, "", f)
+	}
+}
+
+// walkSchemaBlock visits the attributes and nested blocks of the block,
+// where prefix is the dotted path of the block, if nested.
+func walkSchemaBlock(block *tfjson.SchemaBlock, location string, prefix string, f func(schemaElement)) {
+	if block == nil {
+		return
+	}
+
+	walkSchemaAttributes(block.Attributes, location, prefix, f)
+
+	blockNames := make([]string, 0, len(block.NestedBlocks))
+
+	for blockName := range block.NestedBlocks {
+		blockNames = append(blockNames, blockName)
+	}
+
+	sort.Strings(blockNames)
+
+	for _, blockName := range blockNames {
+		var nestedBlock *tfjson.SchemaBlock
+
+		if blockType := block.NestedBlocks[blockName]; blockType != nil {
+			nestedBlock = blockType.Block
+		}
+
+		element := schemaElement{
+			kind:     schemaElementKindBlock,
+			name:     blockName,
+			location: fmt.Sprintf("%s block %s", location, prefix+blockName),
+		}
+
+		if nestedBlock != nil {
+			element.description = nestedBlock.Description
+		}
+
+		f(element)
+
+		walkSchemaBlock(nestedBlock, location, prefix+blockName+".", f)
+	}
+}
+
+// walkSchemaAttributes visits the attributes, including nested attributes,
+// where prefix is the dotted path of the parent, if nested.
+func walkSchemaAttributes(attributes map[string]*tfjson.SchemaAttribute, location string, prefix string, f func(schemaElement)) {
+	attributeNames := make([]string, 0, len(attributes))
+
+	for attributeName := range attributes {
+		attributeNames = append(attributeNames, attributeName)
+	}
+
+	sort.Strings(attributeNames)
+
+	for _, attributeName := range attributeNames {
+		attribute := attributes[attributeName]
+		element := schemaElement{
+			kind:     schemaElementKindAttribute,
+			name:     attributeName,
+			location: fmt.Sprintf("%s attribute %s", location, prefix+attributeName),
+		}
+
+		if attribute != nil {
+			element.description = attribute.Description
+		}
+
+		f(element)
+
+		if attribute != nil && attribute.AttributeNestedType != nil {
+			walkSchemaAttributes(attribute.AttributeNestedType.Attributes, location, prefix+attributeName+".", f)
+		}
+	}
+}
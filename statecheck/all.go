@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ StateCheck = allCheck{}
+
+type allCheck struct {
+	stateChecks []StateCheck
+}
+
+// CheckState implements the state check logic.
+func (a allCheck) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	var result *multierror.Error
+
+	for i, stateCheck := range a.stateChecks {
+		checkResp := CheckStateResponse{}
+
+		stateCheck.CheckState(ctx, req, &checkResp)
+
+		if checkResp.Error != nil {
+			result = multierror.Append(result, fmt.Errorf("state check %d/%d error: %w", i+1, len(a.stateChecks), checkResp.Error))
+		}
+	}
+
+	resp.Error = result.ErrorOrNil()
+}
+
+// All returns a state check that runs every given state check and passes only
+// if all of them pass, aggregating all failures into a single error. It
+// groups state checks so they can be used where a single state check is
+// expected, such as within Any or Not.
+func All(stateChecks ...StateCheck) StateCheck {
+	return allCheck{
+		stateChecks: stateChecks,
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ StateCheck = anyCheck{}
+
+type anyCheck struct {
+	stateChecks []StateCheck
+}
+
+// CheckState implements the state check logic.
+func (a anyCheck) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	var result *multierror.Error
+
+	for i, stateCheck := range a.stateChecks {
+		checkResp := CheckStateResponse{}
+
+		stateCheck.CheckState(ctx, req, &checkResp)
+
+		if checkResp.Error == nil {
+			return
+		}
+
+		result = multierror.Append(result, fmt.Errorf("state check %d/%d error: %w", i+1, len(a.stateChecks), checkResp.Error))
+	}
+
+	if result == nil {
+		resp.Error = fmt.Errorf("no state checks given")
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("expected any state check to pass, but none did: %w", result)
+}
+
+// Any returns a state check that runs the given state checks in order and
+// passes as soon as one of them passes. If none of the state checks pass, the
+// failures of all of them are aggregated into a single error.
+func Any(stateChecks ...StateCheck) StateCheck {
+	return anyCheck{
+		stateChecks: stateChecks,
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// compareValue collects attribute values from the state of multiple
+// TestStep, with state checks returned by AddStateValue, and compares each
+// collected value with the values collected before it using a
+// compare.ValueComparer.
+type compareValue struct {
+	comparer compare.ValueComparer
+
+	mu     sync.Mutex
+	values []interface{}
+}
+
+// CompareValue returns a value collector for state checks, which compares
+// the collected values with the given compare.ValueComparer, such as
+// compare.ValuesDiffer() to verify that a resource was replaced between
+// TestStep:
+//
+//	compareIDChange := statecheck.CompareValue(compare.ValuesDiffer())
+//
+//	// in each TestStep
+//	ConfigStateChecks: []statecheck.StateCheck{
+//		compareIDChange.AddStateValue("example_widget.test", tfjsonpath.New("id")),
+//	},
+//
+// Call CompareValue within each test function, as the collected values are
+// kept for the life of the returned value collector.
+func CompareValue(comparer compare.ValueComparer) *compareValue {
+	return &compareValue{
+		comparer: comparer,
+	}
+}
+
+// AddStateValue returns a state check which collects the value of the
+// attribute at the given path of the resource, then compares all values
+// collected so far. The first collected value is not compared.
+func (c *compareValue) AddStateValue(resourceAddress string, attributePath tfjsonpath.Path) StateCheck {
+	return compareValueAddStateValue{
+		compareValue:    c,
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+	}
+}
+
+var _ StateCheck = compareValueAddStateValue{}
+
+type compareValueAddStateValue struct {
+	compareValue    *compareValue
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+}
+
+// CheckState implements the state check logic.
+func (e compareValueAddStateValue) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	resource, err := stateResource(req.State, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(resource.AttributeValues, e.attributePath)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddress, err)
+
+		return
+	}
+
+	c := e.compareValue
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values = append(c.values, result)
+
+	if err := c.comparer.CompareValues(c.values...); err != nil {
+		resp.Error = fmt.Errorf("%s - error comparing value for attribute at path %s: %w", e.resourceAddress, e.attributePath, err)
+	}
+}
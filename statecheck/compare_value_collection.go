@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = compareValueCollection{}
+
+type compareValueCollection struct {
+	resourceAddressOne string
+	collectionPath     []tfjsonpath.Path
+	resourceAddressTwo string
+	attributePath      tfjsonpath.Path
+	comparer           compare.ValueComparer
+}
+
+// CheckState implements the state check logic.
+func (e compareValueCollection) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	if len(e.collectionPath) == 0 {
+		resp.Error = fmt.Errorf("%s - collection path is empty", e.resourceAddressOne)
+
+		return
+	}
+
+	resourceOne, err := stateResource(req.State, e.resourceAddressOne)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	collection, err := tfjsonpath.Traverse(resourceOne.AttributeValues, e.collectionPath[0])
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddressOne, err)
+
+		return
+	}
+
+	elements, ok := collection.([]interface{})
+
+	if !ok {
+		resp.Error = fmt.Errorf("%s - expected list or set at path %s, got: %T", e.resourceAddressOne, e.collectionPath[0], collection)
+
+		return
+	}
+
+	// Each further path is traversed from every element, expanding any
+	// nested list or set, such as an attribute of each object in a list.
+	for _, path := range e.collectionPath[1:] {
+		var next []interface{}
+
+		for _, element := range elements {
+			value, err := tfjsonpath.Traverse(element, path)
+
+			if err != nil {
+				resp.Error = fmt.Errorf("%s - %w", e.resourceAddressOne, err)
+
+				return
+			}
+
+			if nested, ok := value.([]interface{}); ok {
+				next = append(next, nested...)
+
+				continue
+			}
+
+			next = append(next, value)
+		}
+
+		elements = next
+	}
+
+	resourceTwo, err := stateResource(req.State, e.resourceAddressTwo)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	value, err := tfjsonpath.Traverse(resourceTwo.AttributeValues, e.attributePath)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddressTwo, err)
+
+		return
+	}
+
+	for _, element := range elements {
+		if err := e.comparer.CompareValues(element, value); err == nil {
+			return
+		}
+	}
+
+	resp.Error = fmt.Errorf("%s - no element of collection at path %s matches %s.%s: %v", e.resourceAddressOne, e.collectionPath[0], e.resourceAddressTwo, e.attributePath, value)
+}
+
+// CompareValueCollection returns a state check that compares each element of
+// the list or set attribute at the collection path of the first resource with
+// the value of the attribute at the attribute path of the second resource,
+// using the given compare.ValueComparer. The check passes if the comparison
+// passes for any element, such as to assert that an association resource
+// holds the identifier of another resource:
+//
+//	statecheck.CompareValueCollection(
+//		"example_group.test", []tfjsonpath.Path{tfjsonpath.New("member_ids")},
+//		"example_user.test", tfjsonpath.New("id"),
+//		compare.ValuesSame(),
+//	)
+//
+// Additional collection paths are traversed from each element of the
+// collection, expanding any nested list or set, such as
+// []tfjsonpath.Path{tfjsonpath.New("members"), tfjsonpath.New("id")} for the
+// id attribute of each object in a members list.
+func CompareValueCollection(resourceAddressOne string, collectionPath []tfjsonpath.Path, resourceAddressTwo string, attributePath tfjsonpath.Path, comparer compare.ValueComparer) StateCheck {
+	return compareValueCollection{
+		resourceAddressOne: resourceAddressOne,
+		collectionPath:     collectionPath,
+		resourceAddressTwo: resourceAddressTwo,
+		attributePath:      attributePath,
+		comparer:           comparer,
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestCompareValueCollection(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address: "test_user.one",
+						AttributeValues: map[string]interface{}{
+							"id":   "user-1",
+							"name": "one",
+						},
+					},
+					{
+						Address: "test_group.one",
+						AttributeValues: map[string]interface{}{
+							"member_ids": []interface{}{
+								"user-2",
+								"user-1",
+							},
+							"members": []interface{}{
+								map[string]interface{}{
+									"id":      "user-1",
+									"aliases": []interface{}{"first", "one"},
+								},
+							},
+							"name": "group",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		expectedError error
+	}{
+		"list": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", []tfjsonpath.Path{tfjsonpath.New("member_ids")},
+				"test_user.one", tfjsonpath.New("id"),
+				compare.ValuesSame(),
+			),
+		},
+		"list-no-match": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", []tfjsonpath.Path{tfjsonpath.New("member_ids")},
+				"test_user.one", tfjsonpath.New("name"),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("test_group.one - no element of collection at path member_ids matches test_user.one.name: one"),
+		},
+		"nested-object-attribute": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", []tfjsonpath.Path{tfjsonpath.New("members"), tfjsonpath.New("id")},
+				"test_user.one", tfjsonpath.New("id"),
+				compare.ValuesSame(),
+			),
+		},
+		"nested-list": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", []tfjsonpath.Path{tfjsonpath.New("members"), tfjsonpath.New("aliases")},
+				"test_user.one", tfjsonpath.New("name"),
+				compare.ValuesSame(),
+			),
+		},
+		"not-collection": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", []tfjsonpath.Path{tfjsonpath.New("name")},
+				"test_user.one", tfjsonpath.New("id"),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("test_group.one - expected list or set at path name, got: string"),
+		},
+		"empty-collection-path": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", nil,
+				"test_user.one", tfjsonpath.New("id"),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("test_group.one - collection path is empty"),
+		},
+		"resource-not-found": {
+			stateCheck: statecheck.CompareValueCollection(
+				"test_group.one", []tfjsonpath.Path{tfjsonpath.New("member_ids")},
+				"test_user.two", tfjsonpath.New("id"),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("test_user.two - Resource not found in state"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = compareValuePairs{}
+
+type compareValuePairs struct {
+	resourceAddressOne string
+	attributePathOne   tfjsonpath.Path
+	resourceAddressTwo string
+	attributePathTwo   tfjsonpath.Path
+	comparer           compare.ValueComparer
+}
+
+// CheckState implements the state check logic.
+func (e compareValuePairs) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	resourceOne, err := stateResource(req.State, e.resourceAddressOne)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	resultOne, err := tfjsonpath.Traverse(resourceOne.AttributeValues, e.attributePathOne)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddressOne, err)
+
+		return
+	}
+
+	resourceTwo, err := stateResource(req.State, e.resourceAddressTwo)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	resultTwo, err := tfjsonpath.Traverse(resourceTwo.AttributeValues, e.attributePathTwo)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddressTwo, err)
+
+		return
+	}
+
+	if err := e.comparer.CompareValues(resultOne, resultTwo); err != nil {
+		resp.Error = fmt.Errorf("error comparing %s.%s and %s.%s: %w", e.resourceAddressOne, e.attributePathOne, e.resourceAddressTwo, e.attributePathTwo, err)
+	}
+}
+
+// CompareValuePairs returns a state check that compares the value of the
+// attribute at the first path of the first resource with the value of the
+// attribute at the second path of the second resource, within the same state,
+// using the given compare.ValueComparer. For example, to assert that a
+// reference attribute holds the ARN of another resource:
+//
+//	statecheck.CompareValuePairs(
+//		"example_topic.test", tfjsonpath.New("arn"),
+//		"example_subscription.test", tfjsonpath.New("topic_arn"),
+//		compare.ValuesSame(),
+//	)
+//
+// The resource addresses may be the same to compare two attributes of a
+// single resource.
+func CompareValuePairs(resourceAddressOne string, attributePathOne tfjsonpath.Path, resourceAddressTwo string, attributePathTwo tfjsonpath.Path, comparer compare.ValueComparer) StateCheck {
+	return compareValuePairs{
+		resourceAddressOne: resourceAddressOne,
+		attributePathOne:   attributePathOne,
+		resourceAddressTwo: resourceAddressTwo,
+		attributePathTwo:   attributePathTwo,
+		comparer:           comparer,
+	}
+}
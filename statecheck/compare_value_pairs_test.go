@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestCompareValuePairs(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address: "test_topic.one",
+						AttributeValues: map[string]interface{}{
+							"arn": "arn:test:topic/one",
+						},
+					},
+					{
+						Address: "test_subscription.one",
+						AttributeValues: map[string]interface{}{
+							"topic_arn": "arn:test:topic/one",
+							"targets": []interface{}{
+								"arn:test:queue/one",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		expectedError error
+	}{
+		"values-same": {
+			stateCheck: statecheck.CompareValuePairs(
+				"test_topic.one", tfjsonpath.New("arn"),
+				"test_subscription.one", tfjsonpath.New("topic_arn"),
+				compare.ValuesSame(),
+			),
+		},
+		"values-same-error": {
+			stateCheck: statecheck.CompareValuePairs(
+				"test_topic.one", tfjsonpath.New("arn"),
+				"test_subscription.one", tfjsonpath.New("targets").AtSliceIndex(0),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("error comparing test_topic.one.arn and test_subscription.one.targets.0: expected values to be the same, but value 2 differs: arn:test:topic/one != arn:test:queue/one"),
+		},
+		"values-differ": {
+			stateCheck: statecheck.CompareValuePairs(
+				"test_subscription.one", tfjsonpath.New("topic_arn"),
+				"test_subscription.one", tfjsonpath.New("targets").AtSliceIndex(0),
+				compare.ValuesDiffer(),
+			),
+		},
+		"resource-not-found": {
+			stateCheck: statecheck.CompareValuePairs(
+				"test_topic.one", tfjsonpath.New("arn"),
+				"test_subscription.two", tfjsonpath.New("topic_arn"),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("test_subscription.two - Resource not found in state"),
+		},
+		"path-not-found": {
+			stateCheck: statecheck.CompareValuePairs(
+				"test_topic.one", tfjsonpath.New("id"),
+				"test_subscription.one", tfjsonpath.New("topic_arn"),
+				compare.ValuesSame(),
+			),
+			expectedError: fmt.Errorf("test_topic.one - path not found: specified key id not found in map at id"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func testCompareValueState(id string) *tfjson.State {
+	return &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address: "test_resource.one",
+						AttributeValues: map[string]interface{}{
+							"id": id,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCompareValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		comparer       compare.ValueComparer
+		states         []*tfjson.State
+		attributePath  tfjsonpath.Path
+		expectedErrors []error
+	}{
+		"values-same": {
+			comparer:       compare.ValuesSame(),
+			states:         []*tfjson.State{testCompareValueState("one"), testCompareValueState("one")},
+			attributePath:  tfjsonpath.New("id"),
+			expectedErrors: []error{nil, nil},
+		},
+		"values-same-error": {
+			comparer:      compare.ValuesSame(),
+			states:        []*tfjson.State{testCompareValueState("one"), testCompareValueState("two")},
+			attributePath: tfjsonpath.New("id"),
+			expectedErrors: []error{
+				nil,
+				fmt.Errorf("test_resource.one - error comparing value for attribute at path id: expected values to be the same, but value 2 differs: one != two"),
+			},
+		},
+		"values-differ": {
+			comparer:       compare.ValuesDiffer(),
+			states:         []*tfjson.State{testCompareValueState("one"), testCompareValueState("two"), testCompareValueState("three")},
+			attributePath:  tfjsonpath.New("id"),
+			expectedErrors: []error{nil, nil, nil},
+		},
+		"values-differ-error": {
+			comparer:      compare.ValuesDiffer(),
+			states:        []*tfjson.State{testCompareValueState("one"), testCompareValueState("one")},
+			attributePath: tfjsonpath.New("id"),
+			expectedErrors: []error{
+				nil,
+				fmt.Errorf("test_resource.one - error comparing value for attribute at path id: expected values to differ, but value 2 is the same as value 1: one"),
+			},
+		},
+		"path-not-found": {
+			comparer:      compare.ValuesSame(),
+			states:        []*tfjson.State{testCompareValueState("one")},
+			attributePath: tfjsonpath.New("missing"),
+			expectedErrors: []error{
+				fmt.Errorf("test_resource.one - path not found: specified key missing not found in map at missing"),
+			},
+		},
+		"state-nil": {
+			comparer:       compare.ValuesSame(),
+			states:         []*tfjson.State{nil},
+			attributePath:  tfjsonpath.New("id"),
+			expectedErrors: []error{fmt.Errorf("state is nil")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			compareValue := statecheck.CompareValue(testCase.comparer)
+
+			for i, state := range testCase.states {
+				resp := statecheck.CheckStateResponse{}
+
+				compareValue.AddStateValue("test_resource.one", testCase.attributePath).CheckState(context.Background(), statecheck.CheckStateRequest{State: state}, &resp)
+
+				expectedError := testCase.expectedErrors[i]
+
+				if resp.Error == nil && expectedError != nil {
+					t.Fatalf("state %d: expected error: %s", i+1, expectedError)
+				}
+
+				if resp.Error != nil && expectedError == nil {
+					t.Fatalf("state %d: unexpected error: %s", i+1, resp.Error)
+				}
+
+				if resp.Error != nil && resp.Error.Error() != expectedError.Error() {
+					t.Errorf("state %d: expected error %q, got: %s", i+1, expectedError, resp.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package statecheck contains the state check interface, request/response structs, and common state check implementations.
+package statecheck
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+var _ StateCheck = expectCheckBlockPassed{}
+
+type expectCheckBlockPassed struct {
+	checkAddress string
+}
+
+// CheckState implements the state check logic.
+func (e expectCheckBlockPassed) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	if req.State == nil {
+		resp.Error = fmt.Errorf("state is nil")
+
+		return
+	}
+
+	for _, check := range req.State.Checks {
+		if check.Address.ToDisplay != e.checkAddress {
+			continue
+		}
+
+		if check.Status != tfjson.CheckStatusPass {
+			resp.Error = fmt.Errorf("%s - expected check block to pass, got status: %s%s", e.checkAddress, check.Status, checkProblems(check))
+		}
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s - Check block not found in state Checks", e.checkAddress)
+}
+
+// ExpectCheckBlockPassed returns a state check that asserts that the check
+// block with the given address, such as "check.health" or
+// "module.example.check.health", passed when the state was last updated,
+// such as by an apply. The check fails if the check block status is fail,
+// error, or unknown. Requires Terraform 1.5 or later.
+func ExpectCheckBlockPassed(checkAddress string) StateCheck {
+	return expectCheckBlockPassed{
+		checkAddress: checkAddress,
+	}
+}
+
+// checkProblems returns the failure messages of the check instances, if any,
+// formatted for an error message.
+func checkProblems(check tfjson.CheckResultStatic) string {
+	var messages []string
+
+	for _, instance := range check.Instances {
+		for _, problem := range instance.Problems {
+			messages = append(messages, problem.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return ""
+	}
+
+	return "\n\n" + strings.Join(messages, "\n")
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestExpectCheckBlockPassed(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Checks: []tfjson.CheckResultStatic{
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.pass", Kind: tfjson.CheckKindCheckBlock, Name: "pass"},
+				Status:  tfjson.CheckStatusPass,
+			},
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.fail", Kind: tfjson.CheckKindCheckBlock, Name: "fail"},
+				Status:  tfjson.CheckStatusFail,
+				Instances: []tfjson.CheckResultDynamic{
+					{
+						Address: tfjson.CheckDynamicAddress{ToDisplay: "check.fail"},
+						Status:  tfjson.CheckStatusFail,
+						Problems: []tfjson.CheckResultProblem{
+							{Message: "Widget is not healthy."},
+						},
+					},
+				},
+			},
+			{
+				Address: tfjson.CheckStaticAddress{ToDisplay: "check.unknown", Kind: tfjson.CheckKindCheckBlock, Name: "unknown"},
+				Status:  tfjson.CheckStatusUnknown,
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		state         *tfjson.State
+		expectedError error
+	}{
+		"pass": {
+			stateCheck: statecheck.ExpectCheckBlockPassed("check.pass"),
+			state:      state,
+		},
+		"fail": {
+			stateCheck:    statecheck.ExpectCheckBlockPassed("check.fail"),
+			state:         state,
+			expectedError: fmt.Errorf("check.fail - expected check block to pass, got status: fail\n\nWidget is not healthy."),
+		},
+		"unknown": {
+			stateCheck:    statecheck.ExpectCheckBlockPassed("check.unknown"),
+			state:         state,
+			expectedError: fmt.Errorf("check.unknown - expected check block to pass, got status: unknown"),
+		},
+		"not-found": {
+			stateCheck:    statecheck.ExpectCheckBlockPassed("check.missing"),
+			state:         state,
+			expectedError: fmt.Errorf("check.missing - Check block not found in state Checks"),
+		},
+		"state-nil": {
+			stateCheck:    statecheck.ExpectCheckBlockPassed("check.pass"),
+			expectedError: fmt.Errorf("state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+)
+
+var _ StateCheck = expectKnownOutputValue{}
+
+type expectKnownOutputValue struct {
+	outputAddress string
+	knownValue    knownvalue.Check
+}
+
+// CheckState implements the state check logic.
+func (e expectKnownOutputValue) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	output, err := stateOutput(req.State, e.outputAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(output.Value); err != nil {
+		resp.Error = fmt.Errorf("%s - error checking output value: %w%s", e.outputAddress, err, knownValueDiff(e.knownValue, output.Value))
+	}
+}
+
+// ExpectKnownOutputValue returns a state check that asserts that the
+// specified root module output has the given known value, such as
+// knownvalue.ListExact() for a list output. Unlike TestCheckOutput, the
+// output value is not limited to strings.
+func ExpectKnownOutputValue(outputAddress string, knownValue knownvalue.Check) StateCheck {
+	return expectKnownOutputValue{
+		outputAddress: outputAddress,
+		knownValue:    knownValue,
+	}
+}
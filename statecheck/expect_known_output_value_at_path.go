@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = expectKnownOutputValueAtPath{}
+
+type expectKnownOutputValueAtPath struct {
+	outputAddress string
+	outputPath    tfjsonpath.Path
+	knownValue    knownvalue.Check
+}
+
+// CheckState implements the state check logic.
+func (e expectKnownOutputValueAtPath) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	output, err := stateOutput(req.State, e.outputAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(output.Value, e.outputPath)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.outputAddress, err)
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(result); err != nil {
+		resp.Error = fmt.Errorf("%s - error checking value for output at path %s: %w%s", e.outputAddress, e.outputPath, err, knownValueDiff(e.knownValue, result))
+	}
+}
+
+// ExpectKnownOutputValueAtPath returns a state check that asserts that the
+// value at the given path of the specified root module output has the given
+// known value, such as an attribute of an output containing a whole resource.
+//
+// Nested values are addressed with the output path, for example
+// tfjsonpath.New("list_attribute").AtSliceIndex(0) for the first element of
+// a list attribute.
+func ExpectKnownOutputValueAtPath(outputAddress string, outputPath tfjsonpath.Path, knownValue knownvalue.Check) StateCheck {
+	return expectKnownOutputValueAtPath{
+		outputAddress: outputAddress,
+		outputPath:    outputPath,
+		knownValue:    knownValue,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectKnownOutputValueAtPath(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			Outputs: map[string]*tfjson.StateOutput{
+				"resource": {
+					Value: map[string]interface{}{
+						"name": "example",
+						"rule": []interface{}{
+							map[string]interface{}{
+								"port": json.Number("443"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		state         *tfjson.State
+		expectedError error
+	}{
+		"attribute": {
+			stateCheck: statecheck.ExpectKnownOutputValueAtPath("resource", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			state:      state,
+		},
+		"nested": {
+			stateCheck: statecheck.ExpectKnownOutputValueAtPath("resource", tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("port"), knownvalue.Int64Exact(443)),
+			state:      state,
+		},
+		"mismatch": {
+			stateCheck:    statecheck.ExpectKnownOutputValueAtPath("resource", tfjsonpath.New("name"), knownvalue.StringExact("other")),
+			state:         state,
+			expectedError: fmt.Errorf("resource - error checking value for output at path name: expected value other for StringExact check, got: example"),
+		},
+		"path-not-found": {
+			stateCheck:    statecheck.ExpectKnownOutputValueAtPath("resource", tfjsonpath.New("missing"), knownvalue.StringExact("example")),
+			state:         state,
+			expectedError: fmt.Errorf("resource - path not found: specified key missing not found in map at missing"),
+		},
+		"output-not-found": {
+			stateCheck:    statecheck.ExpectKnownOutputValueAtPath("missing", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			state:         state,
+			expectedError: fmt.Errorf("missing - Output not found in state"),
+		},
+		"state-nil": {
+			stateCheck:    statecheck.ExpectKnownOutputValueAtPath("resource", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			expectedError: fmt.Errorf("state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
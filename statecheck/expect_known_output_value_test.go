@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestExpectKnownOutputValue(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			Outputs: map[string]*tfjson.StateOutput{
+				"name": {
+					Value: "example",
+				},
+				"ports": {
+					Value: []interface{}{json.Number("80"), json.Number("443")},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		state         *tfjson.State
+		expectedError error
+	}{
+		"string": {
+			stateCheck: statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("example")),
+			state:      state,
+		},
+		"list": {
+			stateCheck: statecheck.ExpectKnownOutputValue("ports", knownvalue.ListExact([]knownvalue.Check{
+				knownvalue.Int64Exact(80),
+				knownvalue.Int64Exact(443),
+			})),
+			state: state,
+		},
+		"mismatch": {
+			stateCheck:    statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("other")),
+			state:         state,
+			expectedError: fmt.Errorf("name - error checking output value: expected value other for StringExact check, got: example"),
+		},
+		"output-not-found": {
+			stateCheck:    statecheck.ExpectKnownOutputValue("missing", knownvalue.StringExact("example")),
+			state:         state,
+			expectedError: fmt.Errorf("missing - Output not found in state"),
+		},
+		"state-values-nil": {
+			stateCheck:    statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("example")),
+			state:         &tfjson.State{},
+			expectedError: fmt.Errorf("state does not contain any state values"),
+		},
+		"state-nil": {
+			stateCheck:    statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("example")),
+			expectedError: fmt.Errorf("state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = expectKnownValue{}
+
+type expectKnownValue struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+	knownValue      knownvalue.Check
+}
+
+// CheckState implements the state check logic.
+func (e expectKnownValue) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	resource, err := stateResource(req.State, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	if e.attributePath.HasAnyStep() {
+		results, err := tfjsonpath.TraverseAll(resource.AttributeValues, e.attributePath)
+
+		if err != nil {
+			resp.Error = fmt.Errorf("%s - %w", e.resourceAddress, err)
+
+			return
+		}
+
+		if err := checkAnyKnownValue(e.knownValue, results); err != nil {
+			resp.Error = fmt.Errorf("%s - error checking value for attribute at path %s: %w", e.resourceAddress, e.attributePath, err)
+		}
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(resource.AttributeValues, e.attributePath)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - %w", e.resourceAddress, err)
+
+		return
+	}
+
+	if err := e.knownValue.CheckValue(result); err != nil {
+		resp.Error = fmt.Errorf("%s - error checking value for attribute at path %s: %w%s", e.resourceAddress, e.attributePath, err, knownValueDiff(e.knownValue, result))
+	}
+}
+
+// ExpectKnownValue returns a state check that asserts that the specified
+// attribute at the given resource has the given known value.
+//
+// Nested values are addressed with the attribute path, for example
+// tfjsonpath.New("list_attribute").AtSliceIndex(0) for the first element of
+// a list. A path with an any-element step, such as
+// tfjsonpath.New("set_attribute").AtAnySliceIndex(), asserts that at least
+// one of the matched values has the given known value, such as for set
+// elements, whose indices are not stable.
+func ExpectKnownValue(resourceAddress string, attributePath tfjsonpath.Path, knownValue knownvalue.Check) StateCheck {
+	return expectKnownValue{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+		knownValue:      knownValue,
+	}
+}
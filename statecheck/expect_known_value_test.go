@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectKnownValue(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address: "test_resource.one",
+						AttributeValues: map[string]interface{}{
+							"name":  "example",
+							"count": json.Number("2"),
+							"rule": []interface{}{
+								map[string]interface{}{
+									"ports": []interface{}{"80", "443"},
+								},
+							},
+						},
+					},
+				},
+				ChildModules: []*tfjson.StateModule{
+					{
+						Address: "module.child",
+						Resources: []*tfjson.StateResource{
+							{
+								Address: "module.child.test_resource.one",
+								AttributeValues: map[string]interface{}{
+									"name": "child",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		state         *tfjson.State
+		expectedError error
+	}{
+		"string": {
+			stateCheck: statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			state:      state,
+		},
+		"number": {
+			stateCheck: statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("count"), knownvalue.Int64Exact(2)),
+			state:      state,
+		},
+		"nested": {
+			stateCheck: statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("ports").AtSliceIndex(1), knownvalue.StringExact("443")),
+			state:      state,
+		},
+		"child-module": {
+			stateCheck: statecheck.ExpectKnownValue("module.child.test_resource.one", tfjsonpath.New("name"), knownvalue.StringExact("child")),
+			state:      state,
+		},
+		"mismatch": {
+			stateCheck:    statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("name"), knownvalue.StringExact("other")),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - error checking value for attribute at path name: expected value other for StringExact check, got: example"),
+		},
+		"any-slice-index": {
+			stateCheck: statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("rule").AtAnySliceIndex().AtMapKey("ports").AtAnySliceIndex(), knownvalue.StringExact("443")),
+			state:      state,
+		},
+		"any-slice-index-mismatch": {
+			stateCheck:    statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("rule").AtAnySliceIndex().AtMapKey("ports").AtAnySliceIndex(), knownvalue.StringExact("8080")),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - error checking value for attribute at path rule[*].ports[*]: expected any of 2 value(s) to match 8080"),
+		},
+		"any-slice-index-not-found": {
+			stateCheck:    statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("rule").AtAnySliceIndex().AtMapKey("missing"), knownvalue.StringExact("example")),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - path not found: no elements found at rule[*].missing"),
+		},
+		"path-not-found": {
+			stateCheck:    statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("missing"), knownvalue.StringExact("example")),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - path not found: specified key missing not found in map at missing"),
+		},
+		"resource-not-found": {
+			stateCheck:    statecheck.ExpectKnownValue("test_resource.two", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.two - Resource not found in state"),
+		},
+		"state-nil": {
+			stateCheck:    statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("name"), knownvalue.StringExact("example")),
+			expectedError: fmt.Errorf("state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
+
+func TestExpectKnownValue_Diff(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address: "test_resource.one",
+						AttributeValues: map[string]interface{}{
+							"rule": []interface{}{
+								map[string]interface{}{
+									"port":     json.Number("443"),
+									"protocol": "tcp",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	stateCheck := statecheck.ExpectKnownValue("test_resource.one", tfjsonpath.New("rule"), knownvalue.ListExact([]knownvalue.Check{
+		knownvalue.ObjectExact(map[string]knownvalue.Check{
+			"port":     knownvalue.Int64Exact(80),
+			"protocol": knownvalue.StringExact("udp"),
+		}),
+	}))
+
+	resp := statecheck.CheckStateResponse{}
+
+	stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: state}, &resp)
+
+	if resp.Error == nil {
+		t.Fatal("expected error")
+	}
+
+	// The go-cmp output format, such as whitespace, is not stable.
+	got := strings.Join(strings.Fields(resp.Error.Error()), " ")
+
+	for _, expected := range []string{
+		"test_resource.one - error checking value for attribute at path rule: list element index 0: port object attribute: expected value 80 for Int64Exact check, got: 443",
+		"value differences (-expected +got):",
+		`"port": string("80")`,
+		`"port": string("443")`,
+		`"protocol": string("udp")`,
+		`"protocol": string("tcp")`,
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("expected error to contain %q, got: %s", expected, got)
+		}
+	}
+}
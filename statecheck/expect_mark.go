@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = expectMark{}
+
+type expectMark struct {
+	resourceAddress string
+	attributePath   tfjsonpath.Path
+	mark            Mark
+}
+
+// CheckState implements the state check logic.
+func (e expectMark) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	resource, err := stateResource(req.State, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	markValues, err := resourceMarkValues(resource, e.mark)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	result, err := tfjsonpath.Traverse(markValues, e.attributePath)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - attribute at path %s is not %s", e.resourceAddress, e.attributePath, e.mark)
+
+		return
+	}
+
+	isMarked, ok := result.(bool)
+
+	if !ok || !isMarked {
+		resp.Error = fmt.Errorf("%s - attribute at path %s is not %s", e.resourceAddress, e.attributePath, e.mark)
+	}
+}
+
+// ExpectMark returns a state check that asserts that the specified attribute
+// at the given resource has the given mark, such as MarkSensitive.
+//
+// Nested values are addressed with the attribute path, for example
+// tfjsonpath.New("list_attribute").AtSliceIndex(0) for the first element of
+// a list. An attribute is not considered marked if only values nested
+// beneath it are marked.
+func ExpectMark(resourceAddress string, attributePath tfjsonpath.Path, mark Mark) StateCheck {
+	return expectMark{
+		resourceAddress: resourceAddress,
+		attributePath:   attributePath,
+		mark:            mark,
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectMark(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address:         "test_resource.one",
+						SensitiveValues: json.RawMessage(`{"password":true,"list":[false,true],"nested":{"key":{}}}`),
+					},
+					{
+						Address: "test_resource.two",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		state         *tfjson.State
+		expectedError error
+	}{
+		"marked": {
+			stateCheck: statecheck.ExpectMark("test_resource.one", tfjsonpath.New("password"), statecheck.MarkSensitive),
+			state:      state,
+		},
+		"marked-slice-index": {
+			stateCheck: statecheck.ExpectMark("test_resource.one", tfjsonpath.New("list").AtSliceIndex(1), statecheck.MarkSensitive),
+			state:      state,
+		},
+		"not-marked-slice-index": {
+			stateCheck:    statecheck.ExpectMark("test_resource.one", tfjsonpath.New("list").AtSliceIndex(0), statecheck.MarkSensitive),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - attribute at path list.0 is not sensitive"),
+		},
+		"not-marked-nested": {
+			stateCheck:    statecheck.ExpectMark("test_resource.one", tfjsonpath.New("nested"), statecheck.MarkSensitive),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - attribute at path nested is not sensitive"),
+		},
+		"not-marked-missing": {
+			stateCheck:    statecheck.ExpectMark("test_resource.one", tfjsonpath.New("name"), statecheck.MarkSensitive),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - attribute at path name is not sensitive"),
+		},
+		"no-marks": {
+			stateCheck:    statecheck.ExpectMark("test_resource.two", tfjsonpath.New("password"), statecheck.MarkSensitive),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.two - attribute at path password is not sensitive"),
+		},
+		"unsupported-mark": {
+			stateCheck:    statecheck.ExpectMark("test_resource.one", tfjsonpath.New("password"), statecheck.Mark("ephemeral")),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.one - mark \"ephemeral\" is not supported in Terraform JSON state"),
+		},
+		"resource-not-found": {
+			stateCheck:    statecheck.ExpectMark("test_resource.three", tfjsonpath.New("password"), statecheck.MarkSensitive),
+			state:         state,
+			expectedError: fmt.Errorf("test_resource.three - Resource not found in state"),
+		},
+		"state-nil": {
+			stateCheck:    statecheck.ExpectMark("test_resource.one", tfjsonpath.New("password"), statecheck.MarkSensitive),
+			expectedError: fmt.Errorf("state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var _ StateCheck = expectOnlyChangedPaths{}
+
+type expectOnlyChangedPaths struct {
+	resourceAddress string
+	attributePaths  []tfjsonpath.Path
+}
+
+// CheckState implements the state check logic.
+func (e expectOnlyChangedPaths) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	if req.PriorState == nil {
+		resp.Error = fmt.Errorf("%s - prior state is nil", e.resourceAddress)
+
+		return
+	}
+
+	priorResource, err := stateResource(req.PriorState, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = fmt.Errorf("%s - Resource not found in prior state", e.resourceAddress)
+
+		return
+	}
+
+	resource, err := stateResource(req.State, e.resourceAddress)
+
+	if err != nil {
+		resp.Error = err
+
+		return
+	}
+
+	for _, attributePath := range e.attributePaths {
+		priorValue, _ := tfjsonpath.Traverse(priorResource.AttributeValues, attributePath)
+		value, _ := tfjsonpath.Traverse(resource.AttributeValues, attributePath)
+
+		if reflect.DeepEqual(priorValue, value) {
+			resp.Error = fmt.Errorf("%s - expected change for attribute at path %s, got: unchanged", e.resourceAddress, attributePath)
+
+			return
+		}
+	}
+
+	var unexpected []string
+
+	for _, key := range unionKeys(priorResource.AttributeValues, resource.AttributeValues) {
+		unexpected = append(unexpected, e.unexpectedChangedPaths(priorResource.AttributeValues[key], resource.AttributeValues[key], tfjsonpath.New(key))...)
+	}
+
+	if len(unexpected) > 0 {
+		resp.Error = fmt.Errorf("%s - unexpected change for attribute(s) at path(s): %s", e.resourceAddress, strings.Join(unexpected, ", "))
+	}
+}
+
+// unexpectedChangedPaths returns the paths of the changed values between the
+// prior value and the value at the given path, excluding the expected
+// attribute paths and any values nested within them.
+func (e expectOnlyChangedPaths) unexpectedChangedPaths(priorValue interface{}, value interface{}, path tfjsonpath.Path) []string {
+	for _, attributePath := range e.attributePaths {
+		if path.Equal(attributePath) {
+			return nil
+		}
+	}
+
+	var unexpected []string
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		priorMap, ok := priorValue.(map[string]interface{})
+
+		if !ok {
+			break
+		}
+
+		for _, key := range unionKeys(priorMap, value) {
+			unexpected = append(unexpected, e.unexpectedChangedPaths(priorMap[key], value[key], path.AtMapKey(key))...)
+		}
+
+		return unexpected
+	case []interface{}:
+		priorSlice, ok := priorValue.([]interface{})
+
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(priorSlice) || i < len(value); i++ {
+			var priorElement, element interface{}
+
+			if i < len(priorSlice) {
+				priorElement = priorSlice[i]
+			}
+
+			if i < len(value) {
+				element = value[i]
+			}
+
+			unexpected = append(unexpected, e.unexpectedChangedPaths(priorElement, element, path.AtSliceIndex(i))...)
+		}
+
+		return unexpected
+	}
+
+	if !reflect.DeepEqual(priorValue, value) {
+		unexpected = append(unexpected, path.String())
+	}
+
+	return unexpected
+}
+
+// unionKeys returns the sorted keys of both maps.
+func unionKeys(priorValue map[string]interface{}, value map[string]interface{}) []string {
+	keys := make([]string, 0, len(value))
+
+	for key := range value {
+		keys = append(keys, key)
+	}
+
+	for key := range priorValue {
+		if _, ok := value[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ExpectOnlyChangedPaths returns a state check that asserts that the
+// attribute values of the specified resource changed at exactly the given
+// attribute paths since the prior state, which is the state before the
+// TestStep was applied. Each given attribute path, or a value nested within
+// it, must have changed, while any other change fails the check. This can
+// verify that an update only touched the intended attributes.
+//
+// Use this state check in ConfigStateChecks of a TestStep after the TestStep
+// which creates the resource.
+func ExpectOnlyChangedPaths(resourceAddress string, attributePaths ...tfjsonpath.Path) StateCheck {
+	return expectOnlyChangedPaths{
+		resourceAddress: resourceAddress,
+		attributePaths:  attributePaths,
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExpectOnlyChangedPaths(t *testing.T) {
+	t.Parallel()
+
+	stateWithValues := func(attributeValues map[string]interface{}) *tfjson.State {
+		return &tfjson.State{
+			Values: &tfjson.StateValues{
+				RootModule: &tfjson.StateModule{
+					Resources: []*tfjson.StateResource{
+						{
+							Address:         "test_resource.one",
+							AttributeValues: attributeValues,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	priorState := stateWithValues(map[string]interface{}{
+		"id":    "one",
+		"name":  "example",
+		"count": json.Number("2"),
+		"tags": map[string]interface{}{
+			"env": "test",
+		},
+		"rule": []interface{}{
+			map[string]interface{}{
+				"ports": []interface{}{"80", "443"},
+			},
+		},
+	})
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		priorState    *tfjson.State
+		state         *tfjson.State
+		expectedError error
+	}{
+		"changed": {
+			stateCheck: statecheck.ExpectOnlyChangedPaths("test_resource.one", tfjsonpath.New("name")),
+			priorState: priorState,
+			state: stateWithValues(map[string]interface{}{
+				"id":    "one",
+				"name":  "updated",
+				"count": json.Number("2"),
+				"tags": map[string]interface{}{
+					"env": "test",
+				},
+				"rule": []interface{}{
+					map[string]interface{}{
+						"ports": []interface{}{"80", "443"},
+					},
+				},
+			}),
+		},
+		"changed-nested": {
+			stateCheck: statecheck.ExpectOnlyChangedPaths("test_resource.one", tfjsonpath.New("tags"), tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("ports")),
+			priorState: priorState,
+			state: stateWithValues(map[string]interface{}{
+				"id":    "one",
+				"name":  "example",
+				"count": json.Number("2"),
+				"tags": map[string]interface{}{
+					"env":  "test",
+					"team": "example",
+				},
+				"rule": []interface{}{
+					map[string]interface{}{
+						"ports": []interface{}{"80", "443", "8080"},
+					},
+				},
+			}),
+		},
+		"unchanged": {
+			stateCheck:    statecheck.ExpectOnlyChangedPaths("test_resource.one", tfjsonpath.New("name")),
+			priorState:    priorState,
+			state:         priorState,
+			expectedError: fmt.Errorf("test_resource.one - expected change for attribute at path name, got: unchanged"),
+		},
+		"unexpected-changes": {
+			stateCheck: statecheck.ExpectOnlyChangedPaths("test_resource.one", tfjsonpath.New("name")),
+			priorState: priorState,
+			state: stateWithValues(map[string]interface{}{
+				"id":   "two",
+				"name": "updated",
+				"tags": map[string]interface{}{
+					"env": "prod",
+				},
+				"rule": []interface{}{
+					map[string]interface{}{
+						"ports": []interface{}{"80"},
+					},
+				},
+			}),
+			expectedError: fmt.Errorf("test_resource.one - unexpected change for attribute(s) at path(s): count, id, rule.0.ports.1, tags.env"),
+		},
+		"resource-not-found-prior-state": {
+			stateCheck:    statecheck.ExpectOnlyChangedPaths("test_resource.two", tfjsonpath.New("name")),
+			priorState:    priorState,
+			state:         priorState,
+			expectedError: fmt.Errorf("test_resource.two - Resource not found in prior state"),
+		},
+		"resource-not-found": {
+			stateCheck:    statecheck.ExpectOnlyChangedPaths("test_resource.one", tfjsonpath.New("name")),
+			priorState:    priorState,
+			state:         &tfjson.State{Values: &tfjson.StateValues{RootModule: &tfjson.StateModule{}}},
+			expectedError: fmt.Errorf("test_resource.one - Resource not found in state"),
+		},
+		"prior-state-nil": {
+			stateCheck:    statecheck.ExpectOnlyChangedPaths("test_resource.one", tfjsonpath.New("name")),
+			state:         priorState,
+			expectedError: fmt.Errorf("test_resource.one - prior state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: testCase.state, PriorState: testCase.priorState}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ StateCheck = labelCheck{}
+
+type labelCheck struct {
+	label      string
+	stateCheck StateCheck
+}
+
+// CheckState implements the state check logic.
+func (l labelCheck) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	checkResp := CheckStateResponse{}
+
+	l.stateCheck.CheckState(ctx, req, &checkResp)
+
+	if checkResp.Error != nil {
+		resp.Error = fmt.Errorf("%s: %w", l.label, checkResp.Error)
+	}
+}
+
+// Label returns a state check that runs the given state check and prefixes
+// any failure with the given label, such as "primary widget". Labels make
+// failures easier to identify when many state checks fail at once.
+func Label(label string, stateCheck StateCheck) StateCheck {
+	return labelCheck{
+		label:      label,
+		stateCheck: stateCheck,
+	}
+}
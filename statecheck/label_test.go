@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestLabel(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{
+						Address:         "test_resource.one",
+						SensitiveValues: json.RawMessage(`{"password":true}`),
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		stateCheck    statecheck.StateCheck
+		expectedError error
+	}{
+		"passes": {
+			stateCheck: statecheck.Label("primary", statecheck.ExpectMark("test_resource.one", tfjsonpath.New("password"), statecheck.MarkSensitive)),
+		},
+		"fails": {
+			stateCheck:    statecheck.Label("primary", statecheck.ExpectMark("test_resource.one", tfjsonpath.New("name"), statecheck.MarkSensitive)),
+			expectedError: fmt.Errorf("primary: test_resource.one - attribute at path name is not sensitive"),
+		},
+		"nested": {
+			stateCheck: statecheck.Label("outer", statecheck.All(
+				statecheck.Label("inner", statecheck.ExpectMark("test_resource.one", tfjsonpath.New("name"), statecheck.MarkSensitive)),
+			)),
+			expectedError: fmt.Errorf("outer: 1 error occurred:\n\t* state check 1/1 error: inner: test_resource.one - attribute at path name is not sensitive\n\n"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := statecheck.CheckStateResponse{}
+
+			testCase.stateCheck.CheckState(context.Background(), statecheck.CheckStateRequest{State: state}, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %q", testCase.expectedError, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Mark is the name of a value mark, which Terraform applies to values in
+// addition to their type and content, such as sensitivity.
+//
+// In Terraform JSON state, each mark is represented by an object alongside
+// the resource values, such as "sensitive_values", where marked values are
+// true and unmarked values are omitted.
+type Mark string
+
+const (
+	// MarkSensitive is the mark for values which Terraform redacts from
+	// output, represented by the "sensitive_values" object in state.
+	MarkSensitive Mark = "sensitive"
+)
+
+// resourceMarkValues returns the decoded object for the given mark in the
+// resource, or an error if the mark is not available in Terraform JSON state.
+//
+// As Terraform adds new marks to its JSON output, they only need to be added
+// here to be usable with existing mark checks, such as ExpectMark.
+func resourceMarkValues(resource *tfjson.StateResource, mark Mark) (interface{}, error) {
+	var raw json.RawMessage
+
+	switch mark {
+	case MarkSensitive:
+		raw = resource.SensitiveValues
+	default:
+		return nil, fmt.Errorf("%s - mark %q is not supported in Terraform JSON state", resource.Address, mark)
+	}
+
+	// Terraform omits the mark object when no values are marked.
+	if len(raw) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	var values interface{}
+
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("%s - unable to decode %s values: %w", resource.Address, mark, err)
+	}
+
+	return values, nil
+}
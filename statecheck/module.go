@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// ModuleResourceAddresses returns the addresses of the resources in the
+// module with the given address, such as "module.child" or
+// "module.child[0].module.grandchild", and in its child modules, such as
+// "module.child.example_widget.test". An empty module address returns the
+// addresses of all resources in the state.
+//
+// The addresses can be used with state checks, such as in a loop creating an
+// ExpectKnownValue state check for each resource of a module in a test
+// fixture. An error is returned if the module is not found in the state.
+func ModuleResourceAddresses(state *tfjson.State, moduleAddress string) ([]string, error) {
+	rootModule, err := stateRootModule(state)
+
+	if err != nil {
+		return nil, err
+	}
+
+	module := findModule(rootModule, moduleAddress)
+
+	if module == nil {
+		return nil, fmt.Errorf("%s - Module not found in state", moduleAddress)
+	}
+
+	var addresses []string
+
+	var walk func(*tfjson.StateModule)
+
+	walk = func(module *tfjson.StateModule) {
+		for _, resource := range module.Resources {
+			addresses = append(addresses, resource.Address)
+		}
+
+		for _, childModule := range module.ChildModules {
+			walk(childModule)
+		}
+	}
+
+	walk(module)
+
+	return addresses, nil
+}
+
+// findModule returns the module with the given address from the module or
+// its child modules, or nil if the module is not found. The root module has
+// an empty address.
+func findModule(module *tfjson.StateModule, moduleAddress string) *tfjson.StateModule {
+	if module.Address == moduleAddress {
+		return module
+	}
+
+	for _, childModule := range module.ChildModules {
+		if found := findModule(childModule, moduleAddress); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestModuleResourceAddresses(t *testing.T) {
+	t.Parallel()
+
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				Resources: []*tfjson.StateResource{
+					{Address: "example_widget.root"},
+				},
+				ChildModules: []*tfjson.StateModule{
+					{
+						Address: "module.child",
+						Resources: []*tfjson.StateResource{
+							{Address: "module.child.example_widget.test"},
+						},
+						ChildModules: []*tfjson.StateModule{
+							{
+								Address: "module.child.module.grandchild",
+								Resources: []*tfjson.StateResource{
+									{Address: "module.child.module.grandchild.example_widget.test"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		state         *tfjson.State
+		moduleAddress string
+		expected      []string
+		expectedError error
+	}{
+		"all": {
+			state: state,
+			expected: []string{
+				"example_widget.root",
+				"module.child.example_widget.test",
+				"module.child.module.grandchild.example_widget.test",
+			},
+		},
+		"child-module": {
+			state:         state,
+			moduleAddress: "module.child",
+			expected: []string{
+				"module.child.example_widget.test",
+				"module.child.module.grandchild.example_widget.test",
+			},
+		},
+		"grandchild-module": {
+			state:         state,
+			moduleAddress: "module.child.module.grandchild",
+			expected: []string{
+				"module.child.module.grandchild.example_widget.test",
+			},
+		},
+		"module-not-found": {
+			state:         state,
+			moduleAddress: "module.missing",
+			expectedError: fmt.Errorf("module.missing - Module not found in state"),
+		},
+		"state-nil": {
+			expectedError: fmt.Errorf("state is nil"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := statecheck.ModuleResourceAddresses(testCase.state, testCase.moduleAddress)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ StateCheck = notCheck{}
+
+type notCheck struct {
+	stateCheck StateCheck
+}
+
+// CheckState implements the state check logic.
+func (n notCheck) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	checkResp := CheckStateResponse{}
+
+	n.stateCheck.CheckState(ctx, req, &checkResp)
+
+	if checkResp.Error == nil {
+		resp.Error = fmt.Errorf("expected state check to fail, but it passed")
+	}
+}
+
+// Not returns a state check that inverts the result of the given state check,
+// passing only when the given state check returns an error. For example,
+// Not(ExpectMark("example_widget.test", tfjsonpath.New("name"), MarkSensitive))
+// asserts that the name attribute is not marked as sensitive.
+func Not(stateCheck StateCheck) StateCheck {
+	return notCheck{
+		stateCheck: stateCheck,
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// stateOutput returns the root module output with the given address, such as
+// "example", from the state, or an error if the output is not found.
+func stateOutput(state *tfjson.State, outputAddress string) (*tfjson.StateOutput, error) {
+	if state == nil {
+		return nil, fmt.Errorf("state is nil")
+	}
+
+	if state.Values == nil {
+		return nil, fmt.Errorf("state does not contain any state values")
+	}
+
+	output, ok := state.Values.Outputs[outputAddress]
+
+	if !ok || output == nil {
+		return nil, fmt.Errorf("%s - Output not found in state", outputAddress)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import "context"
+
+var _ StateCheck = rawCheck{}
+
+// RawFunc is the function signature for a state check created with Raw. The
+// request contains the state retrieved via the `terraform show -json` command
+// along with details of the running TestStep. Set the response Error field
+// to report a failure.
+type RawFunc func(context.Context, CheckStateRequest, *CheckStateResponse)
+
+type rawCheck struct {
+	f RawFunc
+}
+
+// CheckState implements the state check logic.
+func (r rawCheck) CheckState(ctx context.Context, req CheckStateRequest, resp *CheckStateResponse) {
+	r.f(ctx, req, resp)
+}
+
+// Raw returns a state check that calls the given function with the raw state
+// and TestStep details. It is intended for one-off assertions which are not
+// covered by other state checks, without needing to declare a type which
+// implements the StateCheck interface.
+func Raw(f RawFunc) StateCheck {
+	return rawCheck{
+		f: f,
+	}
+}
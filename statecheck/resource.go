@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// stateResource returns the resource with the given address, such as
+// "example_widget.test" or "module.child.example_widget.test", from the root
+// module or a child module of the state, or an error if the resource is not
+// found.
+func stateResource(state *tfjson.State, resourceAddress string) (*tfjson.StateResource, error) {
+	rootModule, err := stateRootModule(state)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resource := moduleResource(rootModule, resourceAddress); resource != nil {
+		return resource, nil
+	}
+
+	return nil, fmt.Errorf("%s - Resource not found in state", resourceAddress)
+}
+
+// stateRootModule returns the root module of the state, or an error if the
+// state has no values.
+func stateRootModule(state *tfjson.State) (*tfjson.StateModule, error) {
+	if state == nil {
+		return nil, fmt.Errorf("state is nil")
+	}
+
+	if state.Values == nil {
+		return nil, fmt.Errorf("state does not contain any state values")
+	}
+
+	if state.Values.RootModule == nil {
+		return nil, fmt.Errorf("state does not contain a root module")
+	}
+
+	return state.Values.RootModule, nil
+}
+
+// moduleResource returns the resource with the given address from the module
+// or its child modules, or nil if the resource is not found. Resource
+// addresses in the state include the module address.
+func moduleResource(module *tfjson.StateModule, resourceAddress string) *tfjson.StateResource {
+	for _, resource := range module.Resources {
+		if resource.Address == resourceAddress {
+			return resource
+		}
+	}
+
+	for _, childModule := range module.ChildModules {
+		if resource := moduleResource(childModule, resourceAddress); resource != nil {
+			return resource
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statecheck
+
+import (
+	"context"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// StateCheck defines an interface for implementing test logic that checks a state file and then returns an error
+// if the state file does not match what is expected.
+type StateCheck interface {
+	// CheckState should perform the state check.
+	CheckState(context.Context, CheckStateRequest, *CheckStateResponse)
+}
+
+// CheckStateRequest is a request for an invoke of the CheckState function.
+type CheckStateRequest struct {
+	// State represents a parsed state file, retrieved via the `terraform show -json` command.
+	State *tfjson.State
+
+	// PriorState represents the parsed state file before the TestStep
+	// Config was applied, which is the state after the previous TestStep,
+	// such as for comparing resource attribute values across an update. It
+	// contains no resources for the first TestStep.
+	PriorState *tfjson.State
+
+	// StepNumber is the 1-based index of the TestStep in the TestCase.
+	StepNumber int
+
+	// StepName is the Name of the TestStep, if set.
+	StepName string
+
+	// TestName is the name of the Go test running the TestCase, as
+	// returned by testing.T Name().
+	TestName string
+}
+
+// CheckStateResponse is a response to an invoke of the CheckState function.
+type CheckStateResponse struct {
+	// Error is used to report the failure of a state check assertion and is combined with other StateCheck errors
+	// to be reported as a test failure.
+	Error error
+}
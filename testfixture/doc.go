@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package testfixture contains builders of terraform-json state and plan values, such as for unit testing custom
+// statecheck.StateCheck and plancheck.PlanCheck implementations without running Terraform.
+//
+// Values are encoded as JSON and decoded in the same manner as the terraform-json package decodes the output of the
+// Terraform CLI, so numbers are json.Number, lists and sets are []interface{}, and maps and objects are
+// map[string]interface{}, regardless of the Go types given to the builders.
+package testfixture
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testfixture
+
+import (
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PlanFormatVersion is the format version of plans returned by PlanBuilder.
+const PlanFormatVersion = "1.2"
+
+// Change is a planned change of a resource instance or output in a plan
+// built with PlanBuilder.
+type Change struct {
+	// Actions are the planned actions, such as tfjson.Actions{tfjson.ActionUpdate}.
+	Actions tfjson.Actions
+
+	// Before is the value before the change.
+	Before interface{}
+
+	// After is the value after the change, excluding unknown values.
+	After interface{}
+
+	// AfterUnknown marks the values which are unknown after the change,
+	// such as map[string]interface{}{"id": true}.
+	AfterUnknown interface{}
+
+	// BeforeSensitive marks the sensitive values before the change.
+	BeforeSensitive interface{}
+
+	// AfterSensitive marks the sensitive values after the change.
+	AfterSensitive interface{}
+}
+
+// PlanBuilder builds a *tfjson.Plan, as returned by the `terraform show
+// -json` command for a saved plan, with resource and output changes. Create
+// a PlanBuilder with NewPlan:
+//
+//	plan := testfixture.NewPlan().
+//		ResourceChange("examplecloud_thing.test", testfixture.Change{
+//			Actions: tfjson.Actions{tfjson.ActionCreate},
+//			After: map[string]interface{}{
+//				"name": "example",
+//			},
+//			AfterUnknown: map[string]interface{}{
+//				"id": true,
+//			},
+//		}).
+//		Build()
+type PlanBuilder struct {
+	plan *tfjson.Plan
+}
+
+// NewPlan returns a PlanBuilder of a plan without changes.
+func NewPlan() *PlanBuilder {
+	return &PlanBuilder{
+		plan: &tfjson.Plan{
+			FormatVersion: PlanFormatVersion,
+		},
+	}
+}
+
+// ResourceChange adds a change of the resource instance with the given
+// address, such as "examplecloud_thing.test",
+// "data.examplecloud_thing.test[0]", or
+// "module.child.examplecloud_thing.test". The provider name of the resource
+// defaults to the hashicorp namespace of the public registry and the resource
+// type prefix. It panics if the address is invalid or the values cannot be
+// encoded as JSON.
+func (b *PlanBuilder) ResourceChange(address string, change Change) *PlanBuilder {
+	parsedAddress := parseResourceAddress(address)
+
+	b.plan.ResourceChanges = append(b.plan.ResourceChanges, &tfjson.ResourceChange{
+		Address:       address,
+		ModuleAddress: parsedAddress.moduleAddress,
+		Mode:          parsedAddress.mode,
+		Type:          parsedAddress.resourceType,
+		Name:          parsedAddress.name,
+		Index:         parsedAddress.index,
+		ProviderName:  parsedAddress.providerName(),
+		Change:        change.tfjson(),
+	})
+
+	return b
+}
+
+// OutputChange adds a change of the root module output with the given name.
+// It panics if the values cannot be encoded as JSON.
+func (b *PlanBuilder) OutputChange(name string, change Change) *PlanBuilder {
+	if b.plan.OutputChanges == nil {
+		b.plan.OutputChanges = make(map[string]*tfjson.Change)
+	}
+
+	b.plan.OutputChanges[name] = change.tfjson()
+
+	return b
+}
+
+// PriorState sets the state prior to the plan, such as a state built with
+// StateBuilder.
+func (b *PlanBuilder) PriorState(state *tfjson.State) *PlanBuilder {
+	b.plan.PriorState = state
+
+	return b
+}
+
+// Build returns the plan. The PlanBuilder should not be used afterwards.
+func (b *PlanBuilder) Build() *tfjson.Plan {
+	return b.plan
+}
+
+// tfjson returns the change with values as decoded by the terraform-json
+// package.
+func (c Change) tfjson() *tfjson.Change {
+	return &tfjson.Change{
+		Actions:         c.Actions,
+		Before:          jsonValue(c.Before),
+		After:           jsonValue(c.After),
+		AfterUnknown:    jsonValue(c.AfterUnknown),
+		BeforeSensitive: jsonValue(c.BeforeSensitive),
+		AfterSensitive:  jsonValue(c.AfterSensitive),
+	}
+}
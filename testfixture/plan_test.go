@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testfixture_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/testfixture"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestPlanBuilder(t *testing.T) {
+	t.Parallel()
+
+	priorState := testfixture.NewState().Build()
+
+	testCases := map[string]struct {
+		plan     *tfjson.Plan
+		expected *tfjson.Plan
+	}{
+		"empty": {
+			plan: testfixture.NewPlan().Build(),
+			expected: &tfjson.Plan{
+				FormatVersion: testfixture.PlanFormatVersion,
+			},
+		},
+		"resource-change": {
+			plan: testfixture.NewPlan().
+				ResourceChange("module.child.examplecloud_thing.test[1]", testfixture.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before: map[string]interface{}{
+						"name": "one",
+						"port": 80,
+					},
+					After: map[string]interface{}{
+						"name": "two",
+					},
+					AfterUnknown: map[string]interface{}{
+						"port": true,
+					},
+				}).
+				PriorState(priorState).
+				Build(),
+			expected: &tfjson.Plan{
+				FormatVersion: testfixture.PlanFormatVersion,
+				PriorState:    priorState,
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address:       "module.child.examplecloud_thing.test[1]",
+						ModuleAddress: "module.child",
+						Mode:          tfjson.ManagedResourceMode,
+						Type:          "examplecloud_thing",
+						Name:          "test",
+						Index:         json.Number("1"),
+						ProviderName:  "registry.terraform.io/hashicorp/examplecloud",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionUpdate},
+							Before: map[string]interface{}{
+								"name": "one",
+								"port": json.Number("80"),
+							},
+							After: map[string]interface{}{
+								"name": "two",
+							},
+							AfterUnknown: map[string]interface{}{
+								"port": true,
+							},
+						},
+					},
+				},
+			},
+		},
+		"output-change": {
+			plan: testfixture.NewPlan().
+				OutputChange("name", testfixture.Change{
+					Actions:        tfjson.Actions{tfjson.ActionCreate},
+					After:          "example",
+					AfterSensitive: true,
+				}).
+				Build(),
+			expected: &tfjson.Plan{
+				FormatVersion: testfixture.PlanFormatVersion,
+				OutputChanges: map[string]*tfjson.Change{
+					"name": {
+						Actions:        tfjson.Actions{tfjson.ActionCreate},
+						After:          "example",
+						AfterSensitive: true,
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := testCase.plan.Validate(); err != nil {
+				t.Fatalf("unexpected validation error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.plan, testCase.expected, cmpopts.IgnoreUnexported(tfjson.Plan{}, tfjson.State{})); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPlanBuilder_PlanCheck(t *testing.T) {
+	t.Parallel()
+
+	plan := testfixture.NewPlan().
+		ResourceChange("examplecloud_thing.test", testfixture.Change{
+			Actions: tfjson.Actions{tfjson.ActionCreate},
+			After: map[string]interface{}{
+				"port": 443,
+			},
+		}).
+		Build()
+
+	resp := plancheck.CheckPlanResponse{}
+
+	plancheck.ExpectKnownValue(
+		"examplecloud_thing.test",
+		tfjsonpath.New("port"),
+		knownvalue.Int64Exact(443),
+	).CheckPlan(context.Background(), plancheck.CheckPlanRequest{Plan: plan}, &resp)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+}
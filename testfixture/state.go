@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testfixture
+
+import (
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// StateFormatVersion is the format version of states returned by
+// StateBuilder.
+const StateFormatVersion = "1.0"
+
+// Resource is a resource instance in a state built with StateBuilder.
+type Resource struct {
+	// AttributeValues are the attribute values of the resource, keyed by
+	// attribute name.
+	AttributeValues map[string]interface{}
+
+	// SensitiveValues mark the sensitive attribute values of the resource,
+	// such as map[string]interface{}{"password": true}.
+	SensitiveValues map[string]interface{}
+
+	// ProviderName is the provider source address of the resource. If
+	// empty, it defaults to the hashicorp namespace of the public registry
+	// and the resource type prefix, such as
+	// "registry.terraform.io/hashicorp/examplecloud" for
+	// "examplecloud_thing".
+	ProviderName string
+}
+
+// Output is a root module output value in a state built with StateBuilder.
+type Output struct {
+	// Value is the value of the output.
+	Value interface{}
+
+	// Sensitive is true if the output is marked as sensitive.
+	Sensitive bool
+}
+
+// StateBuilder builds a *tfjson.State, as returned by the `terraform show
+// -json` command, with resources and outputs. Create a StateBuilder with
+// NewState:
+//
+//	state := testfixture.NewState().
+//		Resource("examplecloud_thing.test", testfixture.Resource{
+//			AttributeValues: map[string]interface{}{
+//				"id":   "thing-123",
+//				"name": "example",
+//			},
+//		}).
+//		Output("name", testfixture.Output{Value: "example"}).
+//		Build()
+type StateBuilder struct {
+	state *tfjson.State
+}
+
+// NewState returns a StateBuilder of a state with an empty root module.
+func NewState() *StateBuilder {
+	return &StateBuilder{
+		state: &tfjson.State{
+			FormatVersion: StateFormatVersion,
+			Values: &tfjson.StateValues{
+				RootModule: &tfjson.StateModule{},
+			},
+		},
+	}
+}
+
+// Resource adds a resource instance with the given address, such as
+// "examplecloud_thing.test", "data.examplecloud_thing.test[0]", or
+// "module.child.examplecloud_thing.test". Resources with module addresses
+// are added to child modules, which are created as necessary. It panics if
+// the address is invalid or the values cannot be encoded as JSON.
+func (b *StateBuilder) Resource(address string, resource Resource) *StateBuilder {
+	parsedAddress := parseResourceAddress(address)
+
+	providerName := resource.ProviderName
+
+	if providerName == "" {
+		providerName = parsedAddress.providerName()
+	}
+
+	attributeValues, _ := jsonValue(resource.AttributeValues).(map[string]interface{})
+
+	module := b.module(parsedAddress.moduleAddress)
+	module.Resources = append(module.Resources, &tfjson.StateResource{
+		Address:         address,
+		Mode:            parsedAddress.mode,
+		Type:            parsedAddress.resourceType,
+		Name:            parsedAddress.name,
+		Index:           parsedAddress.index,
+		ProviderName:    providerName,
+		AttributeValues: attributeValues,
+		SensitiveValues: jsonRawMessage(resource.SensitiveValues),
+	})
+
+	return b
+}
+
+// Output adds a root module output value with the given name. It panics if
+// the value cannot be encoded as JSON.
+func (b *StateBuilder) Output(name string, output Output) *StateBuilder {
+	if b.state.Values.Outputs == nil {
+		b.state.Values.Outputs = make(map[string]*tfjson.StateOutput)
+	}
+
+	b.state.Values.Outputs[name] = &tfjson.StateOutput{
+		Sensitive: output.Sensitive,
+		Value:     jsonValue(output.Value),
+	}
+
+	return b
+}
+
+// Build returns the state. The StateBuilder should not be used afterwards.
+func (b *StateBuilder) Build() *tfjson.State {
+	return b.state
+}
+
+// module returns the module with the given address, creating it and any
+// parent child modules if necessary. An empty address is the root module.
+func (b *StateBuilder) module(moduleAddress string) *tfjson.StateModule {
+	module := b.state.Values.RootModule
+
+	if moduleAddress == "" {
+		return module
+	}
+
+	var address string
+
+	for _, part := range splitModuleAddress(moduleAddress) {
+		if address != "" {
+			address += "."
+		}
+
+		address += part
+
+		module = childModule(module, address)
+	}
+
+	return module
+}
+
+// childModule returns the child module of the module with the given
+// address, creating it if necessary.
+func childModule(module *tfjson.StateModule, address string) *tfjson.StateModule {
+	for _, child := range module.ChildModules {
+		if child.Address == address {
+			return child
+		}
+	}
+
+	child := &tfjson.StateModule{
+		Address: address,
+	}
+
+	module.ChildModules = append(module.ChildModules, child)
+
+	return child
+}
+
+// splitModuleAddress returns each module call of a module address, such as
+// "module.parent" and "module.child" for "module.parent.module.child".
+func splitModuleAddress(moduleAddress string) []string {
+	var result []string
+
+	parts := strings.Split(moduleAddress, ".")
+
+	for i := 0; i+1 < len(parts); i += 2 {
+		result = append(result, parts[i]+"."+parts[i+1])
+	}
+
+	return result
+}
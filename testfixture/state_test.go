@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testfixture_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-testing/testfixture"
+)
+
+func TestStateBuilder(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		state    *tfjson.State
+		expected *tfjson.State
+	}{
+		"empty": {
+			state: testfixture.NewState().Build(),
+			expected: &tfjson.State{
+				FormatVersion: testfixture.StateFormatVersion,
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{},
+				},
+			},
+		},
+		"resource": {
+			state: testfixture.NewState().
+				Resource("examplecloud_thing.test", testfixture.Resource{
+					AttributeValues: map[string]interface{}{
+						"name":  "example",
+						"count": 2,
+						"tags":  []string{"one"},
+					},
+					SensitiveValues: map[string]interface{}{
+						"password": true,
+					},
+				}).
+				Build(),
+			expected: &tfjson.State{
+				FormatVersion: testfixture.StateFormatVersion,
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						Resources: []*tfjson.StateResource{
+							{
+								Address:      "examplecloud_thing.test",
+								Mode:         tfjson.ManagedResourceMode,
+								Type:         "examplecloud_thing",
+								Name:         "test",
+								ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+								AttributeValues: map[string]interface{}{
+									"name":  "example",
+									"count": json.Number("2"),
+									"tags":  []interface{}{"one"},
+								},
+								SensitiveValues: json.RawMessage(`{"password":true}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"data-source-index": {
+			state: testfixture.NewState().
+				Resource("data.examplecloud_thing.test[0]", testfixture.Resource{}).
+				Resource(`examplecloud_thing.test["key"]`, testfixture.Resource{
+					ProviderName: "registry.terraform.io/example/examplecloud",
+				}).
+				Build(),
+			expected: &tfjson.State{
+				FormatVersion: testfixture.StateFormatVersion,
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						Resources: []*tfjson.StateResource{
+							{
+								Address:      "data.examplecloud_thing.test[0]",
+								Mode:         tfjson.DataResourceMode,
+								Type:         "examplecloud_thing",
+								Name:         "test",
+								Index:        json.Number("0"),
+								ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+							},
+							{
+								Address:      `examplecloud_thing.test["key"]`,
+								Mode:         tfjson.ManagedResourceMode,
+								Type:         "examplecloud_thing",
+								Name:         "test",
+								Index:        "key",
+								ProviderName: "registry.terraform.io/example/examplecloud",
+							},
+						},
+					},
+				},
+			},
+		},
+		"child-modules": {
+			state: testfixture.NewState().
+				Resource("module.parent.examplecloud_thing.one", testfixture.Resource{}).
+				Resource("module.parent.module.child.examplecloud_thing.two", testfixture.Resource{}).
+				Build(),
+			expected: &tfjson.State{
+				FormatVersion: testfixture.StateFormatVersion,
+				Values: &tfjson.StateValues{
+					RootModule: &tfjson.StateModule{
+						ChildModules: []*tfjson.StateModule{
+							{
+								Address: "module.parent",
+								Resources: []*tfjson.StateResource{
+									{
+										Address:      "module.parent.examplecloud_thing.one",
+										Mode:         tfjson.ManagedResourceMode,
+										Type:         "examplecloud_thing",
+										Name:         "one",
+										ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+									},
+								},
+								ChildModules: []*tfjson.StateModule{
+									{
+										Address: "module.parent.module.child",
+										Resources: []*tfjson.StateResource{
+											{
+												Address:      "module.parent.module.child.examplecloud_thing.two",
+												Mode:         tfjson.ManagedResourceMode,
+												Type:         "examplecloud_thing",
+												Name:         "two",
+												ProviderName: "registry.terraform.io/hashicorp/examplecloud",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"output": {
+			state: testfixture.NewState().
+				Output("name", testfixture.Output{
+					Value:     map[string]interface{}{"count": 1},
+					Sensitive: true,
+				}).
+				Build(),
+			expected: &tfjson.State{
+				FormatVersion: testfixture.StateFormatVersion,
+				Values: &tfjson.StateValues{
+					Outputs: map[string]*tfjson.StateOutput{
+						"name": {
+							Sensitive: true,
+							Value:     map[string]interface{}{"count": json.Number("1")},
+						},
+					},
+					RootModule: &tfjson.StateModule{},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := testCase.state.Validate(); err != nil {
+				t.Fatalf("unexpected validation error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.state, testCase.expected, cmpopts.IgnoreUnexported(tfjson.State{}), cmpopts.IgnoreFields(tfjson.StateOutput{}, "Type")); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStateBuilder_InvalidAddress(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	testfixture.NewState().Resource("module.child.examplecloud_thing", testfixture.Resource{})
+}
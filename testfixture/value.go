@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package testfixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// jsonValue returns the value as decoded by the terraform-json package, such
+// as json.Number for numbers. It panics if the value cannot be encoded as
+// JSON, such as a channel or function, as that is an error in the test.
+func jsonValue(value interface{}) interface{} {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		panic(fmt.Sprintf("testfixture: unable to encode value as JSON: %s", err))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var result interface{}
+
+	if err := dec.Decode(&result); err != nil {
+		panic(fmt.Sprintf("testfixture: unable to decode value from JSON: %s", err))
+	}
+
+	return result
+}
+
+// jsonRawMessage returns the values encoded as a JSON object, or nil if the
+// values are nil. It panics if the values cannot be encoded as JSON.
+func jsonRawMessage(value map[string]interface{}) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		panic(fmt.Sprintf("testfixture: unable to encode value as JSON: %s", err))
+	}
+
+	return b
+}
+
+// resourceAddress is the parsed address of a resource instance, such as
+// "module.child.data.examplecloud_thing.test[0]".
+type resourceAddress struct {
+	moduleAddress string
+	mode          tfjson.ResourceMode
+	resourceType  string
+	name          string
+	index         interface{}
+}
+
+// parseResourceAddress returns the parsed resource instance address. It
+// panics if the address does not contain a resource type and name.
+func parseResourceAddress(address string) resourceAddress {
+	parts := strings.Split(address, ".")
+	result := resourceAddress{
+		mode: tfjson.ManagedResourceMode,
+	}
+
+	var i int
+
+	for ; i+1 < len(parts) && parts[i] == "module"; i += 2 {
+		if result.moduleAddress != "" {
+			result.moduleAddress += "."
+		}
+
+		result.moduleAddress += parts[i] + "." + parts[i+1]
+	}
+
+	if i < len(parts) && parts[i] == "data" {
+		result.mode = tfjson.DataResourceMode
+		i++
+	}
+
+	if len(parts)-i != 2 || parts[i] == "" || parts[i+1] == "" {
+		panic(fmt.Sprintf("testfixture: invalid resource address %q", address))
+	}
+
+	result.resourceType = parts[i]
+	result.name = parts[i+1]
+
+	if open := strings.Index(result.name, "["); open > 0 && strings.HasSuffix(result.name, "]") {
+		key := result.name[open+1 : len(result.name)-1]
+		result.name = result.name[:open]
+
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			result.index = unquoted
+		} else {
+			result.index = json.Number(key)
+		}
+	}
+
+	return result
+}
+
+// providerName returns the default provider source address for the resource
+// type, such as "registry.terraform.io/hashicorp/examplecloud" for
+// "examplecloud_thing".
+func (a resourceAddress) providerName() string {
+	provider, _, _ := strings.Cut(a.resourceType, "_")
+
+	return "registry.terraform.io/hashicorp/" + provider
+}
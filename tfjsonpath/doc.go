@@ -0,0 +1,6 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfjsonpath implements terraform-json path functionality, which defines
+// traversals into Terraform JSON data, for testing purposes.
+package tfjsonpath
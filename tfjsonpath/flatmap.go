@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// flatmapListCount is the final key part of a legacy flatmap key for the
+	// number of elements in a list or set, such as "rule.#".
+	flatmapListCount = "#"
+
+	// flatmapMapCount is the final key part of a legacy flatmap key for the
+	// number of elements in a map, such as "tags.%".
+	flatmapMapCount = "%"
+
+	// flatmapSetElement is the key part of a legacy flatmap key for any
+	// element of a set, such as "rule.*.name", as used by the
+	// TestCheckTypeSetElemNestedAttrs() family of functions.
+	flatmapSetElement = "*"
+)
+
+// FromFlatmap returns the Path for the given legacy flatmap attribute key, as
+// used by the TestCheckResourceAttr() family of functions in the
+// helper/resource package, such as "rule.0.ports.#" or "tags.%".
+//
+// Key parts which are non-negative integers become SliceStep, as list and
+// set elements are slices in Terraform JSON data, and "*" key parts for any
+// set element become AnySliceStep, while all other key parts become MapStep. A map key which is an integer, such as "tags.0", therefore
+// cannot be represented and is converted to a SliceStep.
+//
+// Flatmap keys ending in "#" or "%" refer to the number of elements in a
+// list, set, or map, which has no equivalent in Terraform JSON data. The
+// final "#" or "%" is removed, so the returned Path refers to the list, set,
+// or map itself, whose length is the flatmap value.
+//
+// An error is returned if the key is empty, has an empty key part, does not
+// start with an attribute name, or has "#" or "%" other than as the final
+// key part.
+func FromFlatmap(key string) (Path, error) {
+	if key == "" {
+		return Path{}, fmt.Errorf("flatmap key is empty")
+	}
+
+	parts := strings.Split(key, ".")
+
+	if last := parts[len(parts)-1]; last == flatmapListCount || last == flatmapMapCount {
+		parts = parts[:len(parts)-1]
+	}
+
+	if len(parts) == 0 {
+		return Path{}, fmt.Errorf("flatmap key %q is missing an attribute name", key)
+	}
+
+	steps := make([]step, 0, len(parts))
+
+	for i, part := range parts {
+		switch part {
+		case "":
+			return Path{}, fmt.Errorf("flatmap key %q has an empty key part at index %d", key, i)
+		case flatmapListCount, flatmapMapCount:
+			return Path{}, fmt.Errorf("flatmap key %q has %s before the final key part", key, part)
+		case flatmapSetElement:
+			if i == 0 {
+				return Path{}, fmt.Errorf("flatmap key %q must start with an attribute name", key)
+			}
+
+			steps = append(steps, AnySliceStep{})
+
+			continue
+		}
+
+		// Only unsigned integers are list or set indices, as strconv.Atoi()
+		// also accepts a leading sign.
+		index, err := strconv.Atoi(part)
+
+		if err != nil || part[0] < '0' || part[0] > '9' {
+			steps = append(steps, MapStep(part))
+
+			continue
+		}
+
+		if i == 0 {
+			return Path{}, fmt.Errorf("flatmap key %q must start with an attribute name", key)
+		}
+
+		steps = append(steps, SliceStep(index))
+	}
+
+	return Path{
+		steps: steps,
+	}, nil
+}
+
+// ToFlatmap returns the legacy flatmap attribute key for the given Path, such
+// as "rule.0.ports", which can be used with the TestCheckResourceAttr()
+// family of functions in the helper/resource package. Append ".#" for the
+// number of elements in a list or set, or ".%" for a map. An AnySliceStep
+// becomes a "*" key part, as used by the TestCheckTypeSetElemNestedAttrs()
+// family of functions.
+//
+// An error is returned if the Path is empty, does not start with a MapStep,
+// has an AnyMapStep, or has a MapStep which cannot be represented in a
+// flatmap key, such as an empty key or a key containing a period.
+func ToFlatmap(path Path) (string, error) {
+	if len(path.steps) == 0 {
+		return "", fmt.Errorf("path is empty")
+	}
+
+	if _, ok := path.steps[0].(MapStep); !ok {
+		return "", fmt.Errorf("path %q must start with a MapStep", path.String())
+	}
+
+	for _, pathStep := range path.steps {
+		if _, ok := pathStep.(AnyMapStep); ok {
+			return "", fmt.Errorf("path %q has an AnyMapStep, which cannot be represented in a flatmap key", path.String())
+		}
+
+		mapStep, ok := pathStep.(MapStep)
+
+		if !ok {
+			continue
+		}
+
+		switch {
+		case mapStep == "":
+			return "", fmt.Errorf("path %q has an empty MapStep", path.String())
+		case strings.Contains(string(mapStep), "."):
+			return "", fmt.Errorf("path %q has MapStep %q containing a period", path.String(), string(mapStep))
+		case mapStep == flatmapListCount || mapStep == flatmapMapCount:
+			return "", fmt.Errorf("path %q has MapStep %q, which is reserved for flatmap element counts", path.String(), string(mapStep))
+		case mapStep == flatmapSetElement:
+			return "", fmt.Errorf("path %q has MapStep %q, which is reserved for flatmap set elements", path.String(), string(mapStep))
+		}
+	}
+
+	parts := make([]string, 0, len(path.steps))
+
+	for _, pathStep := range path.steps {
+		parts = append(parts, pathStep.String())
+	}
+
+	return strings.Join(parts, "."), nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestFromFlatmap(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		key           string
+		expected      tfjsonpath.Path
+		expectedError error
+	}{
+		"attribute": {
+			key:      "name",
+			expected: tfjsonpath.New("name"),
+		},
+		"list-count": {
+			key:      "rule.#",
+			expected: tfjsonpath.New("rule"),
+		},
+		"map-count": {
+			key:      "tags.%",
+			expected: tfjsonpath.New("tags"),
+		},
+		"map-key": {
+			key:      "tags.Name",
+			expected: tfjsonpath.New("tags").AtMapKey("Name"),
+		},
+		"nested": {
+			key:      "rule.0.ports.1",
+			expected: tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("ports").AtSliceIndex(1),
+		},
+		"nested-count": {
+			key:      "rule.0.ports.#",
+			expected: tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("ports"),
+		},
+		"signed-integer": {
+			key:      "tags.-1",
+			expected: tfjsonpath.New("tags").AtMapKey("-1"),
+		},
+		"empty": {
+			key:           "",
+			expectedError: fmt.Errorf("flatmap key is empty"),
+		},
+		"empty-part": {
+			key:           "rule..ports",
+			expectedError: fmt.Errorf("flatmap key \"rule..ports\" has an empty key part at index 1"),
+		},
+		"count-only": {
+			key:           "#",
+			expectedError: fmt.Errorf("flatmap key \"#\" is missing an attribute name"),
+		},
+		"count-not-final": {
+			key:           "rule.#.ports",
+			expectedError: fmt.Errorf("flatmap key \"rule.#.ports\" has # before the final key part"),
+		},
+		"index-first": {
+			key:           "0.name",
+			expectedError: fmt.Errorf("flatmap key \"0.name\" must start with an attribute name"),
+		},
+		"set-element": {
+			key:      "rule.*.ports.#",
+			expected: tfjsonpath.New("rule").AtAnySliceIndex().AtMapKey("ports"),
+		},
+		"set-element-first": {
+			key:           "*.name",
+			expectedError: fmt.Errorf("flatmap key \"*.name\" must start with an attribute name"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfjsonpath.FromFlatmap(testCase.key)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestToFlatmap(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		path          tfjsonpath.Path
+		expected      string
+		expectedError error
+	}{
+		"attribute": {
+			path:     tfjsonpath.New("name"),
+			expected: "name",
+		},
+		"nested": {
+			path:     tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("ports").AtSliceIndex(1),
+			expected: "rule.0.ports.1",
+		},
+		"any-slice-index": {
+			path:     tfjsonpath.New("rule").AtAnySliceIndex().AtMapKey("ports"),
+			expected: "rule.*.ports",
+		},
+		"any-map-key": {
+			path:          tfjsonpath.New("tags").AtAnyMapKey(),
+			expectedError: fmt.Errorf(`path "tags.*" has an AnyMapStep, which cannot be represented in a flatmap key`),
+		},
+		"map-key-asterisk": {
+			path:          tfjsonpath.New("tags").AtMapKey("*"),
+			expectedError: fmt.Errorf(`path "tags[\"*\"]" has MapStep "*", which is reserved for flatmap set elements`),
+		},
+		"empty": {
+			path:          tfjsonpath.Path{},
+			expectedError: fmt.Errorf("path is empty"),
+		},
+		"slice-index-first": {
+			path:          tfjsonpath.New(0).AtMapKey("name"),
+			expectedError: fmt.Errorf("path \"0.name\" must start with a MapStep"),
+		},
+		"map-key-empty": {
+			path:          tfjsonpath.New("tags").AtMapKey(""),
+			expectedError: fmt.Errorf(`path "tags[\"\"]" has an empty MapStep`),
+		},
+		"map-key-period": {
+			path:          tfjsonpath.New("tags").AtMapKey("example.com"),
+			expectedError: fmt.Errorf(`path "tags[\"example.com\"]" has MapStep "example.com" containing a period`),
+		},
+		"map-key-count": {
+			path:          tfjsonpath.New("tags").AtMapKey("%"),
+			expectedError: fmt.Errorf("path \"tags.%%\" has MapStep \"%%\", which is reserved for flatmap element counts"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfjsonpath.ToFlatmap(testCase.path)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
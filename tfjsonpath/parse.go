@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse returns the Path for the given string representation, such as
+// "rule[0].destination.ports", which allows paths to be defined as strings,
+// such as within table-driven tests. The string representation returned by
+// the Path String() method can also be parsed, so that
+// Parse(path.String()) is equal to path.
+//
+// Steps are separated by a period or written within square brackets:
+//
+//   - A step which is a non-negative integer, such as "0" or "[0]", becomes
+//     a SliceStep.
+//   - A "[*]" step becomes an AnySliceStep and a "*" step becomes an
+//     AnyMapStep, such as "rule[*].tags.*".
+//   - A quoted step within square brackets, such as `tags["Name"]`, becomes a
+//     MapStep. Quoting is required for map keys which are empty, are "*",
+//     are non-negative integers, or contain a period, square bracket, or
+//     double quote, such as `tags["example.com/owner"]`.
+//   - All other steps become a MapStep.
+//
+// An error is returned if the string is empty or is not a valid path.
+func Parse(s string) (Path, error) {
+	if s == "" {
+		return Path{}, fmt.Errorf("path is empty")
+	}
+
+	var steps []step
+
+	for i := 0; i < len(s); {
+		if s[i] == '[' {
+			end, bracketStep, err := parseBracketStep(s, i)
+
+			if err != nil {
+				return Path{}, err
+			}
+
+			steps = append(steps, bracketStep)
+			i = end
+
+			continue
+		}
+
+		// Steps other than the first step are preceded by a period, unless
+		// written within square brackets.
+		if len(steps) > 0 {
+			if s[i] != '.' {
+				return Path{}, fmt.Errorf("path %q has an unexpected %q at offset %d", s, s[i], i)
+			}
+
+			i++
+		}
+
+		end := len(s)
+
+		if n := strings.IndexAny(s[i:], `.[]"`); n >= 0 {
+			end = i + n
+		}
+
+		if end == i {
+			return Path{}, fmt.Errorf("path %q has an empty step at offset %d", s, i)
+		}
+
+		steps = append(steps, parseDottedStep(s[i:end]))
+		i = end
+	}
+
+	return Path{
+		steps: steps,
+	}, nil
+}
+
+// MustParse is like Parse, but panics if the string is not a valid path. It
+// simplifies defining paths in tests, such as within test case tables.
+func MustParse(s string) Path {
+	path, err := Parse(s)
+
+	if err != nil {
+		panic(fmt.Sprintf("tfjsonpath: %s", err))
+	}
+
+	return path
+}
+
+// parseBracketStep returns the step within the square brackets starting at
+// the given offset and the offset after the closing square bracket.
+func parseBracketStep(s string, start int) (int, step, error) {
+	closing := strings.IndexByte(s[start:], ']')
+
+	if start+1 < len(s) && s[start+1] == '"' {
+		quoted, err := strconv.QuotedPrefix(s[start+1:])
+
+		if err != nil {
+			return 0, nil, fmt.Errorf("path %q has an invalid quoted step at offset %d", s, start)
+		}
+
+		closing = 1 + len(quoted)
+
+		if start+closing >= len(s) || s[start+closing] != ']' {
+			return 0, nil, fmt.Errorf("path %q has an unterminated step at offset %d", s, start)
+		}
+
+		key, _ := strconv.Unquote(quoted)
+
+		return start + closing + 1, MapStep(key), nil
+	}
+
+	if closing < 0 {
+		return 0, nil, fmt.Errorf("path %q has an unterminated step at offset %d", s, start)
+	}
+
+	index := s[start+1 : start+closing]
+
+	if index == "*" {
+		return start + closing + 1, AnySliceStep{}, nil
+	}
+
+	if !isSliceIndex(index) {
+		return 0, nil, fmt.Errorf("path %q has an invalid slice index %q at offset %d", s, index, start)
+	}
+
+	n, _ := strconv.Atoi(index)
+
+	return start + closing + 1, SliceStep(n), nil
+}
+
+// parseDottedStep returns the step for a step separated by periods.
+func parseDottedStep(part string) step {
+	if part == "*" {
+		return AnyMapStep{}
+	}
+
+	if isSliceIndex(part) {
+		n, _ := strconv.Atoi(part)
+
+		return SliceStep(n)
+	}
+
+	return MapStep(part)
+}
+
+// isSliceIndex returns true if the string is a non-negative integer, as
+// strconv.Atoi() also accepts a leading sign.
+func isSliceIndex(s string) bool {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return false
+	}
+
+	_, err := strconv.Atoi(s)
+
+	return err == nil
+}
+
+// isPlainMapKey returns true if the map key can be written in a string
+// representation of a Path without quoting.
+func isPlainMapKey(key string) bool {
+	return key != "" && key != "*" && !isSliceIndex(key) && !strings.ContainsAny(key, `.[]"`)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		path          string
+		expected      tfjsonpath.Path
+		expectedError error
+	}{
+		"map-key": {
+			path:     "name",
+			expected: tfjsonpath.New("name"),
+		},
+		"slice-index": {
+			path:     "0",
+			expected: tfjsonpath.New(0),
+		},
+		"bracket-slice-index": {
+			path:     "rule[0].destination.ports",
+			expected: tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("destination").AtMapKey("ports"),
+		},
+		"dotted-slice-index": {
+			path:     "rule.0.destination.ports.1",
+			expected: tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("destination").AtMapKey("ports").AtSliceIndex(1),
+		},
+		"consecutive-brackets": {
+			path:     "matrix[0][1]",
+			expected: tfjsonpath.New("matrix").AtSliceIndex(0).AtSliceIndex(1),
+		},
+		"leading-bracket": {
+			path:     `["example.com"][2]`,
+			expected: tfjsonpath.New("example.com").AtSliceIndex(2),
+		},
+		"quoted-map-key": {
+			path:     `tags["example.com/owner"].value`,
+			expected: tfjsonpath.New("tags").AtMapKey("example.com/owner").AtMapKey("value"),
+		},
+		"quoted-integer-map-key": {
+			path:     `tags["0"]`,
+			expected: tfjsonpath.New("tags").AtMapKey("0"),
+		},
+		"quoted-escaped-map-key": {
+			path:     `tags["say \"hi\""]`,
+			expected: tfjsonpath.New("tags").AtMapKey(`say "hi"`),
+		},
+		"any-steps": {
+			path:     "rule[*].tags.*",
+			expected: tfjsonpath.New("rule").AtAnySliceIndex().AtMapKey("tags").AtAnyMapKey(),
+		},
+		"quoted-asterisk-map-key": {
+			path:     `tags["*"]`,
+			expected: tfjsonpath.New("tags").AtMapKey("*"),
+		},
+		"signed-integer": {
+			path:     "tags.-1",
+			expected: tfjsonpath.New("tags").AtMapKey("-1"),
+		},
+		"empty": {
+			path:          "",
+			expectedError: fmt.Errorf("path is empty"),
+		},
+		"empty-step": {
+			path:          "rule..ports",
+			expectedError: fmt.Errorf(`path "rule..ports" has an empty step at offset 5`),
+		},
+		"leading-period": {
+			path:          ".rule",
+			expectedError: fmt.Errorf(`path ".rule" has an empty step at offset 0`),
+		},
+		"trailing-period": {
+			path:          "rule.",
+			expectedError: fmt.Errorf(`path "rule." has an empty step at offset 5`),
+		},
+		"period-before-bracket": {
+			path:          "rule.[0]",
+			expectedError: fmt.Errorf(`path "rule.[0]" has an empty step at offset 5`),
+		},
+		"missing-period": {
+			path:          "rule[0]ports",
+			expectedError: fmt.Errorf(`path "rule[0]ports" has an unexpected 'p' at offset 7`),
+		},
+		"unterminated-bracket": {
+			path:          "rule[0",
+			expectedError: fmt.Errorf(`path "rule[0" has an unterminated step at offset 4`),
+		},
+		"unterminated-quoted-bracket": {
+			path:          `tags["key"`,
+			expectedError: fmt.Errorf(`path "tags[\"key\"" has an unterminated step at offset 4`),
+		},
+		"invalid-quoted": {
+			path:          `tags["key]`,
+			expectedError: fmt.Errorf(`path "tags[\"key]" has an invalid quoted step at offset 4`),
+		},
+		"invalid-slice-index": {
+			path:          "rule[-1]",
+			expectedError: fmt.Errorf(`path "rule[-1]" has an invalid slice index "-1" at offset 4`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfjsonpath.Parse(testCase.path)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		path     tfjsonpath.Path
+		expected string
+	}{
+		"nested": {
+			path:     tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("destination").AtMapKey("ports"),
+			expected: "rule.0.destination.ports",
+		},
+		"slice-index": {
+			path:     tfjsonpath.New(1).AtMapKey("name"),
+			expected: "1.name",
+		},
+		"period-map-key": {
+			path:     tfjsonpath.New("tags").AtMapKey("example.com/owner"),
+			expected: `tags["example.com/owner"]`,
+		},
+		"integer-map-key": {
+			path:     tfjsonpath.New("0").AtMapKey("1").AtSliceIndex(2),
+			expected: `["0"]["1"].2`,
+		},
+		"empty-map-key": {
+			path:     tfjsonpath.New("tags").AtMapKey("").AtMapKey("value"),
+			expected: `tags[""].value`,
+		},
+		"quote-map-key": {
+			path:     tfjsonpath.New("tags").AtMapKey(`say "hi"`),
+			expected: `tags["say \"hi\""]`,
+		},
+		"any-steps": {
+			path:     tfjsonpath.New("rule").AtAnySliceIndex().AtAnySliceIndex().AtMapKey("tags").AtAnyMapKey(),
+			expected: "rule[*][*].tags.*",
+		},
+		"asterisk-map-key": {
+			path:     tfjsonpath.New("tags").AtMapKey("*"),
+			expected: `tags["*"]`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.path.String()
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+
+			parsed, err := tfjsonpath.Parse(got)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !parsed.Equal(testCase.path) {
+				t.Errorf("expected %s, got %s", testCase.path, parsed)
+			}
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	t.Parallel()
+
+	if got := tfjsonpath.MustParse("rule[0].name"); !got.Equal(tfjsonpath.New("rule").AtSliceIndex(0).AtMapKey("name")) {
+		t.Errorf("unexpected path: %s", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	tfjsonpath.MustParse("rule[")
+}
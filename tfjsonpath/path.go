@@ -0,0 +1,318 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Path represents exact traversal steps specifying a value inside
+// Terraform JSON data. These steps always start from a MapStep with a key
+// specific to what is being tested.
+//
+// The [terraform-json] library serves as the de facto documentation
+// for JSON format of Terraform data.
+//
+// Use the New() function to create a Path with an initial AtMapKey() step.
+// Path functionality follows a builder pattern, which allows for chaining method
+// calls to construct a full path. The available traversal steps after Path
+// creation are:
+//
+//   - AtSliceIndex(): Step into a slice at a specific 0-based index
+//   - AtMapKey(): Step into a map at a specific key
+//   - AtAnySliceIndex(): Step into every element of a slice
+//   - AtAnyMapKey(): Step into every value of a map
+//
+// For example, to represent the first list element with a root-level
+// "some_attribute" attribute:
+//
+//	tfjsonpath.New("some_attribute").AtSliceIndex(0)
+//
+// Use the Parse() function to create a Path from its string representation,
+// such as "some_attribute[0]".
+//
+// [terraform-json]: (https://pkg.go.dev/github.com/hashicorp/terraform-json)
+type Path struct {
+	steps []step
+}
+
+// New creates a new path with an initial MapStep or SliceStep.
+func New[T int | string](firstStep T) Path {
+	switch t := any(firstStep).(type) {
+	case int:
+		return Path{
+			steps: []step{
+				SliceStep(t),
+			},
+		}
+	case string:
+		return Path{
+			steps: []step{
+				MapStep(t),
+			},
+		}
+	}
+
+	// Unreachable code
+	return Path{}
+}
+
+// AtSliceIndex returns a copied Path with a new SliceStep at the end.
+func (s Path) AtSliceIndex(index int) Path {
+	newSteps := append(s.copySteps(), SliceStep(index))
+	s.steps = newSteps
+	return s
+}
+
+// AtMapKey returns a copied Path with a new MapStep at the end.
+func (s Path) AtMapKey(key string) Path {
+	newSteps := append(s.copySteps(), MapStep(key))
+	s.steps = newSteps
+	return s
+}
+
+// AtAnySliceIndex returns a copied Path with a new AnySliceStep at the end,
+// which matches every element of a slice, such as for set elements, whose
+// indices are not stable. Paths with any-element steps match multiple values,
+// which are returned by TraverseAll().
+func (s Path) AtAnySliceIndex() Path {
+	newSteps := append(s.copySteps(), AnySliceStep{})
+	s.steps = newSteps
+	return s
+}
+
+// AtAnyMapKey returns a copied Path with a new AnyMapStep at the end, which
+// matches every value of a map. Paths with any-element steps match multiple
+// values, which are returned by TraverseAll().
+func (s Path) AtAnyMapKey() Path {
+	newSteps := append(s.copySteps(), AnyMapStep{})
+	s.steps = newSteps
+	return s
+}
+
+// HasAnyStep returns true if the Path has an AnySliceStep or AnyMapStep,
+// so it can match multiple values.
+func (s Path) HasAnyStep() bool {
+	for _, step := range s.steps {
+		switch step.(type) {
+		case AnySliceStep, AnyMapStep:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Equal returns true if the Path has the same steps as the given Path.
+func (s Path) Equal(o Path) bool {
+	if len(s.steps) != len(o.steps) {
+		return false
+	}
+
+	for i, step := range s.steps {
+		if step != o.steps[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns a string representation of the Path, with each step
+// separated by a period, such as "some_attribute.0.nested_attribute". Map
+// keys which would otherwise be ambiguous, such as keys which are integers or
+// contain a period, are quoted within square brackets, such as
+// `tags["example.com/owner"]`. An AnySliceStep is written as "[*]" and an
+// AnyMapStep as "*", such as "rule[*].tags.*". The result can be converted
+// back into an equal Path with Parse().
+func (s Path) String() string {
+	var b strings.Builder
+
+	for i, step := range s.steps {
+		if mapStep, ok := step.(MapStep); ok && !isPlainMapKey(string(mapStep)) {
+			b.WriteString("[" + strconv.Quote(string(mapStep)) + "]")
+
+			continue
+		}
+
+		if _, ok := step.(AnySliceStep); ok {
+			b.WriteString("[*]")
+
+			continue
+		}
+
+		if i > 0 {
+			b.WriteString(".")
+		}
+
+		b.WriteString(step.String())
+	}
+
+	return b.String()
+}
+
+// copySteps returns a copy of the steps, so that appending to the copy
+// does not modify the steps of the original Path.
+func (s Path) copySteps() []step {
+	stepsCopy := make([]step, len(s.steps))
+
+	copy(stepsCopy, s.steps)
+
+	return stepsCopy
+}
+
+// Traverse returns the element found when traversing the given
+// object using the specified Path. The object is an unmarshalled
+// JSON object representing Terraform data.
+//
+// Traverse returns an error if the value specified by the Path
+// is not found in the given object or if the given object does not
+// conform with the types of the specified Path. Paths with an AnySliceStep
+// or AnyMapStep, which can match multiple values, are not supported. Use
+// TraverseAll() instead.
+func Traverse(object interface{}, attrPath Path) (interface{}, error) {
+	if attrPath.HasAnyStep() {
+		return nil, fmt.Errorf("path %s matches any element, which requires TraverseAll", attrPath)
+	}
+
+	result := object
+
+	var steps []string
+
+	for _, step := range attrPath.steps {
+		switch s := step.(type) {
+		case MapStep:
+			steps = append(steps, string(s))
+
+			mapObj, ok := result.(map[string]interface{})
+
+			if !ok {
+				return nil, fmt.Errorf("path not found: cannot convert object at MapStep %s to map[string]interface{}", strings.Join(steps, "."))
+			}
+
+			result, ok = mapObj[string(s)]
+
+			if !ok {
+				return nil, fmt.Errorf("path not found: specified key %s not found in map at %s", string(s), strings.Join(steps, "."))
+			}
+
+		case SliceStep:
+			steps = append(steps, strconv.Itoa(int(s)))
+
+			sliceObj, ok := result.([]interface{})
+
+			if !ok {
+				return nil, fmt.Errorf("path not found: cannot convert object at SliceStep %s to []interface{}", strings.Join(steps, "."))
+			}
+
+			if int(s) < 0 || int(s) >= len(sliceObj) {
+				return nil, fmt.Errorf("path not found: SliceStep index %s is out of range with slice length %d", strings.Join(steps, "."), len(sliceObj))
+			}
+
+			result = sliceObj[s]
+		}
+	}
+
+	return result, nil
+}
+
+// TraverseAll returns every element found when traversing the given object
+// using the specified Path, which can contain AnySliceStep and AnyMapStep to
+// match every element of a slice or every value of a map. Values of a map are
+// returned in the order of their keys. For a Path without such steps, the
+// result is the single element returned by Traverse().
+//
+// Elements of a slice or map which do not contain the remainder of the Path,
+// or do not conform with its types, are skipped. TraverseAll returns an error
+// if no element is found, or if the value before the first AnySliceStep or
+// AnyMapStep is not found, as with Traverse().
+func TraverseAll(object interface{}, attrPath Path) ([]interface{}, error) {
+	for i, step := range attrPath.steps {
+		switch step.(type) {
+		case AnySliceStep, AnyMapStep:
+		default:
+			continue
+		}
+
+		prefix := Path{steps: attrPath.steps[:i]}
+		remainder := Path{steps: attrPath.steps[i+1:]}
+
+		collection, err := Traverse(object, prefix)
+
+		if err != nil {
+			return nil, err
+		}
+
+		elements, err := anyStepElements(collection, step, Path{steps: attrPath.steps[:i+1]})
+
+		if err != nil {
+			return nil, err
+		}
+
+		var results []interface{}
+
+		for _, element := range elements {
+			elementResults, err := TraverseAll(element, remainder)
+
+			if err != nil {
+				continue
+			}
+
+			results = append(results, elementResults...)
+		}
+
+		if len(results) == 0 {
+			return nil, fmt.Errorf("path not found: no elements found at %s", attrPath)
+		}
+
+		return results, nil
+	}
+
+	result, err := Traverse(object, attrPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{result}, nil
+}
+
+// anyStepElements returns the elements of the slice or map matched by the
+// AnySliceStep or AnyMapStep at the given path.
+func anyStepElements(collection interface{}, anyStep step, path Path) ([]interface{}, error) {
+	if _, ok := anyStep.(AnySliceStep); ok {
+		sliceObj, ok := collection.([]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("path not found: cannot convert object at AnySliceStep %s to []interface{}", path)
+		}
+
+		return sliceObj, nil
+	}
+
+	mapObj, ok := collection.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("path not found: cannot convert object at AnyMapStep %s to map[string]interface{}", path)
+	}
+
+	keys := make([]string, 0, len(mapObj))
+
+	for k := range mapObj {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	elements := make([]interface{}, 0, len(keys))
+
+	for _, k := range keys {
+		elements = append(elements, mapObj[k])
+	}
+
+	return elements, nil
+}
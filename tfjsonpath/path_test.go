@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestPath_String(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		path     tfjsonpath.Path
+		expected string
+	}{
+		"map-key": {
+			path:     tfjsonpath.New("attr"),
+			expected: "attr",
+		},
+		"slice-index": {
+			path:     tfjsonpath.New(1),
+			expected: "1",
+		},
+		"nested": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0).AtMapKey("nested"),
+			expected: "list.0.nested",
+		},
+		"any-step": {
+			path:     tfjsonpath.New("list").AtAnySliceIndex().AtMapKey("tags").AtAnyMapKey(),
+			expected: "list[*].tags.*",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.path.String(); got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPath_Equal(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		path     tfjsonpath.Path
+		other    tfjsonpath.Path
+		expected bool
+	}{
+		"equal": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0).AtMapKey("nested"),
+			other:    tfjsonpath.New("list").AtSliceIndex(0).AtMapKey("nested"),
+			expected: true,
+		},
+		"different-length": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0),
+			other:    tfjsonpath.New("list"),
+			expected: false,
+		},
+		"different-step": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0),
+			other:    tfjsonpath.New("list").AtSliceIndex(1),
+			expected: false,
+		},
+		"different-step-type": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0),
+			other:    tfjsonpath.New("list").AtMapKey("0"),
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.path.Equal(testCase.other); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPath_Copy(t *testing.T) {
+	t.Parallel()
+
+	base := tfjsonpath.New("list").AtSliceIndex(0)
+	first := base.AtMapKey("first")
+	second := base.AtMapKey("second")
+
+	if got := first.String(); got != "list.0.first" {
+		t.Errorf("unexpected first path: %s", got)
+	}
+
+	if got := second.String(); got != "list.0.second" {
+		t.Errorf("unexpected second path: %s", got)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	t.Parallel()
+
+	object := map[string]interface{}{
+		"str": "value",
+		"list": []interface{}{
+			map[string]interface{}{
+				"nested": true,
+			},
+		},
+		"map": map[string]interface{}{
+			"key": []interface{}{"first", "second"},
+		},
+	}
+
+	testCases := map[string]struct {
+		path          tfjsonpath.Path
+		expected      interface{}
+		expectedError error
+	}{
+		"map-key": {
+			path:     tfjsonpath.New("str"),
+			expected: "value",
+		},
+		"slice-index-map-key": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0).AtMapKey("nested"),
+			expected: true,
+		},
+		"map-key-slice-index": {
+			path:     tfjsonpath.New("map").AtMapKey("key").AtSliceIndex(1),
+			expected: "second",
+		},
+		"missing-key": {
+			path:          tfjsonpath.New("map").AtMapKey("missing"),
+			expectedError: fmt.Errorf("path not found: specified key missing not found in map at map.missing"),
+		},
+		"not-map": {
+			path:          tfjsonpath.New("list").AtMapKey("nested"),
+			expectedError: fmt.Errorf("path not found: cannot convert object at MapStep list.nested to map[string]interface{}"),
+		},
+		"not-slice": {
+			path:          tfjsonpath.New("str").AtSliceIndex(0),
+			expectedError: fmt.Errorf("path not found: cannot convert object at SliceStep str.0 to []interface{}"),
+		},
+		"out-of-range": {
+			path:          tfjsonpath.New("list").AtSliceIndex(1),
+			expectedError: fmt.Errorf("path not found: SliceStep index list.1 is out of range with slice length 1"),
+		},
+		"any-slice-index": {
+			path:          tfjsonpath.New("list").AtAnySliceIndex().AtMapKey("nested"),
+			expectedError: fmt.Errorf("path list[*].nested matches any element, which requires TraverseAll"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfjsonpath.Traverse(object, testCase.path)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPath_HasAnyStep(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		path     tfjsonpath.Path
+		expected bool
+	}{
+		"none": {
+			path:     tfjsonpath.New("list").AtSliceIndex(0).AtMapKey("nested"),
+			expected: false,
+		},
+		"any-slice-index": {
+			path:     tfjsonpath.New("list").AtAnySliceIndex().AtMapKey("nested"),
+			expected: true,
+		},
+		"any-map-key": {
+			path:     tfjsonpath.New("map").AtAnyMapKey(),
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.path.HasAnyStep(); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestTraverseAll(t *testing.T) {
+	t.Parallel()
+
+	object := map[string]interface{}{
+		"str": "value",
+		"set": []interface{}{
+			map[string]interface{}{
+				"name": "first",
+				"tags": map[string]interface{}{
+					"b": "two",
+					"a": "one",
+				},
+			},
+			map[string]interface{}{
+				"other": true,
+			},
+			map[string]interface{}{
+				"name": "second",
+			},
+		},
+		"empty": []interface{}{},
+	}
+
+	testCases := map[string]struct {
+		path          tfjsonpath.Path
+		expected      []interface{}
+		expectedError error
+	}{
+		"no-any-step": {
+			path:     tfjsonpath.New("str"),
+			expected: []interface{}{"value"},
+		},
+		"any-slice-index": {
+			path:     tfjsonpath.New("set").AtAnySliceIndex().AtMapKey("name"),
+			expected: []interface{}{"first", "second"},
+		},
+		"any-map-key": {
+			path:     tfjsonpath.New("set").AtSliceIndex(0).AtMapKey("tags").AtAnyMapKey(),
+			expected: []interface{}{"one", "two"},
+		},
+		"nested-any-steps": {
+			path:     tfjsonpath.New("set").AtAnySliceIndex().AtMapKey("tags").AtAnyMapKey(),
+			expected: []interface{}{"one", "two"},
+		},
+		"no-any-step-error": {
+			path:          tfjsonpath.New("missing"),
+			expectedError: fmt.Errorf("path not found: specified key missing not found in map at missing"),
+		},
+		"prefix-error": {
+			path:          tfjsonpath.New("missing").AtAnySliceIndex(),
+			expectedError: fmt.Errorf("path not found: specified key missing not found in map at missing"),
+		},
+		"not-slice": {
+			path:          tfjsonpath.New("str").AtAnySliceIndex(),
+			expectedError: fmt.Errorf("path not found: cannot convert object at AnySliceStep str[*] to []interface{}"),
+		},
+		"not-map": {
+			path:          tfjsonpath.New("set").AtAnyMapKey(),
+			expectedError: fmt.Errorf("path not found: cannot convert object at AnyMapStep set.* to map[string]interface{}"),
+		},
+		"empty": {
+			path:          tfjsonpath.New("empty").AtAnySliceIndex(),
+			expectedError: fmt.Errorf("path not found: no elements found at empty[*]"),
+		},
+		"no-match": {
+			path:          tfjsonpath.New("set").AtAnySliceIndex().AtMapKey("missing"),
+			expectedError: fmt.Errorf("path not found: no elements found at set[*].missing"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfjsonpath.TraverseAll(object, testCase.path)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(err.Error(), testCase.expectedError.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(got, testCase.expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfjsonpath
+
+import "strconv"
+
+// step represents a traversal type indicating the underlying Go type
+// representation for a Terraform JSON value.
+type step interface {
+	// String returns a string representation of the step.
+	String() string
+}
+
+// MapStep represents a traversal for map[string]interface{}
+type MapStep string
+
+// String returns the map key.
+func (s MapStep) String() string {
+	return string(s)
+}
+
+// SliceStep represents a traversal for []interface{}
+type SliceStep int
+
+// String returns the slice index.
+func (s SliceStep) String() string {
+	return strconv.Itoa(int(s))
+}
+
+// AnySliceStep represents a traversal for every element of []interface{},
+// such as the elements of a set, whose indices are not stable.
+type AnySliceStep struct{}
+
+// String returns "*".
+func (s AnySliceStep) String() string {
+	return "*"
+}
+
+// AnyMapStep represents a traversal for every value of
+// map[string]interface{}.
+type AnyMapStep struct{}
+
+// String returns "*".
+func (s AnyMapStep) String() string {
+	return "*"
+}
@@ -0,0 +1,5 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfversion contains the Terraform version check interface, request/response structs, and common Terraform version check implementations.
+package tfversion
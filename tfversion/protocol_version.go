@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"github.com/hashicorp/go-version"
+)
+
+// Provider protocol versions supported by Terraform CLI.
+const (
+	// ProtocolVersion5 is provider protocol version 5, supported by Terraform
+	// CLI 0.12.0 and later.
+	ProtocolVersion5 = 5
+
+	// ProtocolVersion6 is provider protocol version 6, supported by Terraform
+	// CLI 0.15.4 and later.
+	ProtocolVersion6 = 6
+)
+
+// version0_12_0 introduced provider protocol version 5.
+var version0_12_0 = version.Must(version.NewVersion("0.12.0"))
+
+// ProtocolVersion returns the highest provider protocol version supported by
+// the given Terraform CLI version, which is the protocol version Terraform CLI
+// negotiates with a provider supporting multiple protocol versions, such as a
+// terraform-plugin-mux server which downgrades protocol version 6 to 5. It
+// returns 0 for Terraform CLI versions before 0.12.0.
+func ProtocolVersion(terraformVersion *version.Version) int {
+	switch {
+	case !terraformVersion.LessThan(Version0_15_4):
+		return ProtocolVersion6
+	case !terraformVersion.LessThan(version0_12_0):
+		return ProtocolVersion5
+	default:
+		return 0
+	}
+}
+
+// supportsProtocolVersion returns true if the given Terraform CLI version
+// supports the given provider protocol version.
+func supportsProtocolVersion(terraformVersion *version.Version, protocolVersion int) bool {
+	return protocolVersion >= ProtocolVersion5 && protocolVersion <= ProtocolVersion(terraformVersion)
+}
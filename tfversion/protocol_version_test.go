@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestProtocolVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		terraformVersion string
+		expected         int
+	}{
+		"0.11.14": {
+			terraformVersion: "0.11.14",
+			expected:         0,
+		},
+		"0.12.0": {
+			terraformVersion: "0.12.0",
+			expected:         tfversion.ProtocolVersion5,
+		},
+		"0.15.3": {
+			terraformVersion: "0.15.3",
+			expected:         tfversion.ProtocolVersion5,
+		},
+		"0.15.4": {
+			terraformVersion: "0.15.4",
+			expected:         tfversion.ProtocolVersion6,
+		},
+		"1.5.7": {
+			terraformVersion: "1.5.7",
+			expected:         tfversion.ProtocolVersion6,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tfversion.ProtocolVersion(version.Must(version.NewVersion(testCase.terraformVersion)))
+
+			if got != testCase.expected {
+				t.Errorf("expected protocol version %d, got: %d", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = requireAboveCheck{}
+
+type requireAboveCheck struct {
+	minimumVersion *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c requireAboveCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.LessThan(c.minimumVersion) {
+		resp.Error = fmt.Errorf("expected Terraform CLI version above %s but detected version is %s", c.minimumVersion, req.TerraformVersion)
+	}
+}
+
+// RequireAbove will fail the test if the Terraform CLI version is below the
+// given minimum version. For example, if the minimum version is 1.3.0, the
+// test fails for Terraform CLI versions before 1.3.0.
+func RequireAbove(minimumVersion *version.Version) TerraformVersionCheck {
+	return requireAboveCheck{
+		minimumVersion: minimumVersion,
+	}
+}
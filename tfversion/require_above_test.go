@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestRequireAbove(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		versionCheck     tfversion.TerraformVersionCheck
+		terraformVersion string
+		expectedError    error
+		expectedSkip     string
+	}{
+		"below": {
+			versionCheck:     tfversion.RequireAbove(tfversion.Version1_3_0),
+			terraformVersion: "1.2.9",
+			expectedError:    fmt.Errorf("expected Terraform CLI version above 1.3.0 but detected version is 1.2.9"),
+		},
+		"equal": {
+			versionCheck:     tfversion.RequireAbove(tfversion.Version1_3_0),
+			terraformVersion: "1.3.0",
+		},
+		"above": {
+			versionCheck:     tfversion.RequireAbove(tfversion.Version1_3_0),
+			terraformVersion: "1.4.0",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tfversion.CheckTerraformVersionRequest{
+				TerraformVersion: version.Must(version.NewVersion(testCase.terraformVersion)),
+			}
+			resp := tfversion.CheckTerraformVersionResponse{}
+
+			testCase.versionCheck.CheckTerraformVersion(context.Background(), req, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+
+			if resp.Skip != testCase.expectedSkip {
+				t.Errorf("expected skip %q, got: %q", testCase.expectedSkip, resp.Skip)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = requireBelowCheck{}
+
+type requireBelowCheck struct {
+	maximumVersion *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c requireBelowCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.GreaterThan(c.maximumVersion) {
+		resp.Error = fmt.Errorf("expected Terraform CLI version below %s but detected version is %s", c.maximumVersion, req.TerraformVersion)
+	}
+}
+
+// RequireBelow will fail the test if the Terraform CLI version is above the
+// given maximum version. For example, if the maximum version is 1.3.0, the
+// test fails for Terraform CLI versions 1.3.1 and later.
+func RequireBelow(maximumVersion *version.Version) TerraformVersionCheck {
+	return requireBelowCheck{
+		maximumVersion: maximumVersion,
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = requireBetweenCheck{}
+
+type requireBetweenCheck struct {
+	minimumVersion *version.Version
+	maximumVersion *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c requireBetweenCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.LessThan(c.minimumVersion) || req.TerraformVersion.GreaterThanOrEqual(c.maximumVersion) {
+		resp.Error = fmt.Errorf("expected Terraform CLI version between %s and %s but detected version is %s", c.minimumVersion, c.maximumVersion, req.TerraformVersion)
+	}
+}
+
+// RequireBetween will fail the test unless the Terraform CLI version is
+// between the given minimum version (inclusive) and maximum version
+// (exclusive). For example, if the minimum version is 1.2.0 and the maximum
+// version is 1.3.0, the test only passes for 1.2.x versions.
+func RequireBetween(minimumVersion, maximumVersion *version.Version) TerraformVersionCheck {
+	return requireBetweenCheck{
+		minimumVersion: minimumVersion,
+		maximumVersion: maximumVersion,
+	}
+}
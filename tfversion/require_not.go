@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = requireNotCheck{}
+
+type requireNotCheck struct {
+	version *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c requireNotCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.Equal(c.version) {
+		resp.Error = fmt.Errorf("unexpected Terraform CLI version: %s", c.version)
+	}
+}
+
+// RequireNot will fail the test if the Terraform CLI version is equal to the
+// given version, such as a version with a known defect.
+func RequireNot(version *version.Version) TerraformVersionCheck {
+	return requireNotCheck{
+		version: version,
+	}
+}
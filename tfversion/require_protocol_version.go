@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ TerraformVersionCheck = requireProtocolVersionCheck{}
+
+type requireProtocolVersionCheck struct {
+	protocolVersion int
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c requireProtocolVersionCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if !supportsProtocolVersion(req.TerraformVersion, c.protocolVersion) {
+		resp.Error = fmt.Errorf("expected Terraform CLI version supporting provider protocol version %d but detected version is %s", c.protocolVersion, req.TerraformVersion)
+	}
+}
+
+// RequireProtocolVersion will fail the test if the Terraform CLI version does
+// not support the given provider protocol version. For example, if the
+// protocol version is 6, the test fails for Terraform CLI versions before
+// 0.15.4.
+func RequireProtocolVersion(protocolVersion int) TerraformVersionCheck {
+	return requireProtocolVersionCheck{
+		protocolVersion: protocolVersion,
+	}
+}
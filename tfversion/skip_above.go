@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = skipAboveCheck{}
+
+type skipAboveCheck struct {
+	maximumVersion *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c skipAboveCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.GreaterThan(c.maximumVersion) {
+		resp.Skip = fmt.Sprintf("Terraform CLI version %s is above the maximum version %s: skipping test", req.TerraformVersion, c.maximumVersion)
+	}
+}
+
+// SkipAbove will skip (pass) the test if the Terraform CLI version is above
+// the given maximum version. For example, if the maximum version is 1.3.0,
+// the test is skipped for Terraform CLI versions 1.3.1 and later.
+func SkipAbove(maximumVersion *version.Version) TerraformVersionCheck {
+	return skipAboveCheck{
+		maximumVersion: maximumVersion,
+	}
+}
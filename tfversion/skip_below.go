@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = skipBelowCheck{}
+
+type skipBelowCheck struct {
+	minimumVersion *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c skipBelowCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.LessThan(c.minimumVersion) {
+		resp.Skip = fmt.Sprintf("Terraform CLI version %s is below the minimum version %s: skipping test", req.TerraformVersion, c.minimumVersion)
+	}
+}
+
+// SkipBelow will skip (pass) the test if the Terraform CLI version is below
+// the given minimum version. For example, if the minimum version is 1.3.0,
+// the test is skipped for Terraform CLI versions before 1.3.0.
+func SkipBelow(minimumVersion *version.Version) TerraformVersionCheck {
+	return skipBelowCheck{
+		minimumVersion: minimumVersion,
+	}
+}
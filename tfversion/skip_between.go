@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = skipBetweenCheck{}
+
+type skipBetweenCheck struct {
+	minimumVersion *version.Version
+	maximumVersion *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c skipBetweenCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.GreaterThanOrEqual(c.minimumVersion) && req.TerraformVersion.LessThan(c.maximumVersion) {
+		resp.Skip = fmt.Sprintf("Terraform CLI version %s is between %s and %s: skipping test", req.TerraformVersion, c.minimumVersion, c.maximumVersion)
+	}
+}
+
+// SkipBetween will skip (pass) the test if the Terraform CLI version is
+// between the given minimum version (inclusive) and maximum version
+// (exclusive). For example, if the minimum version is 1.2.0 and the maximum
+// version is 1.3.0, the test is skipped for all 1.2.x versions.
+func SkipBetween(minimumVersion, maximumVersion *version.Version) TerraformVersionCheck {
+	return skipBetweenCheck{
+		minimumVersion: minimumVersion,
+		maximumVersion: maximumVersion,
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+var _ TerraformVersionCheck = skipIfCheck{}
+
+type skipIfCheck struct {
+	version *version.Version
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c skipIfCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if req.TerraformVersion.Equal(c.version) {
+		resp.Skip = fmt.Sprintf("Terraform CLI version is %s: skipping test", c.version)
+	}
+}
+
+// SkipIf will skip (pass) the test if the Terraform CLI version is equal to
+// the given version, such as a version with a known defect.
+func SkipIf(version *version.Version) TerraformVersionCheck {
+	return skipIfCheck{
+		version: version,
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ TerraformVersionCheck = skipIfProtocolVersionUnsupportedCheck{}
+
+type skipIfProtocolVersionUnsupportedCheck struct {
+	protocolVersion int
+}
+
+// CheckTerraformVersion implements the Terraform version check logic.
+func (c skipIfProtocolVersionUnsupportedCheck) CheckTerraformVersion(ctx context.Context, req CheckTerraformVersionRequest, resp *CheckTerraformVersionResponse) {
+	if !supportsProtocolVersion(req.TerraformVersion, c.protocolVersion) {
+		resp.Skip = fmt.Sprintf("Terraform CLI version %s does not support provider protocol version %d: skipping test", req.TerraformVersion, c.protocolVersion)
+	}
+}
+
+// SkipIfProtocolVersionUnsupported will skip (pass) the test if the Terraform
+// CLI version does not support the given provider protocol version. For
+// example, if the protocol version is 6, the test is skipped for Terraform CLI
+// versions before 0.15.4.
+func SkipIfProtocolVersionUnsupported(protocolVersion int) TerraformVersionCheck {
+	return skipIfProtocolVersionUnsupportedCheck{
+		protocolVersion: protocolVersion,
+	}
+}
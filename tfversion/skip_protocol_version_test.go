@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestSkipIfProtocolVersionUnsupported(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		versionCheck     tfversion.TerraformVersionCheck
+		terraformVersion string
+		expectedError    error
+		expectedSkip     string
+	}{
+		"protocol-5-supported": {
+			versionCheck:     tfversion.SkipIfProtocolVersionUnsupported(tfversion.ProtocolVersion5),
+			terraformVersion: "0.12.0",
+		},
+		"protocol-6-supported": {
+			versionCheck:     tfversion.SkipIfProtocolVersionUnsupported(tfversion.ProtocolVersion6),
+			terraformVersion: "0.15.4",
+		},
+		"protocol-6-unsupported": {
+			versionCheck:     tfversion.SkipIfProtocolVersionUnsupported(tfversion.ProtocolVersion6),
+			terraformVersion: "0.15.3",
+			expectedSkip:     "Terraform CLI version 0.15.3 does not support provider protocol version 6: skipping test",
+		},
+		"protocol-7-unsupported": {
+			versionCheck:     tfversion.SkipIfProtocolVersionUnsupported(7),
+			terraformVersion: "1.5.7",
+			expectedSkip:     "Terraform CLI version 1.5.7 does not support provider protocol version 7: skipping test",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tfversion.CheckTerraformVersionRequest{
+				TerraformVersion: version.Must(version.NewVersion(testCase.terraformVersion)),
+			}
+			resp := tfversion.CheckTerraformVersionResponse{}
+
+			testCase.versionCheck.CheckTerraformVersion(context.Background(), req, &resp)
+
+			if resp.Error == nil && testCase.expectedError != nil {
+				t.Fatalf("expected error: %s", testCase.expectedError)
+			}
+
+			if resp.Error != nil && testCase.expectedError == nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if resp.Error != nil && resp.Error.Error() != testCase.expectedError.Error() {
+				t.Errorf("expected error %q, got: %s", testCase.expectedError, resp.Error)
+			}
+
+			if resp.Skip != testCase.expectedSkip {
+				t.Errorf("expected skip %q, got: %q", testCase.expectedSkip, resp.Skip)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-version"
+)
+
+// TerraformVersionCheck defines an interface for implementing test logic that checks the version of the
+// Terraform CLI running the test and then returns an error, or a reason to skip the test, if the version
+// does not match what is expected.
+type TerraformVersionCheck interface {
+	// CheckTerraformVersion should perform the Terraform version check.
+	CheckTerraformVersion(context.Context, CheckTerraformVersionRequest, *CheckTerraformVersionResponse)
+}
+
+// CheckTerraformVersionRequest is a request for an invoke of the CheckTerraformVersion function.
+type CheckTerraformVersionRequest struct {
+	// TerraformVersion is the version of the Terraform CLI executable running the test.
+	TerraformVersion *version.Version
+}
+
+// CheckTerraformVersionResponse is a response to an invoke of the CheckTerraformVersion function.
+type CheckTerraformVersionResponse struct {
+	// Error is used to report the failure of a Terraform version check assertion, which fails the test.
+	Error error
+
+	// Skip is a non-empty reason to skip the test, such as when the Terraform version does not
+	// support the functionality under test.
+	Skip string
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tfversion
+
+import "github.com/hashicorp/go-version"
+
+// Common Terraform CLI versions for use with Terraform version checks, such as
+// versions which introduced functionality commonly used in provider tests.
+var (
+	// Version0_12_26 introduced the required_providers block source
+	// attribute.
+	Version0_12_26 *version.Version = version.Must(version.NewVersion("0.12.26"))
+
+	// Version0_13_0 introduced module count and for_each.
+	Version0_13_0 *version.Version = version.Must(version.NewVersion("0.13.0"))
+
+	// Version0_14_0 introduced the dependency lock file.
+	Version0_14_0 *version.Version = version.Must(version.NewVersion("0.14.0"))
+
+	// Version0_15_0 introduced provider sensitive values in plans.
+	Version0_15_0 *version.Version = version.Must(version.NewVersion("0.15.0"))
+
+	// Version0_15_4 introduced protocol version 6 providers.
+	Version0_15_4 *version.Version = version.Must(version.NewVersion("0.15.4"))
+
+	// Version1_0_0 is the first generally available release.
+	Version1_0_0 *version.Version = version.Must(version.NewVersion("1.0.0"))
+
+	// Version1_1_0 introduced moved blocks.
+	Version1_1_0 *version.Version = version.Must(version.NewVersion("1.1.0"))
+
+	// Version1_2_0 introduced lifecycle preconditions and postconditions.
+	Version1_2_0 *version.Version = version.Must(version.NewVersion("1.2.0"))
+
+	// Version1_3_0 introduced optional object type attributes.
+	Version1_3_0 *version.Version = version.Must(version.NewVersion("1.3.0"))
+
+	// Version1_4_0 introduced the terraform_data resource.
+	Version1_4_0 *version.Version = version.Must(version.NewVersion("1.4.0"))
+
+	// Version1_5_0 introduced import and check blocks.
+	Version1_5_0 *version.Version = version.Must(version.NewVersion("1.5.0"))
+
+	// Version1_8_0 introduced moved blocks across resource types, which
+	// call the provider MoveResourceState RPC.
+	Version1_8_0 *version.Version = version.Must(version.NewVersion("1.8.0"))
+)